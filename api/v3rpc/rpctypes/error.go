@@ -32,12 +32,17 @@ var (
 	ErrGRPCCompacted               = status.Error(codes.OutOfRange, "etcdserver: mvcc: required revision has been compacted")
 	ErrGRPCFutureRev               = status.Error(codes.OutOfRange, "etcdserver: mvcc: required revision is a future revision")
 	ErrGRPCNoSpace                 = status.Error(codes.ResourceExhausted, "etcdserver: mvcc: database space exceeded")
+	ErrGRPCRevisionTimeUnknown     = status.Error(codes.OutOfRange, "etcdserver: mvcc: no revision is known for the given time")
 
-	ErrGRPCLeaseNotFound    = status.Error(codes.NotFound, "etcdserver: requested lease not found")
-	ErrGRPCLeaseExist       = status.Error(codes.FailedPrecondition, "etcdserver: lease already exists")
-	ErrGRPCLeaseTTLTooLarge = status.Error(codes.OutOfRange, "etcdserver: too large lease TTL")
+	ErrGRPCLeaseNotFound           = status.Error(codes.NotFound, "etcdserver: requested lease not found")
+	ErrGRPCLeaseExist              = status.Error(codes.FailedPrecondition, "etcdserver: lease already exists")
+	ErrGRPCLeaseTTLTooLarge        = status.Error(codes.OutOfRange, "etcdserver: too large lease TTL")
+	ErrGRPCLeaseExpirationsLagging = status.Error(codes.ResourceExhausted, "etcdserver: lease expirations receiver fell behind")
 
-	ErrGRPCWatchCanceled = status.Error(codes.Canceled, "etcdserver: watch canceled")
+	ErrGRPCWatchCanceled      = status.Error(codes.Canceled, "etcdserver: watch canceled")
+	ErrGRPCWatchQuotaExceeded = status.Error(codes.ResourceExhausted, "etcdserver: too many watches for user")
+	ErrGRPCInvalidProjection  = status.Error(codes.InvalidArgument, "etcdserver: invalid watch value projection")
+	ErrGRPCWatchRateExceeded  = status.Error(codes.ResourceExhausted, "etcdserver: too many watch events held over the event rate")
 
 	ErrGRPCMemberExist            = status.Error(codes.FailedPrecondition, "etcdserver: member ID already exist")
 	ErrGRPCPeerURLExist           = status.Error(codes.FailedPrecondition, "etcdserver: Peer URLs already exists")
@@ -85,8 +90,12 @@ var (
 	ErrGRPCTimeoutWaitAppliedIndex    = status.Error(codes.Unavailable, "etcdserver: request timed out, waiting for the applied index took too long")
 	ErrGRPCUnhealthy                  = status.Error(codes.Unavailable, "etcdserver: unhealthy cluster")
 	ErrGRPCCorrupt                    = status.Error(codes.DataLoss, "etcdserver: corrupt cluster")
+	ErrGRPCReadOnly                   = status.Error(codes.FailedPrecondition, "etcdserver: cluster is read-only")
+	ErrGRPCReadOnlyUnsupported        = status.Error(codes.FailedPrecondition, "etcdserver: the READONLY alarm requires cluster version 3.7 or later")
 	ErrGRPCNotSupportedForLearner     = status.Error(codes.FailedPrecondition, "etcdserver: rpc not supported for learner")
 	ErrGRPCBadLeaderTransferee        = status.Error(codes.FailedPrecondition, "etcdserver: bad leader transferee")
+	ErrGRPCCommitModeDisabled         = status.Error(codes.FailedPrecondition, "etcdserver: changing the commit mode is disabled")
+	ErrGRPCInvalidCommitMode          = status.Error(codes.InvalidArgument, "etcdserver: invalid commit mode")
 
 	ErrGRPCWrongDowngradeVersionFormat   = status.Error(codes.InvalidArgument, "etcdserver: wrong downgrade target version format")
 	ErrGRPCInvalidDowngradeTargetVersion = status.Error(codes.InvalidArgument, "etcdserver: invalid downgrade target version")
@@ -103,16 +112,22 @@ var (
 		ErrorDesc(ErrGRPCValueProvided): ErrGRPCValueProvided,
 		ErrorDesc(ErrGRPCLeaseProvided): ErrGRPCLeaseProvided,
 
-		ErrorDesc(ErrGRPCTooManyOps):        ErrGRPCTooManyOps,
-		ErrorDesc(ErrGRPCDuplicateKey):      ErrGRPCDuplicateKey,
-		ErrorDesc(ErrGRPCInvalidSortOption): ErrGRPCInvalidSortOption,
-		ErrorDesc(ErrGRPCCompacted):         ErrGRPCCompacted,
-		ErrorDesc(ErrGRPCFutureRev):         ErrGRPCFutureRev,
-		ErrorDesc(ErrGRPCNoSpace):           ErrGRPCNoSpace,
+		ErrorDesc(ErrGRPCTooManyOps):          ErrGRPCTooManyOps,
+		ErrorDesc(ErrGRPCDuplicateKey):        ErrGRPCDuplicateKey,
+		ErrorDesc(ErrGRPCInvalidSortOption):   ErrGRPCInvalidSortOption,
+		ErrorDesc(ErrGRPCCompacted):           ErrGRPCCompacted,
+		ErrorDesc(ErrGRPCFutureRev):           ErrGRPCFutureRev,
+		ErrorDesc(ErrGRPCNoSpace):             ErrGRPCNoSpace,
+		ErrorDesc(ErrGRPCRevisionTimeUnknown): ErrGRPCRevisionTimeUnknown,
 
-		ErrorDesc(ErrGRPCLeaseNotFound):    ErrGRPCLeaseNotFound,
-		ErrorDesc(ErrGRPCLeaseExist):       ErrGRPCLeaseExist,
-		ErrorDesc(ErrGRPCLeaseTTLTooLarge): ErrGRPCLeaseTTLTooLarge,
+		ErrorDesc(ErrGRPCLeaseNotFound):           ErrGRPCLeaseNotFound,
+		ErrorDesc(ErrGRPCLeaseExist):              ErrGRPCLeaseExist,
+		ErrorDesc(ErrGRPCLeaseTTLTooLarge):        ErrGRPCLeaseTTLTooLarge,
+		ErrorDesc(ErrGRPCLeaseExpirationsLagging): ErrGRPCLeaseExpirationsLagging,
+
+		ErrorDesc(ErrGRPCWatchQuotaExceeded): ErrGRPCWatchQuotaExceeded,
+		ErrorDesc(ErrGRPCInvalidProjection):  ErrGRPCInvalidProjection,
+		ErrorDesc(ErrGRPCWatchRateExceeded):  ErrGRPCWatchRateExceeded,
 
 		ErrorDesc(ErrGRPCMemberExist):            ErrGRPCMemberExist,
 		ErrorDesc(ErrGRPCPeerURLExist):           ErrGRPCPeerURLExist,
@@ -154,8 +169,12 @@ var (
 		ErrorDesc(ErrGRPCTimeoutDueToConnectionLost): ErrGRPCTimeoutDueToConnectionLost,
 		ErrorDesc(ErrGRPCUnhealthy):                  ErrGRPCUnhealthy,
 		ErrorDesc(ErrGRPCCorrupt):                    ErrGRPCCorrupt,
+		ErrorDesc(ErrGRPCReadOnly):                   ErrGRPCReadOnly,
+		ErrorDesc(ErrGRPCReadOnlyUnsupported):        ErrGRPCReadOnlyUnsupported,
 		ErrorDesc(ErrGRPCNotSupportedForLearner):     ErrGRPCNotSupportedForLearner,
 		ErrorDesc(ErrGRPCBadLeaderTransferee):        ErrGRPCBadLeaderTransferee,
+		ErrorDesc(ErrGRPCCommitModeDisabled):         ErrGRPCCommitModeDisabled,
+		ErrorDesc(ErrGRPCInvalidCommitMode):          ErrGRPCInvalidCommitMode,
 
 		ErrorDesc(ErrGRPCClusterVersionUnavailable):     ErrGRPCClusterVersionUnavailable,
 		ErrorDesc(ErrGRPCWrongDowngradeVersionFormat):   ErrGRPCWrongDowngradeVersionFormat,
@@ -167,20 +186,26 @@ var (
 
 // client-side error
 var (
-	ErrEmptyKey          = Error(ErrGRPCEmptyKey)
-	ErrKeyNotFound       = Error(ErrGRPCKeyNotFound)
-	ErrValueProvided     = Error(ErrGRPCValueProvided)
-	ErrLeaseProvided     = Error(ErrGRPCLeaseProvided)
-	ErrTooManyOps        = Error(ErrGRPCTooManyOps)
-	ErrDuplicateKey      = Error(ErrGRPCDuplicateKey)
-	ErrInvalidSortOption = Error(ErrGRPCInvalidSortOption)
-	ErrCompacted         = Error(ErrGRPCCompacted)
-	ErrFutureRev         = Error(ErrGRPCFutureRev)
-	ErrNoSpace           = Error(ErrGRPCNoSpace)
-
-	ErrLeaseNotFound    = Error(ErrGRPCLeaseNotFound)
-	ErrLeaseExist       = Error(ErrGRPCLeaseExist)
-	ErrLeaseTTLTooLarge = Error(ErrGRPCLeaseTTLTooLarge)
+	ErrEmptyKey            = Error(ErrGRPCEmptyKey)
+	ErrKeyNotFound         = Error(ErrGRPCKeyNotFound)
+	ErrValueProvided       = Error(ErrGRPCValueProvided)
+	ErrLeaseProvided       = Error(ErrGRPCLeaseProvided)
+	ErrTooManyOps          = Error(ErrGRPCTooManyOps)
+	ErrDuplicateKey        = Error(ErrGRPCDuplicateKey)
+	ErrInvalidSortOption   = Error(ErrGRPCInvalidSortOption)
+	ErrCompacted           = Error(ErrGRPCCompacted)
+	ErrFutureRev           = Error(ErrGRPCFutureRev)
+	ErrNoSpace             = Error(ErrGRPCNoSpace)
+	ErrRevisionTimeUnknown = Error(ErrGRPCRevisionTimeUnknown)
+
+	ErrLeaseNotFound           = Error(ErrGRPCLeaseNotFound)
+	ErrLeaseExist              = Error(ErrGRPCLeaseExist)
+	ErrLeaseTTLTooLarge        = Error(ErrGRPCLeaseTTLTooLarge)
+	ErrLeaseExpirationsLagging = Error(ErrGRPCLeaseExpirationsLagging)
+
+	ErrWatchQuotaExceeded = Error(ErrGRPCWatchQuotaExceeded)
+	ErrInvalidProjection  = Error(ErrGRPCInvalidProjection)
+	ErrWatchRateExceeded  = Error(ErrGRPCWatchRateExceeded)
 
 	ErrMemberExist            = Error(ErrGRPCMemberExist)
 	ErrPeerURLExist           = Error(ErrGRPCPeerURLExist)
@@ -227,7 +252,11 @@ var (
 	ErrTimeoutWaitAppliedIndex    = Error(ErrGRPCTimeoutWaitAppliedIndex)
 	ErrUnhealthy                  = Error(ErrGRPCUnhealthy)
 	ErrCorrupt                    = Error(ErrGRPCCorrupt)
+	ErrReadOnly                   = Error(ErrGRPCReadOnly)
+	ErrReadOnlyUnsupported        = Error(ErrGRPCReadOnlyUnsupported)
 	ErrBadLeaderTransferee        = Error(ErrGRPCBadLeaderTransferee)
+	ErrCommitModeDisabled         = Error(ErrGRPCCommitModeDisabled)
+	ErrInvalidCommitMode          = Error(ErrGRPCInvalidCommitMode)
 
 	ErrClusterVersionUnavailable     = Error(ErrGRPCClusterVersionUnavailable)
 	ErrWrongDowngradeVersionFormat   = Error(ErrGRPCWrongDowngradeVersionFormat)
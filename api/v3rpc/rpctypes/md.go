@@ -19,4 +19,16 @@ var (
 	MetadataHasLeader        = "true"
 
 	MetadataClientAPIVersionKey = "client-api-version"
+
+	// MetadataWatchCompressionKey names the gRPC compressor, such as "gzip",
+	// the client asks the server to compress the responses of a watch stream
+	// with. Servers ignore it unless the compressor is registered and listed
+	// in the grpc-accept-encoding header of the stream.
+	MetadataWatchCompressionKey = "watch-compression"
+
+	// MetadataWatchFragmentKey tells the server the client reassembles
+	// fragmented watch responses, so that the server fragments oversized
+	// responses of all watchers of the stream as if they set fragment.
+	MetadataWatchFragmentKey = "watch-fragment"
+	MetadataWatchFragment    = "true"
 )
@@ -223,7 +223,7 @@ func TestCacheWatchAtomicOrderedDelivery(t *testing.T) {
 
 			got := collectAndAssertAtomicEvents(ctx, t, watchCh, len(tt.wantBatch))
 
-			if diff := cmp.Diff(tt.wantBatch, got); diff != "" {
+			if diff := cmp.Diff(withSeq(tt.wantBatch), got); diff != "" {
 				t.Fatalf("event mismatch (-want +got):\n%s", diff)
 			}
 		})
@@ -672,6 +672,18 @@ func collectAndAssertAtomicEvents(ctx context.Context, t *testing.T, watchCh cli
 	}
 }
 
+// withSeq returns copies of events numbered as they are delivered to a new
+// watcher.
+func withSeq(events []*clientv3.Event) []*clientv3.Event {
+	var numbered []*clientv3.Event
+	for i, event := range events {
+		ev := *event
+		ev.Seq = uint64(i) + 1
+		numbered = append(numbered, &ev)
+	}
+	return numbered
+}
+
 func verifySnapshot(t *testing.T, cache *Cache, want []*mvccpb.KeyValue) {
 	resp, err := cache.Get(t.Context(), "", clientv3.WithPrefix(), clientv3.WithSerializable())
 	if err != nil {
@@ -27,6 +27,8 @@ type watcher struct {
 	cancelResp *clientv3.WatchResponse
 	keyPred    KeyPredicate
 	stopOnce   sync.Once
+	// seq is the sequence number of the last event delivered to the client.
+	seq uint64
 }
 
 func newWatcher(bufSize int, pred KeyPredicate) *watcher {
@@ -51,8 +53,18 @@ func (w *watcher) enqueueResponse(resp clientv3.WatchResponse) bool {
 		}
 		resp.Events = filtered
 	}
+	// the events are shared by all watchers and numbered for the cache's
+	// own watch; renumber copies for this client.
+	numbered := make([]*clientv3.Event, len(resp.Events))
+	for i, event := range resp.Events {
+		ev := *event
+		ev.Seq = w.seq + uint64(i) + 1
+		numbered[i] = &ev
+	}
+	resp.Events = numbered
 	select {
 	case w.respCh <- resp:
+		w.seq += uint64(len(resp.Events))
 		return true
 	default:
 		return false
@@ -16,10 +16,12 @@ package clientv3
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/coreos/go-semver/semver"
@@ -36,6 +38,7 @@ import (
 	"go.etcd.io/etcd/client/pkg/v3/logutil"
 	"go.etcd.io/etcd/client/pkg/v3/verify"
 	"go.etcd.io/etcd/client/v3/credentials"
+	"go.etcd.io/etcd/client/v3/internal/balancer"
 	"go.etcd.io/etcd/client/v3/internal/endpoint"
 	"go.etcd.io/etcd/client/v3/internal/resolver"
 )
@@ -44,6 +47,8 @@ var (
 	ErrNoAvailableEndpoints = errors.New("etcdclient: no available endpoints")
 	ErrOldCluster           = errors.New("etcdclient: old cluster version")
 	ErrMutuallyExclusiveCfg = errors.New("Username/Password and Token configurations are mutually exclusive")
+	ErrNoTLSConfig          = errors.New("etcdclient: client is not configured with TLS")
+	ErrNoConnection         = errors.New("etcdclient: no connection to any endpoint")
 )
 
 // Client provides and manages an etcd v3 client session.
@@ -61,6 +66,10 @@ type Client struct {
 	creds    grpccredentials.TransportCredentials
 	resolver *resolver.EtcdManualResolver
 
+	// clientCert is the client certificate set by ReloadTLS, presented
+	// instead of the certificates of the TLS config once set.
+	clientCert *atomic.Pointer[tls.Certificate]
+
 	epMu      *sync.RWMutex
 	endpoints []string
 
@@ -96,7 +105,13 @@ func New(cfg Config) (*Client, error) {
 // service interface implementations and do not need connection management.
 func NewCtxClient(ctx context.Context, opts ...Option) *Client {
 	cctx, cancel := context.WithCancel(ctx)
-	c := &Client{ctx: cctx, cancel: cancel, lgMu: new(sync.RWMutex), epMu: new(sync.RWMutex)}
+	c := &Client{
+		ctx:        cctx,
+		cancel:     cancel,
+		lgMu:       new(sync.RWMutex),
+		epMu:       new(sync.RWMutex),
+		clientCert: new(atomic.Pointer[tls.Certificate]),
+	}
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -193,6 +208,26 @@ func (c *Client) Sync(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	c.syncEndpoints(mresp)
+	return nil
+}
+
+// CurrentRevision returns the current store revision of the member serving
+// the request. It is cheaper than a range since no keys are read and the
+// request does not go through raft, so a consumer can poll it to measure how
+// far behind the store it is. The revision may trail the cluster's if the
+// member is lagging.
+func (c *Client) CurrentRevision(ctx context.Context) (int64, error) {
+	resp, err := c.StoreRevision(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Header.Revision, nil
+}
+
+// syncEndpoints sets the client endpoints to the client URLs of the started
+// voting members of a linearizable member list.
+func (c *Client) syncEndpoints(mresp *MemberListResponse) {
 	var eps []string
 	for _, m := range mresp.Members {
 		if len(m.Name) != 0 && !m.IsLearner {
@@ -206,7 +241,6 @@ func (c *Client) Sync(ctx context.Context) error {
 	})
 	c.SetEndpoints(eps...)
 	c.lg.Debug("set etcd endpoints by autoSync", zap.Strings("endpoints", eps))
-	return nil
 }
 
 func (c *Client) autoSync() {
@@ -374,15 +408,52 @@ func (c *Client) credentialsForEndpoint(ep string) grpccredentials.TransportCred
 	}
 }
 
+// reloadableTLSConfig returns a copy of cfg that presents the client
+// certificate set by ReloadTLS, if any, on TLS handshakes.
+func (c *Client) reloadableTLSConfig(cfg *tls.Config) *tls.Config {
+	cfg = cfg.Clone()
+	getClientCertificate := cfg.GetClientCertificate
+	certs := cfg.Certificates
+	cfg.GetClientCertificate = func(cri *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		if cert := c.clientCert.Load(); cert != nil {
+			return cert, nil
+		}
+		if getClientCertificate != nil {
+			return getClientCertificate(cri)
+		}
+		// same selection as crypto/tls without GetClientCertificate
+		for i := range certs {
+			if err := cri.SupportsCertificate(&certs[i]); err == nil {
+				return &certs[i], nil
+			}
+		}
+		return new(tls.Certificate), nil
+	}
+	return cfg
+}
+
+// ReloadTLS replaces the client certificate with the key pair loaded from
+// certFile and keyFile. Established connections, along with the watches and
+// lease keep alives running on them, are kept; the new certificate is
+// presented on subsequent TLS handshakes, such as when reconnecting to an
+// endpoint.
+func (c *Client) ReloadTLS(certFile, keyFile string) error {
+	if c.creds == nil {
+		return ErrNoTLSConfig
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	c.clientCert.Store(&cert)
+	c.GetLogger().Info("reloaded client certificate", zap.String("cert-file", certFile))
+	return nil
+}
+
 func newClient(cfg *Config) (*Client, error) {
 	if cfg == nil {
 		cfg = &Config{}
 	}
-	var creds grpccredentials.TransportCredentials
-	if cfg.TLS != nil {
-		creds = credentials.NewTransportCredential(cfg.TLS)
-	}
-
 	if cfg.Token != "" && (cfg.Username != "" || cfg.Password != "") {
 		return nil, ErrMutuallyExclusiveCfg
 	}
@@ -395,14 +466,17 @@ func newClient(cfg *Config) (*Client, error) {
 
 	ctx, cancel := context.WithCancel(baseCtx)
 	client := &Client{
-		conn:     nil,
-		cfg:      *cfg,
-		creds:    creds,
-		ctx:      ctx,
-		cancel:   cancel,
-		epMu:     new(sync.RWMutex),
-		callOpts: defaultCallOpts,
-		lgMu:     new(sync.RWMutex),
+		conn:       nil,
+		cfg:        *cfg,
+		ctx:        ctx,
+		cancel:     cancel,
+		epMu:       new(sync.RWMutex),
+		callOpts:   defaultCallOpts,
+		lgMu:       new(sync.RWMutex),
+		clientCert: new(atomic.Pointer[tls.Certificate]),
+	}
+	if cfg.TLS != nil {
+		client.creds = credentials.NewTransportCredential(client.reloadableTLSConfig(cfg.TLS))
 	}
 
 	var err error
@@ -449,7 +523,11 @@ func newClient(cfg *Config) (*Client, error) {
 		client.callOpts = callOpts
 	}
 
-	client.resolver = resolver.New(cfg.Endpoints...)
+	if cfg.PinEndpointAfterWrite {
+		client.resolver = resolver.NewWithPolicy(balancer.PinAfterWriteName, cfg.Endpoints...)
+	} else {
+		client.resolver = resolver.New(cfg.Endpoints...)
+	}
 
 	if len(cfg.Endpoints) < 1 {
 		client.cancel()
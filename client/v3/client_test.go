@@ -152,6 +152,29 @@ func TestDialNoTimeout(t *testing.T) {
 	c.Close()
 }
 
+func TestFailFastWhenNoConnection(t *testing.T) {
+	testutil.RegisterLeakDetection(t)
+
+	// nothing listens on the endpoints, so their connections fail
+	cfg := Config{
+		Endpoints:                []string{"unix://failfast:12345", "unix://failfast:12346"},
+		FailFastWhenNoConnection: true,
+	}
+	c, err := NewClient(t, cfg)
+	require.NoError(t, err)
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err = c.Get(ctx, "foo")
+	require.ErrorIs(t, err, ErrNoConnection)
+	_, err = c.Put(ctx, "foo", "bar")
+	require.ErrorIs(t, err, ErrNoConnection)
+	require.Less(t, time.Since(start), 10*time.Second)
+	require.NoError(t, ctx.Err())
+}
+
 func TestMaxUnaryRetries(t *testing.T) {
 	maxUnaryRetries := uint(10)
 	cfg := Config{
@@ -277,6 +300,11 @@ func TestZapWithLogger(t *testing.T) {
 	}
 }
 
+func TestReloadTLSNoConfig(t *testing.T) {
+	c := NewCtxClient(t.Context())
+	require.ErrorIs(t, c.ReloadTLS("client.crt", "client.key"), ErrNoTLSConfig)
+}
+
 func TestAuthTokenBundleNoOverwrite(t *testing.T) {
 	// This call in particular changes working directory to the tmp dir of
 	// the test. The `etcd-auth-test:0` can be created in local directory,
@@ -521,6 +549,10 @@ func (mm mockMaintenance) AlarmDisarm(ctx context.Context, m *AlarmMember) (*Ala
 	return nil, nil
 }
 
+func (mm mockMaintenance) AlarmActivate(ctx context.Context, m *AlarmMember) (*AlarmResponse, error) {
+	return nil, nil
+}
+
 func (mm mockMaintenance) Defragment(ctx context.Context, endpoint string) (*DefragmentResponse, error) {
 	return nil, nil
 }
@@ -545,6 +577,42 @@ func (mm mockMaintenance) Downgrade(ctx context.Context, action DowngradeAction,
 	return nil, nil
 }
 
+func (mm mockMaintenance) DefragmentWithProgress(ctx context.Context, endpoint string, progress func(processed, total int64)) (*DefragmentResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) ForceSnapshot(ctx context.Context, endpoint string) (*ForceSnapshotResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) MemberRemovePreview(ctx context.Context, endpoint string, id uint64) (*MemberRemovePreviewResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) LinearizableProbe(ctx context.Context) (*LinearizableProbeResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) BucketStats(ctx context.Context, endpoint string) (*BucketStatsResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) SetCommitMode(ctx context.Context, endpoint string, mode CommitMode) (*SetCommitModeResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) RevisionSince(ctx context.Context, t time.Time) (*RevisionSinceResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) StoreRevision(ctx context.Context) (*StoreRevisionResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) CancelCompaction(ctx context.Context, endpoint string) (*CancelCompactionResponse, error) {
+	return nil, nil
+}
+
 type mockFailingAuthServer struct {
 	*etcdserverpb.UnimplementedAuthServer
 }
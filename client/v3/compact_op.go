@@ -22,6 +22,8 @@ import (
 type CompactOp struct {
 	revision int64
 	physical bool
+	dryRun   bool
+	bounded  bool
 }
 
 // CompactOption configures compact operation.
@@ -41,7 +43,7 @@ func OpCompact(rev int64, opts ...CompactOption) CompactOp {
 }
 
 func (op CompactOp) toRequest() *pb.CompactionRequest {
-	return &pb.CompactionRequest{Revision: op.revision, Physical: op.physical}
+	return &pb.CompactionRequest{Revision: op.revision, Physical: op.physical, DryRun: op.dryRun, BoundByWatchers: op.bounded}
 }
 
 // WithCompactPhysical makes Compact wait until all compacted entries are
@@ -49,3 +51,16 @@ func (op CompactOp) toRequest() *pb.CompactionRequest {
 func WithCompactPhysical() CompactOption {
 	return func(op *CompactOp) { op.physical = true }
 }
+
+// WithCompactDryRun makes Compact only estimate the number of bytes the
+// compaction would reclaim, without compacting.
+func WithCompactDryRun() CompactOption {
+	return func(op *CompactOp) { op.dryRun = true }
+}
+
+// WithCompactBoundByWatchers makes Compact refuse to compact past the lowest
+// revision that a watcher on the serving member has yet to receive. If it
+// does, the response's BlockingRevision is set to that revision.
+func WithCompactBoundByWatchers() CompactOption {
+	return func(op *CompactOp) { op.bounded = true }
+}
@@ -158,6 +158,38 @@ func (m *Mutex) Key() string { return m.myKey }
 // Header is the response header received from etcd on acquiring the lock.
 func (m *Mutex) Header() *pb.ResponseHeader { return m.hdr }
 
+// AcquireLock acquires the mutex at pfx under a new session, whose lease is
+// kept alive in the background until the returned release func is called.
+// It blocks until the lock is held or ctx is done; if ctx is done first, the
+// session is closed and ctx's error is returned. opts configure the session.
+//
+// The release func closes the session, revoking its lease and with it the
+// lock key.
+func AcquireLock(ctx context.Context, client *v3.Client, pfx string, opts ...SessionOption) (release func() error, err error) {
+	ops := &sessionOptions{ttl: defaultSessionTTL}
+	for _, opt := range opts {
+		opt(ops, client.GetLogger())
+	}
+	// grant the lease here so that ctx bounds the whole acquisition
+	if ops.leaseID == v3.NoLease {
+		resp, err := client.Grant(ctx, int64(ops.ttl))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts[:len(opts):len(opts)], WithLease(resp.ID))
+	}
+	s, err := NewSession(client, opts...)
+	if err != nil {
+		return nil, err
+	}
+	m := NewMutex(s, pfx)
+	if err := m.Lock(ctx); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s.Close, nil
+}
+
 type lockerMutex struct{ *Mutex }
 
 func (lm *lockerMutex) Lock() {
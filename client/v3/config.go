@@ -102,9 +102,44 @@ type Config struct {
 	// BackoffJitterFraction is the jitter fraction to randomize backoff wait time.
 	BackoffJitterFraction float64 `json:"backoff-jitter-fraction"`
 
+	// WatchBackoff configures the backoff between attempts to re-establish
+	// a watch stream after it is lost. Zero fields fall back to the defaults.
+	WatchBackoff WatchBackoff `json:"watch-backoff"`
+
+	// MaxWatchersPerStream limits the number of watchers sharing a grpc
+	// watch stream; watchers beyond the limit are placed on additional
+	// streams. If 0, a single stream is used per context metadata.
+	MaxWatchersPerStream int `json:"max-watchers-per-stream"`
+
+	// PinEndpointAfterWrite when set sticks all requests to the endpoint that
+	// served the last successful write, so that subsequent reads observe it
+	// even if they are served locally. The pin is dropped when the connection
+	// to that endpoint is lost, until the next successful write.
+	PinEndpointAfterWrite bool `json:"pin-endpoint-after-write"`
+
+	// FailFastWhenNoConnection when set fails unary requests with
+	// ErrNoConnection as soon as the connections to all endpoints have failed,
+	// instead of waiting for an endpoint to become reachable until the request
+	// context is done. Watches and lease keep alives keep retrying.
+	FailFastWhenNoConnection bool `json:"fail-fast-when-no-connection"`
+
 	// TODO: support custom balancer picker
 }
 
+// WatchBackoff is the backoff applied when reconnecting a watch stream.
+// Starting from Min, the wait grows by 25% per failed attempt up to Max.
+type WatchBackoff struct {
+	// Min is the initial wait time. If 0, it defaults to 1ms.
+	Min time.Duration `json:"min"`
+
+	// Max is the upper bound of the wait time. If 0, it defaults to 100ms.
+	Max time.Duration `json:"max"`
+
+	// Jitter is the fraction used to randomize each wait time.
+	// If 0, no jitter is applied.
+	Jitter float64 `json:"jitter"`
+}
+
 // ConfigSpec is the configuration from users, which comes from command-line flags,
 // environment variables or config file. It is a fully declarative configuration,
 // and can be serialized & deserialized to/from JSON.
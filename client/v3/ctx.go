@@ -37,6 +37,17 @@ func WithRequireLeader(ctx context.Context) context.Context {
 	return metadata.NewOutgoingContext(ctx, copied)
 }
 
+// withoutRequireLeader removes the requirement set by WithRequireLeader, if any.
+func withoutRequireLeader(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok || len(md.Get(rpctypes.MetadataRequireLeaderKey)) == 0 {
+		return ctx
+	}
+	copied := md.Copy() // avoid racey updates
+	copied.Delete(rpctypes.MetadataRequireLeaderKey)
+	return metadata.NewOutgoingContext(ctx, copied)
+}
+
 // embeds client version
 func withVersion(ctx context.Context) context.Context {
 	md, ok := metadata.FromOutgoingContext(ctx)
@@ -0,0 +1,132 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package balancer
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+)
+
+// PinAfterWriteName is the name of the load balancing policy that pins all
+// requests to the endpoint that served the last successful write.
+const PinAfterWriteName = "etcd_pin_after_write"
+
+// writeMethods are the RPCs whose successful completion pins the endpoint.
+var writeMethods = map[string]struct{}{
+	"/etcdserverpb.KV/Put":         {},
+	"/etcdserverpb.KV/DeleteRange": {},
+	"/etcdserverpb.KV/Txn":         {},
+}
+
+func init() {
+	balancer.Register(pinAfterWriteBuilder{})
+}
+
+type pinAfterWriteBuilder struct{}
+
+func (pinAfterWriteBuilder) Name() string { return PinAfterWriteName }
+
+func (pinAfterWriteBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	// The pinned endpoint is shared by all pickers of a single connection,
+	// so that it survives picker rebuilds on sub-connection state changes.
+	pb := &pinPickerBuilder{pin: &pinnedAddr{}}
+	return &pinAfterWriteBalancer{
+		Balancer: base.NewBalancerBuilder(PinAfterWriteName, pb, base.Config{}).Build(cc, opts),
+	}
+}
+
+type pinAfterWriteBalancer struct {
+	balancer.Balancer
+}
+
+func (b *pinAfterWriteBalancer) UpdateClientConnState(s balancer.ClientConnState) error {
+	// base balancer only handles addresses, while the etcd resolver
+	// reports endpoints.
+	if len(s.ResolverState.Addresses) == 0 {
+		for _, ep := range s.ResolverState.Endpoints {
+			s.ResolverState.Addresses = append(s.ResolverState.Addresses, ep.Addresses...)
+		}
+	}
+	return b.Balancer.UpdateClientConnState(s)
+}
+
+type pinnedAddr struct {
+	mu   sync.Mutex
+	addr string
+}
+
+func (p *pinnedAddr) get() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.addr
+}
+
+func (p *pinnedAddr) set(addr string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.addr = addr
+}
+
+type pinPickerBuilder struct {
+	pin *pinnedAddr
+}
+
+func (b *pinPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		b.pin.set("")
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	p := &pinPicker{pin: b.pin, byAddr: make(map[string]balancer.SubConn, len(info.ReadySCs))}
+	for sc, sci := range info.ReadySCs {
+		p.scs = append(p.scs, sc)
+		p.addrs = append(p.addrs, sci.Address.Addr)
+		p.byAddr[sci.Address.Addr] = sc
+	}
+	// The pinned endpoint is no longer ready, fail over to round robin
+	// until the next successful write.
+	if _, ok := p.byAddr[b.pin.get()]; !ok {
+		b.pin.set("")
+	}
+	return p
+}
+
+type pinPicker struct {
+	pin    *pinnedAddr
+	scs    []balancer.SubConn
+	addrs  []string
+	byAddr map[string]balancer.SubConn
+	next   atomic.Uint32
+}
+
+func (p *pinPicker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	addr := p.pin.get()
+	sc, ok := p.byAddr[addr]
+	if !ok {
+		i := int(p.next.Add(1)-1) % len(p.scs)
+		sc, addr = p.scs[i], p.addrs[i]
+	}
+	res := balancer.PickResult{SubConn: sc}
+	if _, write := writeMethods[info.FullMethodName]; write {
+		res.Done = func(di balancer.DoneInfo) {
+			if di.Err == nil {
+				p.pin.set(addr)
+			}
+		}
+	}
+	return res, nil
+}
@@ -15,6 +15,8 @@
 package resolver
 
 import (
+	"fmt"
+
 	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/resolver/manual"
 	"google.golang.org/grpc/serviceconfig"
@@ -24,6 +26,8 @@ import (
 
 const (
 	Schema = "etcd-endpoints"
+
+	defaultLoadBalancingPolicy = "round_robin"
 )
 
 // EtcdManualResolver is a Resolver (and resolver.Builder) that can be updated
@@ -31,17 +35,24 @@ const (
 type EtcdManualResolver struct {
 	*manual.Resolver
 	endpoints     []string
+	policy        string
 	serviceConfig *serviceconfig.ParseResult
 }
 
 func New(endpoints ...string) *EtcdManualResolver {
+	return NewWithPolicy(defaultLoadBalancingPolicy, endpoints...)
+}
+
+// NewWithPolicy returns a resolver that configures the given load balancing
+// policy on the connections it is used for.
+func NewWithPolicy(policy string, endpoints ...string) *EtcdManualResolver {
 	r := manual.NewBuilderWithScheme(Schema)
-	return &EtcdManualResolver{Resolver: r, endpoints: endpoints, serviceConfig: nil}
+	return &EtcdManualResolver{Resolver: r, endpoints: endpoints, policy: policy, serviceConfig: nil}
 }
 
 // Build returns itself for Resolver, because it's both a builder and a resolver.
 func (r *EtcdManualResolver) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
-	r.serviceConfig = cc.ParseServiceConfig(`{"loadBalancingPolicy": "round_robin"}`)
+	r.serviceConfig = cc.ParseServiceConfig(fmt.Sprintf(`{"loadBalancingPolicy": %q}`, r.policy))
 	if r.serviceConfig.Err != nil {
 		return nil, r.serviceConfig.Err
 	}
@@ -16,10 +16,16 @@ package clientv3
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
 
 	"google.golang.org/grpc"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 )
 
@@ -65,11 +71,33 @@ type KV interface {
 	Txn(ctx context.Context) Txn
 }
 
+// CASMismatch is returned by CompareAndSwap when the key was modified
+// after the expected revision.
+type CASMismatch struct {
+	// Key is the key that failed to be swapped.
+	Key string
+	// ExpectedModRev is the modification revision expected by the caller.
+	ExpectedModRev int64
+	// Current is the current key-value pair, or nil if the key does not exist.
+	Current *mvccpb.KeyValue
+}
+
+func (e *CASMismatch) Error() string {
+	var modRev int64
+	if e.Current != nil {
+		modRev = e.Current.ModRevision
+	}
+	return fmt.Sprintf("compare-and-swap of key %q failed: expected mod revision %d, got %d", e.Key, e.ExpectedModRev, modRev)
+}
+
 type OpResponse struct {
 	put *PutResponse
 	get *GetResponse
 	del *DeleteResponse
 	txn *TxnResponse
+	// degraded is set if a Get ranged at the current revision because the
+	// requested revision was compacted.
+	degraded bool
 }
 
 func (op OpResponse) Put() *PutResponse    { return op.put }
@@ -77,6 +105,10 @@ func (op OpResponse) Get() *GetResponse    { return op.get }
 func (op OpResponse) Del() *DeleteResponse { return op.del }
 func (op OpResponse) Txn() *TxnResponse    { return op.txn }
 
+// Degraded returns true if a Get with WithCompactedFallback ranged at the
+// current revision because the requested revision was compacted.
+func (op OpResponse) Degraded() bool { return op.degraded }
+
 func (resp *PutResponse) OpResponse() OpResponse {
 	return OpResponse{put: resp}
 }
@@ -129,6 +161,117 @@ func (kv *kv) Delete(ctx context.Context, key string, opts ...OpOption) (*Delete
 	return r.del, ContextError(ctx, err)
 }
 
+// batchTxnOps is the number of keys DeleteKeys and PutAll write per
+// transaction.
+const batchTxnOps = 128
+
+// DeleteKeys deletes the given keys through kv and returns the number of
+// deleted keys. The options are applied to the delete of each key. Keys are
+// deleted atomically in transactions of up to 128 keys, the default
+// "--max-txn-ops" of etcd servers; a larger set of keys is not deleted
+// atomically, and on error the keys of already committed transactions stay
+// deleted and are included in the returned count.
+func DeleteKeys(ctx context.Context, kv KV, keys []string, opts ...OpOption) (int64, error) {
+	var deleted int64
+	for len(keys) > 0 {
+		n := min(len(keys), batchTxnOps)
+		ops := make([]Op, n)
+		for i, key := range keys[:n] {
+			ops[i] = OpDelete(key, opts...)
+		}
+		resp, err := kv.Txn(ctx).Then(ops...).Commit()
+		if err != nil {
+			return deleted, err
+		}
+		for _, r := range resp.Responses {
+			deleted += r.GetResponseDeleteRange().Deleted
+		}
+		keys = keys[n:]
+	}
+	return deleted, nil
+}
+
+// PutAll puts the given key-value pairs through kv and returns the revision
+// of the last write. The options are applied to the put of each key. Keys
+// are put in sorted order, atomically in transactions of up to 128 keys, the
+// default "--max-txn-ops" of etcd servers; a larger set of keys is not put
+// atomically, and each transaction commits at its own revision. On error,
+// the keys of already committed transactions stay written and the revision
+// of the last committed transaction, if any, is returned.
+func PutAll(ctx context.Context, kv KV, kvs map[string]string, opts ...OpOption) (int64, error) {
+	keys := slices.Sorted(maps.Keys(kvs))
+	var rev int64
+	for len(keys) > 0 {
+		n := min(len(keys), batchTxnOps)
+		ops := make([]Op, n)
+		for i, key := range keys[:n] {
+			ops[i] = OpPut(key, kvs[key], opts...)
+		}
+		resp, err := kv.Txn(ctx).Then(ops...).Commit()
+		if err != nil {
+			return rev, err
+		}
+		rev = resp.Header.Revision
+		keys = keys[n:]
+	}
+	return rev, nil
+}
+
+// ChunkedTxn applies ops in transactions of at most chunkSize operations,
+// so a set of operations larger than the "--max-txn-ops" of the server can
+// be applied; a chunkSize of 0 uses 128, the default "--max-txn-ops". The
+// chunks are applied in order, but the set is NOT applied atomically: each
+// chunk is a separate transaction committed at its own revision, other
+// writes may interleave between chunks. The returned response holds the
+// responses of all operations in order and the header of the last committed
+// chunk. On error, the already committed chunks stay applied, and the
+// returned response holds their responses, or is nil if no chunk was
+// committed.
+func ChunkedTxn(ctx context.Context, kv KV, ops []Op, chunkSize int) (*TxnResponse, error) {
+	if chunkSize <= 0 {
+		chunkSize = batchTxnOps
+	}
+	var tresp *TxnResponse
+	for {
+		n := min(len(ops), chunkSize)
+		resp, err := kv.Txn(ctx).Then(ops[:n]...).Commit()
+		if err != nil {
+			return tresp, err
+		}
+		if tresp == nil {
+			tresp = &TxnResponse{Succeeded: true}
+		}
+		tresp.Header = resp.Header
+		tresp.Responses = append(tresp.Responses, resp.Responses...)
+		if ops = ops[n:]; len(ops) == 0 {
+			return tresp, nil
+		}
+	}
+}
+
+// CompareAndSwap puts newVal into key through kv only if the key was last
+// modified at expectedModRev; an expectedModRev of 0 requires the key to not
+// exist. If the key was modified since, it returns a *CASMismatch error
+// carrying the current key-value pair.
+func CompareAndSwap(ctx context.Context, kv KV, key string, expectedModRev int64, newVal string, opts ...OpOption) (*PutResponse, error) {
+	resp, err := kv.Txn(ctx).
+		If(Compare(ModRevision(key), "=", expectedModRev)).
+		Then(OpPut(key, newVal, opts...)).
+		Else(OpGet(key)).
+		Commit()
+	if err != nil {
+		return nil, err
+	}
+	if resp.Succeeded {
+		return (*PutResponse)(resp.Responses[0].GetResponsePut()), nil
+	}
+	mismatch := &CASMismatch{Key: key, ExpectedModRev: expectedModRev}
+	if kvs := resp.Responses[0].GetResponseRange().Kvs; len(kvs) > 0 {
+		mismatch.Current = kvs[0]
+	}
+	return nil, mismatch
+}
+
 func (kv *kv) Compact(ctx context.Context, rev int64, opts ...CompactOption) (*CompactResponse, error) {
 	resp, err := kv.remote.Compact(ctx, OpCompact(rev, opts...).toRequest(), kv.callOpts...)
 	if err != nil {
@@ -137,6 +280,83 @@ func (kv *kv) Compact(ctx context.Context, rev int64, opts ...CompactOption) (*C
 	return (*CompactResponse)(resp), nil
 }
 
+// Paginate returns an iterator over the keys of kv in the range [key, end),
+// yielding pages of at most pageSize keys. All pages are read at the
+// revision of the first page; if that revision is compacted before the
+// iteration completes, ErrCompacted is yielded. Iteration stops after the
+// first error.
+func Paginate(ctx context.Context, kv KV, key, end string, pageSize int64) iter.Seq2[*GetResponse, error] {
+	return paginate(ctx, kv, key, end, pageSize)
+}
+
+func paginate(ctx context.Context, kv KV, key, end string, pageSize int64, extraOpts ...OpOption) iter.Seq2[*GetResponse, error] {
+	return func(yield func(*GetResponse, error) bool) {
+		var rev int64
+		for {
+			opts := append([]OpOption{WithLimit(pageSize), WithRange(end)}, extraOpts...)
+			if rev != 0 {
+				opts = append(opts, WithRev(rev))
+			}
+			resp, err := kv.Get(ctx, key, opts...)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if rev == 0 {
+				rev = resp.Header.Revision
+			}
+			if !yield(resp, nil) || !resp.More || len(resp.Kvs) == 0 {
+				return
+			}
+			// continue right after the last key of the page
+			key = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+		}
+	}
+}
+
+// scanKeysPageSize is the number of keys ScanKeys reads per request.
+const scanKeysPageSize = 1000
+
+// ScanKeys streams the keys of kv with the given prefix without their
+// values, reading them in pages at the revision of the first page so the
+// full key list is never held in memory. The key channel is closed once all
+// keys are sent or on error; at most one error is then sent on the error
+// channel, which is closed afterwards. Canceling ctx stops the scan.
+func ScanKeys(ctx context.Context, kv KV, prefix string) (<-chan string, <-chan error) {
+	keyc := make(chan string, scanKeysPageSize)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		err := scanKeys(ctx, kv, prefix, keyc)
+		close(keyc)
+		if err != nil {
+			errc <- err
+		}
+	}()
+	return keyc, errc
+}
+
+func scanKeys(ctx context.Context, kv KV, prefix string, keyc chan<- string) error {
+	key, end := prefix, GetPrefixRangeEnd(prefix)
+	if key == "" {
+		// an empty prefix scans all keys, as with WithPrefix
+		key = "\x00"
+	}
+	for resp, err := range paginate(ctx, kv, key, end, scanKeysPageSize, WithKeysOnly()) {
+		if err != nil {
+			return err
+		}
+		for _, ekv := range resp.Kvs {
+			select {
+			case keyc <- string(ekv.Key):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
+
 func (kv *kv) Txn(ctx context.Context) Txn {
 	return &txn{
 		kv:       kv,
@@ -151,9 +371,16 @@ func (kv *kv) Do(ctx context.Context, op Op) (OpResponse, error) {
 	case tRange:
 		if op.IsSortOptionValid() {
 			var resp *pb.RangeResponse
-			resp, err = kv.remote.Range(ctx, op.toRangeRequest(), kv.callOpts...)
+			req := op.toRangeRequest()
+			resp, err = kv.remote.Range(ctx, req, kv.callOpts...)
+			degraded := false
+			if err != nil && op.compactedFallback && req.Revision > 0 && errors.Is(rpctypes.Error(err), rpctypes.ErrCompacted) {
+				req.Revision = 0
+				resp, err = kv.remote.Range(ctx, req, kv.callOpts...)
+				degraded = true
+			}
 			if err == nil {
-				return OpResponse{get: (*GetResponse)(resp)}, nil
+				return OpResponse{get: (*GetResponse)(resp), degraded: degraded}, nil
 			}
 		} else {
 			err = rpctypes.ErrInvalidSortOption
@@ -48,6 +48,13 @@ type LeaseKeepAliveResponse struct {
 	TTL int64
 }
 
+// KeepAliveOnceResult is the outcome of renewing a single lease with
+// KeepAliveOnceMulti. Exactly one of Response and Err is set.
+type KeepAliveOnceResult struct {
+	Response *LeaseKeepAliveResponse
+	Err      error
+}
+
 // LeaseTimeToLiveResponse wraps the protobuf message LeaseTimeToLiveResponse.
 type LeaseTimeToLiveResponse struct {
 	*pb.ResponseHeader
@@ -143,6 +150,19 @@ type Lease interface {
 	// In most of the cases, Keepalive should be used instead of KeepAliveOnce.
 	KeepAliveOnce(ctx context.Context, id LeaseID) (*LeaseKeepAliveResponse, error)
 
+	// KeepAliveOnceMulti renews each of the given leases once. The keep alive
+	// messages are pipelined on a single stream instead of one stream per lease.
+	// The result for each lease holds either its response or the error that
+	// KeepAliveOnce would have returned for it.
+	KeepAliveOnceMulti(ctx context.Context, ids ...LeaseID) map[LeaseID]KeepAliveOnceResult
+
+	// Expirations returns a channel that receives the IDs of leases revoked
+	// after the call, whether they expired or were revoked explicitly. The
+	// channel is closed once "ctx" is canceled or the lease client is closed.
+	// Revocations that happen while the underlying stream is re-established
+	// are not reported.
+	Expirations(ctx context.Context) <-chan LeaseID
+
 	// Close releases all resources Lease keeps for efficient communication
 	// with the etcd server.
 	Close() error
@@ -331,6 +351,69 @@ func (l *lessor) KeepAliveOnce(ctx context.Context, id LeaseID) (*LeaseKeepAlive
 	}
 }
 
+func (l *lessor) KeepAliveOnceMulti(ctx context.Context, ids ...LeaseID) map[LeaseID]KeepAliveOnceResult {
+	results := make(map[LeaseID]KeepAliveOnceResult, len(ids))
+	pending := make(map[LeaseID]struct{}, len(ids))
+	for _, id := range ids {
+		pending[id] = struct{}{}
+	}
+	for len(pending) > 0 {
+		err := l.keepAliveOnceMulti(ctx, pending, results)
+		if err == nil {
+			break
+		}
+		if isHaltErr(ctx, err) {
+			err = ContextError(ctx, err)
+			for id := range pending {
+				results[id] = KeepAliveOnceResult{Err: err}
+			}
+			break
+		}
+	}
+	return results
+}
+
+func (l *lessor) Expirations(ctx context.Context) <-chan LeaseID {
+	ch := make(chan LeaseID)
+	go l.expirationsLoop(ctx, ch)
+	return ch
+}
+
+// expirationsLoop posts revoked lease IDs to ch until ctx is done or the
+// lessor is closed, re-establishing the stream on errors.
+func (l *lessor) expirationsLoop(ctx context.Context, ch chan<- LeaseID) {
+	defer close(ch)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(l.stopCtx, cancel)
+	defer stop()
+
+	for {
+		stream, err := l.remote.LeaseExpirations(ctx, &pb.LeaseExpirationsRequest{}, l.callOpts...)
+		for err == nil {
+			var resp *pb.LeaseExpirationsResponse
+			if resp, err = stream.Recv(); err != nil {
+				break
+			}
+			select {
+			case ch <- LeaseID(resp.ID):
+			case <-ctx.Done():
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		l.lg.Warn("error occurred during lease expirations stream", zap.Error(err))
+
+		select {
+		case <-time.After(retryConnWait):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (l *lessor) Close() error {
 	l.stopCancel()
 	// close for synchronous teardown if stream goroutines never launched
@@ -446,6 +529,55 @@ func (l *lessor) keepAliveOnce(ctx context.Context, id LeaseID) (karesp *LeaseKe
 	return karesp, nil
 }
 
+// keepAliveOnceMulti sends a keep alive for every pending lease on a single
+// stream. Leases are removed from pending as their results are recorded.
+func (l *lessor) keepAliveOnceMulti(ctx context.Context, pending map[LeaseID]struct{}, results map[LeaseID]KeepAliveOnceResult) error {
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := l.remote.LeaseKeepAlive(cctx, l.callOpts...)
+	if err != nil {
+		return ContextError(ctx, err)
+	}
+
+	ids := make([]LeaseID, 0, len(pending))
+	for id := range pending {
+		ids = append(ids, id)
+	}
+	// send from a separate goroutine so a large batch cannot block on flow
+	// control while responses are waiting to be received
+	go func() {
+		for _, id := range ids {
+			if err := stream.Send(&pb.LeaseKeepAliveRequest{ID: int64(id)}); err != nil {
+				return
+			}
+		}
+		stream.CloseSend()
+	}()
+
+	for range ids {
+		resp, rerr := stream.Recv()
+		if rerr != nil {
+			return ContextError(ctx, rerr)
+		}
+		id := LeaseID(resp.ID)
+		if _, ok := pending[id]; !ok {
+			continue
+		}
+		delete(pending, id)
+		if resp.TTL <= 0 {
+			results[id] = KeepAliveOnceResult{Err: rpctypes.ErrLeaseNotFound}
+			continue
+		}
+		results[id] = KeepAliveOnceResult{Response: &LeaseKeepAliveResponse{
+			ResponseHeader: resp.GetHeader(),
+			ID:             id,
+			TTL:            resp.TTL,
+		}}
+	}
+	return nil
+}
+
 func (l *lessor) recvKeepAliveLoop() (gerr error) {
 	defer func() {
 		l.mu.Lock()
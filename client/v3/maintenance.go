@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -27,15 +28,24 @@ import (
 )
 
 type (
-	DefragmentResponse pb.DefragmentResponse
-	AlarmResponse      pb.AlarmResponse
-	AlarmMember        pb.AlarmMember
-	StatusResponse     pb.StatusResponse
-	HashKVResponse     pb.HashKVResponse
-	MoveLeaderResponse pb.MoveLeaderResponse
-	DowngradeResponse  pb.DowngradeResponse
+	DefragmentResponse          pb.DefragmentResponse
+	AlarmResponse               pb.AlarmResponse
+	AlarmMember                 pb.AlarmMember
+	StatusResponse              pb.StatusResponse
+	HashKVResponse              pb.HashKVResponse
+	MoveLeaderResponse          pb.MoveLeaderResponse
+	DowngradeResponse           pb.DowngradeResponse
+	ForceSnapshotResponse       pb.ForceSnapshotResponse
+	MemberRemovePreviewResponse pb.MemberRemovePreviewResponse
+	LinearizableProbeResponse   pb.LinearizableProbeResponse
+	BucketStatsResponse         pb.BucketStatsResponse
+	SetCommitModeResponse       pb.SetCommitModeResponse
+	RevisionSinceResponse       pb.RevisionSinceResponse
+	StoreRevisionResponse       pb.StoreRevisionResponse
+	CancelCompactionResponse    pb.CancelCompactionResponse
 
 	DowngradeAction pb.DowngradeRequest_DowngradeAction
+	CommitMode      pb.SetCommitModeRequest_CommitMode
 )
 
 const (
@@ -44,6 +54,11 @@ const (
 	DowngradeCancel   = DowngradeAction(pb.DowngradeRequest_CANCEL)
 )
 
+const (
+	CommitModeBatched = CommitMode(pb.SetCommitModeRequest_BATCHED)
+	CommitModeSync    = CommitMode(pb.SetCommitModeRequest_SYNC)
+)
+
 type Maintenance interface {
 	// AlarmList gets all active alarms.
 	AlarmList(ctx context.Context) (*AlarmResponse, error)
@@ -51,6 +66,11 @@ type Maintenance interface {
 	// AlarmDisarm disarms a given alarm.
 	AlarmDisarm(ctx context.Context, m *AlarmMember) (*AlarmResponse, error)
 
+	// AlarmActivate raises a given alarm for a member. Raising READONLY
+	// rejects writes to the cluster until the alarm is disarmed; it fails
+	// with rpctypes.ErrReadOnlyUnsupported until the cluster version is 3.7.
+	AlarmActivate(ctx context.Context, m *AlarmMember) (*AlarmResponse, error)
+
 	// Defragment releases wasted space from internal fragmentation on a given etcd member.
 	// Defragment is only needed when deleting a large number of keys and want to reclaim
 	// the resources.
@@ -60,6 +80,12 @@ type Maintenance interface {
 	// times with different endpoints.
 	Defragment(ctx context.Context, endpoint string) (*DefragmentResponse, error)
 
+	// DefragmentWithProgress defragments a given etcd member like Defragment, calling
+	// progress with the number of key and value bytes copied so far and the total
+	// number of bytes to copy as the member reports them.
+	// Supported since etcd 3.7.
+	DefragmentWithProgress(ctx context.Context, endpoint string, progress func(processed, total int64)) (*DefragmentResponse, error)
+
 	// Status gets the status of the endpoint.
 	Status(ctx context.Context, endpoint string) (*StatusResponse, error)
 
@@ -87,6 +113,57 @@ type Maintenance interface {
 	// on the cluster version.
 	// Supported since etcd 3.5.
 	Downgrade(ctx context.Context, action DowngradeAction, version string) (*DowngradeResponse, error)
+
+	// ForceSnapshot forces the given etcd member to save a raft snapshot to disk,
+	// independent of the configured snapshot count.
+	// Supported since etcd 3.7.
+	ForceSnapshot(ctx context.Context, endpoint string) (*ForceSnapshotResponse, error)
+
+	// MemberRemovePreview reports whether the given etcd member would accept
+	// removing the member with the given id, and the quorum the cluster
+	// would be left with, without removing it.
+	// Supported since etcd 3.7.
+	MemberRemovePreview(ctx context.Context, endpoint string, id uint64) (*MemberRemovePreviewResponse, error)
+
+	// LinearizableProbe confirms with a quorum of the cluster that the
+	// serving member is up to date, as a linearizable read would, without
+	// reading any keys. It fails right away if the member has no leader,
+	// which makes it suitable for load balancer health checks. The response
+	// reports the member and raft term in its header.
+	// Supported since etcd 3.7.
+	LinearizableProbe(ctx context.Context) (*LinearizableProbeResponse, error)
+
+	// BucketStats returns the number of keys and their total size in bytes
+	// for each bucket of the given etcd member's backend.
+	// Supported since etcd 3.7.
+	BucketStats(ctx context.Context, endpoint string) (*BucketStatsResponse, error)
+
+	// SetCommitMode switches the backend of the given etcd member between
+	// committing writes in batches and committing every write transaction
+	// as soon as it is applied. The member must run with the
+	// RuntimeCommitMode feature gate enabled.
+	// Supported since etcd 3.7.
+	SetCommitMode(ctx context.Context, endpoint string, mode CommitMode) (*SetCommitModeResponse, error)
+
+	// RevisionSince returns the first revision committed at or after t, as
+	// recorded by the serving member. It fails with rpctypes.ErrCompacted if
+	// the revisions around t were compacted, and with
+	// rpctypes.ErrRevisionTimeUnknown if t precedes the member's last start.
+	// Supported since etcd 3.7.
+	RevisionSince(ctx context.Context, t time.Time) (*RevisionSinceResponse, error)
+
+	// StoreRevision returns the current store revision of the serving member
+	// in the response header, without reading any keys or going through raft.
+	// Supported since etcd 3.7.
+	StoreRevision(ctx context.Context) (*StoreRevisionResponse, error)
+
+	// CancelCompaction aborts the physical compactions the given etcd member
+	// is running or has scheduled. The compacted revisions stay compacted;
+	// the member frees the rest of their space on the next compaction or
+	// restart. Physical compaction runs on each member independently, so
+	// this has to be called for every member running the compaction.
+	// Supported since etcd 3.7.
+	CancelCompaction(ctx context.Context, endpoint string) (*CancelCompactionResponse, error)
 }
 
 // SnapshotResponse is aggregated response from the snapshot stream.
@@ -188,6 +265,19 @@ func (m *maintenance) AlarmDisarm(ctx context.Context, am *AlarmMember) (*AlarmR
 	return nil, ContextError(ctx, err)
 }
 
+func (m *maintenance) AlarmActivate(ctx context.Context, am *AlarmMember) (*AlarmResponse, error) {
+	req := &pb.AlarmRequest{
+		Action:   pb.AlarmRequest_ACTIVATE,
+		MemberID: am.MemberID,
+		Alarm:    am.Alarm,
+	}
+	resp, err := m.remote.Alarm(ctx, req, m.callOpts...)
+	if err == nil {
+		return (*AlarmResponse)(resp), nil
+	}
+	return nil, ContextError(ctx, err)
+}
+
 func (m *maintenance) Defragment(ctx context.Context, endpoint string) (*DefragmentResponse, error) {
 	remote, cancel, err := m.dial(endpoint)
 	if err != nil {
@@ -201,6 +291,32 @@ func (m *maintenance) Defragment(ctx context.Context, endpoint string) (*Defragm
 	return (*DefragmentResponse)(resp), nil
 }
 
+func (m *maintenance) DefragmentWithProgress(ctx context.Context, endpoint string, progress func(processed, total int64)) (*DefragmentResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	stream, err := remote.DefragmentWithProgress(ctx, &pb.DefragmentRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	resp := &DefragmentResponse{}
+	for {
+		presp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return resp, nil
+		}
+		if err != nil {
+			return nil, ContextError(ctx, err)
+		}
+		resp.Header = presp.Header
+		if progress != nil {
+			progress(presp.ProcessedBytes, presp.TotalBytes)
+		}
+	}
+}
+
 func (m *maintenance) Status(ctx context.Context, endpoint string) (*StatusResponse, error) {
 	remote, cancel, err := m.dial(endpoint)
 	if err != nil {
@@ -348,3 +464,92 @@ func (m *maintenance) Downgrade(ctx context.Context, action DowngradeAction, ver
 	resp, err := m.remote.Downgrade(ctx, &pb.DowngradeRequest{Action: actionType, Version: version}, m.callOpts...)
 	return (*DowngradeResponse)(resp), ContextError(ctx, err)
 }
+
+func (m *maintenance) ForceSnapshot(ctx context.Context, endpoint string) (*ForceSnapshotResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.ForceSnapshot(ctx, &pb.ForceSnapshotRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*ForceSnapshotResponse)(resp), nil
+}
+
+func (m *maintenance) MemberRemovePreview(ctx context.Context, endpoint string, id uint64) (*MemberRemovePreviewResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.MemberRemovePreview(ctx, &pb.MemberRemovePreviewRequest{ID: id}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*MemberRemovePreviewResponse)(resp), nil
+}
+
+func (m *maintenance) LinearizableProbe(ctx context.Context) (*LinearizableProbeResponse, error) {
+	resp, err := m.remote.LinearizableProbe(ctx, &pb.LinearizableProbeRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*LinearizableProbeResponse)(resp), nil
+}
+
+func (m *maintenance) BucketStats(ctx context.Context, endpoint string) (*BucketStatsResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.BucketStats(ctx, &pb.BucketStatsRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*BucketStatsResponse)(resp), nil
+}
+
+func (m *maintenance) SetCommitMode(ctx context.Context, endpoint string, mode CommitMode) (*SetCommitModeResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.SetCommitMode(ctx, &pb.SetCommitModeRequest{Mode: pb.SetCommitModeRequest_CommitMode(mode)}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*SetCommitModeResponse)(resp), nil
+}
+
+func (m *maintenance) RevisionSince(ctx context.Context, t time.Time) (*RevisionSinceResponse, error) {
+	resp, err := m.remote.RevisionSince(ctx, &pb.RevisionSinceRequest{Time: t.UnixNano()}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*RevisionSinceResponse)(resp), nil
+}
+
+func (m *maintenance) StoreRevision(ctx context.Context) (*StoreRevisionResponse, error) {
+	resp, err := m.remote.StoreRevision(ctx, &pb.StoreRevisionRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*StoreRevisionResponse)(resp), nil
+}
+
+func (m *maintenance) CancelCompaction(ctx context.Context, endpoint string) (*CancelCompactionResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.CancelCompaction(ctx, &pb.CancelCompactionRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*CancelCompactionResponse)(resp), nil
+}
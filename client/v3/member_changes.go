@@ -0,0 +1,104 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+// defaultMemberChangesInterval is how often MemberChanges polls the member
+// list when Config.AutoSyncInterval is not set.
+const defaultMemberChangesInterval = 5 * time.Second
+
+// MemberChanges reports changes to the cluster membership. It sends the
+// current member list right away, then polls it every Config.AutoSyncInterval
+// (5s if unset) and sends it again whenever a member was added, removed or
+// promoted, or changed its name, peer URLs or client URLs, e.g. after
+// restarting with new advertised client URLs. Each time a list is sent, the
+// client endpoints are first updated to the client URLs of the started voting
+// members, as Sync does.
+//
+// The returned channel is closed when ctx is canceled or the client is closed.
+// Failed polls are logged and retried at the next interval.
+func (c *Client) MemberChanges(ctx context.Context) <-chan MemberListResponse {
+	interval := c.cfg.AutoSyncInterval
+	if interval == 0 {
+		interval = defaultMemberChangesInterval
+	}
+	ch := make(chan MemberListResponse)
+	go func() {
+		defer close(ch)
+		var last *MemberListResponse
+		for {
+			mctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			mresp, err := c.MemberList(mctx)
+			cancel()
+			switch {
+			case err != nil:
+				if ctx.Err() == nil && c.ctx.Err() == nil {
+					c.lg.Info("failed to poll member list", zap.Error(err))
+				}
+			case last == nil || membersChanged(last.Members, mresp.Members):
+				last = mresp
+				c.syncEndpoints(mresp)
+				select {
+				case ch <- *mresp:
+				case <-ctx.Done():
+					return
+				case <-c.ctx.Done():
+					return
+				}
+			}
+
+			t := time.NewTimer(interval)
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				t.Stop()
+				return
+			case <-c.ctx.Done():
+				t.Stop()
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// membersChanged returns true if the two member lists differ in any member or
+// member attribute, regardless of their order.
+func membersChanged(a, b []*pb.Member) bool {
+	if len(a) != len(b) {
+		return true
+	}
+	byID := make(map[uint64]*pb.Member, len(a))
+	for _, m := range a {
+		byID[m.ID] = m
+	}
+	for _, m := range b {
+		o, ok := byID[m.ID]
+		if !ok || o.Name != m.Name || o.IsLearner != m.IsLearner ||
+			!slices.Equal(o.PeerURLs, m.PeerURLs) || !slices.Equal(o.ClientURLs, m.ClientURLs) {
+			return true
+		}
+	}
+	return false
+}
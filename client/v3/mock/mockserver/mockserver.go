@@ -205,6 +205,10 @@ func (s *mockLeaseServer) LeaseKeepAlive(pb.Lease_LeaseKeepAliveServer) error {
 	return nil
 }
 
+func (s *mockLeaseServer) LeaseExpirations(*pb.LeaseExpirationsRequest, pb.Lease_LeaseExpirationsServer) error {
+	return nil
+}
+
 func (s *mockLeaseServer) LeaseTimeToLive(context.Context, *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error) {
 	return &pb.LeaseTimeToLiveResponse{}, nil
 }
@@ -14,7 +14,11 @@
 
 package clientv3
 
-import pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+import (
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
 
 type opType int
 
@@ -44,6 +48,8 @@ type Op struct {
 	maxModRev    int64
 	minCreateRev int64
 	maxCreateRev int64
+	// compactedFallback ranges at the current revision if rev is compacted
+	compactedFallback bool
 
 	// for range, watch
 	rev int64
@@ -56,6 +62,14 @@ type Op struct {
 	// if true, split watch events when total exceeds
 	// "--max-request-bytes" flag value + 512-byte
 	fragment bool
+	// compress requests the server to compress watch events
+	compress bool
+	// rawEvents delivers watch events undecoded
+	rawEvents bool
+	// staleOK keeps the watch open while the server has no leader
+	staleOK bool
+	// authRevisionNotify watches the auth revision instead of keys
+	authRevisionNotify bool
 
 	// for put
 	ignoreValue bool
@@ -63,11 +77,30 @@ type Op struct {
 
 	// progressNotify is for progress updates.
 	progressNotify bool
+	// progressNotifyInterval overrides the server's progress interval.
+	progressNotifyInterval time.Duration
+	// maxEventRate caps the events per second the server sends a watcher.
+	maxEventRate int64
+	// valueProjection is the dotted path watched values are projected to.
+	valueProjection string
 	// createdNotify is for created event
 	createdNotify bool
 	// filters for watchers
 	filterPut    bool
 	filterDelete bool
+	filterModify bool
+	// batchInterval is how long a watcher coalesces events before delivery.
+	batchInterval time.Duration
+	// latestPerKey collapses the events of a watch response to one per key.
+	latestPerKey bool
+	// healthCheckInterval is how long a watcher may go without responses
+	// before its stream is probed with a progress request.
+	healthCheckInterval time.Duration
+	// healthProbe lets the health check request the progress of the watcher.
+	healthProbe *watchProbe
+	// revSince is the time from which a watcher starts, resolved to a
+	// revision when the watch is created.
+	revSince time.Time
 
 	// for put
 	val     []byte
@@ -140,9 +173,30 @@ func (op Op) IsPrevKV() bool { return op.prevKV }
 // IsFragment returns whether WithFragment() is set.
 func (op Op) IsFragment() bool { return op.fragment }
 
+// IsCompression returns whether WithCompression() is set.
+func (op Op) IsCompression() bool { return op.compress }
+
+// IsRawEvents returns whether WithRawEvents() is set.
+func (op Op) IsRawEvents() bool { return op.rawEvents }
+
+// IsStaleOK returns whether WithStaleOK() is set.
+func (op Op) IsStaleOK() bool { return op.staleOK }
+
+// IsAuthRevisionNotify returns whether WithAuthRevisionNotify() is set.
+func (op Op) IsAuthRevisionNotify() bool { return op.authRevisionNotify }
+
 // IsProgressNotify returns whether WithProgressNotify() is set.
 func (op Op) IsProgressNotify() bool { return op.progressNotify }
 
+// ProgressNotifyInterval returns the interval set by WithProgressNotifyInterval().
+func (op Op) ProgressNotifyInterval() time.Duration { return op.progressNotifyInterval }
+
+// MaxEventRate returns the rate set by WithMaxEventRate().
+func (op Op) MaxEventRate() int64 { return op.maxEventRate }
+
+// ValueProjection returns the path set by WithValueProjection().
+func (op Op) ValueProjection() string { return op.valueProjection }
+
 // IsCreatedNotify returns whether WithCreatedNotify() is set.
 func (op Op) IsCreatedNotify() bool { return op.createdNotify }
 
@@ -152,6 +206,15 @@ func (op Op) IsFilterPut() bool { return op.filterPut }
 // IsFilterDelete returns whether WithFilterDelete() is set.
 func (op Op) IsFilterDelete() bool { return op.filterDelete }
 
+// IsFilterModify returns whether WithFilterModify() is set.
+func (op Op) IsFilterModify() bool { return op.filterModify }
+
+// BatchInterval returns the interval set by WithBatchInterval(), if any.
+func (op Op) BatchInterval() time.Duration { return op.batchInterval }
+
+// IsLatestPerKey returns whether WithLatestPerKey() is set.
+func (op Op) IsLatestPerKey() bool { return op.latestPerKey }
+
 // MinModRev returns the operation's minimum modify revision.
 func (op Op) MinModRev() int64 { return op.minModRev }
 
@@ -287,7 +350,7 @@ func OpDelete(key string, opts ...OpOption) Op {
 		panic("unexpected mod revision filter in delete")
 	case ret.minCreateRev != 0, ret.maxCreateRev != 0:
 		panic("unexpected create revision filter in delete")
-	case ret.filterDelete, ret.filterPut:
+	case ret.filterDelete, ret.filterPut, ret.filterModify:
 		panic("unexpected filter in delete")
 	case ret.createdNotify:
 		panic("unexpected createdNotify in delete")
@@ -316,7 +379,7 @@ func OpPut(key, val string, opts ...OpOption) Op {
 		panic("unexpected mod revision filter in put")
 	case ret.minCreateRev != 0, ret.maxCreateRev != 0:
 		panic("unexpected create revision filter in put")
-	case ret.filterDelete, ret.filterPut:
+	case ret.filterDelete, ret.filterPut, ret.filterModify:
 		panic("unexpected filter in put")
 	case ret.createdNotify:
 		panic("unexpected createdNotify in put")
@@ -459,6 +522,15 @@ func WithSerializable() OpOption {
 	return func(op *Op) { op.serializable = true }
 }
 
+// WithCompactedFallback makes a 'Get' request at a revision set by WithRev
+// range at the current revision instead if the revision was compacted,
+// rather than fail with rpctypes.ErrCompacted. The OpResponse of such a
+// fallback returned by KV.Do reports Degraded. It has no effect on the
+// ranges of a Txn.
+func WithCompactedFallback() OpOption {
+	return func(op *Op) { op.compactedFallback = true }
+}
+
 // WithKeysOnly makes the 'Get' request return only the keys and the corresponding
 // values will be omitted.
 func WithKeysOnly() OpOption {
@@ -514,6 +586,41 @@ func WithProgressNotify() OpOption {
 	}
 }
 
+// WithProgressNotifyInterval makes watch server send progress updates every
+// interval when there is no incoming events, instead of at the server's
+// configured interval. The server raises intervals below its minimum of
+// 100ms, and servers that do not support the option use their own interval.
+func WithProgressNotifyInterval(interval time.Duration) OpOption {
+	return func(op *Op) {
+		op.progressNotify = true
+		op.progressNotifyInterval = interval
+	}
+}
+
+// WithMaxEventRate makes the watch server send at most n events per second
+// to the watcher. Events beyond the rate are held back by the server until
+// they can be sent; meanwhile a held put is dropped once a later event of the
+// same key is held, so only the latest value of a frequently updated key is
+// delivered, while deletes are always delivered. If the server holds more
+// than a minute worth of events, the watch is canceled and its last response
+// carries rpctypes.ErrWatchRateExceeded. Servers that do not support the option
+// send events as they happen.
+func WithMaxEventRate(n int64) OpOption {
+	return func(op *Op) { op.maxEventRate = n }
+}
+
+// WithValueProjection makes the watch server project the JSON object values
+// of the events to the field at path, a dotted list of field names such as
+// "spec.replicas". The values of the events, and of their previous key-values
+// with WithPrevKV, hold the compacted JSON encoding of the field, or are empty
+// if there is no such field. Comparing the value of an event to that of its
+// previous key-value tells whether a put changed the field. A path with an
+// empty field name fails the watch. Servers that do not support the option
+// send whole values.
+func WithValueProjection(path string) OpOption {
+	return func(op *Op) { op.valueProjection = path }
+}
+
 // WithCreatedNotify makes watch server sends the created event.
 func WithCreatedNotify() OpOption {
 	return func(op *Op) {
@@ -531,6 +638,71 @@ func WithFilterDelete() OpOption {
 	return func(op *Op) { op.filterDelete = true }
 }
 
+// WithFilterModify discards PUT events that modify an existing key from the
+// watcher, keeping those for which Event.IsCreate is true.
+func WithFilterModify() OpOption {
+	return func(op *Op) { op.filterModify = true }
+}
+
+// WithCreateOnly makes the watcher receive only events that create a key,
+// i.e. for which Event.IsCreate is true. Both modifications and deletions are
+// filtered out on the server; use WithFilterModify alone to also receive
+// deletions.
+func WithCreateOnly() OpOption {
+	return func(op *Op) {
+		op.filterModify = true
+		op.filterDelete = true
+	}
+}
+
+// WithBatchInterval makes the watcher coalesce events on the client side for up
+// to the given interval and deliver them as a single WatchResponse. The merged
+// response carries the highest header revision seen in the batch and keeps the
+// events in the order they were received. Pending events are flushed right away
+// when a progress notification, cancellation or error arrives. A zero interval
+// disables batching.
+func WithBatchInterval(d time.Duration) OpOption {
+	return func(op *Op) { op.batchInterval = d }
+}
+
+// WithLatestPerKey makes the watcher collapse the events of each WatchResponse
+// so that every key appears once, with its most recent event. A key deleted by
+// its most recent event is delivered as a delete, even if it was put earlier in
+// the same response. The kept event is placed where the most recent event was
+// and, with WithPrevKV, carries the key-value pair from before the first
+// collapsed event. Combined with WithBatchInterval, the events of the whole
+// batch are collapsed.
+func WithLatestPerKey() OpOption {
+	return func(op *Op) { op.latestPerKey = true }
+}
+
+// WithHealthCheck makes the watcher detect a stalled watch stream. Once the
+// watcher receives no response for the given interval, a progress request is
+// issued on its stream; if still nothing arrives within another interval, the
+// watcher sends a final response whose Err() is ErrWatchStalled and closes
+// the channel. The time spent waiting for the receiver to read a response does
+// not count. Progress notifications received this way are delivered like
+// those requested with Watcher.RequestProgress, except that the request only
+// concerns the probed watcher, not the others sharing its stream. A zero
+// interval disables the health check.
+func WithHealthCheck(interval time.Duration) OpOption {
+	return func(op *Op) { op.healthCheckInterval = interval }
+}
+
+// withHealthProbe attaches the probe of a health check to the watcher.
+func withHealthProbe(p *watchProbe) OpOption {
+	return func(op *Op) { op.healthProbe = p }
+}
+
+// WithRevSince makes the watcher start from the first revision committed at
+// or after t, overriding WithRev. The revision is resolved with
+// Maintenance.RevisionSince when the watch is created; if that fails, for
+// instance because the revisions around t were compacted, the returned
+// channel carries a single canceled response with the error and is closed.
+func WithRevSince(t time.Time) OpOption {
+	return func(op *Op) { op.revSince = t }
+}
+
 // WithPrevKV gets the previous key-value pair before the event happens. If the previous KV is already compacted,
 // nothing will be returned.
 func WithPrevKV() OpOption {
@@ -540,16 +712,57 @@ func WithPrevKV() OpOption {
 }
 
 // WithFragment to receive raw watch response with fragmentation.
-// Fragmentation is disabled by default. If fragmentation is enabled,
-// etcd watch server will split watch response before sending to clients
-// when the total size of watch events exceed server-side request limit.
+// If fragmentation is enabled, etcd watch server will split watch response
+// before sending to clients when the total size of watch events exceed
+// server-side request limit.
 // The default server-side request limit is 1.5 MiB, which can be configured
 // as "--max-request-bytes" flag value + gRPC-overhead 512 bytes.
 // See "etcdserver/api/v3rpc/watch.go" for more details.
+// The client reassembles the fragments before delivering them on the watch
+// channel. Since v3.7, servers fragment such responses for all watchers of
+// clients that reassemble them, so this option only matters against older
+// servers.
 func WithFragment() OpOption {
 	return func(op *Op) { op.fragment = true }
 }
 
+// WithCompression requests the etcd watch server to compress the responses
+// sent to the watcher with gzip, which reduces the bandwidth of watches on
+// large values. Compressed watchers are placed on gRPC streams of their own,
+// whose responses are compressed and decompressed by gRPC. Servers that do not
+// support compression ignore this option and send uncompressed responses.
+func WithCompression() OpOption {
+	return func(op *Op) { op.compress = true }
+}
+
+// WithRawEvents delivers the events of the watcher in WatchResponse.RawEvents,
+// still encoded as received from the server, instead of in Events. Consumers
+// that forward events avoid the cost of decoding them; the server sends them
+// encoded so they are not decoded by gRPC either, unless it does not support
+// this option, in which case the client encodes them again. WithLatestPerKey
+// has no effect on raw events, and MergeWatch does not support them.
+func WithRawEvents() OpOption {
+	return func(op *Op) { op.rawEvents = true }
+}
+
+// WithStaleOK keeps the watcher open while the connected server has lost
+// its leader, even if the context was wrapped with "WithRequireLeader".
+// Responses served without a leader have "Stale" set; their events may lag
+// behind the rest of the cluster. Such watchers share a watch stream that
+// never requires a leader.
+func WithStaleOK() OpOption {
+	return func(op *Op) { op.staleOK = true }
+}
+
+// WithAuthRevisionNotify makes the watcher report changes of the server's
+// auth revision instead of key events; the watched key is ignored. A
+// response with "AuthRevision" set is sent whenever a user, role or the auth
+// status changes. Combine with "WithCreatedNotify" to receive the auth
+// revision at the time the watcher was created.
+func WithAuthRevisionNotify() OpOption {
+	return func(op *Op) { op.authRevisionNotify = true }
+}
+
 // WithIgnoreValue updates the key using its current value.
 // This option can not be combined with non-empty values.
 // Returns an error if the key does not exist.
@@ -54,6 +54,10 @@ var (
 
 	// client-side retry backoff default jitter fraction.
 	defaultBackoffJitterFraction = 0.10
+
+	// client-side watch stream reconnect backoff bounds.
+	defaultWatchBackoffMin = time.Millisecond
+	defaultWatchBackoffMax = 100 * time.Millisecond
 )
 
 // defaultCallOpts defines a list of default "gRPC.CallOption".
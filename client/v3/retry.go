@@ -155,6 +155,10 @@ func (rlc *retryLeaseClient) LeaseKeepAlive(ctx context.Context, opts ...grpc.Ca
 	return rlc.lc.LeaseKeepAlive(ctx, append(opts, withRepeatablePolicy())...)
 }
 
+func (rlc *retryLeaseClient) LeaseExpirations(ctx context.Context, in *pb.LeaseExpirationsRequest, opts ...grpc.CallOption) (stream pb.Lease_LeaseExpirationsClient, err error) {
+	return rlc.lc.LeaseExpirations(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
 type retryClusterClient struct {
 	cc pb.ClusterClient
 }
@@ -225,10 +229,46 @@ func (rmc *retryMaintenanceClient) Defragment(ctx context.Context, in *pb.Defrag
 	return rmc.mc.Defragment(ctx, in, opts...)
 }
 
+func (rmc *retryMaintenanceClient) DefragmentWithProgress(ctx context.Context, in *pb.DefragmentRequest, opts ...grpc.CallOption) (stream pb.Maintenance_DefragmentWithProgressClient, err error) {
+	return rmc.mc.DefragmentWithProgress(ctx, in, opts...)
+}
+
 func (rmc *retryMaintenanceClient) Downgrade(ctx context.Context, in *pb.DowngradeRequest, opts ...grpc.CallOption) (resp *pb.DowngradeResponse, err error) {
 	return rmc.mc.Downgrade(ctx, in, opts...)
 }
 
+func (rmc *retryMaintenanceClient) ForceSnapshot(ctx context.Context, in *pb.ForceSnapshotRequest, opts ...grpc.CallOption) (resp *pb.ForceSnapshotResponse, err error) {
+	return rmc.mc.ForceSnapshot(ctx, in, opts...)
+}
+
+func (rmc *retryMaintenanceClient) LinearizableProbe(ctx context.Context, in *pb.LinearizableProbeRequest, opts ...grpc.CallOption) (resp *pb.LinearizableProbeResponse, err error) {
+	return rmc.mc.LinearizableProbe(ctx, in, opts...)
+}
+
+func (rmc *retryMaintenanceClient) MemberRemovePreview(ctx context.Context, in *pb.MemberRemovePreviewRequest, opts ...grpc.CallOption) (resp *pb.MemberRemovePreviewResponse, err error) {
+	return rmc.mc.MemberRemovePreview(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) BucketStats(ctx context.Context, in *pb.BucketStatsRequest, opts ...grpc.CallOption) (resp *pb.BucketStatsResponse, err error) {
+	return rmc.mc.BucketStats(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) SetCommitMode(ctx context.Context, in *pb.SetCommitModeRequest, opts ...grpc.CallOption) (resp *pb.SetCommitModeResponse, err error) {
+	return rmc.mc.SetCommitMode(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) RevisionSince(ctx context.Context, in *pb.RevisionSinceRequest, opts ...grpc.CallOption) (resp *pb.RevisionSinceResponse, err error) {
+	return rmc.mc.RevisionSince(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) StoreRevision(ctx context.Context, in *pb.StoreRevisionRequest, opts ...grpc.CallOption) (resp *pb.StoreRevisionResponse, err error) {
+	return rmc.mc.StoreRevision(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) CancelCompaction(ctx context.Context, in *pb.CancelCompactionRequest, opts ...grpc.CallOption) (resp *pb.CancelCompactionResponse, err error) {
+	return rmc.mc.CancelCompaction(ctx, in, opts...)
+}
+
 type retryAuthClient struct {
 	ac pb.AuthClient
 }
@@ -27,6 +27,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
@@ -43,9 +44,20 @@ func (c *Client) unaryClientInterceptor(optFuncs ...retryOption) grpc.UnaryClien
 		ctx = withVersion(ctx)
 		grpcOpts, retryOpts := filterCallOptions(opts)
 		callOpts := reuseOrNewWithCallOptions(intOpts, retryOpts)
+		if c.cfg.FailFastWhenNoConnection {
+			if noConnection(cc) {
+				return ErrNoConnection
+			}
+			// fail instead of waiting while no endpoint is reachable
+			grpcOpts = append(grpcOpts, grpc.WaitForReady(false))
+		}
 		// short circuit for simplicity, and avoiding allocations.
 		if callOpts.max == 0 {
-			return invoker(ctx, method, req, reply, cc, grpcOpts...)
+			err := invoker(ctx, method, req, reply, cc, grpcOpts...)
+			if err != nil && c.cfg.FailFastWhenNoConnection && noConnection(cc) {
+				return ErrNoConnection
+			}
+			return err
 		}
 		var lastErr error
 		for attempt := uint(0); attempt < callOpts.max; attempt++ {
@@ -69,6 +81,9 @@ func (c *Client) unaryClientInterceptor(optFuncs ...retryOption) grpc.UnaryClien
 				zap.Uint("attempt", attempt),
 				zap.Error(lastErr),
 			)
+			if c.cfg.FailFastWhenNoConnection && noConnection(cc) {
+				return ErrNoConnection
+			}
 			if isContextError(lastErr) {
 				if ctx.Err() != nil {
 					// its the context deadline or cancellation.
@@ -353,6 +368,11 @@ func isContextError(err error) bool {
 	return status.Code(err) == codes.DeadlineExceeded || status.Code(err) == codes.Canceled
 }
 
+// noConnection reports whether the connections to all endpoints of cc failed.
+func noConnection(cc *grpc.ClientConn) bool {
+	return cc.GetState() == connectivity.TransientFailure
+}
+
 func contextErrToGRPCErr(err error) error {
 	switch {
 	case errors.Is(err, context.DeadlineExceeded):
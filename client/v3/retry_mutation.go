@@ -0,0 +1,97 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+const defaultRetryMutationMaxBackoff = time.Second
+
+// RetryPolicy configures how RetryMutation retries a failed call.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls, including the first one.
+	// 0 retries until the context is done.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry; each following wait
+	// doubles it. Defaults to 25ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between retries. Defaults to 1s.
+	MaxBackoff time.Duration
+	// JitterFraction is the fraction by which each wait is randomized.
+	// Defaults to 0.1.
+	JitterFraction float64
+}
+
+// RetryMutation calls fn until it succeeds, retrying with exponential
+// backoff and jitter while it fails with an error that indicates the cluster
+// is temporarily unable to serve writes, such as rpctypes.ErrNoLeader or
+// rpctypes.ErrTimeout. Any other error is returned immediately.
+//
+// A mutation that timed out may still have been applied, so fn should be
+// safe to repeat, e.g. a Put of a fixed value or a Txn guarded by a compare.
+// If ctx is done before fn succeeds, ctx.Err() is returned.
+func RetryMutation(ctx context.Context, fn func() error, policy RetryPolicy) error {
+	backoff := policy.InitialBackoff
+	if backoff <= 0 {
+		backoff = defaultBackoffWaitBetween
+	}
+	maxBackoff := policy.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMutationMaxBackoff
+	}
+	backoff = min(backoff, maxBackoff)
+	jitter := policy.JitterFraction
+	if jitter <= 0 {
+		jitter = defaultBackoffJitterFraction
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !isRetryableMutationError(err) {
+			return err
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return err
+		}
+
+		t := time.NewTimer(jitterUp(backoff, jitter))
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+// isRetryableMutationError returns true if err means the mutation failed
+// because the cluster could not commit it in time, not because the request
+// itself was rejected.
+func isRetryableMutationError(err error) bool {
+	switch rpctypes.Error(err) {
+	case rpctypes.ErrNoLeader,
+		rpctypes.ErrLeaderChanged,
+		rpctypes.ErrTimeout,
+		rpctypes.ErrTimeoutDueToLeaderFail,
+		rpctypes.ErrTimeoutDueToConnectionLost:
+		return true
+	}
+	return false
+}
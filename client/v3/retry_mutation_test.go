@@ -0,0 +1,113 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+// flakyKV fails Put with the queued errors before succeeding.
+type flakyKV struct {
+	KV
+	errs []error
+	puts int
+}
+
+func (kv *flakyKV) Put(ctx context.Context, key, val string, opts ...OpOption) (*PutResponse, error) {
+	kv.puts++
+	if len(kv.errs) > 0 {
+		err := kv.errs[0]
+		kv.errs = kv.errs[1:]
+		return nil, err
+	}
+	return &PutResponse{}, nil
+}
+
+func TestRetryMutation(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+	tcs := []struct {
+		name      string
+		errs      []error
+		policy    RetryPolicy
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "success",
+			policy:    policy,
+			wantCalls: 1,
+		},
+		{
+			name: "transient errors",
+			errs: []error{
+				rpctypes.ErrGRPCNoLeader,
+				rpctypes.ErrTimeout,
+				rpctypes.ErrGRPCTimeoutDueToLeaderFail,
+				rpctypes.ErrLeaderChanged,
+				rpctypes.ErrTimeoutDueToConnectionLost,
+			},
+			policy:    policy,
+			wantCalls: 6,
+		},
+		{
+			name:      "non-retryable error",
+			errs:      []error{rpctypes.ErrNoLeader, rpctypes.ErrGRPCRequestTooLarge},
+			policy:    policy,
+			wantErr:   rpctypes.ErrRequestTooLarge,
+			wantCalls: 2,
+		},
+		{
+			name:      "attempts exhausted",
+			errs:      []error{rpctypes.ErrNoLeader, rpctypes.ErrNoLeader, rpctypes.ErrTimeout},
+			policy:    RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond},
+			wantErr:   rpctypes.ErrNoLeader,
+			wantCalls: 2,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			kv := &flakyKV{errs: tc.errs}
+			err := RetryMutation(t.Context(), func() error {
+				_, err := kv.Put(t.Context(), "foo", "bar")
+				return rpctypes.Error(err)
+			}, tc.policy)
+			require.ErrorIs(t, err, tc.wantErr)
+			require.Equal(t, tc.wantCalls, kv.puts)
+		})
+	}
+}
+
+func TestRetryMutationContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	kv := &flakyKV{errs: make([]error, 1000)}
+	for i := range kv.errs {
+		kv.errs[i] = rpctypes.ErrNoLeader
+	}
+	err := RetryMutation(ctx, func() error {
+		_, err := kv.Put(ctx, "foo", "bar")
+		return err
+	}, RetryPolicy{InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Greater(t, kv.puts, 1)
+	require.Less(t, kv.puts, len(kv.errs))
+}
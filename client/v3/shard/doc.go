@@ -0,0 +1,48 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shard is a clientv3 wrapper that stores each key under a physical
+// key computed by a pluggable ShardFunc, typically to spread keys across the
+// prefixes of several shards.
+//
+// First, create a client:
+//
+//	cli, err := clientv3.New(clientv3.Config{Endpoints: []string{"localhost:2379"}})
+//	if err != nil {
+//		// handle error!
+//	}
+//
+// Next, override the client interfaces with a shard function:
+//
+//	byHash := func(key string) string {
+//		h := fnv.New32a()
+//		h.Write([]byte(key))
+//		return fmt.Sprintf("shard-%d/%s", h.Sum32()%4, key)
+//	}
+//	unshardedKV := cli.KV
+//	cli.KV = shard.NewKV(cli.KV, byHash)
+//	cli.Watcher = shard.NewWatcher(cli.Watcher, byHash)
+//
+// Now calls using 'cli' read and write the keys of their shard, and report
+// the keys as given by the caller:
+//
+//	cli.Put(context.TODO(), "abc", "123")
+//	resp, _ := unshardedKV.Get(context.TODO(), byHash("abc"))
+//	fmt.Printf("%s\n", resp.Kvs[0].Value)
+//	// Output: 123
+//
+// A range of keys spans all shards, so only requests on single keys are
+// supported; range requests, including those with WithPrefix or
+// WithFromKey, fail with ErrRange.
+package shard
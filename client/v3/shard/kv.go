@@ -0,0 +1,251 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shard
+
+import (
+	"context"
+	"errors"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// ShardFunc maps a key to the physical key storing it, for instance by
+// prepending the prefix of the shard the key hashes to. It must always map a
+// key to the same physical key, and distinct keys to distinct physical keys.
+type ShardFunc func(key string) string
+
+// ErrRange is returned for requests on a range of keys, which spans all
+// shards.
+var ErrRange = errors.New("shard: range requests are not supported")
+
+type kvShard struct {
+	clientv3.KV
+	shard ShardFunc
+}
+
+// NewKV wraps a KV instance so that all requests access the physical keys
+// given by shard, and all responses report the keys of the requests.
+func NewKV(kv clientv3.KV, shard ShardFunc) clientv3.KV {
+	return &kvShard{kv, shard}
+}
+
+func (kv *kvShard) Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	r, err := kv.Do(ctx, clientv3.OpPut(key, val, opts...))
+	if err != nil {
+		return nil, err
+	}
+	return r.Put(), nil
+}
+
+func (kv *kvShard) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	r, err := kv.Do(ctx, clientv3.OpGet(key, opts...))
+	if err != nil {
+		return nil, err
+	}
+	return r.Get(), nil
+}
+
+func (kv *kvShard) Delete(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.DeleteResponse, error) {
+	r, err := kv.Do(ctx, clientv3.OpDelete(key, opts...))
+	if err != nil {
+		return nil, err
+	}
+	return r.Del(), nil
+}
+
+func (kv *kvShard) Do(ctx context.Context, op clientv3.Op) (clientv3.OpResponse, error) {
+	shardOp, err := kv.shardOp(op)
+	if err != nil {
+		return clientv3.OpResponse{}, err
+	}
+	r, err := kv.KV.Do(ctx, shardOp)
+	if err != nil {
+		return r, err
+	}
+	switch {
+	case r.Get() != nil:
+		unshardGetResponse(r.Get(), op.KeyBytes())
+	case r.Put() != nil:
+		unshardPutResponse(r.Put(), op.KeyBytes())
+	case r.Del() != nil:
+		unshardDeleteResponse(r.Del(), op.KeyBytes())
+	case r.Txn() != nil:
+		_, thenOps, elseOps := op.Txn()
+		unshardTxnResponse(r.Txn(), thenOps, elseOps)
+	}
+	return r, nil
+}
+
+type txnShard struct {
+	clientv3.Txn
+	kv *kvShard
+
+	thenOps []clientv3.Op
+	elseOps []clientv3.Op
+	// err is the error of sharding the comparisons or operations, returned
+	// on commit.
+	err error
+}
+
+func (kv *kvShard) Txn(ctx context.Context) clientv3.Txn {
+	return &txnShard{Txn: kv.KV.Txn(ctx), kv: kv}
+}
+
+func (txn *txnShard) If(cs ...clientv3.Cmp) clientv3.Txn {
+	shardCmps, err := txn.kv.shardCmps(cs)
+	if err != nil {
+		txn.err = err
+		return txn
+	}
+	txn.Txn = txn.Txn.If(shardCmps...)
+	return txn
+}
+
+func (txn *txnShard) Then(ops ...clientv3.Op) clientv3.Txn {
+	shardOps, err := txn.kv.shardOps(ops)
+	if err != nil {
+		txn.err = err
+		return txn
+	}
+	txn.thenOps = ops
+	txn.Txn = txn.Txn.Then(shardOps...)
+	return txn
+}
+
+func (txn *txnShard) Else(ops ...clientv3.Op) clientv3.Txn {
+	shardOps, err := txn.kv.shardOps(ops)
+	if err != nil {
+		txn.err = err
+		return txn
+	}
+	txn.elseOps = ops
+	txn.Txn = txn.Txn.Else(shardOps...)
+	return txn
+}
+
+func (txn *txnShard) Commit() (*clientv3.TxnResponse, error) {
+	if txn.err != nil {
+		return nil, txn.err
+	}
+	resp, err := txn.Txn.Commit()
+	if err != nil {
+		return nil, err
+	}
+	unshardTxnResponse(resp, txn.thenOps, txn.elseOps)
+	return resp, nil
+}
+
+func (kv *kvShard) shardOp(op clientv3.Op) (clientv3.Op, error) {
+	if op.IsTxn() {
+		cmps, thenOps, elseOps := op.Txn()
+		shardCmps, err := kv.shardCmps(cmps)
+		if err != nil {
+			return op, err
+		}
+		shardThenOps, err := kv.shardOps(thenOps)
+		if err != nil {
+			return op, err
+		}
+		shardElseOps, err := kv.shardOps(elseOps)
+		if err != nil {
+			return op, err
+		}
+		return clientv3.OpTxn(shardCmps, shardThenOps, shardElseOps), nil
+	}
+	if len(op.RangeBytes()) != 0 {
+		return op, ErrRange
+	}
+	if len(op.KeyBytes()) == 0 {
+		return op, rpctypes.ErrEmptyKey
+	}
+	op.WithKeyBytes([]byte(kv.shard(string(op.KeyBytes()))))
+	return op, nil
+}
+
+func (kv *kvShard) shardOps(ops []clientv3.Op) ([]clientv3.Op, error) {
+	newOps := make([]clientv3.Op, len(ops))
+	for i := range ops {
+		var err error
+		if newOps[i], err = kv.shardOp(ops[i]); err != nil {
+			return nil, err
+		}
+	}
+	return newOps, nil
+}
+
+func (kv *kvShard) shardCmps(cs []clientv3.Cmp) ([]clientv3.Cmp, error) {
+	newCmps := make([]clientv3.Cmp, len(cs))
+	for i := range cs {
+		if len(cs[i].RangeEnd) != 0 {
+			return nil, ErrRange
+		}
+		newCmps[i] = cs[i]
+		newCmps[i].WithKeyBytes([]byte(kv.shard(string(cs[i].KeyBytes()))))
+	}
+	return newCmps, nil
+}
+
+// The responses of requests on a single key hold at most that key, so it is
+// restored from the request rather than from the physical key.
+
+func unshardGetResponse(resp *clientv3.GetResponse, key []byte) {
+	for i := range resp.Kvs {
+		resp.Kvs[i].Key = key
+	}
+}
+
+func unshardPutResponse(resp *clientv3.PutResponse, key []byte) {
+	if resp.PrevKv != nil {
+		resp.PrevKv.Key = key
+	}
+}
+
+func unshardDeleteResponse(resp *clientv3.DeleteResponse, key []byte) {
+	for i := range resp.PrevKvs {
+		resp.PrevKvs[i].Key = key
+	}
+}
+
+func unshardTxnResponse(resp *clientv3.TxnResponse, thenOps, elseOps []clientv3.Op) {
+	ops := thenOps
+	if !resp.Succeeded {
+		ops = elseOps
+	}
+	for i, r := range resp.Responses {
+		op := ops[i]
+		switch tv := r.Response.(type) {
+		case *pb.ResponseOp_ResponseRange:
+			if tv.ResponseRange != nil {
+				unshardGetResponse((*clientv3.GetResponse)(tv.ResponseRange), op.KeyBytes())
+			}
+		case *pb.ResponseOp_ResponsePut:
+			if tv.ResponsePut != nil {
+				unshardPutResponse((*clientv3.PutResponse)(tv.ResponsePut), op.KeyBytes())
+			}
+		case *pb.ResponseOp_ResponseDeleteRange:
+			if tv.ResponseDeleteRange != nil {
+				unshardDeleteResponse((*clientv3.DeleteResponse)(tv.ResponseDeleteRange), op.KeyBytes())
+			}
+		case *pb.ResponseOp_ResponseTxn:
+			if tv.ResponseTxn != nil {
+				_, nestedThenOps, nestedElseOps := op.Txn()
+				unshardTxnResponse((*clientv3.TxnResponse)(tv.ResponseTxn), nestedThenOps, nestedElseOps)
+			}
+		default:
+		}
+	}
+}
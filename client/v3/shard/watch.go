@@ -0,0 +1,80 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shard
+
+import (
+	"context"
+	"sync"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+type watcherShard struct {
+	clientv3.Watcher
+	shard ShardFunc
+
+	wg       sync.WaitGroup
+	stopc    chan struct{}
+	stopOnce sync.Once
+}
+
+// NewWatcher wraps a Watcher instance so that all Watch requests watch the
+// physical keys given by shard, and all Watch responses report the keys of
+// the requests. A Watch request on a range of keys is canceled with ErrRange.
+func NewWatcher(w clientv3.Watcher, shard ShardFunc) clientv3.Watcher {
+	return &watcherShard{Watcher: w, shard: shard, stopc: make(chan struct{})}
+}
+
+func (w *watcherShard) Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan {
+	// since OpOption is opaque, determine the range through an OpGet
+	if len(clientv3.OpGet(key, opts...).RangeBytes()) != 0 {
+		return clientv3.CanceledWatchChan(ErrRange)
+	}
+
+	wch := w.Watcher.Watch(ctx, w.shard(key), opts...)
+
+	// translate watch events from physical to requested keys
+	shardWch := make(chan clientv3.WatchResponse)
+	w.wg.Add(1)
+	go func() {
+		defer func() {
+			close(shardWch)
+			w.wg.Done()
+		}()
+		for wr := range wch {
+			for i := range wr.Events {
+				wr.Events[i].Kv.Key = []byte(key)
+				if wr.Events[i].PrevKv != nil {
+					wr.Events[i].PrevKv.Key = wr.Events[i].Kv.Key
+				}
+			}
+			select {
+			case shardWch <- wr:
+			case <-ctx.Done():
+				return
+			case <-w.stopc:
+				return
+			}
+		}
+	}()
+	return shardWch
+}
+
+func (w *watcherShard) Close() error {
+	err := w.Watcher.Close()
+	w.stopOnce.Do(func() { close(w.stopc) })
+	w.wg.Wait()
+	return err
+}
@@ -148,3 +148,80 @@ func (txn *txn) Commit() (*TxnResponse, error) {
 	}
 	return (*TxnResponse)(resp), nil
 }
+
+// ReadTxn batches range requests into a single read-only transaction, so all
+// reads are served in one round trip at the same revision.
+//
+//	NewReadTxn(context.TODO(), kv).Get(k1).Get(k2, WithPrefix()).Commit()
+type ReadTxn interface {
+	// Get adds a range request to the transaction. It takes the same options
+	// as KV.Get; WithSerializable is ignored in favor of Serializable.
+	Get(key string, opts ...OpOption) ReadTxn
+
+	// Serializable makes the transaction serializable instead of
+	// linearizable. Serializable reads are served by the local member and
+	// may return stale data.
+	Serializable() ReadTxn
+
+	// Commit executes the range requests and returns their responses in the
+	// order the requests were added.
+	Commit() (*ReadTxnResponse, error)
+}
+
+// ReadTxnResponse holds the responses of a ReadTxn, all read at the
+// revision of Header.
+type ReadTxnResponse struct {
+	Header    *pb.ResponseHeader
+	Responses []*GetResponse
+}
+
+// NewReadTxn creates a read-only transaction on kv that only permits range
+// requests.
+func NewReadTxn(ctx context.Context, kv KV) ReadTxn {
+	return &readTxn{kv: kv, ctx: ctx}
+}
+
+type readTxn struct {
+	kv  KV
+	ctx context.Context
+
+	mu           sync.Mutex
+	ops          []Op
+	serializable bool
+}
+
+func (rt *readTxn) Get(key string, opts ...OpOption) ReadTxn {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.ops = append(rt.ops, OpGet(key, opts...))
+	return rt
+}
+
+func (rt *readTxn) Serializable() ReadTxn {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.serializable = true
+	return rt
+}
+
+func (rt *readTxn) Commit() (*ReadTxnResponse, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	ops := make([]Op, len(rt.ops))
+	for i, op := range rt.ops {
+		// the server serves a read-only transaction serializably only if
+		// all of its range requests are serializable
+		op.serializable = rt.serializable
+		ops[i] = op
+	}
+	resp, err := rt.kv.Txn(rt.ctx).Then(ops...).Commit()
+	if err != nil {
+		return nil, err
+	}
+	rresp := &ReadTxnResponse{Header: resp.Header, Responses: make([]*GetResponse, len(resp.Responses))}
+	for i, r := range resp.Responses {
+		rresp.Responses[i] = (*GetResponse)(r.GetResponseRange())
+	}
+	return rresp, nil
+}
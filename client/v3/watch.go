@@ -16,14 +16,17 @@ package clientv3
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
@@ -32,6 +35,10 @@ import (
 	v3rpc "go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 )
 
+// ErrWatchStalled is returned by WatchResponse.Err when a watcher created with
+// WithHealthCheck received no response, not even to a progress request, in time.
+var ErrWatchStalled = errors.New("clientv3: watch stream stalled")
+
 const (
 	EventTypeDelete = mvccpb.DELETE
 	EventTypePut    = mvccpb.PUT
@@ -46,6 +53,9 @@ const (
 	InvalidWatchID = -1
 )
 
+// Event is a change to a watched key. Its Seq numbers the events of a
+// watcher one by one; it restarts at 1 in the response marked Resumed, as
+// the server re-creates the watcher when its stream is re-established.
 type Event mvccpb.Event
 
 type WatchChan <-chan WatchResponse
@@ -88,10 +98,30 @@ type Watcher interface {
 	Close() error
 }
 
+// WatchStreamStats describes the watchers sharing a single grpc watch stream.
+type WatchStreamStats struct {
+	// Watchers is the number of watchers registered on the stream.
+	Watchers int
+	// Resuming is the number of watchers waiting to be registered or resumed.
+	Resuming int
+	// BufferedResponses is the number of watch responses received from etcd
+	// but not yet consumed from the watch channels.
+	BufferedResponses int
+	// ResumeRevision is the lowest revision the stream's watchers would resume
+	// from after a reconnect. Zero means all watchers resume from the current
+	// store revision.
+	ResumeRevision int64
+}
+
 type WatchResponse struct {
 	Header pb.ResponseHeader
 	Events []*Event
 
+	// RawEvents holds the events instead of Events for watchers created with
+	// WithRawEvents. It is the encoding of an etcdserverpb.WatchResponse
+	// carrying only the events, which its Unmarshal method decodes.
+	RawEvents []byte
+
 	// CompactRevision is the minimum revision the watcher may receive.
 	CompactRevision int64
 
@@ -103,10 +133,27 @@ type WatchResponse struct {
 	// Created is used to indicate the creation of the watcher.
 	Created bool
 
+	// CreatedRevision is the store revision at the time the watcher was created.
+	// It is only set on the creation response; 0 if the server does not report it.
+	CreatedRevision int64
+
 	closeErr error
 
 	// CancelReason is a reason of canceling watch
 	CancelReason string
+
+	// Stale is set on responses to watchers created with WithStaleOK that
+	// were served while the server had no leader.
+	Stale bool
+
+	// AuthRevision is the auth revision of the server. It is only set on
+	// responses to watchers created with WithAuthRevisionNotify.
+	AuthRevision uint64
+
+	// Resumed is set on the first response delivered after the watcher was
+	// transparently re-established on a new stream. Events before it may have
+	// been observed by a previous stream; it is never set on progress notifies.
+	Resumed bool
 }
 
 // IsCreate returns true if the event tells that the key is newly created.
@@ -125,7 +172,7 @@ func (wr *WatchResponse) Err() error {
 	case wr.closeErr != nil:
 		return v3rpc.Error(wr.closeErr)
 	case wr.CompactRevision != 0:
-		return v3rpc.ErrCompacted
+		return &CompactedError{CompactedRev: wr.CompactRevision}
 	case wr.Canceled:
 		if len(wr.CancelReason) != 0 {
 			return v3rpc.Error(status.Error(codes.FailedPrecondition, wr.CancelReason))
@@ -135,9 +182,22 @@ func (wr *WatchResponse) Err() error {
 	return nil
 }
 
+// CompactedError is the error of a watch response canceled because the
+// watched revision was compacted. It wraps rpctypes.ErrCompacted and has the
+// same message.
+type CompactedError struct {
+	// CompactedRev is the compact revision of the store; the watcher may
+	// be recreated from this revision.
+	CompactedRev int64
+}
+
+func (e *CompactedError) Error() string { return v3rpc.ErrCompacted.Error() }
+
+func (e *CompactedError) Unwrap() error { return v3rpc.ErrCompacted }
+
 // IsProgressNotify returns true if the WatchResponse is progress notification.
 func (wr *WatchResponse) IsProgressNotify() bool {
-	return len(wr.Events) == 0 && !wr.Canceled && !wr.Created && wr.CompactRevision == 0 && wr.Header.Revision != 0
+	return len(wr.Events) == 0 && len(wr.RawEvents) == 0 && !wr.Canceled && !wr.Created && wr.CompactRevision == 0 && wr.AuthRevision == 0 && wr.Header.Revision != 0
 }
 
 // watcher implements the Watcher interface
@@ -148,9 +208,17 @@ type watcher struct {
 	// mu protects the grpc streams map
 	mu sync.Mutex
 
-	// streams holds all the active grpc streams keyed by ctx value.
+	// streams holds all the active grpc streams keyed by ctx value and,
+	// past the first stream for a ctx value, the stream number.
 	streams map[string]*watchGRPCStream
 	lg      *zap.Logger
+
+	// backoff bounds the wait between attempts to reopen a watch stream
+	backoff WatchBackoff
+	// maxWatchers limits the watchers per grpc stream if non-zero
+	maxWatchers int
+	// client resolves WithRevSince; it is nil if the watcher has no client
+	client *Client
 }
 
 // watchGRPCStream tracks all watch resources attached to a single grpc stream.
@@ -163,8 +231,16 @@ type watchGRPCStream struct {
 	ctx context.Context
 	// ctxKey is the key used when looking up this stream's context
 	ctxKey string
-	cancel context.CancelFunc
+	// streamKey is the key of this stream in the owner's streams map
+	streamKey string
+	cancel    context.CancelFunc
+
+	// watchers counts the watchers assigned to this stream that have not
+	// closed yet; guarded by the owner's mu
+	watchers int
 
+	// statsMu guards substreams and resuming against readers outside of run()
+	statsMu sync.Mutex
 	// substreams holds all active watchers on this grpc stream
 	substreams map[int64]*watcherStream
 	// resuming holds all resuming watchers on this grpc stream
@@ -207,21 +283,64 @@ type watchRequest struct {
 	createdNotify bool
 	// progressNotify is for progress updates
 	progressNotify bool
-	// fragmentation should be disabled by default
+	// progressNotifyInterval overrides the server's progress interval
+	progressNotifyInterval time.Duration
+	// maxEventRate caps the events per second the server sends
+	maxEventRate int64
+	// valueProjection is the dotted path the server projects values to
+	valueProjection string
 	// if true, split watch events when total exceeds
 	// "--max-request-bytes" flag value + 512-byte
 	fragment bool
+	// rawEvents delivers events undecoded
+	rawEvents bool
+	// staleOK keeps the watcher open while the server has no leader
+	staleOK bool
+	// authRevisionNotify watches the auth revision instead of keys
+	authRevisionNotify bool
 
 	// filters is the list of events to filter out
 	filters []pb.WatchCreateRequest_FilterType
 	// get the previous key-value pair before the event happens
 	prevKV bool
+	// batchInterval coalesces events on the client for up to this duration
+	batchInterval time.Duration
+	// latestPerKey collapses the events of each response to one per key
+	latestPerKey bool
+	// probe is set if the watcher is health checked
+	probe *watchProbe
 	// retc receives a chan WatchResponse once the watcher is established
 	retc chan chan WatchResponse
 }
 
 // progressRequest is issued by the subscriber to request watch progress
-type progressRequest struct{}
+type progressRequest struct {
+	// probe limits the request to the health checked watcher it belongs to
+	probe *watchProbe
+	// watchID is the ID of the probed watcher on the grpc stream
+	watchID int64
+}
+
+// watchProbe lets the health check of a watcher request the progress of that
+// watcher alone, so that other watchers on its stream are not notified.
+type watchProbe struct {
+	// wgs is the grpc stream the watcher was placed on
+	wgs atomic.Pointer[watchGRPCStream]
+}
+
+// requestProgress sends a progress request for the probed watcher to its
+// grpc stream.
+func (p *watchProbe) requestProgress(ctx context.Context) {
+	wgs := p.wgs.Load()
+	if wgs == nil {
+		return
+	}
+	select {
+	case wgs.reqc <- &progressRequest{probe: p}:
+	case <-ctx.Done():
+	case <-wgs.donec:
+	}
+}
 
 // watcherStream represents a registered watcher
 type watcherStream struct {
@@ -241,6 +360,15 @@ type watcherStream struct {
 
 	// buf holds all events received from etcd but not yet consumed by the client
 	buf []*WatchResponse
+	// buffered mirrors len(buf) for Stats
+	buffered atomic.Int64
+	// resumeRev mirrors initReq.rev for Stats
+	resumeRev atomic.Int64
+	// resumed marks the next response as the first after a reconnection
+	resumed bool
+	// createdSent is set once the watcher handled its first Created response;
+	// Created responses to transparent resumes are not posted again
+	createdSent bool
 }
 
 func NewWatcher(c *Client) Watcher {
@@ -251,10 +379,23 @@ func NewWatchFromWatchClient(wc pb.WatchClient, c *Client) Watcher {
 	w := &watcher{
 		remote:  wc,
 		streams: make(map[string]*watchGRPCStream),
+		backoff: WatchBackoff{Min: defaultWatchBackoffMin, Max: defaultWatchBackoffMax},
 	}
 	if c != nil {
+		w.client = c
 		w.callOpts = c.callOpts
 		w.lg = c.lg
+		if c.cfg.WatchBackoff.Min > 0 {
+			w.backoff.Min = c.cfg.WatchBackoff.Min
+		}
+		if c.cfg.WatchBackoff.Max > 0 {
+			w.backoff.Max = c.cfg.WatchBackoff.Max
+		}
+		if w.backoff.Max < w.backoff.Min {
+			w.backoff.Max = w.backoff.Min
+		}
+		w.backoff.Jitter = c.cfg.WatchBackoff.Jitter
+		w.maxWatchers = c.cfg.MaxWatchersPerStream
 	}
 	return w
 }
@@ -272,14 +413,18 @@ func (vc *valCtx) Deadline() (time.Time, bool) { return zeroTime, false }
 func (vc *valCtx) Done() <-chan struct{}       { return valCtxCh }
 func (vc *valCtx) Err() error                  { return nil }
 
-func (w *watcher) newWatcherGRPCStream(inctx context.Context) *watchGRPCStream {
+func (w *watcher) newWatcherGRPCStream(inctx context.Context, streamKey string) *watchGRPCStream {
 	ctx, cancel := context.WithCancel(&valCtx{inctx})
+	// fragments are reassembled before delivery, so servers may fragment
+	// the responses of all watchers of the stream
+	ctx = metadata.AppendToOutgoingContext(ctx, v3rpc.MetadataWatchFragmentKey, v3rpc.MetadataWatchFragment)
 	wgs := &watchGRPCStream{
 		owner:      w,
 		remote:     w.remote,
 		callOpts:   w.callOpts,
 		ctx:        ctx,
 		ctxKey:     streamKeyFromCtx(inctx),
+		streamKey:  streamKey,
 		cancel:     cancel,
 		substreams: make(map[int64]*watcherStream),
 		respc:      make(chan *pb.WatchResponse),
@@ -297,6 +442,19 @@ func (w *watcher) newWatcherGRPCStream(inctx context.Context) *watchGRPCStream {
 // Watch posts a watch request to run() and waits for a new watcher channel
 func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) WatchChan {
 	ow := OpWatch(key, opts...)
+	if ow.healthCheckInterval > 0 {
+		hctx, cancel := context.WithCancel(ctx)
+		probe := &watchProbe{}
+		opts = append(opts[:len(opts):len(opts)], WithHealthCheck(0), withHealthProbe(probe))
+		return w.healthCheck(hctx, cancel, ow.healthCheckInterval, probe, w.Watch(hctx, key, opts...))
+	}
+	if !ow.revSince.IsZero() {
+		rev, err := w.resolveRevSince(ctx, ow.revSince)
+		if err != nil {
+			return canceledWatchChan(err)
+		}
+		ow.rev = rev
+	}
 
 	var filters []pb.WatchCreateRequest_FilterType
 	if ow.filterPut {
@@ -305,6 +463,9 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 	if ow.filterDelete {
 		filters = append(filters, pb.WatchCreateRequest_NODELETE)
 	}
+	if ow.filterModify {
+		filters = append(filters, pb.WatchCreateRequest_NOMODIFY)
+	}
 
 	wr := &watchRequest{
 		ctx:            ctx,
@@ -314,13 +475,35 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 		rev:            ow.rev,
 		progressNotify: ow.progressNotify,
 		fragment:       ow.fragment,
+		rawEvents:      ow.rawEvents,
+		staleOK:        ow.staleOK,
 		filters:        filters,
 		prevKV:         ow.prevKV,
+		batchInterval:  ow.batchInterval,
+		latestPerKey:   ow.latestPerKey,
+		probe:          ow.healthProbe,
 		retc:           make(chan chan WatchResponse, 1),
+
+		authRevisionNotify:     ow.authRevisionNotify,
+		progressNotifyInterval: ow.progressNotifyInterval,
+		maxEventRate:           ow.maxEventRate,
+		valueProjection:        ow.valueProjection,
+	}
+
+	// stale-tolerant watchers must not share a stream that is closed when
+	// the server loses its leader
+	streamCtx := ctx
+	if ow.staleOK {
+		streamCtx = withoutRequireLeader(ctx)
+	}
+	// gRPC compresses all responses of a stream alike, so compressed
+	// watchers share streams only with each other
+	if ow.compress {
+		streamCtx = metadata.AppendToOutgoingContext(streamCtx, v3rpc.MetadataWatchCompressionKey, gzip.Name)
 	}
 
 	ok := false
-	ctxKey := streamKeyFromCtx(ctx)
+	ctxKey := streamKeyFromCtx(streamCtx)
 
 	var closeCh chan WatchResponse
 	for {
@@ -333,11 +516,8 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 			close(ch)
 			return ch
 		}
-		wgs := w.streams[ctxKey]
-		if wgs == nil {
-			wgs = w.newWatcherGRPCStream(ctx)
-			w.streams[ctxKey] = wgs
-		}
+		wgs := w.streamForWatcher(streamCtx, ctxKey)
+		wgs.watchers++
 		donec := wgs.donec
 		reqc := wgs.reqc
 		w.mu.Unlock()
@@ -351,10 +531,15 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 		select {
 		case reqc <- wr:
 			ok = true
+			if wr.probe != nil {
+				wr.probe.wgs.Store(wgs)
+			}
 		case <-wr.ctx.Done():
 			ok = false
+			w.releaseWatcher(wgs)
 		case <-donec:
 			ok = false
+			w.releaseWatcher(wgs)
 			if wgs.closeErr != nil {
 				closeCh <- WatchResponse{Canceled: true, closeErr: wgs.closeErr}
 				break
@@ -385,6 +570,83 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 	return closeCh
 }
 
+// healthCheck forwards the responses of wch, probing the watcher with a
+// progress request through probe once no response arrived for interval. If the
+// probe gets no response within interval either, it sends ErrWatchStalled and
+// cancels the watch through cancel.
+func (w *watcher) healthCheck(ctx context.Context, cancel context.CancelFunc, interval time.Duration, probe *watchProbe, wch WatchChan) WatchChan {
+	outc := make(chan WatchResponse)
+	go func() {
+		defer close(outc)
+		defer cancel()
+
+		timer := time.NewTimer(interval)
+		defer timer.Stop()
+		probed := false
+		for {
+			select {
+			case wr, ok := <-wch:
+				if !ok {
+					return
+				}
+				select {
+				case outc <- wr:
+				case <-ctx.Done():
+					return
+				}
+				probed = false
+				timer.Reset(interval)
+			case <-timer.C:
+				if probed {
+					select {
+					case outc <- WatchResponse{Canceled: true, closeErr: ErrWatchStalled}:
+					case <-ctx.Done():
+					}
+					return
+				}
+				// a stalled stream may not even accept the request in time
+				pctx, pcancel := context.WithTimeout(ctx, interval)
+				probe.requestProgress(pctx)
+				pcancel()
+				probed = true
+				timer.Reset(interval)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return outc
+}
+
+// resolveRevSince returns the revision a watch created with WithRevSince(t)
+// starts from.
+func (w *watcher) resolveRevSince(ctx context.Context, t time.Time) (int64, error) {
+	if w.client == nil || w.client.Maintenance == nil {
+		return 0, errors.New("clientv3: WithRevSince requires a watcher created from a client")
+	}
+	resp, err := w.client.Maintenance.RevisionSince(ctx, t)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Revision, nil
+}
+
+// CanceledWatchChan returns a watch channel carrying a single canceled
+// response whose Err is err, so Watcher wrappers can refuse a watch the way
+// the Watcher does.
+func CanceledWatchChan(err error) WatchChan {
+	return canceledWatchChan(err)
+}
+
+// canceledWatchChan returns a closed channel holding a single canceled
+// response with err.
+func canceledWatchChan(err error) WatchChan {
+	ch := make(chan WatchResponse, 1)
+	ch <- WatchResponse{Canceled: true, closeErr: err}
+	close(ch)
+	return ch
+}
+
 func (w *watcher) Close() (err error) {
 	w.mu.Lock()
 	streams := w.streams
@@ -402,6 +664,29 @@ func (w *watcher) Close() (err error) {
 	return err
 }
 
+// WatchStats returns a snapshot of the grpc streams of w, so callers can
+// detect consumers that fall behind. w must be a Client or a watcher
+// returned by NewWatcher; for any other Watcher it returns nil.
+func WatchStats(w Watcher) []WatchStreamStats {
+	if c, ok := w.(*Client); ok {
+		w = c.Watcher
+	}
+	if ww, ok := w.(*watcher); ok {
+		return ww.stats()
+	}
+	return nil
+}
+
+func (w *watcher) stats() []WatchStreamStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	stats := make([]WatchStreamStats, 0, len(w.streams))
+	for _, wgs := range w.streams {
+		stats = append(stats, wgs.stats())
+	}
+	return stats
+}
+
 // RequestProgress requests a progress notify response be sent in all watch channels.
 func (w *watcher) RequestProgress(ctx context.Context) (err error) {
 	ctxKey := streamKeyFromCtx(ctx)
@@ -411,31 +696,88 @@ func (w *watcher) RequestProgress(ctx context.Context) (err error) {
 		w.mu.Unlock()
 		return errors.New("no stream found for context")
 	}
-	wgs := w.streams[ctxKey]
-	if wgs == nil {
-		wgs = w.newWatcherGRPCStream(ctx)
+	var streams []*watchGRPCStream
+	for _, wgs := range w.streams {
+		if wgs.ctxKey == ctxKey {
+			streams = append(streams, wgs)
+		}
+	}
+	if len(streams) == 0 {
+		wgs := w.newWatcherGRPCStream(ctx, ctxKey)
 		w.streams[ctxKey] = wgs
+		streams = append(streams, wgs)
 	}
-	donec := wgs.donec
-	reqc := wgs.reqc
 	w.mu.Unlock()
 
 	pr := &progressRequest{}
 
-	select {
-	case reqc <- pr:
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-donec:
-		if wgs.closeErr != nil {
-			return wgs.closeErr
+	for _, wgs := range streams {
+		select {
+		case wgs.reqc <- pr:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wgs.donec:
+			if wgs.closeErr != nil {
+				return wgs.closeErr
+			}
+			// retry; may have dropped stream from no ctxs
+			return w.RequestProgress(ctx)
+		}
+	}
+	return nil
+}
+
+// streamForWatcher returns the grpc stream for ctxKey to place a new watcher
+// on, opening another stream if all streams for ctxKey are full. It must be
+// called with mu held.
+func (w *watcher) streamForWatcher(ctx context.Context, ctxKey string) *watchGRPCStream {
+	for n := 0; ; n++ {
+		streamKey := ctxKey
+		if n > 0 {
+			streamKey = fmt.Sprintf("%s\x00%d", ctxKey, n)
+		}
+		wgs := w.streams[streamKey]
+		if wgs == nil {
+			wgs = w.newWatcherGRPCStream(ctx, streamKey)
+			w.streams[streamKey] = wgs
+			return wgs
+		}
+		if w.maxWatchers <= 0 || wgs.watchers < w.maxWatchers {
+			return wgs
 		}
-		// retry; may have dropped stream from no ctxs
-		return w.RequestProgress(ctx)
 	}
 }
 
+// releaseWatcher frees the place of a closed watcher on its grpc stream.
+func (w *watcher) releaseWatcher(wgs *watchGRPCStream) {
+	w.mu.Lock()
+	wgs.watchers--
+	w.mu.Unlock()
+}
+
+func (w *watchGRPCStream) stats() WatchStreamStats {
+	w.statsMu.Lock()
+	defer w.statsMu.Unlock()
+	var s WatchStreamStats
+	count := func(ws *watcherStream) {
+		s.BufferedResponses += int(ws.buffered.Load())
+		if rev := ws.resumeRev.Load(); rev != 0 && (s.ResumeRevision == 0 || rev < s.ResumeRevision) {
+			s.ResumeRevision = rev
+		}
+	}
+	for _, ws := range w.substreams {
+		s.Watchers++
+		count(ws)
+	}
+	for _, ws := range w.resuming {
+		if ws != nil {
+			s.Resuming++
+			count(ws)
+		}
+	}
+	return s
+}
+
 func (w *watchGRPCStream) close() (err error) {
 	w.cancel()
 	<-w.donec
@@ -451,7 +793,7 @@ func (w *watcher) closeStream(wgs *watchGRPCStream) {
 	close(wgs.donec)
 	wgs.cancel()
 	if w.streams != nil {
-		delete(w.streams, wgs.ctxKey)
+		delete(w.streams, wgs.streamKey)
 	}
 	w.mu.Unlock()
 }
@@ -465,7 +807,19 @@ func (w *watchGRPCStream) addSubstream(resp *pb.WatchResponse, ws *watcherStream
 		return
 	}
 	ws.id = resp.WatchId
+	w.statsMu.Lock()
 	w.substreams[ws.id] = ws
+	w.statsMu.Unlock()
+}
+
+// probedSubstream returns the registered watcher probe belongs to, or nil.
+func (w *watchGRPCStream) probedSubstream(p *watchProbe) *watcherStream {
+	for _, ws := range w.substreams {
+		if ws.initReq.probe == p {
+			return ws
+		}
+	}
+	return nil
 }
 
 func (w *watchGRPCStream) sendCloseSubstream(ws *watcherStream, resp *WatchResponse) {
@@ -489,6 +843,9 @@ func (w *watchGRPCStream) closeSubstream(ws *watcherStream) {
 	} else if ws.outc != nil {
 		close(ws.outc)
 	}
+	w.owner.releaseWatcher(w)
+	w.statsMu.Lock()
+	defer w.statsMu.Unlock()
 	if ws.id != InvalidWatchID {
 		delete(w.substreams, ws.id)
 		return
@@ -540,8 +897,10 @@ func (w *watchGRPCStream) run() {
 
 	cancelSet := make(map[int64]struct{})
 
-	var cur *pb.WatchResponse
-	backoff := time.Millisecond
+	// fragments buffers the partial responses of fragmented watch
+	// responses by watch ID until their last fragment arrives.
+	fragments := make(map[int64]*pb.WatchResponse)
+	backoff := w.owner.backoff.Min
 	for {
 		select {
 		// Watch() requested
@@ -558,12 +917,16 @@ func (w *watchGRPCStream) run() {
 					recvc: make(chan *WatchResponse),
 				}
 
+				ws.resumeRev.Store(ws.initReq.rev)
+
 				ws.donec = make(chan struct{})
 				w.wg.Add(1)
 				go w.serveSubstream(ws, w.resumec)
 
 				// queue up for watcher creation/resume
+				w.statsMu.Lock()
 				w.resuming = append(w.resuming, ws)
+				w.statsMu.Unlock()
 				if len(w.resuming) == 1 {
 					// head of resume queue, can register a new watcher
 					if err := wc.Send(ws.initReq.toPB()); err != nil {
@@ -571,6 +934,14 @@ func (w *watchGRPCStream) run() {
 					}
 				}
 			case *progressRequest:
+				if wreq.probe != nil {
+					ws := w.probedSubstream(wreq.probe)
+					if ws == nil {
+						// the watcher is not registered, e.g. while resuming
+						break
+					}
+					wreq.watchID = ws.id
+				}
 				if err := wc.Send(wreq.toPB()); err != nil {
 					w.lg.Debug("error when sending request", zap.Error(err))
 				}
@@ -578,13 +949,26 @@ func (w *watchGRPCStream) run() {
 
 		// new events from the watch client
 		case pbresp := <-w.respc:
-			if cur == nil || pbresp.Created || pbresp.Canceled {
-				cur = pbresp
-			} else if cur.WatchId == pbresp.WatchId {
-				// merge new events
-				cur.Events = append(cur.Events, pbresp.Events...)
-				// update "Fragment" field; last response with "Fragment" == false
-				cur.Fragment = pbresp.Fragment
+			cur := pbresp
+			if !pbresp.Created && !pbresp.Canceled {
+				if prev, ok := fragments[pbresp.WatchId]; ok {
+					// merge new events; encoded events merge by concatenation
+					prev.Events = append(prev.Events, pbresp.Events...)
+					prev.RawEvents = append(prev.RawEvents, pbresp.RawEvents...)
+					// update "Fragment" field; last response with "Fragment" == false
+					prev.Fragment = pbresp.Fragment
+					cur = prev
+				}
+				if cur.Fragment {
+					// watch response events are still fragmented
+					// continue to fetch next fragmented event arrival
+					fragments[pbresp.WatchId] = cur
+					continue
+				}
+				delete(fragments, pbresp.WatchId)
+			} else if pbresp.Canceled {
+				// a canceled watcher never completes its fragments
+				delete(fragments, pbresp.WatchId)
 			}
 
 			switch {
@@ -594,7 +978,9 @@ func (w *watchGRPCStream) run() {
 					if ws := w.resuming[0]; ws != nil {
 						w.addSubstream(pbresp, ws)
 						w.dispatchEvent(pbresp)
+						w.statsMu.Lock()
 						w.resuming[0] = nil
+						w.statsMu.Unlock()
 					}
 				}
 
@@ -604,10 +990,9 @@ func (w *watchGRPCStream) run() {
 					}
 				}
 
-				// reset for next iteration
-				cur = nil
-
-			case pbresp.Canceled && pbresp.CompactRevision == 0:
+			case pbresp.Canceled && pbresp.CompactRevision == 0 && pbresp.CancelReason == "":
+				// a cancel with a reason is dispatched instead, so that the
+				// watcher receives the reason as its error
 				delete(cancelSet, pbresp.WatchId)
 				if ws, ok := w.substreams[pbresp.WatchId]; ok {
 					// signal to stream goroutine to update closingc
@@ -615,21 +1000,10 @@ func (w *watchGRPCStream) run() {
 					closing[ws] = struct{}{}
 				}
 
-				// reset for next iteration
-				cur = nil
-
-			case cur.Fragment:
-				// watch response events are still fragmented
-				// continue to fetch next fragmented event arrival
-				continue
-
 			default:
 				// dispatch to appropriate watch stream
 				ok := w.dispatchEvent(cur)
 
-				// reset for next iteration
-				cur = nil
-
 				if ok {
 					break
 				}
@@ -668,6 +1042,8 @@ func (w *watchGRPCStream) run() {
 				}
 			}
 			cancelSet = make(map[int64]struct{})
+			// fragments of the broken stream are never completed
+			fragments = make(map[int64]*pb.WatchResponse)
 
 		case <-w.ctx.Done():
 			return
@@ -701,6 +1077,8 @@ func (w *watchGRPCStream) run() {
 // nextResume chooses the next resuming to register with the grpc stream. Abandoned
 // streams are marked as nil in the queue since the head must wait for its inflight registration.
 func (w *watchGRPCStream) nextResume() *watcherStream {
+	w.statsMu.Lock()
+	defer w.statsMu.Unlock()
 	for len(w.resuming) != 0 {
 		if w.resuming[0] != nil {
 			return w.resuming[0]
@@ -722,8 +1100,12 @@ func (w *watchGRPCStream) dispatchEvent(pbresp *pb.WatchResponse) bool {
 		Events:          events,
 		CompactRevision: pbresp.CompactRevision,
 		Created:         pbresp.Created,
+		CreatedRevision: pbresp.CreatedRevision,
 		Canceled:        pbresp.Canceled,
 		CancelReason:    pbresp.CancelReason,
+		Stale:           pbresp.Stale,
+		AuthRevision:    pbresp.AuthRevision,
+		RawEvents:       pbresp.RawEvents,
 	}
 
 	// watch IDs are zero indexed, so request notify watch responses are assigned a watch ID of InvalidWatchID to
@@ -788,7 +1170,31 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 	// nextRev is the minimum expected next revision
 	nextRev := ws.initReq.rev
 	resuming := false
+
+	// batch accumulates event responses when batchInterval is set;
+	// batchc fires once the batch has been held for batchInterval.
+	var batch *WatchResponse
+	var batchTimer *time.Timer
+	var batchc <-chan time.Time
+	flushBatch := func() {
+		if batch == nil {
+			return
+		}
+		if ws.initReq.latestPerKey {
+			batch.Events = latestPerKey(batch.Events)
+		}
+		ws.buf = append(ws.buf, batch)
+		ws.buffered.Store(int64(len(ws.buf)))
+		batch = nil
+		if batchTimer != nil {
+			batchTimer.Stop()
+		}
+		batchc = nil
+	}
+
 	defer func() {
+		// keep pending events buffered so a resumed substream delivers them
+		flushBatch()
 		if !resuming {
 			ws.closing = true
 		}
@@ -816,14 +1222,33 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 			}
 			ws.buf[0] = nil
 			ws.buf = ws.buf[1:]
+			ws.buffered.Store(int64(len(ws.buf)))
 		case wr, ok := <-ws.recvc:
 			if !ok {
 				// shutdown from closeSubstream
 				return
 			}
+			if err := ws.convertEvents(wr); err != nil {
+				wr = &WatchResponse{Header: wr.Header, Canceled: true, closeErr: status.Error(codes.Internal, err.Error())}
+			}
+
+			// events below nextRev were already delivered; a response
+			// interleaved across a reconnect must not regress the watcher
+			if n := wr.dropStaleEvents(nextRev); n > 0 {
+				w.lg.Warn(
+					"dropped watch events below the next expected revision",
+					zap.Int64("watch-id", ws.id),
+					zap.Int64("next-revision", nextRev),
+					zap.Int("dropped", n),
+				)
+				if len(wr.Events) == 0 && len(wr.RawEvents) == 0 && !wr.Canceled && wr.CompactRevision == 0 {
+					continue
+				}
+			}
 
 			if wr.Created {
-				if ws.initReq.retc != nil {
+				if !ws.createdSent {
+					ws.createdSent = true
 					ws.initReq.retc <- ws.outc
 					// to prevent next write from taking the slot in buffered channel
 					// and posting duplicate create events
@@ -844,6 +1269,9 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 					// after it is committed, it'll miss the Put.
 					if ws.initReq.rev == 0 {
 						nextRev = wr.Header.Revision
+						if wr.CreatedRevision != 0 {
+							nextRev = wr.CreatedRevision + 1
+						}
 					}
 				}
 			} else {
@@ -851,20 +1279,56 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 				nextRev = wr.Header.Revision + 1
 			}
 
-			if len(wr.Events) > 0 {
-				nextRev = wr.Events[len(wr.Events)-1].Kv.ModRevision + 1
+			if rev := wr.lastEventRevision(); rev != 0 {
+				nextRev = rev + 1
 			}
 
 			ws.initReq.rev = nextRev
+			ws.resumeRev.Store(nextRev)
 
 			// created event is already sent above,
 			// watcher should not post duplicate events
 			if wr.Created {
+				if !ws.initReq.authRevisionNotify || !ws.resumed {
+					continue
+				}
+				// auth revision changes are not replayed, so report the
+				// revision the watcher was re-established at
+				wr.Created = false
+			}
+
+			if ws.resumed && !wr.IsProgressNotify() {
+				wr.Resumed = true
+				ws.resumed = false
+			}
+
+			if ws.initReq.batchInterval > 0 && (len(wr.Events) > 0 || len(wr.RawEvents) > 0) && wr.Err() == nil {
+				if batch == nil {
+					batch = wr
+					batchTimer = time.NewTimer(ws.initReq.batchInterval)
+					batchc = batchTimer.C
+				} else {
+					batch.Events = append(batch.Events, wr.Events...)
+					batch.RawEvents = append(batch.RawEvents, wr.RawEvents...)
+					batch.Stale = batch.Stale || wr.Stale
+					if wr.Header.Revision > batch.Header.Revision {
+						batch.Header = wr.Header
+					}
+				}
 				continue
 			}
+			// progress notifies and errors must not overtake batched events
+			flushBatch()
+
+			if ws.initReq.latestPerKey {
+				wr.Events = latestPerKey(wr.Events)
+			}
 
 			// TODO pause channel if buffer gets too large
 			ws.buf = append(ws.buf, wr)
+			ws.buffered.Store(int64(len(ws.buf)))
+		case <-batchc:
+			flushBatch()
 		case <-w.ctx.Done():
 			return
 		case <-ws.initReq.ctx.Done():
@@ -877,13 +1341,145 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 	// lazily send cancel message if events on missing id
 }
 
+// convertEvents decodes the events of wr received encoded, or encodes them if
+// the watcher asked for raw events but the server sent them decoded.
+func (ws *watcherStream) convertEvents(wr *WatchResponse) error {
+	switch {
+	case ws.initReq.rawEvents && len(wr.Events) > 0:
+		evs := make([]*mvccpb.Event, len(wr.Events))
+		for i, ev := range wr.Events {
+			evs[i] = (*mvccpb.Event)(ev)
+		}
+		raw, err := (&pb.WatchResponse{Events: evs}).Marshal()
+		if err != nil {
+			return err
+		}
+		wr.Events, wr.RawEvents = nil, raw
+	case !ws.initReq.rawEvents && len(wr.RawEvents) > 0:
+		var resp pb.WatchResponse
+		if err := resp.Unmarshal(wr.RawEvents); err != nil {
+			return err
+		}
+		wr.Events = make([]*Event, len(resp.Events))
+		for i, ev := range resp.Events {
+			wr.Events[i] = (*Event)(ev)
+		}
+		wr.RawEvents = nil
+	}
+	return nil
+}
+
+// lastEventRevision returns the revision of the last event of wr, decoding
+// only that event if the events are raw, or 0 if wr has no events.
+func (wr *WatchResponse) lastEventRevision() int64 {
+	if len(wr.Events) > 0 {
+		return wr.Events[len(wr.Events)-1].Kv.ModRevision
+	}
+	if ev, err := lastRawEvent(wr.RawEvents); err == nil && ev != nil && ev.Kv != nil {
+		return ev.Kv.ModRevision
+	}
+	return 0
+}
+
+// eventsKey is the protobuf key of the events field of a pb.WatchResponse.
+const eventsKey = 11<<3 | 2
+
+var errInvalidRawEvents = errors.New("clientv3: invalid raw watch events")
+
+// lastRawEvent decodes only the last of the raw events in data, the encoding
+// of a pb.WatchResponse carrying only events. It returns nil if data holds no
+// events.
+func lastRawEvent(data []byte) (*mvccpb.Event, error) {
+	var last []byte
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 || key != eventsKey {
+			return nil, errInvalidRawEvents
+		}
+		size, m := binary.Uvarint(data[n:])
+		if m <= 0 || size > uint64(len(data)-n-m) {
+			return nil, errInvalidRawEvents
+		}
+		last, data = data[n+m:n+m+int(size)], data[n+m+int(size):]
+	}
+	if last == nil {
+		return nil, nil
+	}
+	ev := &mvccpb.Event{}
+	if err := ev.Unmarshal(last); err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+
+// dropStaleEvents removes the events of wr with a revision below rev and
+// returns how many it removed. Raw events are only removed as a whole, when
+// the last of them is below rev, so they are not decoded on the common path.
+func (wr *WatchResponse) dropStaleEvents(rev int64) int {
+	if rev == 0 || wr.Created {
+		return 0
+	}
+	if len(wr.RawEvents) > 0 {
+		if last := wr.lastEventRevision(); last == 0 || last >= rev {
+			return 0
+		}
+		var resp pb.WatchResponse
+		if err := resp.Unmarshal(wr.RawEvents); err != nil {
+			return 0
+		}
+		wr.RawEvents = nil
+		return len(resp.Events)
+	}
+	evs := wr.Events[:0]
+	for _, ev := range wr.Events {
+		if ev.Kv.ModRevision >= rev {
+			evs = append(evs, ev)
+		}
+	}
+	n := len(wr.Events) - len(evs)
+	wr.Events = evs
+	return n
+}
+
+// latestPerKey collapses evs so each key appears once, keeping its most recent
+// event at that event's position. The kept event takes the PrevKv of the
+// first event of its key, the key-value pair before any of them.
+func latestPerKey(evs []*Event) []*Event {
+	last := make(map[string]int, len(evs))
+	for i, ev := range evs {
+		last[string(ev.Kv.Key)] = i
+	}
+	if len(last) == len(evs) {
+		return evs
+	}
+	first := make(map[string]*mvccpb.KeyValue, len(last))
+	out := make([]*Event, 0, len(last))
+	for i, ev := range evs {
+		key := string(ev.Kv.Key)
+		prev, seen := first[key]
+		if !seen {
+			prev = ev.PrevKv
+			first[key] = prev
+		}
+		if last[key] == i {
+			ev.PrevKv = prev
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
 func (w *watchGRPCStream) newWatchClient() (pb.Watch_WatchClient, error) {
 	// mark all substreams as resuming
 	close(w.resumec)
 	w.resumec = make(chan struct{})
 	w.joinSubstreams()
+	w.statsMu.Lock()
 	for _, ws := range w.substreams {
 		ws.id = InvalidWatchID
+		// the watch channel was already handed out; flag the first
+		// response on the new stream so the subscriber can resync
+		ws.resumed = true
 		w.resuming = append(w.resuming, ws)
 	}
 	// strip out nils, if any
@@ -895,6 +1491,7 @@ func (w *watchGRPCStream) newWatchClient() (pb.Watch_WatchClient, error) {
 	}
 	w.resuming = resuming
 	w.substreams = make(map[int64]*watcherStream)
+	w.statsMu.Unlock()
 
 	// connect to grpc stream while accepting watcher cancellation
 	stopc := make(chan struct{})
@@ -971,11 +1568,10 @@ func (w *watchGRPCStream) joinSubstreams() {
 	}
 }
 
-var maxBackoff = 100 * time.Millisecond
-
 func (w *watchGRPCStream) backoffIfUnavailable(backoff time.Duration, err error) time.Duration {
 	if isUnavailableErr(w.ctx, err) {
 		// retry, but backoff
+		maxBackoff := w.owner.backoff.Max
 		if backoff < maxBackoff {
 			// 25% backoff factor
 			backoff = backoff + backoff/4
@@ -983,7 +1579,7 @@ func (w *watchGRPCStream) backoffIfUnavailable(backoff time.Duration, err error)
 				backoff = maxBackoff
 			}
 		}
-		time.Sleep(backoff)
+		time.Sleep(jitterUp(backoff, w.owner.backoff.Jitter))
 	}
 	return backoff
 }
@@ -992,7 +1588,7 @@ func (w *watchGRPCStream) backoffIfUnavailable(backoff time.Duration, err error)
 // manually retry in case "ws==nil && err==nil"
 // TODO: remove FailFast=false
 func (w *watchGRPCStream) openWatchClient() (ws pb.Watch_WatchClient, err error) {
-	backoff := time.Millisecond
+	backoff := w.owner.backoff.Min
 	for {
 		select {
 		case <-w.ctx.Done():
@@ -1023,6 +1619,13 @@ func (wr *watchRequest) toPB() *pb.WatchRequest {
 		Filters:        wr.filters,
 		PrevKv:         wr.prevKV,
 		Fragment:       wr.fragment,
+		RawEvents:      wr.rawEvents,
+		StaleOk:        wr.staleOK,
+
+		AuthRevisionNotify:       wr.authRevisionNotify,
+		ProgressNotifyIntervalMs: wr.progressNotifyInterval.Milliseconds(),
+		MaxEventRate:             wr.maxEventRate,
+		ValueProjection:          wr.valueProjection,
 	}
 	cr := &pb.WatchRequest_CreateRequest{CreateRequest: req}
 	return &pb.WatchRequest{RequestUnion: cr}
@@ -1031,6 +1634,10 @@ func (wr *watchRequest) toPB() *pb.WatchRequest {
 // toPB converts an internal progress request structure to its protobuf WatchRequest structure.
 func (pr *progressRequest) toPB() *pb.WatchRequest {
 	req := &pb.WatchProgressRequest{}
+	if pr.probe != nil {
+		req.Scoped = true
+		req.WatchId = pr.watchID
+	}
 	cr := &pb.WatchRequest_ProgressRequest{ProgressRequest: req}
 	return &pb.WatchRequest{RequestUnion: cr}
 }
@@ -0,0 +1,82 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+)
+
+// ErrInvalidBookmark is returned by WatchResponse.Err when WatchFromBookmark
+// is given a token that was not returned by WatchResponse.Bookmark.
+var ErrInvalidBookmark = errors.New("clientv3: invalid watch bookmark")
+
+// bookmarkVersion is the first byte of every bookmark token, followed by the
+// uvarint encoded revision to resume from.
+const bookmarkVersion = 1
+
+// Bookmark returns an opaque token recording how far the watch has
+// progressed once this response is handled. Passing the token to
+// WatchFromBookmark, even from another process, resumes the watch with the
+// first event following this response. Bookmark returns nil for responses
+// that do not mark a resumable position: errors, created notifications and
+// auth revision notifications.
+func (wr *WatchResponse) Bookmark() []byte {
+	if wr.Err() != nil || wr.Created || wr.AuthRevision != 0 {
+		return nil
+	}
+	var next int64
+	switch rev := wr.lastEventRevision(); {
+	case rev != 0:
+		next = rev + 1
+	case wr.Header.Revision != 0:
+		// a progress notification means all events up to the header
+		// revision were sent
+		next = wr.Header.Revision + 1
+	default:
+		return nil
+	}
+	b := make([]byte, 1, 1+binary.MaxVarintLen64)
+	b[0] = bookmarkVersion
+	return binary.AppendUvarint(b, uint64(next))
+}
+
+func decodeBookmark(b []byte) (int64, error) {
+	if len(b) < 2 || b[0] != bookmarkVersion {
+		return 0, ErrInvalidBookmark
+	}
+	rev, n := binary.Uvarint(b[1:])
+	if n != len(b)-1 || rev == 0 || rev > 1<<63-1 {
+		return 0, ErrInvalidBookmark
+	}
+	return int64(rev), nil
+}
+
+// WatchFromBookmark opens a watch on w that resumes from a token returned by
+// WatchResponse.Bookmark, so no event is missed or delivered twice. The key
+// and opts should match the ones of the watch the token was taken from;
+// a WithRev option is overridden by the token. If the token is malformed,
+// the returned channel carries a single canceled response with
+// ErrInvalidBookmark. If the revisions following the token were compacted,
+// the watch is canceled with a CompactedError like any other watch.
+func WatchFromBookmark(ctx context.Context, w Watcher, key string, bookmark []byte, opts ...OpOption) WatchChan {
+	rev, err := decodeBookmark(bookmark)
+	if err != nil {
+		return canceledWatchChan(err)
+	}
+	opts = append(opts[:len(opts):len(opts)], WithRev(rev))
+	return w.Watch(ctx, key, opts...)
+}
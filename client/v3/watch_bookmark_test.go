@@ -0,0 +1,85 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestWatchResponseBookmark(t *testing.T) {
+	tcs := []struct {
+		name    string
+		resp    WatchResponse
+		wantRev int64
+	}{
+		{
+			name: "events",
+			resp: WatchResponse{
+				Header: pb.ResponseHeader{Revision: 20},
+				Events: []*Event{{Kv: &mvccpb.KeyValue{ModRevision: 5}}, {Kv: &mvccpb.KeyValue{ModRevision: 7}}},
+			},
+			wantRev: 8,
+		},
+		{
+			name:    "progress notify",
+			resp:    WatchResponse{Header: pb.ResponseHeader{Revision: 20}},
+			wantRev: 21,
+		},
+		{
+			name: "created",
+			resp: WatchResponse{Header: pb.ResponseHeader{Revision: 20}, Created: true},
+		},
+		{
+			name: "compacted",
+			resp: WatchResponse{Header: pb.ResponseHeader{Revision: 20}, Canceled: true, CompactRevision: 10},
+		},
+		{
+			name: "auth revision",
+			resp: WatchResponse{Header: pb.ResponseHeader{Revision: 20}, AuthRevision: 3},
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			b := tc.resp.Bookmark()
+			if tc.wantRev == 0 {
+				require.Nil(t, b)
+				return
+			}
+			rev, err := decodeBookmark(b)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantRev, rev)
+		})
+	}
+}
+
+func TestWatchFromInvalidBookmark(t *testing.T) {
+	for _, b := range [][]byte{nil, {}, {bookmarkVersion}, {2, 8}, {bookmarkVersion, 0}, {bookmarkVersion, 8, 0}} {
+		wch := WatchFromBookmark(t.Context(), nil, "foo", b)
+		resp, ok := <-wch
+		require.True(t, ok)
+		require.True(t, resp.Canceled)
+		require.ErrorIs(t, resp.Err(), ErrInvalidBookmark)
+		_, ok = <-wch
+		require.False(t, ok)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import "context"
+
+// WatchCallback opens a watch on w like Watch, and calls onEvent with each
+// watched event, in order, from a goroutine it manages. The watch stops when
+// ctx is done, when onEvent returns an error, or when the watch fails, for
+// instance with a CompactedError if the revisions to resume from were
+// compacted. The returned channel then receives the error that stopped the
+// watch: the error of ctx, of onEvent or of the watch response. It is closed
+// once onEvent is no longer called. WithRawEvents has no effect on the events
+// passed to onEvent.
+func WatchCallback(ctx context.Context, w Watcher, key string, onEvent func(*Event) error, opts ...OpOption) <-chan error {
+	ctx, cancel := context.WithCancel(ctx)
+	opts = append(opts[:len(opts):len(opts)], func(op *Op) { op.rawEvents = false })
+	wch := w.Watch(ctx, key, opts...)
+
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer cancel()
+		errc <- serveWatchCallback(ctx, wch, onEvent)
+	}()
+	return errc
+}
+
+func serveWatchCallback(ctx context.Context, wch WatchChan, onEvent func(*Event) error) error {
+	for wr := range wch {
+		// responses buffered before ctx was done are not delivered
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := wr.Err(); err != nil {
+			return err
+		}
+		for _, ev := range wr.Events {
+			if err := onEvent(ev); err != nil {
+				return err
+			}
+		}
+	}
+	return ctx.Err()
+}
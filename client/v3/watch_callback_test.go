@@ -0,0 +1,104 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+// chanWatcher is a Watcher whose watches receive the responses of wch.
+type chanWatcher struct {
+	Watcher
+	wch chan WatchResponse
+	op  Op
+}
+
+func (w *chanWatcher) Watch(ctx context.Context, key string, opts ...OpOption) WatchChan {
+	w.op.applyOpts(opts)
+	return w.wch
+}
+
+func TestWatchCallback(t *testing.T) {
+	ev := func(rev int64) *Event {
+		return &Event{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("foo"), ModRevision: rev}}
+	}
+	errStop := errors.New("stop")
+
+	tcs := []struct {
+		name     string
+		resps    []WatchResponse
+		stopAt   int64
+		wantRevs []int64
+		wantErr  error
+	}{
+		{
+			name:     "closed",
+			resps:    []WatchResponse{{Created: true}, {Events: []*Event{ev(2), ev(3)}}, {Events: []*Event{ev(4)}}},
+			wantRevs: []int64{2, 3, 4},
+		},
+		{
+			name:     "callback error",
+			resps:    []WatchResponse{{Events: []*Event{ev(2), ev(3)}}, {Events: []*Event{ev(4)}}},
+			stopAt:   3,
+			wantRevs: []int64{2, 3},
+			wantErr:  errStop,
+		},
+		{
+			name:     "compacted",
+			resps:    []WatchResponse{{Events: []*Event{ev(2)}}, {Canceled: true, CompactRevision: 5}, {Events: []*Event{ev(6)}}},
+			wantRevs: []int64{2},
+			wantErr:  &CompactedError{CompactedRev: 5},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			w := &chanWatcher{wch: make(chan WatchResponse, len(tc.resps))}
+			for _, resp := range tc.resps {
+				w.wch <- resp
+			}
+			close(w.wch)
+
+			var revs []int64
+			errc := WatchCallback(t.Context(), w, "foo", func(ev *Event) error {
+				revs = append(revs, ev.Kv.ModRevision)
+				if ev.Kv.ModRevision == tc.stopAt {
+					return errStop
+				}
+				return nil
+			}, WithRawEvents())
+			require.Equal(t, tc.wantErr, <-errc)
+			_, ok := <-errc
+			require.False(t, ok)
+			require.Equal(t, tc.wantRevs, revs)
+			require.False(t, w.op.IsRawEvents())
+		})
+	}
+}
+
+func TestWatchCallbackContextCanceled(t *testing.T) {
+	w := &chanWatcher{wch: make(chan WatchResponse)}
+	ctx, cancel := context.WithCancel(t.Context())
+	errc := WatchCallback(ctx, w, "foo", func(*Event) error { return nil })
+	cancel()
+	// the response buffered when ctx is done is not delivered
+	w.wch <- WatchResponse{Events: []*Event{{Kv: &mvccpb.KeyValue{}}}}
+	require.ErrorIs(t, <-errc, context.Canceled)
+}
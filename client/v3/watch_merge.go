@@ -0,0 +1,188 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"time"
+)
+
+// mergeWatchProgressInterval is how often MergeWatch repeats a progress
+// request while it waits for a watch to catch up.
+const mergeWatchProgressInterval = time.Second
+
+// WatchSpec describes one of the watches opened by MergeWatch.
+type WatchSpec struct {
+	Key  string
+	Opts []OpOption
+}
+
+// MergeWatch opens a watch on w for each spec and merges their responses
+// into a single channel ordered by revision. Each forwarded response holds
+// the events of a single revision of a single watch, under the header of the
+// response it was received in. A revision is forwarded only once every watch
+// is known to have sent all events up to it; MergeWatch requests progress
+// notifications from quiet watches to learn so, and does not forward
+// progress or created notifications.
+//
+// The returned channel is closed when ctx is canceled, when any watch
+// channel closes, or after forwarding the first response carrying an error;
+// all watches are canceled at that point. All specs are watched on the same
+// watch stream, so they must not set conflicting stream options.
+func MergeWatch(ctx context.Context, w Watcher, specs ...WatchSpec) <-chan WatchResponse {
+	out := make(chan WatchResponse)
+	ctx, cancel := context.WithCancel(ctx)
+
+	type taggedResponse struct {
+		i    int
+		resp WatchResponse
+		ok   bool
+	}
+	inc := make(chan taggedResponse)
+	for i, spec := range specs {
+		wch := w.Watch(ctx, spec.Key, spec.Opts...)
+		go func() {
+			for {
+				resp, ok := <-wch
+				select {
+				case inc <- taggedResponse{i: i, resp: resp, ok: ok}:
+				case <-ctx.Done():
+					return
+				}
+				if !ok {
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(out)
+		defer cancel()
+
+		m := newWatchMerger(len(specs))
+		var (
+			progressTimer <-chan time.Time
+			progressSent  bool
+		)
+		for {
+			for {
+				resp, ok := m.next()
+				if !ok {
+					break
+				}
+				select {
+				case out <- resp:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if m.blocked() && !progressSent {
+				// progress responses are broadcast to every watch on the stream
+				w.RequestProgress(ctx)
+				progressSent = true
+				progressTimer = time.After(mergeWatchProgressInterval)
+			}
+
+			select {
+			case t := <-inc:
+				if !t.ok {
+					return
+				}
+				if err := t.resp.Err(); err != nil {
+					select {
+					case out <- t.resp:
+					case <-ctx.Done():
+					}
+					return
+				}
+				if t.resp.IsProgressNotify() {
+					progressSent = false
+				}
+				m.add(t.i, t.resp)
+			case <-progressTimer:
+				progressSent = false
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// watchMerger orders the responses of several watches by revision.
+type watchMerger struct {
+	// pending holds the received responses of each watch not yet
+	// forwarded, split by revision.
+	pending [][]WatchResponse
+	// revs holds, per watch, the revision up to which all events have been
+	// received.
+	revs []int64
+}
+
+func newWatchMerger(n int) *watchMerger {
+	return &watchMerger{pending: make([][]WatchResponse, n), revs: make([]int64, n)}
+}
+
+func (m *watchMerger) add(i int, resp WatchResponse) {
+	m.revs[i] = max(m.revs[i], resp.Header.Revision)
+	for len(resp.Events) > 0 {
+		rev := resp.Events[0].Kv.ModRevision
+		n := 1
+		for n < len(resp.Events) && resp.Events[n].Kv.ModRevision == rev {
+			n++
+		}
+		split := resp
+		split.Events = resp.Events[:n:n]
+		m.pending[i] = append(m.pending[i], split)
+		resp.Events = resp.Events[n:]
+	}
+}
+
+// head returns the watch holding the pending response of the lowest
+// revision, or -1 if nothing is pending.
+func (m *watchMerger) head() int {
+	h := -1
+	for i, p := range m.pending {
+		if len(p) > 0 && (h < 0 || p[0].Events[0].Kv.ModRevision < m.pending[h][0].Events[0].Kv.ModRevision) {
+			h = i
+		}
+	}
+	return h
+}
+
+// next returns the next response in revision order, if every watch has
+// received all events up to its revision.
+func (m *watchMerger) next() (WatchResponse, bool) {
+	h := m.head()
+	if h < 0 {
+		return WatchResponse{}, false
+	}
+	rev := m.pending[h][0].Events[0].Kv.ModRevision
+	for i, p := range m.pending {
+		if len(p) == 0 && m.revs[i] < rev {
+			return WatchResponse{}, false
+		}
+	}
+	resp := m.pending[h][0]
+	m.pending[h] = m.pending[h][1:]
+	return resp, true
+}
+
+// blocked returns true if responses are pending on watches that have not
+// caught up.
+func (m *watchMerger) blocked() bool {
+	return m.head() >= 0
+}
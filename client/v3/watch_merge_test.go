@@ -0,0 +1,70 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func mergeTestResponse(headerRev int64, revs ...int64) WatchResponse {
+	resp := WatchResponse{Header: pb.ResponseHeader{Revision: headerRev}}
+	for _, rev := range revs {
+		resp.Events = append(resp.Events, &Event{Kv: &mvccpb.KeyValue{ModRevision: rev}})
+	}
+	return resp
+}
+
+func mergeTestRevisions(t *testing.T, m *watchMerger) []int64 {
+	var revs []int64
+	for {
+		resp, ok := m.next()
+		if !ok {
+			return revs
+		}
+		for _, ev := range resp.Events {
+			require.Equal(t, resp.Events[0].Kv.ModRevision, ev.Kv.ModRevision)
+		}
+		revs = append(revs, resp.Events[0].Kv.ModRevision)
+	}
+}
+
+func TestWatchMerger(t *testing.T) {
+	m := newWatchMerger(3)
+
+	// watch 0 catches up on several revisions in one response
+	m.add(0, mergeTestResponse(7, 2, 2, 5, 7))
+	require.True(t, m.blocked())
+	require.Empty(t, mergeTestRevisions(t, m))
+
+	m.add(1, mergeTestResponse(4, 3, 4))
+	require.Empty(t, mergeTestRevisions(t, m))
+
+	// progress of watch 2 releases revisions every watch has caught up to
+	m.add(2, mergeTestResponse(6))
+	require.Equal(t, []int64{2, 3, 4}, mergeTestRevisions(t, m))
+	require.True(t, m.blocked())
+
+	m.add(1, mergeTestResponse(8))
+	require.Equal(t, []int64{5}, mergeTestRevisions(t, m))
+
+	m.add(2, mergeTestResponse(8, 7))
+	require.Equal(t, []int64{7, 7}, mergeTestRevisions(t, m))
+	require.False(t, m.blocked())
+}
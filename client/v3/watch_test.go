@@ -15,11 +15,15 @@
 package clientv3
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"reflect"
 	"testing"
 
 	"google.golang.org/grpc/metadata"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
 )
 
@@ -106,3 +110,156 @@ func TestStreamKeyFromCtx(t *testing.T) {
 		})
 	}
 }
+
+func TestLatestPerKey(t *testing.T) {
+	put := func(key, val string, rev int64, prev *mvccpb.KeyValue) *Event {
+		return &Event{Type: EventTypePut, Kv: &mvccpb.KeyValue{Key: []byte(key), Value: []byte(val), ModRevision: rev}, PrevKv: prev}
+	}
+	del := func(key string, rev int64, prev *mvccpb.KeyValue) *Event {
+		return &Event{Type: EventTypeDelete, Kv: &mvccpb.KeyValue{Key: []byte(key), ModRevision: rev}, PrevKv: prev}
+	}
+	a0 := &mvccpb.KeyValue{Key: []byte("a"), Value: []byte("0")}
+
+	evs := latestPerKey([]*Event{
+		put("a", "1", 2, a0),
+		put("b", "1", 3, nil),
+		put("a", "2", 4, nil),
+		put("c", "1", 5, nil),
+		del("b", 6, nil),
+		put("a", "3", 7, nil),
+	})
+	want := []*Event{
+		put("c", "1", 5, nil),
+		del("b", 6, nil),
+		put("a", "3", 7, a0),
+	}
+	if !reflect.DeepEqual(want, evs) {
+		t.Errorf("latestPerKey() = %v, expected %v", evs, want)
+	}
+
+	unique := []*Event{put("a", "1", 2, nil), put("b", "1", 3, nil)}
+	if evs = latestPerKey(unique); !reflect.DeepEqual(unique, evs) {
+		t.Errorf("latestPerKey() = %v, expected %v", evs, unique)
+	}
+}
+
+func rawEventsForTest(n int) ([]*Event, []byte) {
+	evs := make([]*Event, n)
+	pbevs := make([]*mvccpb.Event, n)
+	for i := range evs {
+		ev := &mvccpb.Event{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte(fmt.Sprintf("key%d", i)), Value: bytes.Repeat([]byte("v"), 1024), CreateRevision: 2, ModRevision: int64(i + 2), Version: int64(i + 1)}}
+		if i%3 == 2 {
+			ev = &mvccpb.Event{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: ev.Kv.Key, ModRevision: ev.Kv.ModRevision}}
+		}
+		evs[i], pbevs[i] = (*Event)(ev), ev
+	}
+	raw, err := (&pb.WatchResponse{Events: pbevs}).Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return evs, raw
+}
+
+func TestConvertEvents(t *testing.T) {
+	evs, raw := rawEventsForTest(5)
+
+	// raw events received by a watcher that did not ask for them are decoded
+	ws := &watcherStream{}
+	wr := &WatchResponse{RawEvents: raw}
+	if err := ws.convertEvents(wr); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(evs, wr.Events) || wr.RawEvents != nil {
+		t.Errorf("convertEvents() = %v, %v, expected %v", wr.Events, wr.RawEvents, evs)
+	}
+
+	// decoded events received by a raw watcher are encoded
+	ws = &watcherStream{initReq: watchRequest{rawEvents: true}}
+	wr = &WatchResponse{Events: evs}
+	if err := ws.convertEvents(wr); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, wr.RawEvents) || wr.Events != nil {
+		t.Errorf("convertEvents() = %v, %x, expected %x", wr.Events, wr.RawEvents, raw)
+	}
+	if rev := wr.lastEventRevision(); rev != 6 {
+		t.Errorf("lastEventRevision() = %d, expected 6", rev)
+	}
+
+	// raw events decode to the events by hand
+	var resp pb.WatchResponse
+	if err := resp.Unmarshal(wr.RawEvents); err != nil {
+		t.Fatal(err)
+	}
+	for i, ev := range resp.Events {
+		if !reflect.DeepEqual(evs[i], (*Event)(ev)) {
+			t.Errorf("event %d = %v, expected %v", i, ev, evs[i])
+		}
+	}
+}
+
+func TestDropStaleEvents(t *testing.T) {
+	evs, raw := rawEventsForTest(5)
+
+	wr := &WatchResponse{Events: append([]*Event(nil), evs...)}
+	if n := wr.dropStaleEvents(4); n != 2 {
+		t.Errorf("dropStaleEvents(4) = %d, expected 2", n)
+	}
+	if !reflect.DeepEqual(evs[2:], wr.Events) {
+		t.Errorf("events = %v, expected %v", wr.Events, evs[2:])
+	}
+
+	// no revision is expected yet
+	wr = &WatchResponse{Events: append([]*Event(nil), evs...)}
+	if n := wr.dropStaleEvents(0); n != 0 || len(wr.Events) != 5 {
+		t.Errorf("dropStaleEvents(0) = %d, %d events left, expected 0, 5", n, len(wr.Events))
+	}
+
+	// raw events overlapping rev are kept as a whole
+	wr = &WatchResponse{RawEvents: raw}
+	if n := wr.dropStaleEvents(4); n != 0 || !bytes.Equal(raw, wr.RawEvents) {
+		t.Errorf("dropStaleEvents(4) = %d, expected raw events kept", n)
+	}
+	wr = &WatchResponse{RawEvents: raw}
+	if n := wr.dropStaleEvents(7); n != 5 || wr.RawEvents != nil {
+		t.Errorf("dropStaleEvents(7) = %d, expected all 5 raw events dropped", n)
+	}
+}
+
+func TestLastRawEvent(t *testing.T) {
+	evs, raw := rawEventsForTest(5)
+
+	ev, err := lastRawEvent(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(evs[4], (*Event)(ev)) {
+		t.Errorf("lastRawEvent() = %v, expected %v", ev, evs[4])
+	}
+
+	if ev, err = lastRawEvent(nil); ev != nil || err != nil {
+		t.Errorf("lastRawEvent(nil) = %v, %v, expected nil, nil", ev, err)
+	}
+	if _, err = lastRawEvent(raw[:len(raw)-1]); err == nil {
+		t.Error("lastRawEvent() of truncated events succeeded, expected an error")
+	}
+}
+
+func BenchmarkConvertEvents(b *testing.B) {
+	_, raw := rawEventsForTest(100)
+	for _, rawEvents := range []bool{false, true} {
+		b.Run(fmt.Sprintf("raw=%v", rawEvents), func(b *testing.B) {
+			ws := &watcherStream{initReq: watchRequest{rawEvents: rawEvents}}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				wr := &WatchResponse{RawEvents: raw}
+				if err := ws.convertEvents(wr); err != nil {
+					b.Fatal(err)
+				}
+				if wr.lastEventRevision() != 101 {
+					b.Fatal("unexpected last event revision")
+				}
+			}
+		})
+	}
+}
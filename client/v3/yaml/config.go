@@ -18,10 +18,12 @@ package yaml
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"os"
 
 	"sigs.k8s.io/yaml"
 
+	"go.etcd.io/etcd/client/pkg/v3/srv"
 	"go.etcd.io/etcd/client/pkg/v3/tlsutil"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
@@ -38,8 +40,18 @@ type yamlConfig struct {
 	// CAfile is being deprecated. Use 'TrustedCAfile' instead.
 	// TODO: deprecate this in v4
 	CAfile string `json:"ca-file"`
+
+	// DiscoverySrv is the domain name to query for SRV records of the
+	// client endpoints. Discovered endpoints take precedence over
+	// 'Endpoints'.
+	DiscoverySrv string `json:"discovery-srv"`
+	// DiscoverySrvName is the suffix of the SRV service name to query.
+	DiscoverySrvName string `json:"discovery-srv-name"`
 }
 
+// getClient is overridden in tests to avoid DNS lookups.
+var getClient = srv.GetClient
+
 // NewConfig creates a new clientv3.Config from a yaml file.
 func NewConfig(fpath string) (*clientv3.Config, error) {
 	b, err := os.ReadFile(fpath)
@@ -54,6 +66,20 @@ func NewConfig(fpath string) (*clientv3.Config, error) {
 		return nil, err
 	}
 
+	if yc.DiscoverySrvName != "" && yc.DiscoverySrv == "" {
+		return nil, errors.New("discovery-srv-name requires discovery-srv")
+	}
+	if yc.DiscoverySrv != "" {
+		srvs, err := getClient("etcd-client", yc.DiscoverySrv, yc.DiscoverySrvName)
+		if err != nil {
+			return nil, err
+		}
+		// fall back to the configured endpoints if nothing is discovered
+		if len(srvs.Endpoints) > 0 {
+			yc.Endpoints = srvs.Endpoints
+		}
+	}
+
 	if yc.InsecureTransport {
 		return &yc.Config, nil
 	}
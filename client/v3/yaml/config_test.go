@@ -15,13 +15,19 @@
 package yaml
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/yaml"
+
+	"go.etcd.io/etcd/client/pkg/v3/srv"
+	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 var (
@@ -118,3 +124,75 @@ func TestConfigFromFile(t *testing.T) {
 		os.Remove(tmpfile.Name())
 	}
 }
+
+func TestConfigFromFileDiscovery(t *testing.T) {
+	defer func(f func(string, string, string) (*srv.SRVClients, error)) { getClient = f }(getClient)
+	getClient = func(service, domain, serviceName string) (*srv.SRVClients, error) {
+		if service != "etcd-client" {
+			return nil, fmt.Errorf("unexpected service %q", service)
+		}
+		switch domain + "/" + serviceName {
+		case "example.com/":
+			return &srv.SRVClients{Endpoints: []string{"https://10.0.0.1:2379", "https://10.0.0.2:2379"}}, nil
+		case "example.com/prod":
+			return &srv.SRVClients{Endpoints: []string{"https://10.0.1.1:2379"}}, nil
+		case "empty.com/":
+			return &srv.SRVClients{}, nil
+		}
+		return nil, errors.New("no SRV records")
+	}
+
+	tests := []struct {
+		ym *yamlConfig
+
+		wendpoints []string
+		werr       bool
+	}{
+		{
+			ym:         &yamlConfig{DiscoverySrv: "example.com", InsecureTransport: true},
+			wendpoints: []string{"https://10.0.0.1:2379", "https://10.0.0.2:2379"},
+		},
+		{
+			ym:         &yamlConfig{DiscoverySrv: "example.com", DiscoverySrvName: "prod", TrustedCAfile: caPath},
+			wendpoints: []string{"https://10.0.1.1:2379"},
+		},
+		{
+			ym: &yamlConfig{
+				Config:       clientv3.Config{Endpoints: []string{"https://127.0.0.1:2379"}},
+				DiscoverySrv: "example.com",
+			},
+			wendpoints: []string{"https://10.0.0.1:2379", "https://10.0.0.2:2379"},
+		},
+		{
+			ym: &yamlConfig{
+				Config:       clientv3.Config{Endpoints: []string{"https://127.0.0.1:2379"}},
+				DiscoverySrv: "empty.com",
+			},
+			wendpoints: []string{"https://127.0.0.1:2379"},
+		},
+		{
+			ym:   &yamlConfig{DiscoverySrv: "unknown.com"},
+			werr: true,
+		},
+		{
+			ym:   &yamlConfig{DiscoverySrvName: "prod"},
+			werr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		fpath := filepath.Join(t.TempDir(), "clientcfg")
+		b, err := yaml.Marshal(tt.ym)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(fpath, b, 0o600))
+
+		cfg, err := NewConfig(fpath)
+		if tt.werr {
+			require.Errorf(t, err, "#%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "#%d", i)
+		require.Equalf(t, tt.wendpoints, cfg.Endpoints, "#%d", i)
+		require.Equalf(t, tt.ym.InsecureTransport, cfg.TLS == nil, "#%d", i)
+	}
+}
@@ -16,9 +16,11 @@ package command
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	v3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
@@ -32,12 +34,49 @@ func NewAlarmCommand() *cobra.Command {
 		GroupID: groupClusterMaintenanceID,
 	}
 
+	ac.AddCommand(NewAlarmArmCommand())
 	ac.AddCommand(NewAlarmDisarmCommand())
 	ac.AddCommand(NewAlarmListCommand())
 
 	return ac
 }
 
+func NewAlarmArmCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "arm <alarm>",
+		Short: "Arms an alarm for the member serving the request",
+		Long: `Arms an alarm for the member serving the request.
+
+Arming READONLY rejects writes to the cluster until the alarm is disarmed.`,
+		Run: alarmArmCommandFunc,
+	}
+	return &cmd
+}
+
+// alarmArmCommandFunc executes the "alarm arm" command.
+func alarmArmCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("alarm arm command needs 1 argument"))
+	}
+	alarm, ok := pb.AlarmType_value[strings.ToUpper(args[0])]
+	if !ok || alarm == int32(pb.AlarmType_NONE) {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("unknown alarm %q", args[0]))
+	}
+
+	cli := mustClientFromCmd(cmd)
+	ctx, cancel := commandCtx(cmd)
+	defer cancel()
+	status, err := cli.Status(ctx, cli.Endpoints()[0])
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	resp, err := cli.AlarmActivate(ctx, &v3.AlarmMember{MemberID: status.Header.MemberId, Alarm: pb.AlarmType(alarm)})
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.Alarm(*resp)
+}
+
 func NewAlarmDisarmCommand() *cobra.Command {
 	cmd := cobra.Command{
 		Use:   "disarm",
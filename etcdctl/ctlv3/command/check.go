@@ -22,6 +22,7 @@ import (
 	"math/rand"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -36,12 +37,13 @@ import (
 )
 
 var (
-	checkPerfLoad        string
-	checkPerfPrefix      string
-	checkDatascaleLoad   string
-	checkDatascalePrefix string
-	autoCompact          bool
-	autoDefrag           bool
+	checkPerfLoad         string
+	checkPerfPrefix       string
+	checkDatascaleLoad    string
+	checkDatascalePrefix  string
+	checkDatascaleWriters int
+	autoCompact           bool
+	autoDefrag            bool
 )
 
 type checkPerfCfg struct {
@@ -305,6 +307,7 @@ func NewCheckDatascaleCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&checkDatascaleLoad, "load", "s", "The datascale check's workload model. Accepted workloads: s(small), m(medium), l(large), xl(xLarge)")
 	cmd.Flags().StringVar(&checkDatascalePrefix, "prefix", "/etcdctl-check-datascale/", "The prefix for writing the datascale check's keys.")
+	cmd.Flags().IntVar(&checkDatascaleWriters, "writers", 0, "The number of concurrent writers. Defaults to the number of clients of the workload model.")
 	cmd.Flags().BoolVar(&autoCompact, "auto-compact", false, "Compact storage with last revision after test is finished.")
 	cmd.Flags().BoolVar(&autoDefrag, "auto-defrag", false, "Defragment storage after test is finished.")
 
@@ -325,6 +328,12 @@ func newCheckDatascaleCommand(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitBadFeature, fmt.Errorf("unknown load option %v", checkDatascaleLoad))
 	}
 	cfg := checkDatascaleCfgMap[model]
+	if checkDatascaleWriters < 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("invalid number of writers %d", checkDatascaleWriters))
+	}
+	if checkDatascaleWriters > 0 {
+		cfg.clients = checkDatascaleWriters
+	}
 
 	requests := make(chan v3.Op, cfg.clients)
 
@@ -370,16 +379,22 @@ func newCheckDatascaleCommand(cmd *cobra.Command, args []string) {
 	bar := pb.New(cfg.limit)
 	bar.Start()
 
+	// each writer records the latencies of its own requests
+	lats := make([][]float64, len(clients))
 	for i := range clients {
-		go func(c *v3.Client) {
+		go func(i int, c *v3.Client) {
 			defer wg.Done()
 			for op := range requests {
 				st := time.Now()
 				_, derr := c.Do(context.Background(), op)
-				r.Results() <- report.Result{Err: derr, Start: st, End: time.Now()}
+				end := time.Now()
+				r.Results() <- report.Result{Err: derr, Start: st, End: end}
+				if derr == nil {
+					lats[i] = append(lats[i], end.Sub(st).Seconds())
+				}
 				bar.Increment()
 			}
-		}(clients[i])
+		}(i, clients[i])
 	}
 
 	go func() {
@@ -436,5 +451,26 @@ func newCheckDatascaleCommand(cmd *cobra.Command, args []string) {
 		}
 		os.Exit(cobrautl.ExitError)
 	}
+	printWriterLatencies(lats)
 	fmt.Printf("PASS: Approximate system memory used : %v MB.\n", strconv.FormatFloat(mbUsed, 'f', 2, 64))
 }
+
+// printWriterLatencies prints the p50, p90 and p99 latencies of the
+// successful requests of each writer.
+func printWriterLatencies(lats [][]float64) {
+	for i, l := range lats {
+		if len(l) == 0 {
+			fmt.Printf("Writer %d: no successful writes\n", i)
+			continue
+		}
+		slices.Sort(l)
+		fmt.Printf("Writer %d: %d writes, p50 %.4fs, p90 %.4fs, p99 %.4fs\n", i, len(l), percentile(l, 50), percentile(l, 90), percentile(l, 99))
+	}
+}
+
+// percentile returns the p-th percentile of the sorted latencies, using the
+// nearest-rank method.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
@@ -24,7 +24,10 @@ import (
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
 
-var compactPhysical bool
+var (
+	compactPhysical bool
+	compactDryRun   bool
+)
 
 // NewCompactionCommand returns the cobra command for "compaction".
 func NewCompactionCommand() *cobra.Command {
@@ -35,6 +38,7 @@ func NewCompactionCommand() *cobra.Command {
 		GroupID: groupKVID,
 	}
 	cmd.Flags().BoolVar(&compactPhysical, "physical", false, "'true' to wait for compaction to physically remove all old revisions")
+	cmd.Flags().BoolVar(&compactDryRun, "dry-run", false, "'true' to only estimate the space reclaimed by the compaction without compacting")
 	return cmd
 }
 
@@ -53,13 +57,22 @@ func compactionCommandFunc(cmd *cobra.Command, args []string) {
 	if compactPhysical {
 		opts = append(opts, clientv3.WithCompactPhysical())
 	}
+	if compactDryRun {
+		opts = append(opts, clientv3.WithCompactDryRun())
+	}
 
 	c := mustClientFromCmd(cmd)
 	ctx, cancel := commandCtx(cmd)
-	_, cerr := c.Compact(ctx, rev, opts...)
+	resp, cerr := c.Compact(ctx, rev, opts...)
 	cancel()
 	if cerr != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, cerr)
 	}
+	if compactDryRun {
+		fmt.Println("current revision", resp.Header.Revision)
+		fmt.Println("target revision", rev)
+		fmt.Println("estimated reclaimable bytes", resp.ReclaimableBytes)
+		return
+	}
 	fmt.Println("compacted revision", rev)
 }
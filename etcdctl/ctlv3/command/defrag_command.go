@@ -15,15 +15,20 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
 
+	"github.com/cheggaaa/pb/v3"
 	"github.com/spf13/cobra"
 
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
 
+var defragProgress bool
+
 // NewDefragCommand returns the cobra command for "Defrag".
 func NewDefragCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -33,6 +38,7 @@ func NewDefragCommand() *cobra.Command {
 		GroupID: groupClusterMaintenanceID,
 	}
 	cmd.PersistentFlags().BoolVar(&epClusterEndpoints, "cluster", false, "use all endpoints from the cluster member list")
+	cmd.Flags().BoolVar(&defragProgress, "progress", false, "print a progress bar to stderr while defragmenting")
 	return cmd
 }
 
@@ -44,7 +50,12 @@ func defragCommandFunc(cmd *cobra.Command, args []string) {
 		c := mustClient(cfg)
 		ctx, cancel := commandCtx(cmd)
 		start := time.Now()
-		_, err := c.Defragment(ctx, ep)
+		var err error
+		if defragProgress {
+			err = defragmentWithProgressBar(ctx, c, ep)
+		} else {
+			_, err = c.Defragment(ctx, ep)
+		}
 		d := time.Since(start)
 		cancel()
 		if err != nil {
@@ -60,3 +71,18 @@ func defragCommandFunc(cmd *cobra.Command, args []string) {
 		os.Exit(cobrautl.ExitError)
 	}
 }
+
+// defragmentWithProgressBar defragments the member at ep, rendering the
+// progress it reports as a progress bar on stderr.
+func defragmentWithProgressBar(ctx context.Context, c *clientv3.Client, ep string) error {
+	bar := pb.New64(0)
+	bar.Set(pb.Bytes, true)
+	bar.SetWriter(os.Stderr)
+	bar.Start()
+	_, err := c.DefragmentWithProgress(ctx, ep, func(processed, total int64) {
+		bar.SetTotal(total)
+		bar.SetCurrent(processed)
+	})
+	bar.Finish()
+	return err
+}
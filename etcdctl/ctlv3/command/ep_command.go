@@ -32,8 +32,9 @@ import (
 )
 
 var (
-	epClusterEndpoints bool
-	epHashKVRev        int64
+	epClusterEndpoints    bool
+	epHashKVRev           int64
+	epHealthRequireLeader bool
 )
 
 // NewEndpointCommand returns the cobra command for "endpoint".
@@ -49,6 +50,7 @@ func NewEndpointCommand() *cobra.Command {
 	ec.AddCommand(newEpHealthCommand())
 	ec.AddCommand(newEpStatusCommand())
 	ec.AddCommand(newEpHashKVCommand())
+	ec.AddCommand(newEpBucketStatsCommand())
 
 	return ec
 }
@@ -59,6 +61,7 @@ func newEpHealthCommand() *cobra.Command {
 		Short: "Checks the healthiness of endpoints specified in `--endpoints` flag",
 		Run:   epHealthCommandFunc,
 	}
+	cmd.Flags().BoolVar(&epHealthRequireLeader, "require-leader", false, "report an endpoint unhealthy if it has no leader")
 
 	return cmd
 }
@@ -84,11 +87,21 @@ func newEpHashKVCommand() *cobra.Command {
 	return hc
 }
 
+func newEpBucketStatsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "bucket-stats",
+		Short: "Prints the number of keys and their size in bytes for each backend bucket of each endpoint in --endpoints",
+		Run:   epBucketStatsCommandFunc,
+	}
+}
+
 type epHealth struct {
 	Ep     string `json:"endpoint"`
 	Health bool   `json:"health"`
 	Took   string `json:"took"`
-	Error  string `json:"error,omitempty"`
+	// LatencyMs is the latency of the health check in milliseconds.
+	LatencyMs float64 `json:"latency_ms,omitempty"`
+	Error     string  `json:"error,omitempty"`
 }
 
 // epHealthCommandFunc executes the "endpoint-health" command.
@@ -128,8 +141,13 @@ func epHealthCommandFunc(cmd *cobra.Command, args []string) {
 			// get a random key. As long as we can get the response without an error, the
 			// endpoint is health.
 			ctx, cancel := commandCtx(cmd)
+			if epHealthRequireLeader {
+				// fail fast with ErrNoLeader instead of waiting for a leader
+				ctx = clientv3.WithRequireLeader(ctx)
+			}
 			_, err = cli.Get(ctx, "health")
-			eh := epHealth{Ep: ep, Health: false, Took: time.Since(st).String()}
+			took := time.Since(st)
+			eh := epHealth{Ep: ep, Health: false, Took: took.String(), LatencyMs: float64(took) / float64(time.Millisecond)}
 			// permission denied is OK since proposal goes through consensus to get it
 			if err == nil || errors.Is(err, rpctypes.ErrPermissionDenied) {
 				eh.Health = true
@@ -148,6 +166,8 @@ func epHealthCommandFunc(cmd *cobra.Command, args []string) {
 							eh.Error = eh.Error + "NOSPACE "
 						case etcdserverpb.AlarmType_CORRUPT:
 							eh.Error = eh.Error + "CORRUPT "
+						case etcdserverpb.AlarmType_READONLY:
+							eh.Error = eh.Error + "READONLY "
 						default:
 							eh.Error = eh.Error + "UNKNOWN "
 						}
@@ -243,6 +263,38 @@ func epHashKVCommandFunc(cmd *cobra.Command, args []string) {
 	}
 }
 
+type epBucketStats struct {
+	Ep   string                        `json:"Endpoint"`
+	Resp *clientv3.BucketStatsResponse `json:"BucketStats"`
+}
+
+func epBucketStatsCommandFunc(cmd *cobra.Command, args []string) {
+	cfg := clientConfigFromCmd(cmd)
+
+	var statsList []epBucketStats
+	var err error
+	for _, ep := range endpointsFromCluster(cmd) {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, serr := c.BucketStats(ctx, ep)
+		cancel()
+		c.Close()
+		if serr != nil {
+			err = serr
+			fmt.Fprintf(os.Stderr, "Failed to get the bucket stats of endpoint %s (%v)\n", ep, serr)
+			continue
+		}
+		statsList = append(statsList, epBucketStats{Ep: ep, Resp: resp})
+	}
+
+	display.EndpointBucketStats(statsList)
+
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
 func endpointsFromCluster(cmd *cobra.Command) []string {
 	if !epClusterEndpoints {
 		endpoints, err := cmd.Flags().GetStringSlice("endpoints")
@@ -39,6 +39,7 @@ var (
 	getMaxCreateRev int64
 	getMinModRev    int64
 	getMaxModRev    int64
+	getLeaseTTL     bool
 )
 
 // NewGetCommand returns the cobra command for "get".
@@ -58,12 +59,13 @@ func NewGetCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&getFromKey, "from-key", false, "Get keys that are greater than or equal to the given key using byte compare")
 	cmd.Flags().Int64Var(&getRev, "rev", 0, "Specify the kv revision")
 	cmd.Flags().BoolVar(&getKeysOnly, "keys-only", false, "Get only the keys")
-	cmd.Flags().BoolVar(&getCountOnly, "count-only", false, "Get only the count")
+	cmd.Flags().BoolVar(&getCountOnly, "count-only", false, "Get only the count of keys without fetching them")
 	cmd.Flags().BoolVar(&printValueOnly, "print-value-only", false, `Only write values when using the "simple" output format`)
 	cmd.Flags().Int64Var(&getMinCreateRev, "min-create-rev", 0, "Minimum create revision")
 	cmd.Flags().Int64Var(&getMaxCreateRev, "max-create-rev", 0, "Maximum create revision")
 	cmd.Flags().Int64Var(&getMinModRev, "min-mod-rev", 0, "Minimum modification revision")
 	cmd.Flags().Int64Var(&getMaxModRev, "max-mod-rev", 0, "Maximum modification revision")
+	cmd.Flags().BoolVar(&getLeaseTTL, "with-lease-ttl", false, `Print the remaining lease TTL of leased keys when using the "simple" output format`)
 
 	cmd.RegisterFlagCompletionFunc("consistency", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 		return []string{"l", "s"}, cobra.ShellCompDirectiveDefault
@@ -81,16 +83,17 @@ func NewGetCommand() *cobra.Command {
 // getCommandFunc executes the "get" command.
 func getCommandFunc(cmd *cobra.Command, args []string) {
 	key, opts := getGetOp(args)
+	cli := mustClientFromCmd(cmd)
 	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).Get(ctx, key, opts...)
+	resp, err := cli.Get(ctx, key, opts...)
 	cancel()
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
 
 	if getCountOnly {
-		if _, fields := display.(*fieldsPrinter); !fields {
-			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--count-only is only for `--write-out=fields`"))
+		if dp, simple := display.(*simplePrinter); simple {
+			dp.countOnly = true
 		}
 	}
 
@@ -101,9 +104,39 @@ func getCommandFunc(cmd *cobra.Command, args []string) {
 		}
 		dp.valueOnly = true
 	}
+
+	if getLeaseTTL {
+		dp, simple := (display).(*simplePrinter)
+		if !simple {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("with-lease-ttl is only for `--write-out=simple`"))
+		}
+		dp.leaseTTLs = getLeaseTTLs(cmd, cli, resp)
+	}
 	display.Get(*resp)
 }
 
+// getLeaseTTLs returns the remaining TTL, in seconds, of each lease attached
+// to the keys in resp.
+func getLeaseTTLs(cmd *cobra.Command, cli *clientv3.Client, resp *clientv3.GetResponse) map[int64]int64 {
+	ttls := make(map[int64]int64)
+	for _, kv := range resp.Kvs {
+		if kv.Lease == 0 {
+			continue
+		}
+		if _, ok := ttls[kv.Lease]; ok {
+			continue
+		}
+		ctx, cancel := commandCtx(cmd)
+		lresp, err := cli.TimeToLive(ctx, clientv3.LeaseID(kv.Lease))
+		cancel()
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+		ttls[kv.Lease] = lresp.TTL
+	}
+	return ttls
+}
+
 func getGetOp(args []string) (string, []clientv3.OpOption) {
 	if len(args) == 0 {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("get command needs one argument as key and an optional argument as range_end"))
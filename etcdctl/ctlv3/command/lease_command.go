@@ -15,8 +15,10 @@
 package command
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 
 	"github.com/spf13/cobra"
@@ -38,6 +40,7 @@ func NewLeaseCommand() *cobra.Command {
 	lc.AddCommand(NewLeaseRevokeCommand())
 	lc.AddCommand(NewLeaseTimeToLiveCommand())
 	lc.AddCommand(NewLeaseListCommand())
+	lc.AddCommand(NewLeaseStatusCommand())
 	lc.AddCommand(NewLeaseKeepAliveCommand())
 
 	return lc
@@ -153,6 +156,42 @@ func leaseListCommandFunc(cmd *cobra.Command, args []string) {
 	display.Leases(*resp)
 }
 
+var leaseStatusKeys bool
+
+// NewLeaseStatusCommand returns the cobra command for "lease status".
+func NewLeaseStatusCommand() *cobra.Command {
+	lc := &cobra.Command{
+		Use:   "status [options]",
+		Short: "List all active leases with their remaining TTL and number of attached keys",
+		Run:   leaseStatusCommandFunc,
+	}
+	lc.Flags().BoolVar(&leaseStatusKeys, "with-keys", false, "Print the keys attached to each lease")
+	return lc
+}
+
+// leaseStatusCommandFunc executes the "lease status" command.
+func leaseStatusCommandFunc(cmd *cobra.Command, args []string) {
+	cli := mustClientFromCmd(cmd)
+	resp, rerr := cli.Leases(context.TODO())
+	if rerr != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadConnection, rerr)
+	}
+	leases := make([]v3.LeaseTimeToLiveResponse, 0, len(resp.Leases))
+	for _, item := range resp.Leases {
+		ttl, err := cli.TimeToLive(context.TODO(), item.ID, v3.WithAttachedKeys())
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitBadConnection, err)
+		}
+		// skip leases expired or revoked since they were listed
+		if ttl.GrantedTTL == 0 && ttl.TTL == -1 {
+			continue
+		}
+		slices.SortFunc(ttl.Keys, bytes.Compare)
+		leases = append(leases, *ttl)
+	}
+	display.LeaseStatus(leases, leaseStatusKeys)
+}
+
 var leaseKeepAliveOnce bool
 
 // NewLeaseKeepAliveCommand returns the cobra command for "lease keep-alive".
@@ -15,13 +15,18 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 
+	"go.etcd.io/etcd/client/pkg/v3/logutil"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
@@ -30,8 +35,18 @@ var (
 	memberPeerURLs    string
 	isLearner         bool
 	memberConsistency string
+	memberAddWait     bool
+	memberWaitTimeout time.Duration
+
+	memberPromoteWaitReady   bool
+	memberPromoteMaxLag      uint64
+	memberPromoteWaitTimeout time.Duration
 )
 
+// memberWaitInterval is how often "member add --wait" polls the new member,
+// and "member promote --wait-ready" the learner.
+var memberWaitInterval = 500 * time.Millisecond
+
 // NewMemberCommand returns the cobra command for "member".
 func NewMemberCommand() *cobra.Command {
 	mc := &cobra.Command{
@@ -61,6 +76,8 @@ func NewMemberAddCommand() *cobra.Command {
 
 	cc.Flags().StringVar(&memberPeerURLs, "peer-urls", "", "comma separated peer URLs for the new member.")
 	cc.Flags().BoolVar(&isLearner, "learner", false, "indicates if the new member is raft learner")
+	cc.Flags().BoolVar(&memberAddWait, "wait", false, "wait until the new member is started and healthy")
+	cc.Flags().DurationVar(&memberWaitTimeout, "wait-timeout", time.Minute, "timeout for --wait")
 
 	return cc
 }
@@ -98,6 +115,9 @@ func NewMemberListCommand() *cobra.Command {
 		Short: "Lists all members in the cluster",
 		Long: `When --write-out is set to simple, this command prints out comma-separated member lists for each endpoint.
 The items in the lists are ID, Status, Name, Peer Addrs, Client Addrs, Is Learner.
+
+The json, table and fields formats also report whether each member is the leader and its raft term,
+as given by the status of the member. They are left out for the members whose status cannot be fetched.
 `,
 
 		Run: memberListCommandFunc,
@@ -119,6 +139,10 @@ func NewMemberPromoteCommand() *cobra.Command {
 		Run: memberPromoteCommandFunc,
 	}
 
+	cc.Flags().BoolVar(&memberPromoteWaitReady, "wait-ready", false, "refuse to promote the learner until it has caught up with the leader")
+	cc.Flags().Uint64Var(&memberPromoteMaxLag, "max-lag", 1000, "the maximum number of raft entries the learner may lag behind the leader to be ready, used by --wait-ready")
+	cc.Flags().DurationVar(&memberPromoteWaitTimeout, "wait-timeout", 0, "how long --wait-ready waits for the learner to catch up; 0 refuses right away if it is not ready")
+
 	return cc
 }
 
@@ -180,6 +204,81 @@ func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 		fmt.Printf("ETCD_INITIAL_ADVERTISE_PEER_URLS=%q\n", memberPeerURLs)
 		fmt.Print("ETCD_INITIAL_CLUSTER_STATE=\"existing\"\n")
 	}
+
+	if memberAddWait {
+		ctx, cancel = context.WithTimeout(context.Background(), memberWaitTimeout)
+		err = waitMemberHealthy(ctx, cmd, cli, newID)
+		cancel()
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+		if _, ok := (display).(*simplePrinter); ok {
+			fmt.Printf("Member %16x is started and healthy\n", newID)
+		}
+	}
+}
+
+// waitMemberHealthy polls until the member with the given ID has published its
+// client URLs and reports a leader, or the context is done.
+func waitMemberHealthy(ctx context.Context, cmd *cobra.Command, cli *clientv3.Client, id uint64) error {
+	ticker := time.NewTicker(memberWaitInterval)
+	defer ticker.Stop()
+	for {
+		if memberHealthy(ctx, cmd, cli, id) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("member %x is not healthy: %w", id, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// memberHealthy returns true if the member with the given ID is started and
+// any of its client URLs reports a leader.
+func memberHealthy(ctx context.Context, cmd *cobra.Command, cli *clientv3.Client, id uint64) bool {
+	st := memberStatus(ctx, cmd, cli, id)
+	return st != nil && st.Leader != 0
+}
+
+// memberStatus returns the status reported by the first reachable client URL
+// of the member with the given ID, or nil if none of them responds.
+func memberStatus(ctx context.Context, cmd *cobra.Command, cli *clientv3.Client, id uint64) *clientv3.StatusResponse {
+	resp, err := cli.MemberList(ctx)
+	if err != nil {
+		return nil
+	}
+	var clientURLs []string
+	for _, m := range resp.Members {
+		if m.ID == id {
+			clientURLs = m.ClientURLs
+		}
+	}
+	// client URLs are only published once the member is started
+	if len(clientURLs) == 0 {
+		return nil
+	}
+
+	cfgSpec := clientConfigFromCmd(cmd)
+	cfgSpec.Endpoints = clientURLs
+	lg, _ := logutil.CreateDefaultZapLogger(zap.InfoLevel)
+	cfg, err := clientv3.NewClientConfig(cfgSpec, lg)
+	if err != nil {
+		return nil
+	}
+	mcli, err := clientv3.New(*cfg)
+	if err != nil {
+		return nil
+	}
+	defer mcli.Close()
+	for _, ep := range clientURLs {
+		st, err := mcli.Status(ctx, ep)
+		if err == nil {
+			return st
+		}
+	}
+	return nil
 }
 
 // memberRemoveCommandFunc executes the "member remove" command.
@@ -242,7 +341,46 @@ func memberListCommandFunc(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
 
-	display.MemberList(*resp)
+	display.MemberList(*resp, memberStatuses(cmd, resp))
+}
+
+// memberStatuses fetches the statuses of the started members in resp, by
+// member ID. The members that cannot be reached are left out.
+func memberStatuses(cmd *cobra.Command, resp *clientv3.MemberListResponse) map[uint64]*clientv3.StatusResponse {
+	cfg := mustClientCfgFromCmd(cmd)
+	// do not wait for the members which are down
+	cfg.FailFastWhenNoConnection = true
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	statuses := make(map[uint64]*clientv3.StatusResponse)
+	for _, m := range resp.Members {
+		if len(m.ClientURLs) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mcfg := *cfg
+			mcfg.Endpoints = m.ClientURLs
+			c, err := clientv3.New(mcfg)
+			if err != nil {
+				return
+			}
+			defer c.Close()
+			ctx, cancel := commandCtx(cmd)
+			defer cancel()
+			status, err := c.Status(ctx, m.ClientURLs[0])
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			statuses[m.ID] = status
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return statuses
 }
 
 // memberPromoteCommandFunc executes the "member promote" command.
@@ -256,11 +394,58 @@ func memberPromoteCommandFunc(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad member ID arg (%w), expecting ID in Hex", err))
 	}
 
+	cli := mustClientFromCmd(cmd)
+	if memberPromoteWaitReady {
+		if err = waitLearnerReady(cmd, cli, id, memberPromoteWaitTimeout); err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+	}
+
 	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).MemberPromote(ctx, id)
+	resp, err := cli.MemberPromote(ctx, id)
 	cancel()
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
 	display.MemberPromote(id, *resp)
 }
+
+// waitLearnerReady polls until the learner with the given ID is ready to be
+// promoted. It checks at least once, and gives up after the timeout.
+func waitLearnerReady(cmd *cobra.Command, cli *clientv3.Client, id uint64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(memberWaitInterval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := commandCtx(cmd)
+		err := learnerReady(ctx, cmd, cli, id)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("member %x is not ready to be promoted: %w", id, err)
+		}
+		<-ticker.C
+	}
+}
+
+// learnerReady returns nil if the learner with the given ID has applied the
+// raft log up to at most memberPromoteMaxLag entries behind the leader.
+func learnerReady(ctx context.Context, cmd *cobra.Command, cli *clientv3.Client, id uint64) error {
+	st := memberStatus(ctx, cmd, cli, id)
+	if st == nil {
+		return errors.New("member is not started")
+	}
+	if st.Leader == 0 {
+		return errors.New("member has no leader")
+	}
+	lst := memberStatus(ctx, cmd, cli, st.Leader)
+	if lst == nil {
+		return fmt.Errorf("leader %x is unreachable", st.Leader)
+	}
+	if lst.RaftIndex > st.RaftAppliedIndex && lst.RaftIndex-st.RaftAppliedIndex > memberPromoteMaxLag {
+		return fmt.Errorf("member has applied raft index %d, leader is at %d", st.RaftAppliedIndex, lst.RaftIndex)
+	}
+	return nil
+}
@@ -15,15 +15,26 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"go.etcd.io/etcd/client/pkg/v3/types"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
 
+var (
+	moveLeaderWait        bool
+	moveLeaderWaitTimeout time.Duration
+)
+
+// moveLeaderWaitInterval is how often "move-leader --wait" polls the transferee.
+var moveLeaderWaitInterval = 100 * time.Millisecond
+
 // NewMoveLeaderCommand returns the cobra command for "move-leader".
 func NewMoveLeaderCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -32,6 +43,8 @@ func NewMoveLeaderCommand() *cobra.Command {
 		Run:     transferLeadershipCommandFunc,
 		GroupID: groupClusterMaintenanceID,
 	}
+	cmd.Flags().BoolVar(&moveLeaderWait, "wait", false, "wait until the transferee reports itself as leader")
+	cmd.Flags().DurationVar(&moveLeaderWaitTimeout, "wait-timeout", time.Minute, "timeout for --wait")
 	return cmd
 }
 
@@ -81,5 +94,31 @@ func transferLeadershipCommandFunc(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
 
+	if moveLeaderWait {
+		ctx, cancel = context.WithTimeout(context.Background(), moveLeaderWaitTimeout)
+		err = waitMemberLeader(ctx, cmd, leaderCli, target)
+		cancel()
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+	}
+
 	display.MoveLeader(leaderID, target, *resp)
 }
+
+// waitMemberLeader polls until the member with the given ID reports itself
+// as leader, or the context is done.
+func waitMemberLeader(ctx context.Context, cmd *cobra.Command, cli *clientv3.Client, id uint64) error {
+	ticker := time.NewTicker(moveLeaderWaitInterval)
+	defer ticker.Stop()
+	for {
+		if st := memberStatus(ctx, cmd, cli, id); st != nil && st.Leader == id {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for member %s to become leader: %w", types.ID(id), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
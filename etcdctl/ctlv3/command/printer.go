@@ -39,16 +39,18 @@ type printer interface {
 	KeepAlive(r v3.LeaseKeepAliveResponse)
 	TimeToLive(r v3.LeaseTimeToLiveResponse, keys bool)
 	Leases(r v3.LeaseLeasesResponse)
+	LeaseStatus(leases []v3.LeaseTimeToLiveResponse, keys bool)
 
 	MemberAdd(v3.MemberAddResponse)
 	MemberRemove(id uint64, r v3.MemberRemoveResponse)
 	MemberUpdate(id uint64, r v3.MemberUpdateResponse)
 	MemberPromote(id uint64, r v3.MemberPromoteResponse)
-	MemberList(v3.MemberListResponse)
+	MemberList(r v3.MemberListResponse, statuses map[uint64]*v3.StatusResponse)
 
 	EndpointHealth([]epHealth)
 	EndpointStatus([]epStatus)
 	EndpointHashKV([]epHashKV)
+	EndpointBucketStats([]epBucketStats)
 	MoveLeader(leader, target uint64, r v3.MoveLeaderResponse)
 
 	DowngradeValidate(r v3.DowngradeResponse)
@@ -120,8 +122,10 @@ func (p *printerRPC) MemberUpdate(id uint64, r v3.MemberUpdateResponse) {
 func (p *printerRPC) MemberPromote(id uint64, r v3.MemberPromoteResponse) {
 	p.p((*pb.MemberPromoteResponse)(&r))
 }
-func (p *printerRPC) MemberList(r v3.MemberListResponse) { p.p((*pb.MemberListResponse)(&r)) }
-func (p *printerRPC) Alarm(r v3.AlarmResponse)           { p.p((*pb.AlarmResponse)(&r)) }
+func (p *printerRPC) MemberList(r v3.MemberListResponse, _ map[uint64]*v3.StatusResponse) {
+	p.p((*pb.MemberListResponse)(&r))
+}
+func (p *printerRPC) Alarm(r v3.AlarmResponse) { p.p((*pb.AlarmResponse)(&r)) }
 func (p *printerRPC) MoveLeader(leader, target uint64, r v3.MoveLeaderResponse) {
 	p.p((*pb.MoveLeaderResponse)(&r))
 }
@@ -173,15 +177,52 @@ func newPrinterUnsupported(n string) printer {
 	return &printerUnsupported{printerRPC{nil, f}}
 }
 
-func (p *printerUnsupported) EndpointHealth([]epHealth) { p.p(nil) }
-func (p *printerUnsupported) EndpointStatus([]epStatus) { p.p(nil) }
-func (p *printerUnsupported) EndpointHashKV([]epHashKV) { p.p(nil) }
+func (p *printerUnsupported) EndpointHealth([]epHealth)           { p.p(nil) }
+func (p *printerUnsupported) EndpointStatus([]epStatus)           { p.p(nil) }
+func (p *printerUnsupported) EndpointHashKV([]epHashKV)           { p.p(nil) }
+func (p *printerUnsupported) EndpointBucketStats([]epBucketStats) { p.p(nil) }
+
+func (p *printerUnsupported) LeaseStatus([]v3.LeaseTimeToLiveResponse, bool) { p.p(nil) }
 
 func (p *printerUnsupported) MoveLeader(leader, target uint64, r v3.MoveLeaderResponse) { p.p(nil) }
 func (p *printerUnsupported) DowngradeValidate(r v3.DowngradeResponse)                  { p.p(nil) }
 func (p *printerUnsupported) DowngradeEnable(r v3.DowngradeResponse)                    { p.p(nil) }
 func (p *printerUnsupported) DowngradeCancel(r v3.DowngradeResponse)                    { p.p(nil) }
 
+func makePutTable(r v3.PutResponse) (hdr []string, rows [][]string) {
+	hdr = []string{"revision", "prev key", "prev value", "prev mod revision", "prev version"}
+	row := []string{fmt.Sprint(r.Header.Revision), "", "", "", ""}
+	if kv := r.PrevKv; kv != nil {
+		row[1], row[2] = string(kv.Key), string(kv.Value)
+		row[3], row[4] = fmt.Sprint(kv.ModRevision), fmt.Sprint(kv.Version)
+	}
+	return hdr, append(rows, row)
+}
+
+func makeLeaseStatusTable(leases []v3.LeaseTimeToLiveResponse, keys bool) (hdr []string, rows [][]string) {
+	hdr = []string{"ID", "TTL", "granted TTL", "attached keys"}
+	if keys {
+		hdr = append(hdr, "keys")
+	}
+	for _, l := range leases {
+		row := []string{
+			fmt.Sprintf("%016x", l.ID),
+			fmt.Sprint(l.TTL),
+			fmt.Sprint(l.GrantedTTL),
+			fmt.Sprint(len(l.Keys)),
+		}
+		if keys {
+			ks := make([]string, len(l.Keys))
+			for i := range l.Keys {
+				ks[i] = string(l.Keys[i])
+			}
+			row = append(row, strings.Join(ks, ","))
+		}
+		rows = append(rows, row)
+	}
+	return hdr, rows
+}
+
 func makeMemberListTable(r v3.MemberListResponse) (hdr []string, rows [][]string) {
 	hdr = []string{"ID", "Status", "Name", "Peer Addrs", "Client Addrs", "Is Learner"}
 	for _, m := range r.Members {
@@ -205,6 +246,16 @@ func makeMemberListTable(r v3.MemberListResponse) (hdr []string, rows [][]string
 	return hdr, rows
 }
 
+// makeMemberStatusColumns returns whether m is the leader and its raft term,
+// as given by its status, or empty strings if its status is unknown.
+func makeMemberStatusColumns(m *pb.Member, statuses map[uint64]*v3.StatusResponse) (isLeader, raftTerm string) {
+	status, ok := statuses[m.ID]
+	if !ok {
+		return "", ""
+	}
+	return fmt.Sprint(status.Leader == m.ID), fmt.Sprint(status.RaftTerm)
+}
+
 func makeEndpointHealthTable(healthList []epHealth) (hdr []string, rows [][]string) {
 	hdr = []string{"endpoint", "health", "took", "error"}
 	for _, h := range healthList {
@@ -257,3 +308,18 @@ func makeEndpointHashKVTable(hashList []epHashKV) (hdr []string, rows [][]string
 	}
 	return hdr, rows
 }
+
+func makeEndpointBucketStatsTable(statsList []epBucketStats) (hdr []string, rows [][]string) {
+	hdr = []string{"endpoint", "bucket", "keys", "size"}
+	for _, s := range statsList {
+		for _, b := range s.Resp.Buckets {
+			rows = append(rows, []string{
+				s.Ep,
+				b.Name,
+				fmt.Sprint(b.Keys),
+				humanize.Bytes(uint64(b.Bytes)),
+			})
+		}
+	}
+	return hdr, rows
+}
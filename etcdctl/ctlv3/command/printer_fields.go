@@ -15,6 +15,7 @@
 package command
 
 import (
+	"encoding/hex"
 	"fmt"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
@@ -159,7 +160,26 @@ func (p *fieldsPrinter) Leases(r v3.LeaseLeasesResponse) {
 	}
 }
 
-func (p *fieldsPrinter) MemberList(r v3.MemberListResponse) {
+func (p *fieldsPrinter) LeaseStatus(leases []v3.LeaseTimeToLiveResponse, keys bool) {
+	for _, l := range leases {
+		if p.isHex {
+			fmt.Printf("\"ID\" : %016x\n", l.ID)
+		} else {
+			fmt.Println(`"ID" :`, l.ID)
+		}
+		fmt.Println(`"TTL" :`, l.TTL)
+		fmt.Println(`"GrantedTTL" :`, l.GrantedTTL)
+		fmt.Println(`"AttachedKeys" :`, len(l.Keys))
+		if keys {
+			for _, k := range l.Keys {
+				fmt.Printf("\"Key\" : %q\n", string(k))
+			}
+		}
+		fmt.Println()
+	}
+}
+
+func (p *fieldsPrinter) MemberList(r v3.MemberListResponse, statuses map[uint64]*v3.StatusResponse) {
 	p.hdr(r.Header)
 	for _, m := range r.Members {
 		if p.isHex {
@@ -175,6 +195,10 @@ func (p *fieldsPrinter) MemberList(r v3.MemberListResponse) {
 			fmt.Printf("\"ClientURL\" : %q\n", u)
 		}
 		fmt.Println(`"IsLearner" :`, m.IsLearner)
+		if status, ok := statuses[m.ID]; ok {
+			fmt.Println(`"IsLeader" :`, status.Leader == m.ID)
+			fmt.Println(`"RaftTerm" :`, status.RaftTerm)
+		}
 		fmt.Println()
 	}
 }
@@ -216,6 +240,21 @@ func (p *fieldsPrinter) EndpointHashKV(hs []epHashKV) {
 		fmt.Printf("\"Endpoint\" : %q\n", h.Ep)
 		fmt.Println(`"Hash" :`, h.Resp.Hash)
 		fmt.Println(`"HashRevision" :`, h.Resp.HashRevision)
+		fmt.Printf("\"HashAlgorithm\" : %q\n", h.Resp.HashAlgorithm)
+		fmt.Printf("\"HashDigest\" : %q\n", hex.EncodeToString(h.Resp.HashDigest))
+		fmt.Println()
+	}
+}
+
+func (p *fieldsPrinter) EndpointBucketStats(ss []epBucketStats) {
+	for _, s := range ss {
+		p.hdr(s.Resp.Header)
+		fmt.Printf("\"Endpoint\" : %q\n", s.Ep)
+		for _, b := range s.Resp.Buckets {
+			fmt.Printf("\"Bucket\" : %q\n", b.Name)
+			fmt.Println(`"Keys" :`, b.Keys)
+			fmt.Println(`"Bytes" :`, b.Bytes)
+		}
 		fmt.Println()
 	}
 }
@@ -21,6 +21,7 @@ import (
 	"os"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
@@ -61,6 +62,14 @@ func (m *HexMember) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// watchEventJSON is a watch event printed as a single line of JSON.
+type watchEventJSON struct {
+	Header *pb.ResponseHeader `json:"header"`
+	Type   string             `json:"type"`
+	Kv     *mvccpb.KeyValue   `json:"kv,omitempty"`
+	PrevKv *mvccpb.KeyValue   `json:"prev_kv,omitempty"`
+}
+
 func newJSONPrinter(isHex bool) printer {
 	return &jsonPrinter{
 		writer:  os.Stdout,
@@ -69,15 +78,99 @@ func newJSONPrinter(isHex bool) printer {
 	}
 }
 
-func (p *jsonPrinter) EndpointHealth(r []epHealth) { printJSON(r) }
-func (p *jsonPrinter) EndpointStatus(r []epStatus) { printJSON(r) }
-func (p *jsonPrinter) EndpointHashKV(r []epHashKV) { printJSON(r) }
+func (p *jsonPrinter) EndpointHealth(r []epHealth)           { printJSON(r) }
+func (p *jsonPrinter) EndpointStatus(r []epStatus)           { printJSON(r) }
+func (p *jsonPrinter) EndpointHashKV(r []epHashKV)           { printJSON(r) }
+func (p *jsonPrinter) EndpointBucketStats(r []epBucketStats) { printJSON(r) }
+
+// Watch prints each event of the response as its own line of JSON, so the
+// output can be consumed as a stream. Responses without events, such as
+// progress notifications or cancellations, are printed as a whole.
+func (p *jsonPrinter) Watch(r clientv3.WatchResponse) {
+	if len(r.Events) == 0 {
+		printJSONTo(p.writer, &r)
+		return
+	}
+	for _, ev := range r.Events {
+		printJSONTo(p.writer, &watchEventJSON{
+			Header: &r.Header,
+			Type:   ev.Type.String(),
+			Kv:     ev.Kv,
+			PrevKv: ev.PrevKv,
+		})
+	}
+}
 
 func (p *jsonPrinter) MemberAdd(r clientv3.MemberAddResponse)                   { p.printJSON(r) }
 func (p *jsonPrinter) MemberRemove(_ uint64, r clientv3.MemberRemoveResponse)   { p.printJSON(r) }
 func (p *jsonPrinter) MemberUpdate(_ uint64, r clientv3.MemberUpdateResponse)   { p.printJSON(r) }
 func (p *jsonPrinter) MemberPromote(_ uint64, r clientv3.MemberPromoteResponse) { p.printJSON(r) }
-func (p *jsonPrinter) MemberList(r clientv3.MemberListResponse)                 { p.printJSON(r) }
+
+// leaseStatusJSON is a lease printed with the number of its attached keys.
+type leaseStatusJSON struct {
+	ID           clientv3.LeaseID `json:"id"`
+	TTL          int64            `json:"ttl"`
+	GrantedTTL   int64            `json:"granted-ttl"`
+	AttachedKeys int              `json:"attached-keys"`
+	Keys         [][]byte         `json:"keys,omitempty"`
+}
+
+// hexLeaseStatusJSON is a leaseStatusJSON printed with a hexadecimal ID.
+type hexLeaseStatusJSON struct {
+	ID string `json:"id"`
+	leaseStatusJSON
+}
+
+func (p *jsonPrinter) LeaseStatus(leases []clientv3.LeaseTimeToLiveResponse, keys bool) {
+	out := make([]any, len(leases))
+	for i, l := range leases {
+		lj := leaseStatusJSON{ID: l.ID, TTL: l.TTL, GrantedTTL: l.GrantedTTL, AttachedKeys: len(l.Keys)}
+		if keys {
+			lj.Keys = l.Keys
+		}
+		out[i] = lj
+		if p.isHex {
+			out[i] = hexLeaseStatusJSON{ID: fmt.Sprintf("%016x", l.ID), leaseStatusJSON: lj}
+		}
+	}
+	printJSONTo(p.writer, out)
+}
+
+// memberJSON is a member printed with its status, if known.
+type memberJSON struct {
+	*pb.Member
+	IsLeader *bool  `json:"isLeader,omitempty"`
+	RaftTerm uint64 `json:"raftTerm,omitempty"`
+}
+
+// hexMemberJSON is a memberJSON printed with a hexadecimal ID.
+type hexMemberJSON struct {
+	ID string `json:"ID"`
+	memberJSON
+}
+
+func (p *jsonPrinter) MemberList(r clientv3.MemberListResponse, statuses map[uint64]*clientv3.StatusResponse) {
+	members := make([]any, len(r.Members))
+	for i, m := range r.Members {
+		mj := memberJSON{Member: m}
+		if status, ok := statuses[m.ID]; ok {
+			isLeader := status.Leader == m.ID
+			mj.IsLeader, mj.RaftTerm = &isLeader, status.RaftTerm
+		}
+		members[i] = mj
+		if p.isHex {
+			members[i] = hexMemberJSON{ID: fmt.Sprintf("%x", m.ID), memberJSON: mj}
+		}
+	}
+	var header any = r.Header
+	if p.isHex {
+		header = (*HexResponseHeader)(r.Header)
+	}
+	printJSONTo(p.writer, &struct {
+		Header  any   `json:"header"`
+		Members []any `json:"members"`
+	}{Header: header, Members: members})
+}
 
 func printJSONTo(w io.Writer, v any) {
 	b, err := json.Marshal(v)
@@ -141,18 +234,6 @@ func (p *jsonPrinter) printJSON(v any) {
 	case clientv3.MemberPromoteResponse:
 		type Alias clientv3.MemberPromoteResponse
 
-		data = &struct {
-			Header  *HexResponseHeader `json:"header"`
-			Members []*HexMember       `json:"members"`
-			*Alias
-		}{
-			Header:  (*HexResponseHeader)(r.Header),
-			Members: toHexMembers(r.Members),
-			Alias:   (*Alias)(&r),
-		}
-	case clientv3.MemberListResponse:
-		type Alias clientv3.MemberListResponse
-
 		data = &struct {
 			Header  *HexResponseHeader `json:"header"`
 			Members []*HexMember       `json:"members"`
@@ -19,12 +19,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
@@ -313,7 +315,8 @@ func TestMemberList(t *testing.T) {
 						},
 						Members: []*pb.Member{{ID: tt.number}},
 					}
-					p.MemberList(response)
+					statuses := map[uint64]*clientv3.StatusResponse{tt.number: {Leader: tt.number, RaftTerm: 7}}
+					p.MemberList(response, statuses)
 
 					var got map[string]any
 					err := decoder.Decode(&got)
@@ -321,8 +324,72 @@ func TestMemberList(t *testing.T) {
 
 					assertHeader(t, &testGroup, &tt, got)
 					assertMembers(t, &testGroup, &tt, got)
+					member := got[keyMembers].([]any)[0].(map[string]any)
+					assert.Equal(t, true, member["isLeader"])
+					assert.Equal(t, json.Number("7"), member["raftTerm"])
+
+					// the status of unreachable members is left out
+					p.MemberList(response, nil)
+					got = nil
+					require.NoError(t, decoder.Decode(&got))
+					member = got[keyMembers].([]any)[0].(map[string]any)
+					assert.NotContains(t, member, "isLeader")
+					assert.NotContains(t, member, "raftTerm")
 				})
 			}
 		})
 	}
 }
+
+func TestLeaseStatus(t *testing.T) {
+	var buffer bytes.Buffer
+	leases := []clientv3.LeaseTimeToLiveResponse{
+		{ID: 0x1f, TTL: 50, GrantedTTL: 60, Keys: [][]byte{[]byte("a"), []byte("b")}},
+		{ID: 0x20, TTL: 10, GrantedTTL: 20},
+	}
+
+	p := &jsonPrinter{writer: &buffer}
+	p.LeaseStatus(leases, false)
+	assert.JSONEq(t, `[{"id":31,"ttl":50,"granted-ttl":60,"attached-keys":2},{"id":32,"ttl":10,"granted-ttl":20,"attached-keys":0}]`, buffer.String())
+
+	buffer.Reset()
+	p = &jsonPrinter{writer: &buffer, isHex: true}
+	p.LeaseStatus(leases, true)
+	assert.JSONEq(t, `[{"id":"000000000000001f","ttl":50,"granted-ttl":60,"attached-keys":2,"keys":["YQ==","Yg=="]},{"id":"0000000000000020","ttl":10,"granted-ttl":20,"attached-keys":0}]`, buffer.String())
+}
+
+func TestWatch(t *testing.T) {
+	var buffer bytes.Buffer
+	p := &jsonPrinter{writer: &buffer}
+
+	header := pb.ResponseHeader{ClusterId: 1, MemberId: 2, Revision: 5, RaftTerm: 3}
+	p.Watch(clientv3.WatchResponse{
+		Header: header,
+		Events: []*clientv3.Event{
+			{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), ModRevision: 5}},
+			{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("baz"), ModRevision: 5}, PrevKv: &mvccpb.KeyValue{Key: []byte("baz"), Value: []byte("qux")}},
+		},
+	})
+	p.Watch(clientv3.WatchResponse{Header: header})
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+
+	wantTypes := []string{"PUT", "DELETE"}
+	wantKeys := []string{"foo", "baz"}
+	for i, line := range lines[:2] {
+		var got watchEventJSON
+		require.NoErrorf(t, json.Unmarshal([]byte(line), &got), "line %d is not valid JSON: %s", i, line)
+		assert.Equal(t, header.Revision, got.Header.Revision)
+		assert.Equal(t, wantTypes[i], got.Type)
+		assert.Equal(t, wantKeys[i], string(got.Kv.Key))
+	}
+	var ev watchEventJSON
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &ev))
+	assert.Equal(t, "qux", string(ev.PrevKv.Value))
+
+	var resp clientv3.WatchResponse
+	require.NoErrorf(t, json.Unmarshal([]byte(lines[2]), &resp), "line 2 is not valid JSON: %s", lines[2])
+	assert.Equal(t, header.Revision, resp.Header.Revision)
+	assert.Empty(t, resp.Events)
+}
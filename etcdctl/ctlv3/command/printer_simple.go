@@ -29,6 +29,10 @@ const rootRole = "root"
 type simplePrinter struct {
 	isHex     bool
 	valueOnly bool
+	countOnly bool
+	// leaseTTLs, if set, holds the remaining TTL of the leases attached to
+	// the keys printed by Get.
+	leaseTTLs map[int64]int64
 }
 
 func (s *simplePrinter) Del(resp v3.DeleteResponse) {
@@ -39,8 +43,15 @@ func (s *simplePrinter) Del(resp v3.DeleteResponse) {
 }
 
 func (s *simplePrinter) Get(resp v3.GetResponse) {
+	if s.countOnly {
+		fmt.Println(resp.Count)
+		return
+	}
 	for _, kv := range resp.Kvs {
 		printKV(s.isHex, s.valueOnly, kv)
+		if ttl, ok := s.leaseTTLs[kv.Lease]; ok {
+			fmt.Printf("ttl: %d\n", ttl)
+		}
 	}
 }
 
@@ -119,6 +130,13 @@ func (s *simplePrinter) Leases(resp v3.LeaseLeasesResponse) {
 	}
 }
 
+func (s *simplePrinter) LeaseStatus(leases []v3.LeaseTimeToLiveResponse, keys bool) {
+	_, rows := makeLeaseStatusTable(leases, keys)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
 func (s *simplePrinter) Alarm(resp v3.AlarmResponse) {
 	for _, e := range resp.Alarms {
 		fmt.Printf("%+v\n", e)
@@ -145,7 +163,7 @@ func (s *simplePrinter) MemberPromote(id uint64, r v3.MemberPromoteResponse) {
 	fmt.Printf("Member %16x promoted in cluster %16x\n", id, r.Header.ClusterId)
 }
 
-func (s *simplePrinter) MemberList(resp v3.MemberListResponse) {
+func (s *simplePrinter) MemberList(resp v3.MemberListResponse, _ map[uint64]*v3.StatusResponse) {
 	_, rows := makeMemberListTable(resp)
 	for _, row := range rows {
 		fmt.Println(strings.Join(row, ", "))
@@ -176,6 +194,13 @@ func (s *simplePrinter) EndpointHashKV(hashList []epHashKV) {
 	}
 }
 
+func (s *simplePrinter) EndpointBucketStats(statsList []epBucketStats) {
+	_, rows := makeEndpointBucketStatsTable(statsList)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
 func (s *simplePrinter) MoveLeader(leader, target uint64, r v3.MoveLeaderResponse) {
 	fmt.Printf("Leadership transferred from %s to %s\n", types.ID(leader), types.ID(target))
 }
@@ -25,8 +25,24 @@ import (
 
 type tablePrinter struct{ printer }
 
-func (tp *tablePrinter) MemberList(r v3.MemberListResponse) {
+func (tp *tablePrinter) Put(r v3.PutResponse) {
+	hdr, rows := makePutTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func (tp *tablePrinter) MemberList(r v3.MemberListResponse, statuses map[uint64]*v3.StatusResponse) {
 	hdr, rows := makeMemberListTable(r)
+	hdr = append(hdr, "Is Leader", "Raft Term")
+	for i, m := range r.Members {
+		isLeader, raftTerm := makeMemberStatusColumns(m, statuses)
+		rows[i] = append(rows[i], isLeader, raftTerm)
+	}
 	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
 	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
 	table.Header(hdr)
@@ -68,3 +84,25 @@ func (tp *tablePrinter) EndpointHashKV(r []epHashKV) {
 	}
 	table.Render()
 }
+
+func (tp *tablePrinter) EndpointBucketStats(r []epBucketStats) {
+	hdr, rows := makeEndpointBucketStatsTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func (tp *tablePrinter) LeaseStatus(leases []v3.LeaseTimeToLiveResponse, keys bool) {
+	hdr, rows := makeLeaseStatusTable(leases, keys)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
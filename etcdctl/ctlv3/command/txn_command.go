@@ -23,13 +23,25 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
 
-var txnInteractive bool
+var (
+	txnInteractive bool
+	txnFile        string
+)
+
+// txnSpec is a transaction read with "--file". Compares and requests use
+// the same syntax as the standard input.
+type txnSpec struct {
+	Compares []string `json:"compares"`
+	Success  []string `json:"success"`
+	Failure  []string `json:"failure"`
+}
 
 // NewTxnCommand returns the cobra command for "txn".
 func NewTxnCommand() *cobra.Command {
@@ -58,11 +70,26 @@ put key1 "created-key1"
 put key2 "some extra key"
 ---
 
+Example usage with a YAML or JSON file:
+
+---
+etcdctl txn --file txn.yaml
+# txn.yaml:
+compares:
+- mod("key1") > "0"
+success:
+- put key1 "overwrote-key1"
+failure:
+- put key1 "created-key1"
+- put key2 "some extra key"
+---
+
 Refer to https://github.com/etcd-io/etcd/blob/main/etcdctl/README.md#txn-options.`,
 		Run:     txnCommandFunc,
 		GroupID: groupKVID,
 	}
 	cmd.Flags().BoolVarP(&txnInteractive, "interactive", "i", false, "Input transaction in interactive mode")
+	cmd.Flags().StringVar(&txnFile, "file", "", "Read the transaction from a YAML or JSON file instead of standard input")
 	return cmd
 }
 
@@ -72,6 +99,14 @@ func txnCommandFunc(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("txn command does not accept argument"))
 	}
 
+	if txnFile != "" {
+		if txnInteractive {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--file and --interactive cannot be set at the same time"))
+		}
+		txnFromFile(cmd)
+		return
+	}
+
 	reader := bufio.NewReader(os.Stdin)
 
 	txn := mustClientFromCmd(cmd).Txn(context.Background())
@@ -90,6 +125,48 @@ func txnCommandFunc(cmd *cobra.Command, args []string) {
 	display.Txn(*resp)
 }
 
+// txnFromFile executes the transaction read from txnFile.
+func txnFromFile(cmd *cobra.Command) {
+	b, err := os.ReadFile(txnFile)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	var spec txnSpec
+	if err = yaml.UnmarshalStrict(b, &spec); err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitInvalidInput, fmt.Errorf("invalid txn file %s: %w", txnFile, err))
+	}
+
+	cmps := make([]clientv3.Cmp, 0, len(spec.Compares))
+	for _, line := range spec.Compares {
+		cmp, err := ParseCompare(strings.TrimSpace(line))
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitInvalidInput, err)
+		}
+		cmps = append(cmps, *cmp)
+	}
+	thenOps := parseFileOps(spec.Success)
+	elseOps := parseFileOps(spec.Failure)
+
+	resp, err := mustClientFromCmd(cmd).Txn(context.Background()).If(cmps...).Then(thenOps...).Else(elseOps...).Commit()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+
+	display.Txn(*resp)
+}
+
+func parseFileOps(lines []string) []clientv3.Op {
+	ops := make([]clientv3.Op, 0, len(lines))
+	for _, line := range lines {
+		op, err := parseRequestUnion(strings.TrimSpace(line))
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitInvalidInput, err)
+		}
+		ops = append(ops, *op)
+	}
+	return ops
+}
+
 func promptInteractive(s string) {
 	if txnInteractive {
 		fmt.Println(s)
@@ -168,7 +168,8 @@ func printWatchCh(c *clientv3.Client, ch clientv3.WatchChan, execArgs []string)
 		if resp.Canceled {
 			fmt.Fprintf(os.Stderr, "watch was canceled (%v)\n", resp.Err())
 		}
-		if resp.IsProgressNotify() {
+		if _, ok := display.(*jsonPrinter); !ok && resp.IsProgressNotify() {
+			// keep json output parsable line by line
 			fmt.Fprintf(os.Stdout, "progress notify: %d\n", resp.Header.Revision)
 		}
 		display.Watch(resp)
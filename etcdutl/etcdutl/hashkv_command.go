@@ -15,6 +15,8 @@
 package etcdutl
 
 import (
+	"encoding/hex"
+
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
@@ -23,7 +25,10 @@ import (
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
-var hashKVRevision int64
+var (
+	hashKVRevision      int64
+	hashKVHashAlgorithm string
+)
 
 // NewHashKVCommand returns the cobra command for "hashkv".
 func NewHashKVCommand() *cobra.Command {
@@ -34,13 +39,14 @@ func NewHashKVCommand() *cobra.Command {
 		Run:   hashKVCommandFunc,
 	}
 	cmd.Flags().Int64Var(&hashKVRevision, "rev", 0, "maximum revision to hash (default: latest revision)")
+	cmd.Flags().StringVar(&hashKVHashAlgorithm, "hash-algorithm", mvcc.HashAlgorithmCRC32, "algorithm computing the hash ('crc32' or 'sha256')")
 	return cmd
 }
 
 func hashKVCommandFunc(cmd *cobra.Command, args []string) {
 	printer := initPrinterFromCmd(cmd)
 
-	ds, err := calculateHashKV(args[0], hashKVRevision)
+	ds, err := calculateHashKV(args[0], hashKVRevision, hashKVHashAlgorithm)
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
@@ -51,11 +57,16 @@ type HashKV struct {
 	Hash            uint32 `json:"hash"`
 	HashRevision    int64  `json:"hashRevision"`
 	CompactRevision int64  `json:"compactRevision"`
+	HashAlgorithm   string `json:"hashAlgorithm"`
+	HashDigest      string `json:"hashDigest"`
 }
 
-func calculateHashKV(dbPath string, rev int64) (HashKV, error) {
+func calculateHashKV(dbPath string, rev int64, algorithm string) (HashKV, error) {
+	if _, err := mvcc.NewHash(algorithm); err != nil {
+		return HashKV{}, err
+	}
 	b := backend.NewDefaultBackend(zap.NewNop(), dbPath, backend.WithTimeout(FlockTimeout))
-	st := mvcc.NewStore(zap.NewNop(), b, nil, mvcc.StoreConfig{})
+	st := mvcc.NewStore(zap.NewNop(), b, nil, mvcc.StoreConfig{HashAlgorithm: algorithm})
 	hst := mvcc.NewHashStorage(zap.NewNop(), st)
 
 	h, _, err := hst.HashByRev(rev)
@@ -66,5 +77,7 @@ func calculateHashKV(dbPath string, rev int64) (HashKV, error) {
 		Hash:            h.Hash,
 		HashRevision:    h.Revision,
 		CompactRevision: h.CompactRevision,
+		HashAlgorithm:   hst.HashAlgorithm(),
+		HashDigest:      hex.EncodeToString(h.Digest),
 	}, nil
 }
@@ -24,6 +24,8 @@ type fieldsPrinter struct{ printer }
 
 func (p *fieldsPrinter) DBStatus(r snapshot.Status) {
 	fmt.Println(`"Hash" :`, r.Hash)
+	fmt.Println(`"Hash algorithm" :`, r.HashAlgorithm)
+	fmt.Println(`"Hash digest" :`, r.HashDigest)
 	fmt.Println(`"Revision" :`, r.Revision)
 	fmt.Println(`"Keys" :`, r.TotalKey)
 	fmt.Println(`"Size" :`, r.TotalSize)
@@ -34,4 +36,6 @@ func (p *fieldsPrinter) DBHashKV(r HashKV) {
 	fmt.Println(`"Hash" :`, r.Hash)
 	fmt.Println(`"Hash revision" :`, r.HashRevision)
 	fmt.Println(`"Compact revision" :`, r.CompactRevision)
+	fmt.Println(`"Hash algorithm" :`, r.HashAlgorithm)
+	fmt.Println(`"Hash digest" :`, r.HashDigest)
 }
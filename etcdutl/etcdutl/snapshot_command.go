@@ -15,15 +15,19 @@
 package etcdutl
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/etcdutl/v3/snapshot"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/datadir"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
 const (
@@ -42,6 +46,15 @@ var (
 	initialMmapSize     = backend.InitialMmapSize
 	markCompacted       bool
 	revisionBump        uint64
+	statusVerify        bool
+	statusHashAlgorithm string
+	replayPrefix        bool
+	replayTimeout       time.Duration
+	replayUser          string
+	replayClient        = clientv3.ConfigSpec{
+		Secure: &clientv3.SecureConfig{},
+		Auth:   &clientv3.AuthConfig{},
+	}
 )
 
 // NewSnapshotCommand returns the cobra command for "snapshot".
@@ -52,11 +65,12 @@ func NewSnapshotCommand() *cobra.Command {
 	}
 	cmd.AddCommand(NewSnapshotRestoreCommand())
 	cmd.AddCommand(newSnapshotStatusCommand())
+	cmd.AddCommand(newSnapshotReplayCommand())
 	return cmd
 }
 
 func newSnapshotStatusCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "status <filename>",
 		Short: "Gets backend snapshot status of a given file",
 		Long: `When --write-out is set to simple, this command prints out comma-separated status lists for each endpoint.
@@ -64,6 +78,9 @@ The items in the lists are hash, revision, total keys, total size.
 `,
 		Run: SnapshotStatusCommandFunc,
 	}
+	cmd.Flags().BoolVar(&statusVerify, "verify", false, "Verify the snapshot integrity hash and consistent index, exiting with an error on mismatch")
+	cmd.Flags().StringVar(&statusHashAlgorithm, "hash-algorithm", mvcc.HashAlgorithmCRC32, "Algorithm computing the snapshot hash ('crc32' or 'sha256')")
+	return cmd
 }
 
 func NewSnapshotRestoreCommand() *cobra.Command {
@@ -89,6 +106,36 @@ func NewSnapshotRestoreCommand() *cobra.Command {
 	return cmd
 }
 
+func newSnapshotReplayCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay <filename> <key> [range_end] --endpoints {client urls}",
+		Short: "Puts a range of keys of a snapshot into a running cluster",
+		Long: `Puts the keys in the range [key, range_end) of a snapshot file, with their values at the latest revision
+of the snapshot, into a running cluster. Other keys of the cluster are left untouched.
+The connection flags are the ones of etcdctl.
+`,
+		Run: snapshotReplayCommandFunc,
+	}
+	cmd.Flags().BoolVar(&replayPrefix, "prefix", false, "Replay the keys with the given key as prefix")
+	cmd.Flags().DurationVar(&replayTimeout, "timeout", time.Minute, "Timeout of the replay")
+
+	cmd.Flags().StringSliceVar(&replayClient.Endpoints, "endpoints", []string{"127.0.0.1:2379"}, "gRPC endpoints of the target cluster")
+	cmd.Flags().DurationVar(&replayClient.DialTimeout, "dial-timeout", 2*time.Second, "dial timeout for client connections")
+	cmd.Flags().DurationVar(&replayClient.KeepAliveTime, "keepalive-time", 2*time.Second, "keepalive time for client connections")
+	cmd.Flags().DurationVar(&replayClient.KeepAliveTimeout, "keepalive-timeout", 6*time.Second, "keepalive timeout for client connections")
+	cmd.Flags().IntVar(&replayClient.MaxCallSendMsgSize, "max-request-bytes", 0, "client-side request send limit in bytes (if 0, it defaults to 2.0 MiB (2 * 1024 * 1024).)")
+	cmd.Flags().IntVar(&replayClient.MaxCallRecvMsgSize, "max-recv-bytes", 0, "client-side response receive limit in bytes (if 0, it defaults to \"math.MaxInt32\")")
+	cmd.Flags().BoolVar(&replayClient.Secure.InsecureTransport, "insecure-transport", true, "disable transport security for client connections")
+	cmd.Flags().BoolVar(&replayClient.Secure.InsecureSkipVerify, "insecure-skip-tls-verify", false, "skip server certificate verification (CAUTION: this option should be enabled only for testing purposes)")
+	cmd.Flags().StringVar(&replayClient.Secure.Cert, "cert", "", "identify secure client using this TLS certificate file")
+	cmd.Flags().StringVar(&replayClient.Secure.Key, "key", "", "identify secure client using this TLS key file")
+	cmd.Flags().StringVar(&replayClient.Secure.Cacert, "cacert", "", "verify certificates of TLS-enabled secure servers using this CA bundle")
+	cmd.Flags().StringVar(&replayClient.Auth.Token, "auth-jwt-token", "", "JWT token used for authentication (if this option is used, --user and --password should not be set)")
+	cmd.Flags().StringVar(&replayUser, "user", "", "username[:password] for authentication")
+	cmd.Flags().StringVar(&replayClient.Auth.Password, "password", "", "password for authentication (if this option is used, --user option shouldn't include password)")
+	return cmd
+}
+
 func SnapshotStatusCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
 		err := fmt.Errorf("snapshot status requires exactly one argument")
@@ -98,10 +145,15 @@ func SnapshotStatusCommandFunc(cmd *cobra.Command, args []string) {
 
 	lg := GetLogger()
 	sp := snapshot.NewV3(lg)
-	ds, err := sp.Status(args[0])
+	ds, err := sp.StatusWithHashAlgorithm(args[0], statusHashAlgorithm)
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
+	if statusVerify {
+		if err = sp.Verify(args[0]); err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+	}
 	printer.DBStatus(ds)
 }
 
@@ -162,6 +214,58 @@ func SnapshotRestoreCommandFunc(restoreCluster string,
 	}
 }
 
+func snapshotReplayCommandFunc(_ *cobra.Command, args []string) {
+	if len(args) < 2 || len(args) > 3 {
+		err := fmt.Errorf("snapshot replay requires a filename, a key and an optional range end")
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	key, end := args[1], ""
+	switch {
+	case replayPrefix && len(args) == 3:
+		err := fmt.Errorf("`range_end` and `--prefix` are mutually exclusive")
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	case replayPrefix:
+		end = clientv3.GetPrefixRangeEnd(key)
+	case len(args) == 3:
+		end = args[2]
+	}
+
+	if replayUser != "" {
+		if replayClient.Auth.Token != "" {
+			err := fmt.Errorf("`--user` and `--auth-jwt-token` are mutually exclusive")
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		}
+		user, password, ok := strings.Cut(replayUser, ":")
+		switch {
+		case ok && replayClient.Auth.Password != "":
+			err := fmt.Errorf("`--user` must not include a password when `--password` is set")
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		case ok:
+			replayClient.Auth.Password = password
+		case replayClient.Auth.Password == "":
+			err := fmt.Errorf("`--user` requires a password, in `--user` or `--password`")
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		}
+		replayClient.Auth.Username = user
+	}
+
+	lg := GetLogger()
+	cfg, err := clientv3.NewClientConfig(&replayClient, lg)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	cfg.Logger = lg
+	sp := snapshot.NewV3(lg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), replayTimeout)
+	defer cancel()
+	n, err := sp.Replay(ctx, *cfg, args[0], key, end)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	fmt.Printf("Replayed %d keys from %s\n", n, args[0])
+}
+
 func initialClusterFromName(name string) string {
 	n := name
 	if name == "" {
@@ -18,9 +18,10 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"hash/crc32"
 	"io"
 	"os"
 	"path/filepath"
@@ -62,13 +63,32 @@ type Manager interface {
 	// the selected node.
 	Save(ctx context.Context, cfg clientv3.Config, dbPath string) (version string, err error)
 
-	// Status returns the snapshot file information.
+	// Status returns the snapshot file information, with a crc32 hash.
 	Status(dbPath string) (Status, error)
 
+	// StatusWithHashAlgorithm returns the snapshot file information, with a
+	// hash computed by the given algorithm, "crc32" or "sha256".
+	StatusWithHashAlgorithm(dbPath, algorithm string) (Status, error)
+
+	// Verify checks the integrity hash appended to the snapshot file and
+	// that the consistent index stored in the snapshot covers its revisions.
+	Verify(dbPath string) error
+
 	// Restore restores a new etcd data directory from given snapshot
 	// file. It returns an error if specified data directory already
 	// exists, to prevent unintended data directory overwrites.
 	Restore(cfg RestoreConfig) error
+
+	// Replay puts the keys in the range [key, end) of the snapshot file,
+	// with their values at the latest revision of the snapshot, into the
+	// running cluster of the client configuration, and returns the number
+	// of keys put. An empty end selects key alone, and an end of "\x00" all
+	// keys from key. The keys are put without their leases. Other keys of
+	// the cluster, including the keys of the range missing from the
+	// snapshot, are left untouched. The keys are read and put in batches of
+	// up to 128 keys, each in its own transaction, so on error some of them
+	// may already be put.
+	Replay(ctx context.Context, cfg clientv3.Config, dbPath, key, end string) (int, error)
 }
 
 // NewV3 returns a new snapshot Manager for v3.x snapshot.
@@ -111,10 +131,26 @@ type Status struct {
 	// Version is equal to storageVersion of the snapshot
 	// Empty if server does not supports versioned snapshots (<v3.6)
 	Version string `json:"version"`
+	// HashAlgorithm is the algorithm that computed Hash.
+	HashAlgorithm string `json:"hashAlgorithm"`
+	// HashDigest is the full digest of HashAlgorithm, hex encoded, of which
+	// Hash holds the first 4 bytes.
+	HashDigest string `json:"hashDigest"`
 }
 
 // Status returns the snapshot file information.
 func (s *v3Manager) Status(dbPath string) (ds Status, err error) {
+	return s.StatusWithHashAlgorithm(dbPath, mvcc.HashAlgorithmCRC32)
+}
+
+// StatusWithHashAlgorithm returns the snapshot file information, hashed with
+// the given algorithm.
+func (s *v3Manager) StatusWithHashAlgorithm(dbPath, algorithm string) (ds Status, err error) {
+	h, err := mvcc.NewHash(algorithm)
+	if err != nil {
+		return ds, err
+	}
+
 	if _, err = os.Stat(dbPath); err != nil {
 		return ds, err
 	}
@@ -125,7 +161,6 @@ func (s *v3Manager) Status(dbPath string) (ds Status, err error) {
 	}
 	defer db.Close()
 
-	h := crc32.New(crc32.MakeTable(crc32.Castagnoli))
 	seenKeys := make(map[string]struct{})
 
 	if err = db.View(func(tx *bolt.Tx) error {
@@ -195,10 +230,89 @@ func (s *v3Manager) Status(dbPath string) (ds Status, err error) {
 	}
 
 	ds.TotalKey = len(seenKeys)
-	ds.Hash = h.Sum32()
+	ds.Hash = mvcc.Sum32(h)
+	ds.HashAlgorithm = algorithm
+	ds.HashDigest = hex.EncodeToString(h.Sum(nil))
 	return ds, nil
 }
 
+// Verify checks the snapshot file integrity hash and consistent index.
+func (s *v3Manager) Verify(dbPath string) error {
+	if err := verifyChecksum(dbPath); err != nil {
+		return err
+	}
+
+	db, err := bolt.Open(dbPath, 0o400, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.View(func(tx *bolt.Tx) error {
+		var index, term uint64
+		if meta := tx.Bucket(schema.Meta.Name()); meta != nil {
+			if v := meta.Get(schema.MetaConsistentIndexKeyName); len(v) == 8 {
+				index = binary.BigEndian.Uint64(v)
+			}
+			if v := meta.Get(schema.MetaTermKeyName); len(v) == 8 {
+				term = binary.BigEndian.Uint64(v)
+			}
+		}
+
+		var latest int64
+		if kb := tx.Bucket(schema.Key.Name()); kb != nil {
+			if k, _ := kb.Cursor().Last(); k != nil {
+				rev, rerr := bytesToRev(k)
+				if rerr != nil {
+					return fmt.Errorf("cannot parse revision key: %q err: %w", k, rerr)
+				}
+				latest = rev.Main
+			}
+		}
+
+		// every revision is created by applying a raft entry, so a snapshot
+		// holding revisions must record the index of an applied entry
+		if latest > 0 && index == 0 {
+			return fmt.Errorf("snapshot has revision %d but no consistent index", latest)
+		}
+		if index > 0 && term == 0 {
+			return fmt.Errorf("snapshot has consistent index %d but no term", index)
+		}
+		return nil
+	})
+}
+
+// verifyChecksum compares the sha256 digest appended to the snapshot file
+// with the digest of its content.
+func verifyChecksum(dbPath string) error {
+	f, err := os.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if !hasChecksum(fi.Size()) {
+		return fmt.Errorf("snapshot missing hash")
+	}
+
+	h := sha256.New()
+	if _, err = io.CopyN(h, f, fi.Size()-sha256.Size); err != nil {
+		return err
+	}
+	sha := make([]byte, sha256.Size)
+	if _, err = io.ReadFull(f, sha); err != nil {
+		return err
+	}
+	if dbsha := h.Sum(nil); !bytes.Equal(sha, dbsha) {
+		return fmt.Errorf("expected sha256 %v, got %v", sha, dbsha)
+	}
+	return nil
+}
+
 func bytesToRev(b []byte) (rev mvcc.Revision, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -208,6 +322,128 @@ func bytesToRev(b []byte) (rev mvcc.Revision, err error) {
 	return mvcc.BytesToRev(b), err
 }
 
+// replayBatchSize is the number of keys read from the snapshot and put
+// into the cluster at once, the default "--max-txn-ops" of the server.
+const replayBatchSize = 128
+
+// Replay puts a range of keys of the snapshot file into a running cluster.
+func (s *v3Manager) Replay(ctx context.Context, cfg clientv3.Config, dbPath, key, end string) (int, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		return 0, err
+	}
+	db, err := bolt.Open(dbPath, 0o400, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	var (
+		cli *clientv3.Client
+		n   int
+		rev int64
+	)
+	defer func() {
+		if cli != nil {
+			cli.Close()
+		}
+	}()
+	err = db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(schema.Key.Name())
+		if b == nil {
+			return nil
+		}
+		latest, err := latestRevisions(b, key, end)
+		if err != nil || len(latest) == 0 {
+			return err
+		}
+		if cli, err = clientv3.New(cfg); err != nil {
+			return err
+		}
+
+		batch := make(map[string]string, replayBatchSize)
+		flush := func() (err error) {
+			if len(batch) == 0 {
+				return nil
+			}
+			if rev, err = clientv3.PutAll(ctx, cli, batch); err != nil {
+				return err
+			}
+			n += len(batch)
+			clear(batch)
+			return nil
+		}
+		err = b.ForEach(func(k, v []byte) error {
+			var kv mvccpb.KeyValue
+			if err := kv.Unmarshal(v); err != nil {
+				return fmt.Errorf("cannot unmarshal value, key: %q value: %q err: %w", k, v, err)
+			}
+			r, ok := latest[string(kv.Key)]
+			if !ok || r != mvcc.BytesToRev(k) {
+				return nil
+			}
+			batch[string(kv.Key)] = string(kv.Value)
+			if len(batch) < replayBatchSize {
+				return nil
+			}
+			return flush()
+		})
+		if err != nil {
+			return err
+		}
+		return flush()
+	})
+	if err != nil {
+		return n, err
+	}
+	s.lg.Info(
+		"replayed keys from snapshot",
+		zap.String("path", dbPath),
+		zap.Int("keys", n),
+		zap.Int64("revision", rev),
+	)
+	return n, nil
+}
+
+// latestRevisions returns the latest revision of each key in the range
+// [key, end) of the key bucket, leaving out the keys deleted at their
+// latest revision. Only the keys and revisions are held in memory, the
+// values are read again when they are put.
+func latestRevisions(b *bolt.Bucket, key, end string) (map[string]mvcc.Revision, error) {
+	latest := make(map[string]mvcc.Revision)
+	// the revisions are iterated in order, so the last one of a key wins
+	err := b.ForEach(func(k, v []byte) error {
+		var kv mvccpb.KeyValue
+		if err := kv.Unmarshal(v); err != nil {
+			return fmt.Errorf("cannot unmarshal value, key: %q value: %q err: %w", k, v, err)
+		}
+		if !inRange(string(kv.Key), key, end) {
+			return nil
+		}
+		if mvcc.IsTombstone(k) {
+			delete(latest, string(kv.Key))
+			return nil
+		}
+		rev, err := bytesToRev(k)
+		if err != nil {
+			return fmt.Errorf("cannot decode revision, key: %q err: %w", k, err)
+		}
+		latest[string(kv.Key)] = rev
+		return nil
+	})
+	return latest, err
+}
+
+func inRange(k, key, end string) bool {
+	switch end {
+	case "":
+		return k == key
+	case "\x00":
+		return k >= key
+	default:
+		return k >= key && k < end
+	}
+}
+
 // RestoreConfig configures snapshot restore operation.
 type RestoreConfig struct {
 	// SnapshotPath is the path of snapshot file to restore from.
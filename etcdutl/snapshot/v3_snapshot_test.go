@@ -15,9 +15,13 @@
 package snapshot
 
 import (
+	"crypto/sha256"
 	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,6 +31,7 @@ import (
 
 	"go.etcd.io/bbolt"
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/server/v3/embed"
 	"go.etcd.io/etcd/server/v3/etcdserver"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
@@ -47,6 +52,27 @@ func TestSnapshotStatus(t *testing.T) {
 	assert.Equal(t, int64(11), status.Revision)
 }
 
+// TestSnapshotStatusHashAlgorithm asserts the output hash of status command
+// with each hash algorithm over the same snapshot.
+func TestSnapshotStatusHashAlgorithm(t *testing.T) {
+	dbpath := createDB(t, insertKeys(t, 10, 100))
+
+	for alg, want := range map[string]uint32{
+		mvcc.HashAlgorithmCRC32:  0xe7a6e44b,
+		mvcc.HashAlgorithmSHA256: 0x1238c0d4,
+	} {
+		status, err := NewV3(zap.NewNop()).StatusWithHashAlgorithm(dbpath, alg)
+		require.NoError(t, err)
+		assert.Equalf(t, want, status.Hash, "hash algorithm %s", alg)
+		assert.Equal(t, alg, status.HashAlgorithm)
+		assert.True(t, strings.HasPrefix(status.HashDigest, fmt.Sprintf("%08x", want)), "hash digest %s", status.HashDigest)
+		assert.Equal(t, int64(11), status.Revision)
+	}
+
+	_, err := NewV3(zap.NewNop()).StatusWithHashAlgorithm(dbpath, "md5")
+	require.ErrorContains(t, err, `unknown hash algorithm "md5"`)
+}
+
 // TestSnapshotStatusCorruptRevision tests if snapshot status command fails when there is an unexpected revision in "key" bucket.
 func TestSnapshotStatusCorruptRevision(t *testing.T) {
 	dbpath := createDB(t, insertKeys(t, 1, 0))
@@ -217,6 +243,159 @@ func TestSnapshotStatusTotalKey(t *testing.T) {
 	}
 }
 
+// TestSnapshotVerify tests if snapshot verification detects a corrupted file
+// and a missing consistent index.
+func TestSnapshotVerify(t *testing.T) {
+	cases := []struct {
+		name    string
+		corrupt func(t *testing.T, dbpath string)
+		wantErr string
+	}{
+		{
+			name: "valid snapshot",
+		},
+		{
+			name: "corrupted byte",
+			corrupt: func(t *testing.T, dbpath string) {
+				f, err := os.OpenFile(dbpath, os.O_RDWR, 0o600)
+				require.NoError(t, err)
+				defer f.Close()
+				b := make([]byte, 1)
+				_, err = f.ReadAt(b, 100)
+				require.NoError(t, err)
+				b[0]++
+				_, err = f.WriteAt(b, 100)
+				require.NoError(t, err)
+			},
+			wantErr: "expected sha256",
+		},
+		{
+			name: "missing hash",
+			corrupt: func(t *testing.T, dbpath string) {
+				fi, err := os.Stat(dbpath)
+				require.NoError(t, err)
+				require.NoError(t, os.Truncate(dbpath, fi.Size()-sha256.Size))
+			},
+			wantErr: "snapshot missing hash",
+		},
+		{
+			name: "missing consistent index",
+			corrupt: func(t *testing.T, dbpath string) {
+				fi, err := os.Stat(dbpath)
+				require.NoError(t, err)
+				require.NoError(t, os.Truncate(dbpath, fi.Size()-sha256.Size))
+
+				db, err := bbolt.Open(dbpath, 0o600, nil)
+				require.NoError(t, err)
+				err = db.Update(func(tx *bbolt.Tx) error {
+					return tx.Bucket(schema.Meta.Name()).Delete(schema.MetaConsistentIndexKeyName)
+				})
+				require.NoError(t, err)
+				require.NoError(t, db.Close())
+				appendChecksum(t, dbpath)
+			},
+			wantErr: "no consistent index",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dbpath := createDB(t, insertKeys(t, 10, 100))
+			appendChecksum(t, dbpath)
+			if tc.corrupt != nil {
+				tc.corrupt(t, dbpath)
+			}
+
+			err := NewV3(zap.NewNop()).Verify(dbpath)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+// TestSnapshotReplay ensures the keys of a range of a snapshot are put into
+// a running cluster with their latest values, leaving the other keys alone.
+func TestSnapshotReplay(t *testing.T) {
+	dbpath := createDB(t, func(srv *etcdserver.EtcdServer) {
+		for _, kv := range [][2]string{{"a/1", "old"}, {"a/1", "v1"}, {"a/2", "v2"}, {"a/3", "v3"}, {"b/1", "v1"}} {
+			_, err := srv.Put(t.Context(), &etcdserverpb.PutRequest{Key: []byte(kv[0]), Value: []byte(kv[1])})
+			require.NoError(t, err)
+		}
+		_, err := srv.DeleteRange(t.Context(), &etcdserverpb.DeleteRangeRequest{Key: []byte("a/3")})
+		require.NoError(t, err)
+	})
+
+	cli := startReplayTarget(t)
+	for _, kv := range [][2]string{{"a/2", "new"}, {"a/3", "kept"}, {"b/1", "kept"}} {
+		_, err := cli.Put(t.Context(), kv[0], kv[1])
+		require.NoError(t, err)
+	}
+
+	n, err := NewV3(zap.NewNop()).Replay(t.Context(), clientv3.Config{Endpoints: cli.Endpoints()}, dbpath, "a/", clientv3.GetPrefixRangeEnd("a/"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	resp, err := cli.Get(t.Context(), "", clientv3.WithFromKey())
+	require.NoError(t, err)
+	got := make(map[string]string)
+	for _, kv := range resp.Kvs {
+		got[string(kv.Key)] = string(kv.Value)
+	}
+	assert.Equal(t, map[string]string{"a/1": "v1", "a/2": "v2", "a/3": "kept", "b/1": "kept"}, got)
+}
+
+// TestSnapshotReplayBatches ensures a range larger than a batch is put in
+// several transactions.
+func TestSnapshotReplayBatches(t *testing.T) {
+	dbpath := createDB(t, insertKeys(t, 2*replayBatchSize+1, 8))
+	cli := startReplayTarget(t)
+
+	n, err := NewV3(zap.NewNop()).Replay(t.Context(), clientv3.Config{Endpoints: cli.Endpoints()}, dbpath, "", "\x00")
+	require.NoError(t, err)
+	assert.Equal(t, 2*replayBatchSize+1, n)
+
+	resp, err := cli.Get(t.Context(), "", clientv3.WithFromKey(), clientv3.WithCountOnly())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2*replayBatchSize+1), resp.Count)
+	// one revision for each of the three transactions
+	assert.Equal(t, int64(4), resp.Header.Revision)
+}
+
+// startReplayTarget starts an embedded etcd server to replay snapshots into
+// and returns a client of it.
+func startReplayTarget(t *testing.T) *clientv3.Client {
+	t.Helper()
+	cfg := embed.NewConfig()
+	cfg.LogLevel = "fatal"
+	cfg.Dir = t.TempDir()
+	etcd, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	t.Cleanup(etcd.Close)
+	select {
+	case <-etcd.Server.ReadyNotify():
+	case <-time.After(10 * time.Second):
+		t.FailNow()
+	}
+
+	cli, err := clientv3.New(clientv3.Config{Endpoints: []string{cfg.AdvertiseClientUrls[0].String()}})
+	require.NoError(t, err)
+	t.Cleanup(func() { cli.Close() })
+	return cli
+}
+
+// appendChecksum appends the sha256 digest of the file content, the way
+// snapshots saved from a running server are.
+func appendChecksum(t *testing.T, dbpath string) {
+	t.Helper()
+	data, err := os.ReadFile(dbpath)
+	require.NoError(t, err)
+	sha := sha256.Sum256(data)
+	require.NoError(t, os.WriteFile(dbpath, append(data, sha[:]...), 0o600))
+}
+
 // insertKeys insert `numKeys` number of keys of `valueSize` size into a running etcd server.
 func insertKeys(t *testing.T, numKeys, valueSize int) func(*etcdserver.EtcdServer) {
 	t.Helper()
@@ -0,0 +1,134 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceutil
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Event is the structured record of a completed trace.
+type Event struct {
+	Operation string         `json:"operation"`
+	Start     time.Time      `json:"start"`
+	End       time.Time      `json:"end"`
+	Duration  time.Duration  `json:"duration"`
+	Fields    map[string]any `json:"fields,omitempty"`
+	Steps     []StepEvent    `json:"steps,omitempty"`
+}
+
+// StepEvent is the structured record of a step of a completed trace. The
+// fields of an enclosing sub trace are included in its Fields.
+type StepEvent struct {
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+	// Duration is the time elapsed since the previous step, or since the
+	// start of the trace for the first step.
+	Duration time.Duration  `json:"duration"`
+	Fields   map[string]any `json:"fields,omitempty"`
+}
+
+// Sink receives completed traces.
+type Sink interface {
+	Export(ev Event) error
+}
+
+// JSONSink is a Sink that writes each event to a writer as a line of JSON.
+// It is safe for concurrent use.
+type JSONSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewJSONSink(w io.Writer) *JSONSink {
+	return &JSONSink{w: w}
+}
+
+func (s *JSONSink) Export(ev Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+// Event returns the structured record of the trace, ending now.
+func (t *Trace) Event() Event {
+	end := time.Now()
+	ev := Event{
+		Operation: t.operation,
+		Start:     t.startTime,
+		End:       end,
+		Duration:  end.Sub(t.startTime),
+		Fields:    fieldMap(t.fields),
+	}
+
+	var subTraceFields []Field
+	inSubTrace := false
+	lastStepTime := t.startTime
+	for i, tstep := range t.steps {
+		if tstep.isSubTraceStart {
+			subTraceFields, inSubTrace = tstep.fields, true
+			continue
+		}
+		if tstep.isSubTraceEnd {
+			subTraceFields, inSubTrace = nil, false
+			continue
+		}
+		fields := append(append([]Field(nil), subTraceFields...), tstep.fields...)
+		// fields given when a sub trace ends apply to all of its steps
+		if inSubTrace {
+			for j := i + 1; j < len(t.steps); j++ {
+				if t.steps[j].isSubTraceEnd {
+					fields = append(fields, t.steps[j].fields...)
+					break
+				}
+			}
+		}
+		ev.Steps = append(ev.Steps, StepEvent{
+			Message:  tstep.msg,
+			Time:     tstep.time,
+			Duration: tstep.time.Sub(lastStepTime),
+			Fields:   fieldMap(fields),
+		})
+		lastStepTime = tstep.time
+	}
+	return ev
+}
+
+// ExportIfLong exports the trace to sink if the duration is longer than
+// threshold.
+func (t *Trace) ExportIfLong(threshold time.Duration, sink Sink) error {
+	if sink == nil || t.IsEmpty() || time.Since(t.startTime) <= threshold {
+		return nil
+	}
+	return sink.Export(t.Event())
+}
+
+func fieldMap(fields []Field) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+	m := make(map[string]any, len(fields))
+	for _, f := range fields {
+		m[f.Key] = f.Value
+	}
+	return m
+}
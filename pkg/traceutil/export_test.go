@@ -0,0 +1,79 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceEvent(t *testing.T) {
+	start := time.Now().Add(-100 * time.Millisecond)
+	trace := &Trace{
+		operation: "Test",
+		startTime: start,
+		fields:    []Field{{Key: "traceKey1", Value: "traceValue1"}},
+		steps: []step{
+			{time: start.Add(10 * time.Millisecond), msg: "msg1", fields: []Field{{Key: "stepKey1", Value: "stepValue1"}}},
+			{fields: []Field{{Key: "beginSubTrace", Value: "true"}}, isSubTraceStart: true},
+			{time: start.Add(30 * time.Millisecond), msg: "submsg"},
+			{fields: []Field{{Key: "endSubTrace", Value: "true"}}, isSubTraceEnd: true},
+			{time: start.Add(60 * time.Millisecond), msg: "msg2"},
+		},
+	}
+
+	ev := trace.Event()
+	assert.Equal(t, "Test", ev.Operation)
+	assert.Equal(t, start, ev.Start)
+	assert.Equal(t, ev.End.Sub(start), ev.Duration)
+	assert.Equal(t, map[string]any{"traceKey1": "traceValue1"}, ev.Fields)
+	assert.Equal(t, []StepEvent{
+		{Message: "msg1", Time: start.Add(10 * time.Millisecond), Duration: 10 * time.Millisecond, Fields: map[string]any{"stepKey1": "stepValue1"}},
+		{Message: "submsg", Time: start.Add(30 * time.Millisecond), Duration: 20 * time.Millisecond, Fields: map[string]any{"beginSubTrace": "true", "endSubTrace": "true"}},
+		{Message: "msg2", Time: start.Add(60 * time.Millisecond), Duration: 30 * time.Millisecond},
+	}, ev.Steps)
+}
+
+func TestTraceExportIfLong(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONSink(&buf)
+
+	trace := &Trace{
+		operation: "Test",
+		startTime: time.Now().Add(-100 * time.Millisecond),
+		fields:    []Field{{Key: "revision", Value: 5}},
+	}
+	require.NoError(t, trace.ExportIfLong(time.Second, sink))
+	require.Zero(t, buf.Len())
+	require.NoError(t, TODO().ExportIfLong(0, sink))
+	require.Zero(t, buf.Len())
+
+	require.NoError(t, trace.ExportIfLong(50*time.Millisecond, sink))
+	require.NoError(t, trace.ExportIfLong(50*time.Millisecond, sink))
+	dec := json.NewDecoder(&buf)
+	for i := 0; i < 2; i++ {
+		var ev Event
+		require.NoError(t, dec.Decode(&ev))
+		assert.Equal(t, "Test", ev.Operation)
+		assert.Greater(t, ev.Duration, 50*time.Millisecond)
+		assert.Equal(t, map[string]any{"revision": float64(5)}, ev.Fields)
+	}
+	assert.False(t, dec.More())
+}
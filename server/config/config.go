@@ -22,14 +22,17 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 
 	bolt "go.etcd.io/bbolt"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
 	"go.etcd.io/etcd/client/pkg/v3/types"
 	"go.etcd.io/etcd/pkg/v3/featuregate"
 	"go.etcd.io/etcd/pkg/v3/netutil"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3discovery"
 	"go.etcd.io/etcd/server/v3/storage/datadir"
 )
@@ -122,6 +125,7 @@ type ServerConfig struct {
 	CompactionBatchLimit    int
 	CompactionSleepInterval time.Duration
 	QuotaBackendBytes       int64
+	QuotaSoftRatio          float64
 	MaxTxnOps               uint
 
 	// MaxRequestBytes is the maximum request size to send over raft.
@@ -134,6 +138,16 @@ type ServerConfig struct {
 	WarningApplyDuration        time.Duration
 	WarningUnaryRequestDuration time.Duration
 
+	// TraceExportSink, if set, receives the trace of each request that takes
+	// longer than TraceExportThreshold to serve.
+	TraceExportSink      traceutil.Sink
+	TraceExportThreshold time.Duration
+
+	// AuditHook, if set, is called after each applied request that may
+	// mutate state, with the response header after the apply and the
+	// response or error of the request.
+	AuditHook func(r *pb.InternalRaftRequest, header *pb.ResponseHeader, resp proto.Message, err error)
+
 	StrictReconfigCheck bool
 
 	// ClientCertAuthEnabled is true when cert has been signed by the client CA.
@@ -148,6 +162,9 @@ type ServerConfig struct {
 	InitialCorruptCheck  bool
 	CorruptCheckTime     time.Duration
 	CompactHashCheckTime time.Duration
+	// HashAlgorithm is the algorithm computing the key-value store hashes
+	// compared by corruption checks.
+	HashAlgorithm string
 
 	// PreVote is true to enable Raft Pre-Vote.
 	PreVote bool
@@ -172,6 +189,50 @@ type ServerConfig struct {
 
 	WatchProgressNotifyInterval time.Duration
 
+	// MaxWatchesPerUser is the maximum number of watches an authenticated
+	// user may have open on this member. 0 means unlimited.
+	MaxWatchesPerUser int
+
+	// MaxValueBytes is the largest value this member proposes in a Put or in
+	// the puts of a Txn, including nested transactions. 0 means unlimited.
+	MaxValueBytes int
+
+	// HotKeyWriteRate is the number of writes per second this member proposes
+	// to a single key; writes over it fail with ErrTooManyRequests. 0 disables
+	// the limit.
+	HotKeyWriteRate float64
+	// HotKeyWriteBurst is the number of writes to a single key allowed at once
+	// above HotKeyWriteRate. 0 allows one second worth of writes.
+	HotKeyWriteBurst int
+
+	// MaxWatchResponseBytes is the maximum size of the events of a watch
+	// response; larger responses are split. 0 means unlimited.
+	MaxWatchResponseBytes int
+
+	// SnapshotCopy sends snapshots from a temporary copy of the database
+	// when there is enough free disk space for it.
+	SnapshotCopy bool
+
+	// WatchHistorySize is the number of most recent events kept in memory to
+	// resume unsynced watchers. 0 disables it.
+	WatchHistorySize int
+
+	// WatchIDQuarantine is the number of watcher creations on a watch stream
+	// during which a canceled watch ID is not reused. 0 disables it.
+	WatchIDQuarantine int
+
+	// WatchVictimRetryInterval is the interval at which watch responses are
+	// resent to watchers whose channel was full. 0 uses 10ms.
+	WatchVictimRetryInterval time.Duration
+
+	// TombstoneRetention is the number of revisions up to a compaction
+	// revision whose deletes the compaction keeps. 0 disables it.
+	TombstoneRetention int64
+
+	// AlwaysSendPrevKVOnDelete sends the previous key-value in the delete
+	// events of every watcher.
+	AlwaysSendPrevKVOnDelete bool
+
 	// UnsafeNoFsync disables all uses of fsync.
 	// Setting this is unsafe and will cause data loss.
 	UnsafeNoFsync bool `json:"unsafe-no-fsync"`
@@ -30,12 +30,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc"
 	"sigs.k8s.io/yaml"
 
 	bolt "go.etcd.io/bbolt"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/client/pkg/v3/logutil"
 	"go.etcd.io/etcd/client/pkg/v3/srv"
 	"go.etcd.io/etcd/client/pkg/v3/tlsutil"
@@ -45,6 +47,7 @@ import (
 	"go.etcd.io/etcd/pkg/v3/featuregate"
 	"go.etcd.io/etcd/pkg/v3/flags"
 	"go.etcd.io/etcd/pkg/v3/netutil"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/config"
 	"go.etcd.io/etcd/server/v3/etcdserver"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
@@ -52,6 +55,7 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3compactor"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3discovery"
 	"go.etcd.io/etcd/server/v3/features"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
 const (
@@ -74,6 +78,7 @@ const (
 	DefaultAutoCompactionRetention     = "0"
 	DefaultAuthToken                   = "simple"
 	DefaultCompactHashCheckTime        = time.Minute
+	DefaultLeaseCheckpointInterval     = 5 * time.Minute
 	DefaultLoggingFormat               = "json"
 
 	DefaultDiscoveryDialTimeout       = 2 * time.Second
@@ -224,8 +229,12 @@ type Config struct {
 	// BackendFreelistType specifies the type of freelist that boltdb backend uses (array and map are supported types).
 	BackendFreelistType string `json:"backend-bbolt-freelist-type"`
 	QuotaBackendBytes   int64  `json:"quota-backend-bytes"`
-	MaxTxnOps           uint   `json:"max-txn-ops"`
-	MaxRequestBytes     uint   `json:"max-request-bytes"`
+	// QuotaSoftRatio is the fraction of the backend quota above which
+	// applied puts and txns carry a quota warning in their response header.
+	// 0 disables it.
+	QuotaSoftRatio  float64 `json:"quota-backend-soft-ratio"`
+	MaxTxnOps       uint    `json:"max-txn-ops"`
+	MaxRequestBytes uint    `json:"max-request-bytes"`
 
 	// MaxConcurrentStreams specifies the maximum number of concurrent
 	// streams that each client can open at a time.
@@ -349,6 +358,15 @@ type Config struct {
 	//	}
 	//	embed.StartEtcd(cfg)
 	ServiceRegister func(*grpc.Server) `json:"-"`
+	// TraceExportSink, if set, receives the trace of each request that takes
+	// longer than TraceExportThreshold to serve, e.g. a traceutil.JSONSink.
+	TraceExportSink      traceutil.Sink `json:"-"`
+	TraceExportThreshold time.Duration  `json:"-"`
+	// AuditHook, if set, is called after each applied request that may
+	// mutate state, with the response header carrying the member ID, raft
+	// term and store revision after the apply, and the response or error of
+	// the request. Read-only ranges and transactions are not reported.
+	AuditHook func(r *pb.InternalRaftRequest, header *pb.ResponseHeader, resp proto.Message, err error) `json:"-"`
 
 	AuthToken  string `json:"auth-token"`
 	BcryptCost uint   `json:"bcrypt-cost"`
@@ -361,12 +379,64 @@ type Config struct {
 
 	// CompactHashCheckTime is the duration of time between leader checks followers compaction hashes.
 	CompactHashCheckTime time.Duration `json:"compact-hash-check-time"`
+
+	// HashAlgorithm is the algorithm computing the key-value store hashes
+	// compared by corruption checks, "crc32" or "sha256". All members must
+	// use the same algorithm for their hashes to be compared: corruption
+	// checks log an error and skip the members hashing with another one.
+	HashAlgorithm string `json:"hash-algorithm"`
+
 	// CompactionBatchLimit Sets the maximum revisions deleted in each compaction batch.
 	CompactionBatchLimit int `json:"compaction-batch-limit"`
 	// CompactionSleepInterval is the sleep interval between every etcd compaction loop.
 	CompactionSleepInterval time.Duration `json:"compaction-sleep-interval"`
 	// WatchProgressNotifyInterval is the time duration of periodic watch progress notifications.
 	WatchProgressNotifyInterval time.Duration `json:"watch-progress-notify-interval"`
+	// LeaseCheckpointInterval is the time duration between checkpoints of the
+	// remaining TTLs of leases, when the LeaseCheckpoint feature is enabled.
+	// Shorter intervals keep TTLs more accurate across leader changes at the
+	// cost of more raft proposals.
+	LeaseCheckpointInterval time.Duration `json:"lease-checkpoint-interval"`
+	// MaxWatchesPerUser is the maximum number of watches an authenticated user
+	// may have open on this member; new watches over the limit are rejected.
+	// 0 means unlimited.
+	MaxWatchesPerUser int `json:"max-watches-per-user"`
+	// MaxValueBytes is the largest value a member accepts in a put, including
+	// the puts of transactions. 0 means unlimited.
+	MaxValueBytes int `json:"max-value-bytes"`
+	// HotKeyWriteRate is the number of writes per second a member accepts to
+	// a single key; writes over it are rejected before being proposed and
+	// may be retried. 0 disables the limit.
+	HotKeyWriteRate float64 `json:"hot-key-write-rate"`
+	// HotKeyWriteBurst is the number of writes to a single key accepted at
+	// once above HotKeyWriteRate. 0 allows one second worth of writes.
+	HotKeyWriteBurst int `json:"hot-key-write-burst"`
+	// MaxWatchResponseBytes is the maximum size in bytes of the events of a
+	// watch response; larger responses are split into several responses of
+	// the same revision. 0 means unlimited.
+	MaxWatchResponseBytes int `json:"max-watch-response-bytes"`
+	// SnapshotCopy sends snapshots from a temporary copy of the database
+	// written next to it, so that slow snapshot clients do not hold a read
+	// transaction blocking the growth of the database file. The copy is
+	// only made when it leaves at least the size of the database free.
+	SnapshotCopy bool `json:"snapshot-copy"`
+	// WatchHistorySize is the number of most recent events kept in memory to
+	// resume unsynced watchers without reading the backend. 0 disables it.
+	WatchHistorySize int `json:"watch-history-size"`
+	// WatchIDQuarantine is the number of watcher creations on a watch stream
+	// during which a canceled watch ID is not assigned again. 0 disables it.
+	WatchIDQuarantine int `json:"watch-id-quarantine"`
+	// WatchVictimRetryInterval is the interval at which watch responses are
+	// resent to watchers whose channel was full. 0 uses 10ms.
+	WatchVictimRetryInterval time.Duration `json:"watch-victim-retry-interval"`
+	// TombstoneRetention is the number of revisions up to a compaction
+	// revision whose deletes the compaction keeps, so that watchers filtering
+	// out puts resuming from them still receive the deletes. 0 disables it. All members
+	// should use the same value, as it changes what compactions keep.
+	TombstoneRetention int64 `json:"tombstone-retention"`
+	// AlwaysSendPrevKVOnDelete sends the previous key-value in the delete
+	// events of every watcher, as if each watcher requested it.
+	AlwaysSendPrevKVOnDelete bool `json:"always-send-prev-kv-on-delete"`
 	// WarningApplyDuration is the time duration after which a warning is generated if applying request
 	WarningApplyDuration time.Duration `json:"warning-apply-duration"`
 	// BootstrapDefragThresholdMegabytes is the minimum number of megabytes needed to be freed for etcd server to
@@ -568,6 +638,9 @@ func NewConfig() *Config {
 		DistributedTracingServiceName: DefaultDistributedTracingServiceName,
 
 		CompactHashCheckTime: DefaultCompactHashCheckTime,
+		HashAlgorithm:        mvcc.HashAlgorithmCRC32,
+
+		LeaseCheckpointInterval: DefaultLeaseCheckpointInterval,
 
 		V2Deprecation: config.V2DeprDefault,
 
@@ -624,6 +697,7 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.UintVar(&cfg.ElectionMs, "election-timeout", cfg.ElectionMs, "Time (in milliseconds) for an election to timeout.")
 	fs.BoolVar(&cfg.InitialElectionTickAdvance, "initial-election-tick-advance", cfg.InitialElectionTickAdvance, "Whether to fast-forward initial election ticks on boot for faster election.")
 	fs.Int64Var(&cfg.QuotaBackendBytes, "quota-backend-bytes", cfg.QuotaBackendBytes, "Sets the maximum size (in bytes) that the etcd backend database may consume. Exceeding this triggers an alarm and puts etcd in read-only mode. Set to 0 to use the default 2GiB limit.")
+	fs.Float64Var(&cfg.QuotaSoftRatio, "quota-backend-soft-ratio", cfg.QuotaSoftRatio, "Fraction of the backend quota above which puts and txns still succeed, but carry a quota warning in their response header (0 disables it).")
 	fs.StringVar(&cfg.BackendFreelistType, "backend-bbolt-freelist-type", cfg.BackendFreelistType, "BackendFreelistType specifies the type of freelist that boltdb backend uses(array and map are supported types)")
 	fs.DurationVar(&cfg.BackendBatchInterval, "backend-batch-interval", cfg.BackendBatchInterval, "BackendBatchInterval is the maximum time before commit the backend transaction.")
 	fs.IntVar(&cfg.BackendBatchLimit, "backend-batch-limit", cfg.BackendBatchLimit, "BackendBatchLimit is the maximum operations before commit the backend transaction.")
@@ -745,10 +819,23 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&cfg.EnableGRPCGateway, "enable-grpc-gateway", cfg.EnableGRPCGateway, "Enable GRPC gateway.")
 	fs.DurationVar(&cfg.CorruptCheckTime, "corrupt-check-time", cfg.CorruptCheckTime, "Duration of time between cluster corruption check passes.")
 	fs.DurationVar(&cfg.CompactHashCheckTime, "compact-hash-check-time", cfg.CompactHashCheckTime, "Duration of time between leader checks followers compaction hashes.")
+	fs.StringVar(&cfg.HashAlgorithm, "hash-algorithm", cfg.HashAlgorithm, "Algorithm computing the key-value store hashes compared by corruption checks ('crc32' or 'sha256').")
 
 	fs.IntVar(&cfg.CompactionBatchLimit, "compaction-batch-limit", cfg.CompactionBatchLimit, "Sets the maximum revisions deleted in each compaction batch.")
 	fs.DurationVar(&cfg.CompactionSleepInterval, "compaction-sleep-interval", cfg.CompactionSleepInterval, "Sets the sleep interval between each compaction batch.")
 	fs.DurationVar(&cfg.WatchProgressNotifyInterval, "watch-progress-notify-interval", cfg.WatchProgressNotifyInterval, "Duration of periodic watch progress notifications.")
+	fs.DurationVar(&cfg.LeaseCheckpointInterval, "lease-checkpoint-interval", cfg.LeaseCheckpointInterval, "Duration of time between checkpoints of the remaining TTLs of leases. Requires feature gate LeaseCheckpoint.")
+	fs.IntVar(&cfg.MaxWatchesPerUser, "max-watches-per-user", cfg.MaxWatchesPerUser, "Maximum number of watches an authenticated user may have open on this member (0 is unlimited).")
+	fs.IntVar(&cfg.MaxValueBytes, "max-value-bytes", cfg.MaxValueBytes, "Maximum size in bytes of a value this member accepts in a put, including the puts of transactions (0 is unlimited).")
+	fs.Float64Var(&cfg.HotKeyWriteRate, "hot-key-write-rate", cfg.HotKeyWriteRate, "Maximum number of writes per second this member accepts to a single key (0 is unlimited).")
+	fs.IntVar(&cfg.HotKeyWriteBurst, "hot-key-write-burst", cfg.HotKeyWriteBurst, "Number of writes to a single key accepted at once above --hot-key-write-rate (0 is one second worth of writes).")
+	fs.IntVar(&cfg.MaxWatchResponseBytes, "max-watch-response-bytes", cfg.MaxWatchResponseBytes, "Maximum size in bytes of the events of a watch response; larger responses are split (0 is unlimited).")
+	fs.BoolVar(&cfg.SnapshotCopy, "snapshot-copy", cfg.SnapshotCopy, "Send snapshots from a temporary copy of the database when there is enough free disk space for it.")
+	fs.IntVar(&cfg.WatchHistorySize, "watch-history-size", cfg.WatchHistorySize, "Number of most recent events kept in memory to resume unsynced watchers without reading the backend (0 disables it).")
+	fs.IntVar(&cfg.WatchIDQuarantine, "watch-id-quarantine", cfg.WatchIDQuarantine, "Number of watcher creations on a watch stream during which a canceled watch ID is not reused (0 disables it).")
+	fs.DurationVar(&cfg.WatchVictimRetryInterval, "watch-victim-retry-interval", cfg.WatchVictimRetryInterval, "Interval at which watch responses are resent to watchers whose channel was full (0 is 10ms).")
+	fs.Int64Var(&cfg.TombstoneRetention, "tombstone-retention", cfg.TombstoneRetention, "Number of revisions up to a compaction revision whose deletes are kept for resuming watchers that filter out puts (0 disables it). It should be the same on all members.")
+	fs.BoolVar(&cfg.AlwaysSendPrevKVOnDelete, "always-send-prev-kv-on-delete", cfg.AlwaysSendPrevKVOnDelete, "Send the previous key-value in the delete events of every watcher, as if each watcher requested it.")
 	fs.DurationVar(&cfg.DowngradeCheckTime, "downgrade-check-time", cfg.DowngradeCheckTime, "Duration of time between two downgrade status checks.")
 	fs.DurationVar(&cfg.WarningApplyDuration, "warning-apply-duration", cfg.WarningApplyDuration, "Time duration after which a warning is generated if watch progress takes more time.")
 	fs.DurationVar(&cfg.WarningUnaryRequestDuration, "warning-unary-request-duration", cfg.WarningUnaryRequestDuration, "Time duration after which a warning is generated if a unary request takes more time.")
@@ -1029,10 +1116,49 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("enabling feature gate LeaseCheckpointPersist requires enabling feature gate LeaseCheckpoint")
 	}
 
+	if cfg.LeaseCheckpointInterval <= 0 {
+		return fmt.Errorf("--lease-checkpoint-interval must be >0 (set to %v)", cfg.LeaseCheckpointInterval)
+	}
+
 	if cfg.CompactHashCheckTime <= 0 {
 		return fmt.Errorf("--compact-hash-check-time must be >0 (set to %v)", cfg.CompactHashCheckTime)
 	}
 
+	if cfg.MaxWatchesPerUser < 0 {
+		return fmt.Errorf("--max-watches-per-user must be >=0 (set to %d)", cfg.MaxWatchesPerUser)
+	}
+	if cfg.MaxValueBytes < 0 {
+		return fmt.Errorf("--max-value-bytes must be >=0 (set to %d)", cfg.MaxValueBytes)
+	}
+	if cfg.HotKeyWriteRate < 0 {
+		return fmt.Errorf("--hot-key-write-rate must be >=0 (set to %v)", cfg.HotKeyWriteRate)
+	}
+	if cfg.HotKeyWriteBurst < 0 {
+		return fmt.Errorf("--hot-key-write-burst must be >=0 (set to %d)", cfg.HotKeyWriteBurst)
+	}
+	if cfg.MaxWatchResponseBytes < 0 {
+		return fmt.Errorf("--max-watch-response-bytes must be >=0 (set to %d)", cfg.MaxWatchResponseBytes)
+	}
+	if cfg.WatchHistorySize < 0 {
+		return fmt.Errorf("--watch-history-size must be >=0 (set to %d)", cfg.WatchHistorySize)
+	}
+	if cfg.WatchIDQuarantine < 0 {
+		return fmt.Errorf("--watch-id-quarantine must be >=0 (set to %d)", cfg.WatchIDQuarantine)
+	}
+	if cfg.WatchVictimRetryInterval < 0 {
+		return fmt.Errorf("--watch-victim-retry-interval must be >=0 (set to %v)", cfg.WatchVictimRetryInterval)
+	}
+	if cfg.QuotaSoftRatio < 0 || cfg.QuotaSoftRatio >= 1 {
+		return fmt.Errorf("--quota-backend-soft-ratio must be in [0, 1) (set to %v)", cfg.QuotaSoftRatio)
+	}
+	if cfg.TombstoneRetention < 0 {
+		return fmt.Errorf("--tombstone-retention must be >=0 (set to %d)", cfg.TombstoneRetention)
+	}
+
+	if _, err := mvcc.NewHash(cfg.HashAlgorithm); err != nil {
+		return fmt.Errorf("--hash-algorithm must be %q or %q (set to %q)", mvcc.HashAlgorithmCRC32, mvcc.HashAlgorithmSHA256, cfg.HashAlgorithm)
+	}
+
 	// If `--name` isn't configured, then multiple members may have the same "default" name.
 	// When adding a new member with the "default" name as well, etcd may regards its peerURL
 	// as one additional peerURL of the existing member which has the same "default" name,
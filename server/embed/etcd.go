@@ -200,6 +200,7 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		AutoCompactionRetention:           autoCompactionRetention,
 		AutoCompactionMode:                cfg.AutoCompactionMode,
 		QuotaBackendBytes:                 cfg.QuotaBackendBytes,
+		QuotaSoftRatio:                    cfg.QuotaSoftRatio,
 		BackendBatchLimit:                 cfg.BackendBatchLimit,
 		BackendFreelistType:               backendFreelistType,
 		BackendBatchInterval:              cfg.BackendBatchInterval,
@@ -216,6 +217,7 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		HostWhitelist:                     cfg.HostWhitelist,
 		CorruptCheckTime:                  cfg.CorruptCheckTime,
 		CompactHashCheckTime:              cfg.CompactHashCheckTime,
+		HashAlgorithm:                     cfg.HashAlgorithm,
 		PreVote:                           cfg.PreVote,
 		Logger:                            cfg.logger,
 		ForceNewCluster:                   cfg.ForceNewCluster,
@@ -225,9 +227,24 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		CompactionBatchLimit:              cfg.CompactionBatchLimit,
 		CompactionSleepInterval:           cfg.CompactionSleepInterval,
 		WatchProgressNotifyInterval:       cfg.WatchProgressNotifyInterval,
+		MaxWatchesPerUser:                 cfg.MaxWatchesPerUser,
+		MaxValueBytes:                     cfg.MaxValueBytes,
+		HotKeyWriteRate:                   cfg.HotKeyWriteRate,
+		HotKeyWriteBurst:                  cfg.HotKeyWriteBurst,
+		MaxWatchResponseBytes:             cfg.MaxWatchResponseBytes,
+		SnapshotCopy:                      cfg.SnapshotCopy,
+		WatchHistorySize:                  cfg.WatchHistorySize,
+		WatchIDQuarantine:                 cfg.WatchIDQuarantine,
+		WatchVictimRetryInterval:          cfg.WatchVictimRetryInterval,
+		TombstoneRetention:                cfg.TombstoneRetention,
+		AlwaysSendPrevKVOnDelete:          cfg.AlwaysSendPrevKVOnDelete,
+		LeaseCheckpointInterval:           cfg.LeaseCheckpointInterval,
 		DowngradeCheckTime:                cfg.DowngradeCheckTime,
 		WarningApplyDuration:              cfg.WarningApplyDuration,
 		WarningUnaryRequestDuration:       cfg.WarningUnaryRequestDuration,
+		TraceExportSink:                   cfg.TraceExportSink,
+		TraceExportThreshold:              cfg.TraceExportThreshold,
+		AuditHook:                         cfg.AuditHook,
 		MemoryMlock:                       cfg.MemoryMlock,
 		BootstrapDefragThresholdMegabytes: cfg.BootstrapDefragThresholdMegabytes,
 		MaxLearners:                       cfg.MaxLearners,
@@ -353,6 +370,7 @@ func print(lg *zap.Logger, ec Config, sc config.ServerConfig, memberInitialized
 		zap.String("initial-cluster-state", ec.ClusterState),
 		zap.String("initial-cluster-token", sc.InitialClusterToken),
 		zap.Int64("quota-backend-bytes", quota),
+		zap.Float64("quota-backend-soft-ratio", sc.QuotaSoftRatio),
 		zap.Uint("max-request-bytes", sc.MaxRequestBytes),
 		zap.Uint32("max-concurrent-streams", sc.MaxConcurrentStreams),
 
@@ -361,6 +379,7 @@ func print(lg *zap.Logger, ec Config, sc config.ServerConfig, memberInitialized
 		zap.Bool("initial-corrupt-check", sc.InitialCorruptCheck),
 		zap.String("corrupt-check-time-interval", sc.CorruptCheckTime.String()),
 		zap.Duration("compact-check-time-interval", sc.CompactHashCheckTime),
+		zap.String("hash-algorithm", sc.HashAlgorithm),
 		zap.String("auto-compaction-mode", sc.AutoCompactionMode),
 		zap.Duration("auto-compaction-retention", sc.AutoCompactionRetention),
 		zap.String("auto-compaction-interval", sc.AutoCompactionRetention.String()),
@@ -380,6 +399,18 @@ func print(lg *zap.Logger, ec Config, sc config.ServerConfig, memberInitialized
 
 		zap.String("downgrade-check-interval", sc.DowngradeCheckTime.String()),
 		zap.Int("max-learners", sc.MaxLearners),
+		zap.Int("max-watches-per-user", sc.MaxWatchesPerUser),
+		zap.Int("max-value-bytes", sc.MaxValueBytes),
+		zap.Float64("hot-key-write-rate", sc.HotKeyWriteRate),
+		zap.Int("hot-key-write-burst", sc.HotKeyWriteBurst),
+		zap.Int("max-watch-response-bytes", sc.MaxWatchResponseBytes),
+		zap.Bool("snapshot-copy", sc.SnapshotCopy),
+		zap.Int("watch-history-size", sc.WatchHistorySize),
+		zap.Int("watch-id-quarantine", sc.WatchIDQuarantine),
+		zap.Duration("watch-victim-retry-interval", sc.WatchVictimRetryInterval),
+		zap.Int64("tombstone-retention", sc.TombstoneRetention),
+		zap.Bool("always-send-prev-kv-on-delete", sc.AlwaysSendPrevKVOnDelete),
+		zap.Duration("lease-checkpoint-interval", sc.LeaseCheckpointInterval),
 
 		zap.String("v2-deprecation", string(ec.V2Deprecation)),
 	)
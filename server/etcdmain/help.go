@@ -78,6 +78,8 @@ Member:
     Enable to enforce etcd pages (in particular bbolt) to stay in RAM.
   --quota-backend-bytes '0'
     Sets the maximum size (in bytes) that the etcd backend database may consume. Exceeding this triggers an alarm and puts etcd in read-only mode. Set to 0 to use the default 2GiB limit.
+  --quota-backend-soft-ratio '0'
+    Fraction of the backend quota above which puts and txns still succeed, but carry a quota warning in their response header (0 disables it).
   --backend-bbolt-freelist-type 'map'
     BackendFreelistType specifies the type of freelist that boltdb backend uses(array and map are supported types).
   --backend-batch-interval ''
@@ -276,12 +278,38 @@ Features:
     Duration of time between cluster corruption check passes.
   --compact-hash-check-time '1m'
     Duration of time between leader checks followers compaction hashes.
+  --hash-algorithm 'crc32'
+    Algorithm computing the key-value store hashes compared by corruption checks ('crc32' or 'sha256').
   --compaction-batch-limit 1000
     CompactionBatchLimit sets the maximum revisions deleted in each compaction batch.
   --peer-skip-client-san-verification 'false'
     Skip verification of SAN field in client certificate for peer connections.
   --watch-progress-notify-interval '10m'
     Duration of periodical watch progress notification.
+  --lease-checkpoint-interval '5m'
+    Duration of time between checkpoints of the remaining TTLs of leases. Requires feature gate LeaseCheckpoint.
+  --max-watches-per-user '0'
+    Maximum number of watches an authenticated user may have open on this member (0 is unlimited).
+  --max-value-bytes '0'
+    Maximum size in bytes of a value this member accepts in a put, including the puts of transactions (0 is unlimited).
+  --hot-key-write-rate '0'
+    Maximum number of writes per second this member accepts to a single key (0 is unlimited).
+  --hot-key-write-burst '0'
+    Number of writes to a single key accepted at once above --hot-key-write-rate (0 is one second worth of writes).
+  --max-watch-response-bytes '0'
+    Maximum size in bytes of the events of a watch response; larger responses are split (0 is unlimited).
+  --snapshot-copy 'false'
+    Send snapshots from a temporary copy of the database when there is enough free disk space for it.
+  --watch-history-size '0'
+    Number of most recent events kept in memory to resume unsynced watchers without reading the backend (0 disables it).
+  --watch-id-quarantine '0'
+    Number of watcher creations on a watch stream during which a canceled watch ID is not reused (0 disables it).
+  --watch-victim-retry-interval '0s'
+    Interval at which watch responses are resent to watchers whose channel was full (0 is 10ms).
+  --tombstone-retention '0'
+    Number of revisions up to a compaction revision whose deletes are kept for resuming watchers that filter out puts (0 disables it). It should be the same on all members.
+  --always-send-prev-kv-on-delete 'false'
+    Send the previous key-value in the delete events of every watcher, as if each watcher requested it.
   --warning-apply-duration '100ms'
     Warning is generated if requests take more than this duration.
   --bootstrap-defrag-threshold-megabytes
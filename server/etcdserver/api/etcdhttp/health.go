@@ -196,6 +196,8 @@ func checkAlarms(lg *zap.Logger, srv ServerHealth, excludedAlarms StringSet) Hea
 			h.Reason = "ALARM NOSPACE"
 		case pb.AlarmType_CORRUPT:
 			h.Reason = "ALARM CORRUPT"
+		case pb.AlarmType_READONLY:
+			h.Reason = "ALARM READONLY"
 		default:
 			h.Reason = "ALARM UNKNOWN"
 		}
@@ -0,0 +1,51 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3compactor
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	lastCompactedRevision = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "auto_compaction_last_revision",
+		Help:      "The revision of the last successful auto compaction.",
+	})
+	compactionDurationSec = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "auto_compaction_duration_seconds",
+		Help:      "The latency distributions of successful auto compactions.",
+
+		// lowest bucket start of upper bound 0.001 sec (1 ms) with factor 2
+		// highest bucket start of 0.001 sec * 2^13 == 8.192 sec
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+	})
+)
+
+func init() {
+	prometheus.MustRegister(lastCompactedRevision)
+	prometheus.MustRegister(compactionDurationSec)
+}
+
+// reportCompaction records a successful auto compaction to rev that took d.
+func reportCompaction(rev int64, d time.Duration) {
+	lastCompactedRevision.Set(float64(rev))
+	compactionDurationSec.Observe(d.Seconds())
+}
@@ -141,6 +141,7 @@ func (pc *Periodic) Run() {
 			startTime := pc.clock.Now()
 			_, err := pc.c.Compact(pc.ctx, &pb.CompactionRequest{Revision: rev})
 			if err == nil || errors.Is(err, mvcc.ErrCompacted) {
+				reportCompaction(rev, pc.clock.Now().Sub(startTime))
 				pc.lg.Info(
 					"completed auto periodic compaction",
 					zap.Int64("revision", rev),
@@ -92,6 +92,7 @@ func (rc *Revision) Run() {
 			_, err := rc.c.Compact(rc.ctx, &pb.CompactionRequest{Revision: rev})
 			if err == nil || errors.Is(err, mvcc.ErrCompacted) {
 				prev = rev
+				reportCompaction(rev, time.Since(now))
 				rc.lg.Info(
 					"completed auto revision compaction",
 					zap.Int64("revision", rev),
@@ -169,6 +169,7 @@ func (as *AuthServer) UserChangePassword(ctx context.Context, r *pb.AuthUserChan
 type AuthGetter interface {
 	AuthInfoFromCtx(ctx context.Context) (*auth.AuthInfo, error)
 	AuthStore() auth.AuthStore
+	AuthRevisionNotify() <-chan struct{}
 }
 
 type AuthAdmin struct {
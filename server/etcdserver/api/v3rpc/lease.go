@@ -90,6 +90,31 @@ func (ls *LeaseServer) LeaseLeases(ctx context.Context, rr *pb.LeaseLeasesReques
 	return resp, nil
 }
 
+func (ls *LeaseServer) LeaseExpirations(_ *pb.LeaseExpirationsRequest, stream pb.Lease_LeaseExpirationsServer) error {
+	for id := range ls.le.LeaseRevokedNotify(stream.Context()) {
+		resp := &pb.LeaseExpirationsResponse{Header: &pb.ResponseHeader{}, ID: int64(id)}
+		ls.hdr.fill(resp.Header)
+		if err := stream.Send(resp); err != nil {
+			if isClientCtxErr(stream.Context().Err(), err) {
+				ls.lg.Debug("failed to send lease expiration to gRPC stream", zap.Error(err))
+			} else {
+				ls.lg.Warn("failed to send lease expiration to gRPC stream", zap.Error(err))
+				streamFailures.WithLabelValues("send", "lease-expirations").Inc()
+			}
+			return err
+		}
+	}
+	if err := stream.Context().Err(); err != nil {
+		// the only server-side cancellation is noleader for now.
+		if errors.Is(err, context.Canceled) {
+			return rpctypes.ErrGRPCNoLeader
+		}
+		return err
+	}
+	// the receiver fell behind the revocations
+	return rpctypes.ErrGRPCLeaseExpirationsLagging
+}
+
 func (ls *LeaseServer) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) (err error) {
 	errc := make(chan error, 1)
 	go func() {
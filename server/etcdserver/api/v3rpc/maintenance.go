@@ -19,6 +19,7 @@ import (
 	"crypto/sha256"
 	errorspkg "errors"
 	"io"
+	"sync/atomic"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -32,6 +33,7 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver/apply"
 	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	serverversion "go.etcd.io/etcd/server/v3/etcdserver/version"
+	"go.etcd.io/etcd/server/v3/features"
 	"go.etcd.io/etcd/server/v3/storage"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
@@ -49,6 +51,7 @@ type BackendGetter interface {
 
 type Defrager interface {
 	Defragment() error
+	DefragmentWithProgress(fn backend.DefragProgressFunc) error
 }
 
 type Alarmer interface {
@@ -62,6 +65,22 @@ type Downgrader interface {
 	Downgrade(ctx context.Context, dr *pb.DowngradeRequest) (*pb.DowngradeResponse, error)
 }
 
+type SnapshotSaver interface {
+	SaveSnapshot(ctx context.Context) (uint64, error)
+}
+
+type MemberRemovePreviewer interface {
+	PreviewRemoveMember(id uint64) (*etcdserver.RemoveMemberPreview, error)
+}
+
+type LinearizableReader interface {
+	LinearizableReadNotify(ctx context.Context) error
+}
+
+type CompactionCanceler interface {
+	CancelCompaction()
+}
+
 type LeaderTransferrer interface {
 	MoveLeader(ctx context.Context, lead, target uint64) error
 }
@@ -78,6 +97,7 @@ type maintenanceServer struct {
 	lg     *zap.Logger
 	rg     apply.RaftStatusGetter
 	hasher mvcc.HashStorage
+	kg     KVGetter
 	bg     BackendGetter
 	defrag Defrager
 	a      Alarmer
@@ -87,7 +107,12 @@ type maintenanceServer struct {
 	d      Downgrader
 	vs     serverversion.Server
 	cg     ConfigGetter
+	ss     SnapshotSaver
+	rp     MemberRemovePreviewer
+	lr     LinearizableReader
+	cc     CompactionCanceler
 
+	snapshotCopy   bool
 	healthNotifier notifier
 }
 
@@ -96,6 +121,7 @@ func NewMaintenanceServer(s *etcdserver.EtcdServer, healthNotifier notifier) pb.
 		lg:             s.Cfg.Logger,
 		rg:             s,
 		hasher:         s.KV().HashStorage(),
+		kg:             s,
 		bg:             s,
 		defrag:         s,
 		a:              s,
@@ -106,6 +132,11 @@ func NewMaintenanceServer(s *etcdserver.EtcdServer, healthNotifier notifier) pb.
 		vs:             etcdserver.NewServerVersionAdapter(s),
 		healthNotifier: healthNotifier,
 		cg:             s,
+		ss:             s,
+		rp:             s,
+		lr:             s,
+		cc:             s,
+		snapshotCopy:   s.Cfg.SnapshotCopy,
 	}
 	if srv.lg == nil {
 		srv.lg = zap.NewNop()
@@ -126,6 +157,63 @@ func (ms *maintenanceServer) Defragment(ctx context.Context, sr *pb.DefragmentRe
 	return &pb.DefragmentResponse{}, nil
 }
 
+func (ms *maintenanceServer) DefragmentWithProgress(sr *pb.DefragmentRequest, srv pb.Maintenance_DefragmentWithProgressServer) error {
+	ms.lg.Info("starting defragment with progress")
+	ms.healthNotifier.defragStarted()
+	defer ms.healthNotifier.defragFinished()
+
+	// The backend reports progress while holding its locks, so the callback
+	// only records the latest progress and a separate goroutine streams it.
+	var processed, total atomic.Int64
+	notifyc := make(chan struct{}, 1)
+	donec := make(chan struct{})
+	sendErrc := make(chan error, 1)
+	go func() {
+		var sendErr error
+		for {
+			select {
+			case <-notifyc:
+				if sendErr == nil {
+					sendErr = ms.sendDefragProgress(srv, processed.Load(), total.Load())
+				}
+			case <-donec:
+				sendErrc <- sendErr
+				return
+			}
+		}
+	}()
+
+	err := ms.defrag.DefragmentWithProgress(func(p, t int64) {
+		total.Store(t)
+		processed.Store(p)
+		select {
+		case notifyc <- struct{}{}:
+		default:
+		}
+	})
+	close(donec)
+	sendErr := <-sendErrc
+	if err != nil {
+		ms.lg.Warn("failed to defragment", zap.Error(err))
+		return togRPCError(err)
+	}
+	ms.lg.Info("finished defragment with progress")
+	if sendErr != nil {
+		return sendErr
+	}
+	return ms.sendDefragProgress(srv, processed.Load(), total.Load())
+}
+
+func (ms *maintenanceServer) sendDefragProgress(srv pb.Maintenance_DefragmentWithProgressServer, processed, total int64) error {
+	resp := &pb.DefragmentProgressResponse{
+		Header:         &pb.ResponseHeader{},
+		ProcessedBytes: processed,
+		TotalBytes:     total,
+	}
+	ms.hdr.fill(resp.Header)
+	return srv.Send(resp)
+}
+
 // big enough size to hold >1 OS pages in the buffer
 const snapshotSendBufferSize = 32 * 1024
 
@@ -135,7 +223,18 @@ func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance
 	if ver != nil {
 		storageVersion = ver.String()
 	}
-	snap := ms.bg.Backend().Snapshot()
+	var snap backend.Snapshot
+	if ms.snapshotCopy {
+		// the snapshot is sent from a copy of the database, so that slow
+		// clients do not hold a read transaction blocking the apply path
+		var err error
+		if snap, err = ms.bg.Backend().ConcurrentSnapshot(); err != nil {
+			ms.lg.Warn("failed to copy database for snapshot, sending it from a read transaction", zap.Error(err))
+		}
+	}
+	if snap == nil {
+		snap = ms.bg.Backend().Snapshot()
+	}
 	pr, pw := io.Pipe()
 
 	defer pr.Close()
@@ -238,6 +337,8 @@ func (ms *maintenanceServer) HashKV(ctx context.Context, r *pb.HashKVRequest) (*
 		Hash:            h.Hash,
 		CompactRevision: h.CompactRevision,
 		HashRevision:    h.Revision,
+		HashAlgorithm:   ms.hasher.HashAlgorithm(),
+		HashDigest:      h.Digest,
 	}
 	ms.hdr.fill(resp.Header)
 	return resp, nil
@@ -313,6 +414,120 @@ func (ms *maintenanceServer) Downgrade(ctx context.Context, r *pb.DowngradeReque
 	return resp, nil
 }
 
+func (ms *maintenanceServer) ForceSnapshot(ctx context.Context, r *pb.ForceSnapshotRequest) (*pb.ForceSnapshotResponse, error) {
+	ms.lg.Info("starting force snapshot")
+	index, err := ms.ss.SaveSnapshot(ctx)
+	if err != nil {
+		ms.lg.Warn("failed to force snapshot", zap.Error(err))
+		return nil, togRPCError(err)
+	}
+	ms.lg.Info("finished force snapshot", zap.Uint64("snapshot-index", index))
+	resp := &pb.ForceSnapshotResponse{Header: &pb.ResponseHeader{}, SnapshotIndex: index}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) MemberRemovePreview(ctx context.Context, r *pb.MemberRemovePreviewRequest) (*pb.MemberRemovePreviewResponse, error) {
+	p, err := ms.rp.PreviewRemoveMember(r.ID)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	resp := &pb.MemberRemovePreviewResponse{
+		Header:        &pb.ResponseHeader{},
+		Accepted:      p.Err == nil,
+		VotingMembers: int64(p.VotingMembers),
+		ActiveMembers: int64(p.ActiveMembers),
+		Quorum:        int64(p.Quorum()),
+	}
+	if p.Err != nil {
+		resp.Reason = p.Err.Error()
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) LinearizableProbe(ctx context.Context, r *pb.LinearizableProbeRequest) (*pb.LinearizableProbeResponse, error) {
+	// a read index request would wait for a leader to be elected
+	if uint64(ms.rg.Leader()) == raft.None {
+		return nil, rpctypes.ErrGRPCNoLeader
+	}
+	start := time.Now()
+	if err := ms.lr.LinearizableReadNotify(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+	resp := &pb.LinearizableProbeResponse{
+		Header:    &pb.ResponseHeader{},
+		LatencyUs: time.Since(start).Microseconds(),
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) BucketStats(ctx context.Context, r *pb.BucketStatsRequest) (*pb.BucketStatsResponse, error) {
+	be := ms.bg.Backend()
+	resp := &pb.BucketStatsResponse{Header: &pb.ResponseHeader{}}
+	for _, bkt := range schema.AllBuckets {
+		keys, bytes := be.BucketStats(bkt)
+		resp.Buckets = append(resp.Buckets, &pb.BucketStat{Name: bkt.String(), Keys: keys, Bytes: bytes})
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) SetCommitMode(ctx context.Context, r *pb.SetCommitModeRequest) (*pb.SetCommitModeResponse, error) {
+	// batched commits rely on the WAL alone for durability between commits,
+	// so switching modes must be explicitly allowed by the operator.
+	if !ms.cg.Config().ServerFeatureGate.Enabled(features.RuntimeCommitMode) {
+		return nil, rpctypes.ErrGRPCCommitModeDisabled
+	}
+	var mode backend.CommitMode
+	switch r.Mode {
+	case pb.SetCommitModeRequest_BATCHED:
+		mode = backend.CommitModeBatched
+	case pb.SetCommitModeRequest_SYNC:
+		mode = backend.CommitModeSync
+	default:
+		return nil, rpctypes.ErrGRPCInvalidCommitMode
+	}
+	prev := ms.bg.Backend().SetCommitMode(mode)
+	if prev != mode {
+		ms.lg.Warn("changed backend commit mode", zap.Stringer("previous-mode", prev), zap.Stringer("mode", mode))
+	}
+	resp := &pb.SetCommitModeResponse{Header: &pb.ResponseHeader{}, PreviousMode: pb.SetCommitModeRequest_BATCHED}
+	if prev == backend.CommitModeSync {
+		resp.PreviousMode = pb.SetCommitModeRequest_SYNC
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+// RevisionSince is open to all users, not only admins, since any watcher
+// may use it to pick its start revision.
+func (ms *maintenanceServer) RevisionSince(ctx context.Context, r *pb.RevisionSinceRequest) (*pb.RevisionSinceResponse, error) {
+	rev, err := ms.kg.KV().RevisionSince(time.Unix(0, r.Time))
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	resp := &pb.RevisionSinceResponse{Header: &pb.ResponseHeader{}, Revision: rev}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+// StoreRevision is open to all users, like any response header revision.
+func (ms *maintenanceServer) StoreRevision(ctx context.Context, r *pb.StoreRevisionRequest) (*pb.StoreRevisionResponse, error) {
+	resp := &pb.StoreRevisionResponse{Header: &pb.ResponseHeader{}}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) CancelCompaction(ctx context.Context, r *pb.CancelCompactionRequest) (*pb.CancelCompactionResponse, error) {
+	ms.lg.Info("canceling compaction")
+	ms.cc.CancelCompaction()
+	resp := &pb.CancelCompactionResponse{Header: &pb.ResponseHeader{}}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
 type authMaintenanceServer struct {
 	*maintenanceServer
 	*AuthAdmin
@@ -326,6 +541,14 @@ func (ams *authMaintenanceServer) Defragment(ctx context.Context, sr *pb.Defragm
 	return ams.maintenanceServer.Defragment(ctx, sr)
 }
 
+func (ams *authMaintenanceServer) DefragmentWithProgress(sr *pb.DefragmentRequest, srv pb.Maintenance_DefragmentWithProgressServer) error {
+	if err := ams.isPermitted(srv.Context()); err != nil {
+		return togRPCError(err)
+	}
+
+	return ams.maintenanceServer.DefragmentWithProgress(sr, srv)
+}
+
 func (ams *authMaintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance_SnapshotServer) error {
 	if err := ams.isPermitted(srv.Context()); err != nil {
 		return togRPCError(err)
@@ -372,3 +595,51 @@ func (ams *authMaintenanceServer) Downgrade(ctx context.Context, r *pb.Downgrade
 
 	return ams.maintenanceServer.Downgrade(ctx, r)
 }
+
+func (ams *authMaintenanceServer) ForceSnapshot(ctx context.Context, r *pb.ForceSnapshotRequest) (*pb.ForceSnapshotResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.ForceSnapshot(ctx, r)
+}
+
+func (ams *authMaintenanceServer) MemberRemovePreview(ctx context.Context, r *pb.MemberRemovePreviewRequest) (*pb.MemberRemovePreviewResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.MemberRemovePreview(ctx, r)
+}
+
+func (ams *authMaintenanceServer) LinearizableProbe(ctx context.Context, r *pb.LinearizableProbeRequest) (*pb.LinearizableProbeResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.LinearizableProbe(ctx, r)
+}
+
+func (ams *authMaintenanceServer) BucketStats(ctx context.Context, r *pb.BucketStatsRequest) (*pb.BucketStatsResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.BucketStats(ctx, r)
+}
+
+func (ams *authMaintenanceServer) SetCommitMode(ctx context.Context, r *pb.SetCommitModeRequest) (*pb.SetCommitModeResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.SetCommitMode(ctx, r)
+}
+
+func (ams *authMaintenanceServer) CancelCompaction(ctx context.Context, r *pb.CancelCompactionRequest) (*pb.CancelCompactionResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.CancelCompaction(ctx, r)
+}
@@ -42,11 +42,12 @@ var toGRPCErrorMap = map[error]error{
 	errors.ErrNotEnoughStartedMembers: rpctypes.ErrMemberNotEnoughStarted,
 	errors.ErrLearnerNotReady:         rpctypes.ErrGRPCLearnerNotReady,
 
-	mvcc.ErrCompacted:         rpctypes.ErrGRPCCompacted,
-	mvcc.ErrFutureRev:         rpctypes.ErrGRPCFutureRev,
-	errors.ErrRequestTooLarge: rpctypes.ErrGRPCRequestTooLarge,
-	errors.ErrNoSpace:         rpctypes.ErrGRPCNoSpace,
-	errors.ErrTooManyRequests: rpctypes.ErrTooManyRequests,
+	mvcc.ErrCompacted:           rpctypes.ErrGRPCCompacted,
+	mvcc.ErrFutureRev:           rpctypes.ErrGRPCFutureRev,
+	mvcc.ErrRevisionTimeUnknown: rpctypes.ErrGRPCRevisionTimeUnknown,
+	errors.ErrRequestTooLarge:   rpctypes.ErrGRPCRequestTooLarge,
+	errors.ErrNoSpace:           rpctypes.ErrGRPCNoSpace,
+	errors.ErrTooManyRequests:   rpctypes.ErrTooManyRequests,
 
 	errors.ErrNoLeader:                   rpctypes.ErrGRPCNoLeader,
 	errors.ErrNotLeader:                  rpctypes.ErrGRPCNotLeader,
@@ -59,6 +60,8 @@ var toGRPCErrorMap = map[error]error{
 	errors.ErrUnhealthy:                  rpctypes.ErrGRPCUnhealthy,
 	errors.ErrKeyNotFound:                rpctypes.ErrGRPCKeyNotFound,
 	errors.ErrCorrupt:                    rpctypes.ErrGRPCCorrupt,
+	errors.ErrReadOnly:                   rpctypes.ErrGRPCReadOnly,
+	errors.ErrReadOnlyUnsupported:        rpctypes.ErrGRPCReadOnlyUnsupported,
 	errors.ErrBadLeaderTransferee:        rpctypes.ErrGRPCBadLeaderTransferee,
 
 	errors.ErrClusterVersionUnavailable:      rpctypes.ErrGRPCClusterVersionUnavailable,
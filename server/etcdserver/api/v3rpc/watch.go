@@ -25,10 +25,15 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	// registers the gzip compressor clients may request for watch streams
+	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"go.etcd.io/etcd/client/pkg/v3/types"
 	"go.etcd.io/etcd/client/pkg/v3/verify"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
@@ -36,6 +41,7 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver"
 	"go.etcd.io/etcd/server/v3/etcdserver/apply"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/raft/v3"
 )
 
 const minWatchProgressInterval = 100 * time.Millisecond
@@ -51,6 +57,8 @@ type watchServer struct {
 	sg        apply.RaftStatusGetter
 	watchable mvcc.WatchableKV
 	ag        AuthGetter
+
+	quota *watchQuota
 }
 
 // NewWatchServer returns a new watch server.
@@ -66,6 +74,8 @@ func NewWatchServer(s *etcdserver.EtcdServer) pb.WatchServer {
 		sg:        s,
 		watchable: s.Watchable(),
 		ag:        s,
+
+		quota: newWatchQuota(s.Cfg.MaxWatchesPerUser),
 	}
 	if srv.lg == nil {
 		srv.lg = zap.NewNop()
@@ -134,20 +144,43 @@ type serverWatchStream struct {
 	sg        apply.RaftStatusGetter
 	watchable mvcc.WatchableKV
 	ag        AuthGetter
+	quota     *watchQuota
 
 	gRPCStream  pb.Watch_WatchServer
 	watchStream mvcc.WatchStream
 	ctrlStream  chan *pb.WatchResponse
 
-	// mu protects progress, prevKV, fragment
+	// mu protects progress, progressInterval, progressDue, prevKV, fragment,
+	// rawEvents, staleOK, authRevision, maxEventRate, projection, users
 	mu sync.RWMutex
 	// tracks the watchID that stream might need to send progress to
 	// TODO: combine progress and prevKV into a single struct?
 	progress map[mvcc.WatchID]bool
+	// records the progress interval of watch IDs that override the stream's
+	// interval, and when their next progress check is due
+	progressInterval map[mvcc.WatchID]time.Duration
+	progressDue      map[mvcc.WatchID]time.Time
 	// record watch IDs that need return previous key-value pair
 	prevKV map[mvcc.WatchID]bool
 	// records fragmented watch IDs
 	fragment map[mvcc.WatchID]bool
+	// fragmentAll is set if the client reassembles fragmented responses, so
+	// that all watchers of the stream are fragmented
+	fragmentAll bool
+	// records watch IDs that receive their events encoded
+	rawEvents map[mvcc.WatchID]bool
+	// records watch IDs that accept responses while the member has no leader
+	staleOK map[mvcc.WatchID]bool
+	// records watch IDs that report auth revision changes instead of events
+	authRevision map[mvcc.WatchID]bool
+	// records the maximum number of events per second of rate limited
+	// watch IDs
+	maxEventRate map[mvcc.WatchID]int64
+	// records the field path values are projected to for watch IDs with a
+	// value projection
+	projection map[mvcc.WatchID][]string
+	// records the user whose watch quota counts watch IDs
+	users map[mvcc.WatchID]string
 
 	// closec indicates the stream is closed.
 	closec chan struct{}
@@ -168,18 +201,40 @@ func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
 		sg:        ws.sg,
 		watchable: ws.watchable,
 		ag:        ws.ag,
+		quota:     ws.quota,
 
 		gRPCStream:  stream,
 		watchStream: ws.watchable.NewWatchStream(),
 		// chan for sending control response like watcher created and canceled.
 		ctrlStream: make(chan *pb.WatchResponse, ctrlStreamBufLen),
 
-		progress: make(map[mvcc.WatchID]bool),
-		prevKV:   make(map[mvcc.WatchID]bool),
-		fragment: make(map[mvcc.WatchID]bool),
+		progress:  make(map[mvcc.WatchID]bool),
+		prevKV:    make(map[mvcc.WatchID]bool),
+		fragment:  make(map[mvcc.WatchID]bool),
+		rawEvents: make(map[mvcc.WatchID]bool),
+		staleOK:   make(map[mvcc.WatchID]bool),
+
+		progressInterval: make(map[mvcc.WatchID]time.Duration),
+		progressDue:      make(map[mvcc.WatchID]time.Time),
+		authRevision:     make(map[mvcc.WatchID]bool),
+		maxEventRate:     make(map[mvcc.WatchID]int64),
+		projection:       make(map[mvcc.WatchID][]string),
+		users:            make(map[mvcc.WatchID]string),
 
 		closec: make(chan struct{}),
 	}
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		if vs := md.Get(rpctypes.MetadataWatchFragmentKey); len(vs) > 0 && vs[0] == rpctypes.MetadataWatchFragment {
+			sws.fragmentAll = true
+		}
+		if names := md.Get(rpctypes.MetadataWatchCompressionKey); len(names) > 0 {
+			// fails unless the compressor is registered and the client
+			// accepts it, leaving the responses uncompressed
+			if err := grpc.SetSendCompressor(stream.Context(), names[0]); err != nil {
+				sws.lg.Debug("failed to compress watch stream", zap.String("compressor", names[0]), zap.Error(err))
+			}
+		}
+	}
 
 	sws.wg.Add(1)
 	go func() {
@@ -230,16 +285,51 @@ func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
 	return err
 }
 
-func (sws *serverWatchStream) isWatchPermitted(wcr *pb.WatchCreateRequest) error {
+// isWatchPermitted returns the auth info of the stream if it may create the
+// watch; the user is empty if auth is disabled.
+func (sws *serverWatchStream) isWatchPermitted(wcr *pb.WatchCreateRequest) (*auth.AuthInfo, error) {
 	authInfo, err := sws.ag.AuthInfoFromCtx(sws.gRPCStream.Context())
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if authInfo == nil {
 		// if auth is enabled, IsRangePermitted() can cause an error
 		authInfo = &auth.AuthInfo{}
 	}
-	return sws.ag.AuthStore().IsRangePermitted(authInfo, wcr.Key, wcr.RangeEnd)
+	if wcr.AuthRevisionNotify {
+		// the auth revision is not protected, as for AuthStatus
+		return authInfo, nil
+	}
+	return authInfo, sws.ag.AuthStore().IsRangePermitted(authInfo, wcr.Key, wcr.RangeEnd)
+}
+
+// forgetWatch drops the options of a canceled watch id and uncounts it from
+// the quota of its user.
+func (sws *serverWatchStream) forgetWatch(id mvcc.WatchID) {
+	sws.mu.Lock()
+	delete(sws.progress, id)
+	delete(sws.progressInterval, id)
+	delete(sws.progressDue, id)
+	delete(sws.prevKV, id)
+	delete(sws.fragment, id)
+	delete(sws.rawEvents, id)
+	delete(sws.staleOK, id)
+	delete(sws.authRevision, id)
+	delete(sws.maxEventRate, id)
+	delete(sws.projection, id)
+	sws.mu.Unlock()
+	sws.releaseWatch(id)
+}
+
+// releaseWatch uncounts the watch id from the quota of its user, if counted.
+func (sws *serverWatchStream) releaseWatch(id mvcc.WatchID) {
+	sws.mu.Lock()
+	user, ok := sws.users[id]
+	delete(sws.users, id)
+	sws.mu.Unlock()
+	if ok {
+		sws.quota.release(user)
+	}
 }
 
 func (sws *serverWatchStream) recvLoop() error {
@@ -259,6 +349,16 @@ func (sws *serverWatchStream) recvLoop() error {
 			}
 
 			creq := uv.CreateRequest
+			if creq.AuthRevisionNotify {
+				// auth revision watchers are backed by a watcher that
+				// filters out all events, so they share the ID space and
+				// cancellation of key watchers
+				creq = &pb.WatchCreateRequest{
+					WatchId:            creq.WatchId,
+					Filters:            []pb.WatchCreateRequest_FilterType{pb.WatchCreateRequest_NOPUT, pb.WatchCreateRequest_NODELETE},
+					AuthRevisionNotify: true,
+				}
+			}
 			if len(creq.Key) == 0 {
 				// \x00 is the smallest key
 				creq.Key = []byte{0}
@@ -289,7 +389,28 @@ func (sws *serverWatchStream) recvLoop() error {
 				}
 			}
 
-			err := sws.isWatchPermitted(creq)
+			var projection []string
+			if creq.ValueProjection != "" {
+				var ok bool
+				if projection, ok = parseValueProjection(creq.ValueProjection); !ok {
+					wr := &pb.WatchResponse{
+						Header:       sws.newResponseHeader(sws.watchStream.Rev()),
+						WatchId:      clientv3.InvalidWatchID,
+						Canceled:     true,
+						Created:      true,
+						CancelReason: rpctypes.ErrGRPCInvalidProjection.Error(),
+					}
+
+					select {
+					case sws.ctrlStream <- wr:
+						continue
+					case <-sws.closec:
+						return nil
+					}
+				}
+			}
+
+			authInfo, err := sws.isWatchPermitted(creq)
 			if err != nil {
 				var cancelReason string
 				switch {
@@ -322,14 +443,39 @@ func (sws *serverWatchStream) recvLoop() error {
 				}
 			}
 
+			user := authInfo.Username
+			if !sws.quota.acquire(user) {
+				sws.lg.Debug("rejected watch over the watch quota of user", zap.String("user-name", user))
+				wr := &pb.WatchResponse{
+					Header:       sws.newResponseHeader(sws.watchStream.Rev()),
+					WatchId:      clientv3.InvalidWatchID,
+					Canceled:     true,
+					Created:      true,
+					CancelReason: rpctypes.ErrGRPCWatchQuotaExceeded.Error(),
+				}
+
+				select {
+				case sws.ctrlStream <- wr:
+					continue
+				case <-sws.closec:
+					return nil
+				}
+			}
+
 			filters := FiltersFromRequest(creq)
 			ctx, _ := traceutil.Tracer.Start(sws.gRPCStream.Context(), "watch", trace.WithAttributes(
 				attribute.String("key", string(creq.Key)),
 				attribute.String("range_end", string(creq.RangeEnd)),
 				attribute.Int64("start_rev", creq.StartRevision),
 				attribute.Bool("progress_notify", creq.ProgressNotify),
+				attribute.Int64("progress_notify_interval_ms", creq.ProgressNotifyIntervalMs),
 				attribute.Bool("prev_kv", creq.PrevKv),
 				attribute.Bool("fragment", creq.Fragment),
+				attribute.Bool("raw_events", creq.RawEvents),
+				attribute.Bool("stale_ok", creq.StaleOk),
+				attribute.Bool("auth_revision_notify", creq.AuthRevisionNotify),
+				attribute.Int64("max_event_rate", creq.MaxEventRate),
+				attribute.String("value_projection", creq.ValueProjection),
 			))
 
 			id, err := sws.watchStream.Watch(ctx, mvcc.WatchID(creq.WatchId), creq.Key, creq.RangeEnd, creq.StartRevision, filters...)
@@ -337,15 +483,44 @@ func (sws *serverWatchStream) recvLoop() error {
 				sws.mu.Lock()
 				if creq.ProgressNotify {
 					sws.progress[id] = true
+					if creq.ProgressNotifyIntervalMs > 0 {
+						interval := max(time.Duration(creq.ProgressNotifyIntervalMs)*time.Millisecond, minWatchProgressInterval)
+						sws.progressInterval[id] = interval
+						sws.progressDue[id] = time.Now().Add(interval)
+					}
 				}
 				if creq.PrevKv {
 					sws.prevKV[id] = true
 				}
-				if creq.Fragment {
+				if creq.Fragment || sws.fragmentAll {
 					sws.fragment[id] = true
 				}
+				if creq.RawEvents {
+					sws.rawEvents[id] = true
+				}
+				if creq.StaleOk {
+					sws.staleOK[id] = true
+				}
+				if creq.AuthRevisionNotify {
+					sws.authRevision[id] = true
+				}
+				if creq.MaxEventRate > 0 {
+					sws.maxEventRate[id] = creq.MaxEventRate
+				}
+				if projection != nil {
+					sws.projection[id] = projection
+				}
+				switch {
+				case !sws.quota.enabled(user):
+				case sws.users == nil:
+					// the stream was closed while the watch was created
+					sws.quota.release(user)
+				default:
+					sws.users[id] = user
+				}
 				sws.mu.Unlock()
 			} else {
+				sws.quota.release(user)
 				id = clientv3.InvalidWatchID
 			}
 
@@ -357,6 +532,11 @@ func (sws *serverWatchStream) recvLoop() error {
 			}
 			if err != nil {
 				wr.CancelReason = err.Error()
+			} else {
+				wr.CreatedRevision = sws.watchStream.CreatedRev(id)
+				if creq.AuthRevisionNotify {
+					wr.AuthRevision = sws.ag.AuthStore().Revision()
+				}
 			}
 			select {
 			case sws.ctrlStream <- wr:
@@ -380,17 +560,17 @@ func (sws *serverWatchStream) recvLoop() error {
 						return nil
 					}
 
-					sws.mu.Lock()
-					delete(sws.progress, mvcc.WatchID(id))
-					delete(sws.prevKV, mvcc.WatchID(id))
-					delete(sws.fragment, mvcc.WatchID(id))
-					sws.mu.Unlock()
+					sws.forgetWatch(mvcc.WatchID(id))
 				}
 			}
 		case *pb.WatchRequest_ProgressRequest:
 			if uv.ProgressRequest != nil {
 				sws.mu.Lock()
-				sws.watchStream.RequestProgressAll()
+				if uv.ProgressRequest.Scoped {
+					sws.watchStream.RequestProgress(mvcc.WatchID(uv.ProgressRequest.WatchId))
+				} else {
+					sws.watchStream.RequestProgressAll()
+				}
 				sws.mu.Unlock()
 			}
 		default:
@@ -412,8 +592,122 @@ func (sws *serverWatchStream) sendLoop() {
 	interval := GetProgressReportInterval()
 	progressTicker := time.NewTicker(interval)
 
+	// watchers with their own progress interval are checked when the
+	// earliest of them is due
+	var (
+		progressTimer *time.Timer
+		progressc     <-chan time.Time
+	)
+	resetProgressTimer := func() {
+		var next time.Time
+		sws.mu.RLock()
+		for _, due := range sws.progressDue {
+			if next.IsZero() || due.Before(next) {
+				next = due
+			}
+		}
+		sws.mu.RUnlock()
+		switch {
+		case next.IsZero():
+			if progressTimer != nil {
+				progressTimer.Stop()
+			}
+			progressc = nil
+		case progressTimer == nil:
+			progressTimer = time.NewTimer(time.Until(next))
+			progressc = progressTimer.C
+		default:
+			progressTimer.Reset(time.Until(next))
+			progressc = progressTimer.C
+		}
+	}
+
+	authRevisionc := sws.ag.AuthRevisionNotify()
+
+	// rate limited watchers hold back their events, which are sent when the
+	// earliest of them is due
+	limiters := make(map[mvcc.WatchID]*watchRateLimiter)
+	var (
+		rateTimer *time.Timer
+		ratec     <-chan time.Time
+	)
+	resetRateTimer := func() {
+		var next time.Time
+		for _, l := range limiters {
+			if l.pending() && (next.IsZero() || l.next().Before(next)) {
+				next = l.next()
+			}
+		}
+		switch {
+		case next.IsZero():
+			if rateTimer != nil {
+				rateTimer.Stop()
+			}
+			ratec = nil
+		case rateTimer == nil:
+			rateTimer = time.NewTimer(time.Until(next))
+			ratec = rateTimer.C
+		default:
+			rateTimer.Reset(time.Until(next))
+			ratec = rateTimer.C
+		}
+	}
+	// heldWatchers returns the rate limited watchers holding events, or nil.
+	heldWatchers := func() map[mvcc.WatchID]struct{} {
+		var held map[mvcc.WatchID]struct{}
+		for id, l := range limiters {
+			if l.pending() {
+				if held == nil {
+					held = make(map[mvcc.WatchID]struct{})
+				}
+				held[id] = struct{}{}
+			}
+		}
+		return held
+	}
+	// sendHeld sends the held events of a rate limited watcher that are due,
+	// or all of them if all is set.
+	sendHeld := func(id mvcc.WatchID, l *watchRateLimiter, all bool) error {
+		var (
+			evs []*mvccpb.Event
+			rev int64
+		)
+		if all {
+			evs, rev = l.takeAll(time.Now())
+		} else {
+			evs, rev = l.take(time.Now())
+		}
+		if len(evs) == 0 {
+			return nil
+		}
+		sws.mu.RLock()
+		staleOK := sws.staleOK[id]
+		sws.mu.RUnlock()
+		wr := &pb.WatchResponse{
+			Header:  sws.newResponseHeader(rev),
+			WatchId: int64(id),
+			Events:  evs,
+			Stale:   staleOK && sws.sg.Leader() == types.ID(raft.None),
+		}
+		if err := sws.sendEvents(wr); err != nil {
+			return err
+		}
+		sws.mu.Lock()
+		if sws.progress[id] {
+			sws.progress[id] = false
+		}
+		sws.mu.Unlock()
+		return nil
+	}
+
 	defer func() {
 		progressTicker.Stop()
+		if progressTimer != nil {
+			progressTimer.Stop()
+		}
+		if rateTimer != nil {
+			rateTimer.Stop()
+		}
 		// drain the chan to clean up pending events
 		for ws := range sws.watchStream.Chan() {
 			mvcc.ReportEventReceived(len(ws.Events))
@@ -439,25 +733,41 @@ func (sws *serverWatchStream) sendLoop() {
 			events := make([]*mvccpb.Event, len(evs))
 			sws.mu.RLock()
 			needPrevKV := sws.prevKV[wresp.WatchID]
+			fragmented := sws.fragment[wresp.WatchID]
+			staleOK := sws.staleOK[wresp.WatchID]
+			maxEventRate := sws.maxEventRate[wresp.WatchID]
+			projection := sws.projection[wresp.WatchID]
 			sws.mu.RUnlock()
 			for i := range evs {
 				events[i] = &evs[i]
-				if needPrevKV && !IsCreateEvent(evs[i]) {
+				if needPrevKV && !IsCreateEvent(evs[i]) && evs[i].PrevKv == nil {
 					opt := mvcc.RangeOptions{Rev: evs[i].Kv.ModRevision - 1}
 					r, err := sws.watchable.Range(context.TODO(), evs[i].Kv.Key, nil, opt)
 					if err == nil && len(r.KVs) != 0 {
 						events[i].PrevKv = &(r.KVs[0])
 					}
 				}
+				if projection != nil {
+					events[i].Kv = projectKeyValue(events[i].Kv, projection)
+					events[i].PrevKv = projectKeyValue(events[i].PrevKv, projection)
+				}
 			}
 
 			canceled := wresp.CompactRevision != 0
+			if canceled {
+				// a compacted watcher no longer counts against the quota
+				sws.releaseWatch(wresp.WatchID)
+			}
 			wr := &pb.WatchResponse{
 				Header:          sws.newResponseHeader(wresp.Revision),
 				WatchId:         int64(wresp.WatchID),
 				Events:          events,
 				CompactRevision: wresp.CompactRevision,
 				Canceled:        canceled,
+				Stale:           staleOK && sws.sg.Leader() == types.ID(raft.None),
+				// responses the store split to honor MaxWatchResponseBytes
+				// are only marked for watchers that reassemble fragments
+				Fragment: wresp.Fragment && fragmented,
 			}
 
 			// Progress notifications can have WatchID -1
@@ -473,16 +783,57 @@ func (sws *serverWatchStream) sendLoop() {
 
 			mvcc.ReportEventReceived(len(evs))
 
-			sws.mu.RLock()
-			fragmented, ok := sws.fragment[wresp.WatchID]
-			sws.mu.RUnlock()
-
+			// events of rate limited watchers are sent by their limiter, and
+			// no other response may overtake the events they hold
 			var serr error
-			// gofail: var beforeSendWatchResponse struct{}
-			if !fragmented && !ok {
-				serr = sws.gRPCStream.Send(wr)
-			} else {
-				serr = sendFragments(wr, sws.maxRequestBytes, sws.gRPCStream.Send)
+			sendResp := true
+			if wresp.WatchID == clientv3.InvalidWatchID {
+				if held := heldWatchers(); held != nil {
+					// a stream wide progress would announce a revision the
+					// held events have not reached, so only the watchers
+					// without held events are notified, one by one
+					sendResp = false
+					for id := range ids {
+						if _, ok := held[id]; ok {
+							continue
+						}
+						pwr := *wr
+						pwr.WatchId = int64(id)
+						if serr = sws.send(&pwr); serr != nil {
+							break
+						}
+					}
+				}
+			} else if l := limiters[wresp.WatchID]; l != nil || maxEventRate > 0 {
+				if l == nil {
+					l = newWatchRateLimiter(maxEventRate, time.Now())
+					limiters[wresp.WatchID] = l
+				}
+				switch {
+				case canceled:
+					serr = sendHeld(wresp.WatchID, l, true)
+					delete(limiters, wresp.WatchID)
+				case len(events) != 0:
+					sendResp = false
+					if !l.add(events, wresp.Revision) {
+						// the watcher falls behind the watched keys for
+						// good; holding its events would grow without bound
+						delete(limiters, wresp.WatchID)
+						delete(ids, wresp.WatchID)
+						serr = sws.cancelRateExceeded(wresp.WatchID)
+						break
+					}
+					serr = sendHeld(wresp.WatchID, l, false)
+				default:
+					// held events carry a later revision than the progress
+					sendResp = !l.pending()
+				}
+				resetRateTimer()
+			}
+
+			if serr == nil && sendResp {
+				// gofail: var beforeSendWatchResponse struct{}
+				serr = sws.sendEvents(wr)
 			}
 
 			if serr != nil {
@@ -519,11 +870,18 @@ func (sws *serverWatchStream) sendLoop() {
 
 			// track id creation
 			wid := mvcc.WatchID(c.WatchId)
+			if c.Created || c.Canceled {
+				resetProgressTimer()
+			}
 
 			verify.Assert(!(c.Canceled && c.Created) || wid == clientv3.InvalidWatchID, "unexpected watchId: %d, wanted: %d, since both 'Canceled' and 'Created' are true", wid, clientv3.InvalidWatchID)
 
 			if c.Canceled && wid != clientv3.InvalidWatchID {
 				delete(ids, wid)
+				if _, ok := limiters[wid]; ok {
+					delete(limiters, wid)
+					resetRateTimer()
+				}
 				continue
 			}
 			if c.Created {
@@ -531,7 +889,7 @@ func (sws *serverWatchStream) sendLoop() {
 				ids[wid] = struct{}{}
 				for _, v := range pending[wid] {
 					mvcc.ReportEventReceived(len(v.Events))
-					if err := sws.gRPCStream.Send(v); err != nil {
+					if err := sws.send(v); err != nil {
 						if isClientCtxErr(sws.gRPCStream.Context().Err(), err) {
 							sws.lg.Debug("failed to send pending watch response to gRPC stream", zap.Error(err))
 						} else {
@@ -544,9 +902,45 @@ func (sws *serverWatchStream) sendLoop() {
 				delete(pending, wid)
 			}
 
+		case <-authRevisionc:
+			// renew the chan before reading the revision so that no
+			// change is missed
+			authRevisionc = sws.ag.AuthRevisionNotify()
+			rev := sws.ag.AuthStore().Revision()
+			sws.mu.RLock()
+			wids := make([]mvcc.WatchID, 0, len(sws.authRevision))
+			for id := range sws.authRevision {
+				wids = append(wids, id)
+			}
+			sws.mu.RUnlock()
+			for _, id := range wids {
+				wr := &pb.WatchResponse{
+					Header:       sws.newResponseHeader(sws.watchStream.Rev()),
+					WatchId:      int64(id),
+					AuthRevision: rev,
+				}
+				if _, okID := ids[id]; !okID {
+					// buffer if id not yet announced
+					pending[id] = append(pending[id], wr)
+					continue
+				}
+				if err := sws.gRPCStream.Send(wr); err != nil {
+					if isClientCtxErr(sws.gRPCStream.Context().Err(), err) {
+						sws.lg.Debug("failed to send auth revision response to gRPC stream", zap.Error(err))
+					} else {
+						sws.lg.Warn("failed to send auth revision response to gRPC stream", zap.Error(err))
+						streamFailures.WithLabelValues("send", "watch").Inc()
+					}
+					return
+				}
+			}
+
 		case <-progressTicker.C:
 			sws.mu.Lock()
 			for id, ok := range sws.progress {
+				if _, custom := sws.progressInterval[id]; custom {
+					continue
+				}
 				if ok {
 					sws.watchStream.RequestProgress(id)
 				}
@@ -554,6 +948,35 @@ func (sws *serverWatchStream) sendLoop() {
 			}
 			sws.mu.Unlock()
 
+		case <-ratec:
+			for id, l := range limiters {
+				if err := sendHeld(id, l, false); err != nil {
+					if isClientCtxErr(sws.gRPCStream.Context().Err(), err) {
+						sws.lg.Debug("failed to send held watch response to gRPC stream", zap.Error(err))
+					} else {
+						sws.lg.Warn("failed to send held watch response to gRPC stream", zap.Error(err))
+						streamFailures.WithLabelValues("send", "watch").Inc()
+					}
+					return
+				}
+			}
+			resetRateTimer()
+
+		case now := <-progressc:
+			sws.mu.Lock()
+			for id, due := range sws.progressDue {
+				if now.Before(due) {
+					continue
+				}
+				if sws.progress[id] {
+					sws.watchStream.RequestProgress(id)
+				}
+				sws.progress[id] = true
+				sws.progressDue[id] = now.Add(sws.progressInterval[id])
+			}
+			sws.mu.Unlock()
+			resetProgressTimer()
+
 		case <-sws.closec:
 			return
 		}
@@ -564,6 +987,34 @@ func IsCreateEvent(e mvccpb.Event) bool {
 	return e.Type == mvccpb.PUT && e.Kv.CreateRevision == e.Kv.ModRevision
 }
 
+// send sends an event response to the gRPC stream, encoding its events if
+// the watcher requested raw events.
+func (sws *serverWatchStream) send(wr *pb.WatchResponse) error {
+	sws.mu.RLock()
+	raw := sws.rawEvents[mvcc.WatchID(wr.WatchId)]
+	sws.mu.RUnlock()
+	if raw && len(wr.Events) != 0 {
+		data, err := (&pb.WatchResponse{Events: wr.Events}).Marshal()
+		if err != nil {
+			return err
+		}
+		wr.Events, wr.RawEvents = nil, data
+	}
+	return sws.gRPCStream.Send(wr)
+}
+
+// sendEvents sends an event response to the gRPC stream, split into
+// fragments if the watcher requested fragmentation.
+func (sws *serverWatchStream) sendEvents(wr *pb.WatchResponse) error {
+	sws.mu.RLock()
+	fragmented := sws.fragment[mvcc.WatchID(wr.WatchId)]
+	sws.mu.RUnlock()
+	if !fragmented {
+		return sws.send(wr)
+	}
+	return sendFragments(wr, sws.maxRequestBytes, sws.send)
+}
+
 func sendFragments(
 	wr *pb.WatchResponse,
 	maxRequestBytes uint,
@@ -591,23 +1042,47 @@ func sendFragments(
 			idx++
 		}
 		if idx == len(wr.Events) {
-			// last response has no more fragment
-			cur.Fragment = false
+			// last response has no more fragment, unless the events of wr
+			// continue in the next response
+			cur.Fragment = wr.Fragment
 		}
 		if err := sendFunc(&cur); err != nil {
 			return err
 		}
-		if !cur.Fragment {
+		if idx == len(wr.Events) {
 			break
 		}
 	}
 	return nil
 }
 
+// cancelRateExceeded cancels a rate limited watcher holding too many events,
+// unless the client canceled it already.
+func (sws *serverWatchStream) cancelRateExceeded(id mvcc.WatchID) error {
+	if err := sws.watchStream.Cancel(id); err != nil {
+		return nil
+	}
+	sws.lg.Debug("canceled watch holding too many events over its event rate", zap.Int64("watch-id", int64(id)))
+	sws.forgetWatch(id)
+	return sws.send(&pb.WatchResponse{
+		Header:       sws.newResponseHeader(sws.watchStream.Rev()),
+		WatchId:      int64(id),
+		Canceled:     true,
+		CancelReason: rpctypes.ErrGRPCWatchRateExceeded.Error(),
+	})
+}
+
 func (sws *serverWatchStream) close() {
 	sws.watchStream.Close()
 	close(sws.closec)
 	sws.wg.Wait()
+
+	sws.mu.Lock()
+	for _, user := range sws.users {
+		sws.quota.release(user)
+	}
+	sws.users = nil
+	sws.mu.Unlock()
 }
 
 func (sws *serverWatchStream) newResponseHeader(rev int64) *pb.ResponseHeader {
@@ -627,6 +1102,10 @@ func filterNoPut(e mvccpb.Event) bool {
 	return e.Type == mvccpb.PUT
 }
 
+func filterNoModify(e mvccpb.Event) bool {
+	return e.Type == mvccpb.PUT && e.Kv.CreateRevision != e.Kv.ModRevision
+}
+
 // FiltersFromRequest returns "mvcc.FilterFunc" from a given watch create request.
 func FiltersFromRequest(creq *pb.WatchCreateRequest) []mvcc.FilterFunc {
 	filters := make([]mvcc.FilterFunc, 0, len(creq.Filters))
@@ -636,6 +1115,8 @@ func FiltersFromRequest(creq *pb.WatchCreateRequest) []mvcc.FilterFunc {
 			filters = append(filters, filterNoPut)
 		case pb.WatchCreateRequest_NODELETE:
 			filters = append(filters, filterNoDelete)
+		case pb.WatchCreateRequest_NOMODIFY:
+			filters = append(filters, filterNoModify)
 		default:
 		}
 	}
@@ -0,0 +1,69 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+// parseValueProjection splits the dotted path of a value projection into
+// the names of the nested fields it selects. It returns false if a name is
+// empty.
+func parseValueProjection(path string) ([]string, bool) {
+	names := strings.Split(path, ".")
+	for _, name := range names {
+		if name == "" {
+			return nil, false
+		}
+	}
+	return names, true
+}
+
+// projectValue returns the compacted JSON encoding of the field at path in
+// value, or nil if value is not a JSON object with such a field.
+func projectValue(value []byte, path []string) []byte {
+	field := json.RawMessage(value)
+	for _, name := range path {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(field, &obj); err != nil {
+			return nil
+		}
+		var ok bool
+		if field, ok = obj[name]; !ok {
+			return nil
+		}
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, field); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// projectKeyValue returns a copy of kv whose value is projected to path.
+// The key-values of events may be shared between watchers, so they are not
+// modified in place.
+func projectKeyValue(kv *mvccpb.KeyValue, path []string) *mvccpb.KeyValue {
+	if kv == nil {
+		return nil
+	}
+	projected := *kv
+	projected.Value = projectValue(kv.Value, path)
+	return &projected
+}
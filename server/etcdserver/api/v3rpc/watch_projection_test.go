@@ -0,0 +1,53 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValueProjection(t *testing.T) {
+	path, ok := parseValueProjection("spec.replicas")
+	require.True(t, ok)
+	require.Equal(t, []string{"spec", "replicas"}, path)
+
+	for _, invalid := range []string{".spec", "spec.", "spec..replicas"} {
+		_, ok = parseValueProjection(invalid)
+		require.Falsef(t, ok, "path %q", invalid)
+	}
+}
+
+func TestProjectValue(t *testing.T) {
+	tcs := []struct {
+		name  string
+		value string
+		path  []string
+		want  []byte
+	}{
+		{name: "top level", value: `{"a": 1, "b": 2}`, path: []string{"b"}, want: []byte(`2`)},
+		{name: "nested", value: `{"a": {"b": {"c": "x"}}}`, path: []string{"a", "b"}, want: []byte(`{"c":"x"}`)},
+		{name: "compacted", value: `{"a": [1, 2,  3]}`, path: []string{"a"}, want: []byte(`[1,2,3]`)},
+		{name: "missing", value: `{"a": 1}`, path: []string{"b"}},
+		{name: "not an object", value: `{"a": 1}`, path: []string{"a", "b"}},
+		{name: "not json", value: `a=1`, path: []string{"a"}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, projectValue([]byte(tc.value), tc.path))
+		})
+	}
+}
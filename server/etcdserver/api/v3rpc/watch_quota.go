@@ -0,0 +1,62 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import "sync"
+
+// watchQuota counts the watches of authenticated users across the watch
+// streams of a member, and refuses the watches of a user beyond its limit.
+type watchQuota struct {
+	// limit is the maximum number of watches per user; 0 is unlimited.
+	limit int
+
+	mu      sync.Mutex
+	watches map[string]int
+}
+
+func newWatchQuota(limit int) *watchQuota {
+	return &watchQuota{limit: limit, watches: make(map[string]int)}
+}
+
+// enabled reports whether the watches of user are counted.
+func (q *watchQuota) enabled(user string) bool {
+	return q.limit > 0 && user != ""
+}
+
+// acquire counts a new watch of user, unless user already has limit watches.
+func (q *watchQuota) acquire(user string) bool {
+	if !q.enabled(user) {
+		return true
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.watches[user] >= q.limit {
+		return false
+	}
+	q.watches[user]++
+	return true
+}
+
+// release uncounts a watch of user acquired before.
+func (q *watchQuota) release(user string) {
+	if !q.enabled(user) {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.watches[user]--; q.watches[user] <= 0 {
+		delete(q.watches, user)
+	}
+}
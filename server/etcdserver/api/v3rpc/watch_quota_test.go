@@ -0,0 +1,47 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchQuota(t *testing.T) {
+	q := newWatchQuota(2)
+	assert.True(t, q.acquire("alice"))
+	assert.True(t, q.acquire("alice"))
+	assert.False(t, q.acquire("alice"))
+	assert.True(t, q.acquire("bob"))
+
+	q.release("alice")
+	assert.True(t, q.acquire("alice"))
+	assert.False(t, q.acquire("alice"))
+
+	// watches without a user, when auth is disabled, are not counted
+	for range 3 {
+		assert.True(t, q.acquire(""))
+	}
+
+	q.release("bob")
+	assert.NotContains(t, q.watches, "bob")
+
+	unlimited := newWatchQuota(0)
+	for range 3 {
+		assert.True(t, unlimited.acquire("alice"))
+	}
+	assert.Empty(t, unlimited.watches)
+}
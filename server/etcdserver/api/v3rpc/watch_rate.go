@@ -0,0 +1,157 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"math"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+// maxHeldSeconds is how many seconds worth of events a watchRateLimiter
+// holds at most.
+const maxHeldSeconds = 60
+
+// watchRateLimiter holds back the events of a watcher beyond its maximum
+// event rate until they can be sent. While held, a put is dropped once a
+// later event of the same key is held; deletes are never dropped.
+type watchRateLimiter struct {
+	// rate is the number of events per second that may be sent. Up to one
+	// second worth of events may be sent at once.
+	rate float64
+	// tokens is the number of events that may be sent as of last. It is
+	// negative if more were sent to keep the events of a revision together.
+	tokens float64
+	last   time.Time
+
+	// events holds the events not yet sent, in revision order; the events of
+	// dropped puts are nil.
+	events []*mvccpb.Event
+	// held is the number of events not dropped in events, and maxHeld the
+	// number of events that may be held.
+	held    int
+	maxHeld int
+	// puts maps a key to the index in events of its held put, if any.
+	puts map[string]int
+	// rev is the revision of the last response whose events were held.
+	rev int64
+}
+
+func newWatchRateLimiter(rate int64, now time.Time) *watchRateLimiter {
+	return &watchRateLimiter{
+		rate:    float64(rate),
+		tokens:  float64(rate),
+		last:    now,
+		maxHeld: int(min(rate, math.MaxInt/maxHeldSeconds)) * maxHeldSeconds,
+		puts:    make(map[string]int),
+	}
+}
+
+// add holds back the events of a response at revision rev. It returns false
+// if, along with events held before, more than maxHeldSeconds worth of events
+// are held, in which case the watcher can no longer keep up and must be
+// canceled.
+func (l *watchRateLimiter) add(evs []*mvccpb.Event, rev int64) bool {
+	heldBefore := l.held
+	for _, ev := range evs {
+		key := string(ev.Kv.Key)
+		if i, ok := l.puts[key]; ok {
+			l.events[i] = nil
+			l.held--
+			delete(l.puts, key)
+		}
+		if ev.Type == mvccpb.PUT {
+			l.puts[key] = len(l.events)
+		}
+		l.events = append(l.events, ev)
+		l.held++
+	}
+	l.rev = rev
+	return heldBefore == 0 || l.held <= l.maxHeld
+}
+
+// pending returns true if events are held.
+func (l *watchRateLimiter) pending() bool {
+	return len(l.events) != 0
+}
+
+// take returns the held events that may be sent at now, and the revision the
+// watcher has observed once they are sent. The events of a revision are
+// never split.
+func (l *watchRateLimiter) take(now time.Time) ([]*mvccpb.Event, int64) {
+	l.refill(now)
+	if l.tokens < 1 {
+		return nil, 0
+	}
+	return l.takeN(int(l.tokens))
+}
+
+// takeAll returns all held events regardless of the rate, and the revision
+// the watcher has observed once they are sent.
+func (l *watchRateLimiter) takeAll(now time.Time) ([]*mvccpb.Event, int64) {
+	l.refill(now)
+	return l.takeN(len(l.events))
+}
+
+func (l *watchRateLimiter) takeN(n int) ([]*mvccpb.Event, int64) {
+	held := l.events[:0]
+	for _, ev := range l.events {
+		if ev != nil {
+			held = append(held, ev)
+		}
+	}
+	if len(held) == 0 {
+		l.events = nil
+		l.held = 0
+		return nil, 0
+	}
+
+	n = min(n, len(held))
+	for n < len(held) && held[n].Kv.ModRevision == held[n-1].Kv.ModRevision {
+		n++
+	}
+	evs := append([]*mvccpb.Event(nil), held[:n]...)
+	l.tokens -= float64(n)
+
+	rev := l.rev
+	if n < len(held) {
+		rev = evs[n-1].Kv.ModRevision
+	}
+	l.events = append(held[:0], held[n:]...)
+	l.held = len(l.events)
+	clear(l.puts)
+	for i, ev := range l.events {
+		if ev.Type == mvccpb.PUT {
+			l.puts[string(ev.Kv.Key)] = i
+		}
+	}
+	return evs, rev
+}
+
+// next returns when held events may next be sent.
+func (l *watchRateLimiter) next() time.Time {
+	if l.tokens >= 1 {
+		return l.last
+	}
+	return l.last.Add(time.Duration((1 - l.tokens) / l.rate * float64(time.Second)))
+}
+
+func (l *watchRateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(max(l.rate, 1), l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestWatchRateLimiter(t *testing.T) {
+	ev := func(typ mvccpb.Event_EventType, key string, rev int64) *mvccpb.Event {
+		return &mvccpb.Event{Type: typ, Kv: &mvccpb.KeyValue{Key: []byte(key), ModRevision: rev}}
+	}
+	format := func(evs []*mvccpb.Event) []string {
+		var s []string
+		for _, ev := range evs {
+			s = append(s, fmt.Sprintf("%s %s@%d", ev.Type, ev.Kv.Key, ev.Kv.ModRevision))
+		}
+		return s
+	}
+
+	now := time.Unix(1000, 0)
+	l := newWatchRateLimiter(2, now)
+
+	// the first second worth of events is sent right away
+	l.add([]*mvccpb.Event{ev(mvccpb.PUT, "a", 2)}, 2)
+	evs, rev := l.take(now)
+	assert.Equal(t, []string{"PUT a@2"}, format(evs))
+	assert.Equal(t, int64(2), rev)
+
+	l.add([]*mvccpb.Event{ev(mvccpb.PUT, "b", 3), ev(mvccpb.PUT, "c", 3)}, 3)
+	l.add([]*mvccpb.Event{ev(mvccpb.PUT, "b", 4)}, 4)
+	l.add([]*mvccpb.Event{ev(mvccpb.DELETE, "d", 5)}, 5)
+	l.add([]*mvccpb.Event{ev(mvccpb.PUT, "d", 6)}, 6)
+	l.add([]*mvccpb.Event{ev(mvccpb.DELETE, "d", 7)}, 7)
+	l.add([]*mvccpb.Event{ev(mvccpb.PUT, "b", 8)}, 8)
+
+	// one token is left; the events of a revision are not split
+	evs, rev = l.take(now)
+	assert.Equal(t, []string{"PUT c@3"}, format(evs))
+	assert.Equal(t, int64(3), rev)
+	evs, _ = l.take(now)
+	require.Empty(t, evs)
+	assert.Equal(t, now.Add(500*time.Millisecond), l.next())
+
+	// a held put is replaced by later events of its key; deletes are kept
+	evs, rev = l.take(now.Add(time.Second))
+	assert.Equal(t, []string{"DELETE d@5", "DELETE d@7"}, format(evs))
+	assert.Equal(t, int64(7), rev)
+	require.True(t, l.pending())
+
+	l.add([]*mvccpb.Event{ev(mvccpb.PUT, "e", 9), ev(mvccpb.PUT, "f", 9), ev(mvccpb.PUT, "g", 9)}, 10)
+	evs, rev = l.take(now.Add(1500 * time.Millisecond))
+	assert.Equal(t, []string{"PUT b@8"}, format(evs))
+	assert.Equal(t, int64(8), rev)
+
+	// the events of a revision are sent together, in debt
+	evs, rev = l.take(now.Add(2 * time.Second))
+	assert.Equal(t, []string{"PUT e@9", "PUT f@9", "PUT g@9"}, format(evs))
+	assert.Equal(t, int64(10), rev)
+	require.False(t, l.pending())
+	assert.Equal(t, now.Add(3500*time.Millisecond), l.next())
+
+	l.add([]*mvccpb.Event{ev(mvccpb.PUT, "h", 11), ev(mvccpb.DELETE, "i", 12)}, 12)
+	evs, rev = l.takeAll(now.Add(2 * time.Second))
+	assert.Equal(t, []string{"PUT h@11", "DELETE i@12"}, format(evs))
+	assert.Equal(t, int64(12), rev)
+	require.False(t, l.pending())
+}
+
+func TestWatchRateLimiterMaxHeld(t *testing.T) {
+	put := func(key string, rev int64) []*mvccpb.Event {
+		return []*mvccpb.Event{{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte(key), ModRevision: rev}}}
+	}
+	now := time.Unix(1000, 0)
+	l := newWatchRateLimiter(1, now)
+
+	// puts replacing held puts of the same key are not counted
+	for rev := int64(2); rev < 200; rev++ {
+		require.True(t, l.add(put("a", rev), rev))
+	}
+	taken, _ := l.take(now)
+	require.Len(t, taken, 1)
+
+	for i := 0; i < maxHeldSeconds; i++ {
+		require.True(t, l.add(put(fmt.Sprint("k", i), int64(200+i)), int64(200+i)))
+	}
+	require.False(t, l.add(put("overflow", 300), 300))
+
+	// the events of a single response are held regardless of their number
+	l = newWatchRateLimiter(1, now)
+	var evs []*mvccpb.Event
+	for i := 0; i <= maxHeldSeconds; i++ {
+		evs = append(evs, put(fmt.Sprint("k", i), 2)...)
+	}
+	require.True(t, l.add(evs, 2))
+}
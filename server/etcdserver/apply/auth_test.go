@@ -70,6 +70,10 @@ func (*fakeRaftStatusGetter) Term() uint64 {
 	return 0
 }
 
+func (*fakeRaftStatusGetter) Status() RaftStatus {
+	return RaftStatus{}
+}
+
 type fakeSnapshotServer struct{}
 
 func (*fakeSnapshotServer) ForceSnapshot() {}
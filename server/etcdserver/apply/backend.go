@@ -44,7 +44,16 @@ func newApplierV3Backend(opts ApplierOptions) applierV3 {
 }
 
 func (a *applierV3backend) Apply(r *pb.InternalRaftRequest, shouldApplyV3 membership.ShouldApplyV3, applyFunc applyFunc) *Result {
-	return applyFunc(r, shouldApplyV3)
+	result := applyFunc(r, shouldApplyV3)
+	if a.options.AuditHook != nil && result != nil && !isReadOnlyRequest(r) {
+		a.options.AuditHook(r, a.newHeader(), result.Resp, result.Err)
+	}
+	return result
+}
+
+// isReadOnlyRequest returns true if applying r cannot mutate the store.
+func isReadOnlyRequest(r *pb.InternalRaftRequest) bool {
+	return r.Range != nil || (r.Txn != nil && mvcctxn.IsTxnReadonly(r.Txn))
 }
 
 func (a *applierV3backend) Put(p *pb.PutRequest) (resp *pb.PutResponse, trace *traceutil.Trace, err error) {
@@ -63,14 +72,14 @@ func (a *applierV3backend) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, *traceutil.T
 	return mvcctxn.Txn(context.TODO(), a.options.Logger, rt, a.options.TxnModeWriteWithSharedBuffer, a.options.KV, a.options.Lessor)
 }
 
-func (a *applierV3backend) Compaction(compaction *pb.CompactionRequest) (*pb.CompactionResponse, <-chan struct{}, *traceutil.Trace, error) {
+func (a *applierV3backend) Compaction(ctx context.Context, compaction *pb.CompactionRequest) (*pb.CompactionResponse, <-chan struct{}, *traceutil.Trace, error) {
 	resp := &pb.CompactionResponse{}
 	resp.Header = &pb.ResponseHeader{}
-	ctx, trace := traceutil.EnsureTrace(context.TODO(), a.options.Logger, "compact",
+	ctx, trace := traceutil.EnsureTrace(ctx, a.options.Logger, "compact",
 		traceutil.Field{Key: "revision", Value: compaction.Revision},
 	)
 
-	ch, err := a.options.KV.Compact(trace, compaction.Revision)
+	ch, err := a.options.KV.Compact(ctx, trace, compaction.Revision)
 	if err != nil {
 		return nil, ch, nil, err
 	}
@@ -136,6 +145,7 @@ func (a *applierV3backend) Alarm(ar *pb.AlarmRequest) (*pb.AlarmResponse, error)
 }
 
 func (a *applierV3backend) AuthEnable() (*pb.AuthEnableResponse, error) {
+	defer a.notifyAuthRevision(a.options.AuthStore.Revision())
 	err := a.options.AuthStore.AuthEnable()
 	if err != nil {
 		return nil, err
@@ -144,10 +154,19 @@ func (a *applierV3backend) AuthEnable() (*pb.AuthEnableResponse, error) {
 }
 
 func (a *applierV3backend) AuthDisable() (*pb.AuthDisableResponse, error) {
+	defer a.notifyAuthRevision(a.options.AuthStore.Revision())
 	a.options.AuthStore.AuthDisable()
 	return &pb.AuthDisableResponse{Header: a.newHeader()}, nil
 }
 
+// notifyAuthRevision notifies the AuthRevisionNotifier if the auth revision
+// moved past the given one.
+func (a *applierV3backend) notifyAuthRevision(prev uint64) {
+	if a.options.AuthRevisionNotifier != nil && a.options.AuthStore.Revision() != prev {
+		a.options.AuthRevisionNotifier.Notify()
+	}
+}
+
 func (a *applierV3backend) AuthStatus() (*pb.AuthStatusResponse, error) {
 	enabled := a.options.AuthStore.IsAuthEnabled()
 	authRevision := a.options.AuthStore.Revision()
@@ -164,6 +183,7 @@ func (a *applierV3backend) Authenticate(r *pb.InternalAuthenticateRequest) (*pb.
 }
 
 func (a *applierV3backend) UserAdd(r *pb.AuthUserAddRequest) (*pb.AuthUserAddResponse, error) {
+	defer a.notifyAuthRevision(a.options.AuthStore.Revision())
 	resp, err := a.options.AuthStore.UserAdd(r)
 	if resp != nil {
 		resp.Header = a.newHeader()
@@ -172,6 +192,7 @@ func (a *applierV3backend) UserAdd(r *pb.AuthUserAddRequest) (*pb.AuthUserAddRes
 }
 
 func (a *applierV3backend) UserDelete(r *pb.AuthUserDeleteRequest) (*pb.AuthUserDeleteResponse, error) {
+	defer a.notifyAuthRevision(a.options.AuthStore.Revision())
 	resp, err := a.options.AuthStore.UserDelete(r)
 	if resp != nil {
 		resp.Header = a.newHeader()
@@ -180,6 +201,7 @@ func (a *applierV3backend) UserDelete(r *pb.AuthUserDeleteRequest) (*pb.AuthUser
 }
 
 func (a *applierV3backend) UserChangePassword(r *pb.AuthUserChangePasswordRequest) (*pb.AuthUserChangePasswordResponse, error) {
+	defer a.notifyAuthRevision(a.options.AuthStore.Revision())
 	resp, err := a.options.AuthStore.UserChangePassword(r)
 	if resp != nil {
 		resp.Header = a.newHeader()
@@ -188,6 +210,7 @@ func (a *applierV3backend) UserChangePassword(r *pb.AuthUserChangePasswordReques
 }
 
 func (a *applierV3backend) UserGrantRole(r *pb.AuthUserGrantRoleRequest) (*pb.AuthUserGrantRoleResponse, error) {
+	defer a.notifyAuthRevision(a.options.AuthStore.Revision())
 	resp, err := a.options.AuthStore.UserGrantRole(r)
 	if resp != nil {
 		resp.Header = a.newHeader()
@@ -204,6 +227,7 @@ func (a *applierV3backend) UserGet(r *pb.AuthUserGetRequest) (*pb.AuthUserGetRes
 }
 
 func (a *applierV3backend) UserRevokeRole(r *pb.AuthUserRevokeRoleRequest) (*pb.AuthUserRevokeRoleResponse, error) {
+	defer a.notifyAuthRevision(a.options.AuthStore.Revision())
 	resp, err := a.options.AuthStore.UserRevokeRole(r)
 	if resp != nil {
 		resp.Header = a.newHeader()
@@ -212,6 +236,7 @@ func (a *applierV3backend) UserRevokeRole(r *pb.AuthUserRevokeRoleRequest) (*pb.
 }
 
 func (a *applierV3backend) RoleAdd(r *pb.AuthRoleAddRequest) (*pb.AuthRoleAddResponse, error) {
+	defer a.notifyAuthRevision(a.options.AuthStore.Revision())
 	resp, err := a.options.AuthStore.RoleAdd(r)
 	if resp != nil {
 		resp.Header = a.newHeader()
@@ -220,6 +245,7 @@ func (a *applierV3backend) RoleAdd(r *pb.AuthRoleAddRequest) (*pb.AuthRoleAddRes
 }
 
 func (a *applierV3backend) RoleGrantPermission(r *pb.AuthRoleGrantPermissionRequest) (*pb.AuthRoleGrantPermissionResponse, error) {
+	defer a.notifyAuthRevision(a.options.AuthStore.Revision())
 	resp, err := a.options.AuthStore.RoleGrantPermission(r)
 	if resp != nil {
 		resp.Header = a.newHeader()
@@ -236,6 +262,7 @@ func (a *applierV3backend) RoleGet(r *pb.AuthRoleGetRequest) (*pb.AuthRoleGetRes
 }
 
 func (a *applierV3backend) RoleRevokePermission(r *pb.AuthRoleRevokePermissionRequest) (*pb.AuthRoleRevokePermissionResponse, error) {
+	defer a.notifyAuthRevision(a.options.AuthStore.Revision())
 	resp, err := a.options.AuthStore.RoleRevokePermission(r)
 	if resp != nil {
 		resp.Header = a.newHeader()
@@ -244,6 +271,7 @@ func (a *applierV3backend) RoleRevokePermission(r *pb.AuthRoleRevokePermissionRe
 }
 
 func (a *applierV3backend) RoleDelete(r *pb.AuthRoleDeleteRequest) (*pb.AuthRoleDeleteResponse, error) {
+	defer a.notifyAuthRevision(a.options.AuthStore.Revision())
 	resp, err := a.options.AuthStore.RoleDelete(r)
 	if resp != nil {
 		resp.Header = a.newHeader()
@@ -304,10 +332,11 @@ func (a *applierV3backend) DowngradeInfoSet(r *membershippb.DowngradeInfoSetRequ
 }
 
 func (a *applierV3backend) newHeader() *pb.ResponseHeader {
+	status := a.options.RaftStatus.Status()
 	return &pb.ResponseHeader{
 		ClusterId: uint64(a.options.Cluster.ID()),
-		MemberId:  uint64(a.options.RaftStatus.MemberID()),
+		MemberId:  uint64(status.MemberID),
 		Revision:  a.options.KV.Rev(),
-		RaftTerm:  a.options.RaftStatus.Term(),
+		RaftTerm:  status.Term,
 	}
 }
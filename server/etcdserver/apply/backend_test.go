@@ -0,0 +1,233 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/lease"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+type termRaftStatusGetter struct {
+	fakeRaftStatusGetter
+	id   types.ID
+	term uint64
+}
+
+func (s *termRaftStatusGetter) MemberID() types.ID { return s.id }
+func (s *termRaftStatusGetter) Term() uint64       { return s.term }
+func (s *termRaftStatusGetter) Status() RaftStatus {
+	return RaftStatus{MemberID: s.id, Term: s.term}
+}
+
+type auditRecord struct {
+	req    *pb.InternalRaftRequest
+	header *pb.ResponseHeader
+}
+
+func TestApplierV3BackendAuditHook(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	t.Cleanup(func() {
+		betesting.Close(t, be)
+	})
+
+	cluster := membership.NewCluster(lg)
+	lessor := lease.NewLessor(lg, be, cluster, lease.LessorConfig{})
+	kv := mvcc.NewStore(lg, be, lessor, mvcc.StoreConfig{})
+
+	var records []auditRecord
+	applier := newApplierV3Backend(ApplierOptions{
+		Logger:     lg,
+		KV:         kv,
+		Lessor:     lessor,
+		Cluster:    cluster,
+		RaftStatus: &termRaftStatusGetter{id: memberID, term: 7},
+		AuditHook: func(r *pb.InternalRaftRequest, header *pb.ResponseHeader, _ proto.Message, _ error) {
+			records = append(records, auditRecord{req: r, header: header})
+		},
+	})
+	dispatch := func(r *pb.InternalRaftRequest, _ membership.ShouldApplyV3) *Result {
+		ar := &Result{}
+		switch {
+		case r.Put != nil:
+			ar.Resp, ar.Trace, ar.Err = applier.Put(r.Put)
+		case r.Range != nil:
+			ar.Resp, ar.Trace, ar.Err = applier.Range(r.Range)
+		case r.Txn != nil:
+			ar.Resp, ar.Trace, ar.Err = applier.Txn(r.Txn)
+		}
+		return ar
+	}
+
+	put := &pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}}
+	rng := &pb.InternalRaftRequest{Range: &pb.RangeRequest{Key: []byte("foo")}}
+	readTxn := &pb.InternalRaftRequest{Txn: &pb.TxnRequest{
+		Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{Key: []byte("foo")}}}},
+	}}
+	writeTxn := &pb.InternalRaftRequest{Txn: &pb.TxnRequest{
+		Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("foo"), Value: []byte("baz")}}}},
+	}}
+	for _, r := range []*pb.InternalRaftRequest{put, rng, readTxn, writeTxn} {
+		result := applier.Apply(r, membership.ApplyBoth, dispatch)
+		require.NoError(t, result.Err)
+	}
+
+	require.Len(t, records, 2)
+	assert.Same(t, put, records[0].req)
+	assert.Same(t, writeTxn, records[1].req)
+	for i, rec := range records {
+		assert.Equal(t, uint64(memberID), rec.header.MemberId)
+		assert.Equal(t, uint64(7), rec.header.RaftTerm)
+		assert.Equal(t, int64(i+2), rec.header.Revision)
+	}
+}
+
+func TestApplierV3BackendNilAuditHook(t *testing.T) {
+	applier := newApplierV3Backend(ApplierOptions{})
+	result := applier.Apply(&pb.InternalRaftRequest{Put: &pb.PutRequest{}}, membership.ApplyBoth, dummyApplyFunc)
+	require.NotNil(t, result)
+}
+
+// movingRaftStatusGetter advances the term on every read, as if entries of
+// new terms were applied between the reads.
+type movingRaftStatusGetter struct {
+	fakeRaftStatusGetter
+	term        uint64
+	statusReads int
+}
+
+func (s *movingRaftStatusGetter) MemberID() types.ID {
+	s.term++
+	return types.ID(s.term)
+}
+
+func (s *movingRaftStatusGetter) Term() uint64 {
+	s.term++
+	return s.term
+}
+
+func (s *movingRaftStatusGetter) Status() RaftStatus {
+	s.term++
+	s.statusReads++
+	return RaftStatus{MemberID: memberID, Term: s.term}
+}
+
+func TestApplierV3BackendHeaderConsistentStatus(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	t.Cleanup(func() {
+		betesting.Close(t, be)
+	})
+
+	cluster := membership.NewCluster(lg)
+	lessor := lease.NewLessor(lg, be, cluster, lease.LessorConfig{})
+	kv := mvcc.NewStore(lg, be, lessor, mvcc.StoreConfig{})
+	status := &movingRaftStatusGetter{term: 4}
+	applier := newApplierV3Backend(ApplierOptions{
+		Logger:     lg,
+		KV:         kv,
+		Lessor:     lessor,
+		Cluster:    cluster,
+		RaftStatus: status,
+	}).(*applierV3backend)
+
+	header := applier.newHeader()
+	assert.Equal(t, 1, status.statusReads)
+	assert.Equal(t, uint64(memberID), header.MemberId)
+	assert.Equal(t, uint64(5), header.RaftTerm)
+	assert.Equal(t, int64(1), header.Revision)
+}
+
+func TestApplierV3BackendCompactionCancel(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	t.Cleanup(func() {
+		betesting.Close(t, be)
+	})
+
+	cluster := membership.NewCluster(lg)
+	lessor := lease.NewLessor(lg, be, cluster, lease.LessorConfig{})
+	// the compaction pauses for long after each batch, and is canceled
+	// during the pause following the first one
+	kv := mvcc.NewStore(lg, be, lessor, mvcc.StoreConfig{CompactionBatchLimit: 100, CompactionSleepInterval: time.Hour})
+	applier := newApplierV3Backend(ApplierOptions{
+		Logger:     lg,
+		KV:         kv,
+		Lessor:     lessor,
+		Cluster:    cluster,
+		RaftStatus: &fakeRaftStatusGetter{},
+	})
+
+	const keys, puts = 10, 100
+	for i := range puts {
+		for k := range keys {
+			_, _, err := applier.Put(&pb.PutRequest{Key: fmt.Appendf(nil, "foo%d", k), Value: fmt.Appendf(nil, "%d", i)})
+			require.NoError(t, err)
+		}
+	}
+	rev := kv.Rev()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	_, physc, _, err := applier.Compaction(ctx, &pb.CompactionRequest{Revision: rev})
+	require.NoError(t, err)
+	cancel()
+	select {
+	case <-physc:
+	case <-time.After(10 * time.Second):
+		t.Fatal("compaction was not canceled")
+	}
+
+	// only the first batch of revisions was freed
+	tx := be.ReadTx()
+	tx.RLock()
+	var revs int
+	require.NoError(t, tx.UnsafeForEach(schema.Key, func(k, v []byte) error {
+		revs++
+		return nil
+	}))
+	scheduled, _ := mvcc.UnsafeReadScheduledCompact(tx)
+	_, finished := mvcc.UnsafeReadFinishedCompact(tx)
+	tx.RUnlock()
+	assert.Equal(t, keys*puts-100, revs)
+	assert.Equal(t, rev, scheduled)
+	assert.False(t, finished)
+
+	// the store is readable and consistent at and after the compaction
+	resp, _, err := applier.Range(&pb.RangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop")})
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, keys)
+	for k, kv := range resp.Kvs {
+		assert.Equal(t, fmt.Sprintf("foo%d", k), string(kv.Key))
+		assert.Equal(t, fmt.Sprintf("%d", puts-1), string(kv.Value))
+		assert.Equal(t, int64(puts), kv.Version)
+	}
+	_, _, err = applier.Range(&pb.RangeRequest{Key: []byte("foo0"), Revision: rev - 1})
+	require.ErrorIs(t, err, mvcc.ErrCompacted)
+}
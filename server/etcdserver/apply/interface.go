@@ -15,6 +15,7 @@
 package apply
 
 import (
+	"context"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -23,6 +24,7 @@ import (
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/membershippb"
 	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/pkg/v3/notify"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
@@ -43,7 +45,9 @@ type applierV3 interface {
 	Range(r *pb.RangeRequest) (*pb.RangeResponse, *traceutil.Trace, error)
 	DeleteRange(dr *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, *traceutil.Trace, error)
 	Txn(rt *pb.TxnRequest) (*pb.TxnResponse, *traceutil.Trace, error)
-	Compaction(compaction *pb.CompactionRequest) (*pb.CompactionResponse, <-chan struct{}, *traceutil.Trace, error)
+	// Compaction compacts the store; the physical compaction it schedules
+	// stops once ctx is done.
+	Compaction(ctx context.Context, compaction *pb.CompactionRequest) (*pb.CompactionResponse, <-chan struct{}, *traceutil.Trace, error)
 
 	LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error)
 	LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error)
@@ -89,9 +93,27 @@ type ApplierOptions struct {
 	TxnModeWriteWithSharedBuffer bool
 	Backend                      backend.Backend
 	QuotaBackendBytesCfg         int64
-	WarningApplyDuration         time.Duration
+	// SoftQuotaRatio is the fraction of the backend quota above which Put and
+	// Txn still succeed, but their response header carries a quota warning.
+	// 0 disables the soft quota.
+	SoftQuotaRatio       float64
+	WarningApplyDuration time.Duration
+	// AuditHook, if set, is called after each applied request that may
+	// mutate state. Read-only ranges and transactions are not reported.
+	AuditHook AuditHook
+	// AuthRevisionNotifier, if set, is notified after each applied auth
+	// request that advanced the auth revision.
+	AuthRevisionNotifier *notify.Notifier
+	// CompactionContext, if set, returns the context bounding the physical
+	// compaction of each applied compaction request. Canceling it aborts the
+	// compaction, leaving the store compacted up to the last batch done.
+	CompactionContext func() context.Context
 }
 
+// AuditHook observes an applied request and its response or error. The
+// header carries the member ID, raft term and store revision after the apply.
+type AuditHook func(r *pb.InternalRaftRequest, header *pb.ResponseHeader, resp proto.Message, err error)
+
 type SnapshotServer interface {
 	ForceSnapshot()
 }
@@ -103,6 +125,18 @@ type RaftStatusGetter interface {
 	CommittedIndex() uint64
 	AppliedIndex() uint64
 	Term() uint64
+	// Status returns all of the above read at once, so that they are
+	// consistent with each other.
+	Status() RaftStatus
+}
+
+// RaftStatus is a snapshot of the Raft progress of a member.
+type RaftStatus struct {
+	MemberID       types.ID
+	Leader         types.ID
+	CommittedIndex uint64
+	AppliedIndex   uint64
+	Term           uint64
 }
 
 type Result struct {
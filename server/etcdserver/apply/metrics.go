@@ -26,6 +26,16 @@ var alarms = prometheus.NewGaugeVec(
 	[]string{"server_id", "alarm_type"},
 )
 
+var softQuotaExceeded = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "server",
+		Name:      "soft_quota_exceeded_total",
+		Help:      "Total number of applied requests that exceeded the soft backend quota.",
+	},
+)
+
 func init() {
 	prometheus.MustRegister(alarms)
+	prometheus.MustRegister(softQuotaExceeded)
 }
@@ -26,11 +26,20 @@ import (
 
 type quotaApplierV3 struct {
 	applierV3
-	q serverstorage.Quota
+	q  serverstorage.Quota
+	be backend.Backend
+	// softQuotaBytes is the backend size above which applied requests carry
+	// a quota warning; 0 if the soft quota is disabled.
+	softQuotaBytes int64
 }
 
-func newQuotaApplierV3(lg *zap.Logger, quotaBackendBytesCfg int64, be backend.Backend, app applierV3) applierV3 {
-	return &quotaApplierV3{app, serverstorage.NewBackendQuota(lg, quotaBackendBytesCfg, be, "v3-applier")}
+func newQuotaApplierV3(lg *zap.Logger, quotaBackendBytesCfg int64, softQuotaRatio float64, be backend.Backend, app applierV3) applierV3 {
+	a := &quotaApplierV3{applierV3: app, q: serverstorage.NewBackendQuota(lg, quotaBackendBytesCfg, be, "v3-applier"), be: be}
+	if bq, ok := a.q.(*serverstorage.BackendQuota); ok && softQuotaRatio > 0 && softQuotaRatio < 1 {
+		// the remaining quota plus the backend size is the hard quota
+		a.softQuotaBytes = int64(float64(bq.Remaining()+be.Size()) * softQuotaRatio)
+	}
+	return a
 }
 
 func (a *quotaApplierV3) Put(p *pb.PutRequest) (*pb.PutResponse, *traceutil.Trace, error) {
@@ -39,6 +48,9 @@ func (a *quotaApplierV3) Put(p *pb.PutRequest) (*pb.PutResponse, *traceutil.Trac
 	if err == nil && !ok {
 		err = errors.ErrNoSpace
 	}
+	if err == nil && resp != nil && a.exceedsSoftQuota(p) {
+		resp.Header.QuotaWarning = true
+	}
 	return resp, trace, err
 }
 
@@ -48,9 +60,26 @@ func (a *quotaApplierV3) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, *traceutil.Tra
 	if err == nil && !ok {
 		err = errors.ErrNoSpace
 	}
+	if err == nil && resp != nil && a.exceedsSoftQuota(rt) {
+		resp.Header.QuotaWarning = true
+	}
 	return resp, trace, err
 }
 
+// exceedsSoftQuota returns true if the request is mutating and the backend
+// size including its cost is above the soft quota.
+func (a *quotaApplierV3) exceedsSoftQuota(req any) bool {
+	if a.softQuotaBytes == 0 {
+		return false
+	}
+	cost := a.q.Cost(req)
+	if cost == 0 || a.be.Size()+int64(cost) < a.softQuotaBytes {
+		return false
+	}
+	softQuotaExceeded.Inc()
+	return true
+}
+
 func (a *quotaApplierV3) LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
 	ok := a.q.Available(lc)
 	resp, err := a.applierV3.LeaseGrant(lc)
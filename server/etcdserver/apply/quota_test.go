@@ -0,0 +1,133 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/lease"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+func TestQuotaApplierV3SoftQuota(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	// disable periodic commits so the backend size stays fixed
+	be, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
+	t.Cleanup(func() {
+		betesting.Close(t, be)
+	})
+
+	cluster := membership.NewCluster(lg)
+	lessor := lease.NewLessor(lg, be, cluster, lease.LessorConfig{})
+	kv := mvcc.NewStore(lg, be, lessor, mvcc.StoreConfig{})
+	opts := ApplierOptions{
+		Logger:     lg,
+		KV:         kv,
+		Lessor:     lessor,
+		Cluster:    cluster,
+		RaftStatus: &fakeRaftStatusGetter{},
+	}
+
+	size := be.Size()
+	// the hard quota is twice the current size, the soft quota 1.5 times
+	applier := newQuotaApplierV3(lg, 2*size, 0.75, be, newApplierV3Backend(opts))
+	// valueFor returns a value whose put would grow the backend to about target
+	// bytes; 256 is the per-key overhead charged by the backend quota.
+	valueFor := func(target int64) []byte {
+		return make([]byte, target-size-256-int64(len("foo")))
+	}
+
+	tests := []struct {
+		name        string
+		value       []byte
+		wantWarning bool
+		wantErr     error
+	}{
+		{
+			name:  "below soft quota",
+			value: valueFor(size + size/4),
+		},
+		{
+			name:        "above soft quota",
+			value:       valueFor(size + size*3/4),
+			wantWarning: true,
+		},
+		{
+			name:    "above hard quota",
+			value:   valueFor(2*size + 1),
+			wantErr: errors.ErrNoSpace,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			before := testutil.ToFloat64(softQuotaExceeded)
+
+			putResp, _, err := applier.Put(&pb.PutRequest{Key: []byte("foo"), Value: tc.value})
+			require.ErrorIs(t, err, tc.wantErr)
+			if tc.wantErr == nil {
+				assert.Equal(t, tc.wantWarning, putResp.Header.QuotaWarning)
+			}
+
+			txnResp, _, err := applier.Txn(&pb.TxnRequest{
+				Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("foo"), Value: tc.value}}}},
+			})
+			require.ErrorIs(t, err, tc.wantErr)
+			if tc.wantErr == nil {
+				assert.Equal(t, tc.wantWarning, txnResp.Header.QuotaWarning)
+			}
+
+			var wantInc float64
+			if tc.wantWarning {
+				wantInc = 2
+			}
+			assert.InDelta(t, wantInc, testutil.ToFloat64(softQuotaExceeded)-before, 0)
+		})
+	}
+}
+
+func TestQuotaApplierV3SoftQuotaDisabled(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	be, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
+	t.Cleanup(func() {
+		betesting.Close(t, be)
+	})
+
+	cluster := membership.NewCluster(lg)
+	lessor := lease.NewLessor(lg, be, cluster, lease.LessorConfig{})
+	kv := mvcc.NewStore(lg, be, lessor, mvcc.StoreConfig{})
+	opts := ApplierOptions{
+		Logger:     lg,
+		KV:         kv,
+		Lessor:     lessor,
+		Cluster:    cluster,
+		RaftStatus: &fakeRaftStatusGetter{},
+	}
+
+	size := be.Size()
+	applier := newQuotaApplierV3(lg, 2*size, 0, be, newApplierV3Backend(opts))
+	resp, _, err := applier.Put(&pb.PutRequest{Key: []byte("foo"), Value: make([]byte, size/2)})
+	require.NoError(t, err)
+	assert.False(t, resp.Header.QuotaWarning)
+}
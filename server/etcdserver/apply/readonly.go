@@ -0,0 +1,66 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/version"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/etcdserver/txn"
+)
+
+type applierV3ReadOnly struct {
+	applierV3
+	cluster *membership.RaftCluster
+}
+
+// newApplierV3ReadOnly creates an applyV3 that will reject Puts, DeleteRanges
+// and transactions with either, so the key-value store is not modified while
+// the READONLY alarm is active. Keys attached to expiring leases are still
+// deleted.
+func newApplierV3ReadOnly(base applierV3, cluster *membership.RaftCluster) applierV3 {
+	return &applierV3ReadOnly{applierV3: base, cluster: cluster}
+}
+
+// enforced returns true if the cluster version is at least 3.7. Older
+// members do not know the READONLY alarm and keep applying writes, so writes
+// are only rejected once every member rejects them.
+func (a *applierV3ReadOnly) enforced() bool {
+	cv := a.cluster.Version()
+	return cv != nil && !cv.LessThan(version.V3_7)
+}
+
+func (a *applierV3ReadOnly) Put(p *pb.PutRequest) (*pb.PutResponse, *traceutil.Trace, error) {
+	if !a.enforced() {
+		return a.applierV3.Put(p)
+	}
+	return nil, nil, errors.ErrReadOnly
+}
+
+func (a *applierV3ReadOnly) DeleteRange(dr *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, *traceutil.Trace, error) {
+	if !a.enforced() {
+		return a.applierV3.DeleteRange(dr)
+	}
+	return nil, nil, errors.ErrReadOnly
+}
+
+func (a *applierV3ReadOnly) Txn(r *pb.TxnRequest) (*pb.TxnResponse, *traceutil.Trace, error) {
+	if a.enforced() && !txn.IsTxnReadonly(r) {
+		return nil, nil, errors.ErrReadOnly
+	}
+	return a.applierV3.Txn(r)
+}
@@ -15,6 +15,7 @@
 package apply
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -35,6 +36,7 @@ type uberApplier struct {
 	lg *zap.Logger
 
 	alarmStore           *v3alarm.AlarmStore
+	cluster              *membership.RaftCluster
 	warningApplyDuration time.Duration
 
 	// This is the applier that is taking in consideration current alarms
@@ -42,6 +44,8 @@ type uberApplier struct {
 
 	// This is the applier used for wrapping when alarms change
 	applyV3base applierV3
+
+	compactionCtx func() context.Context
 }
 
 func NewUberApplier(opts ApplierOptions) UberApplier {
@@ -50,9 +54,11 @@ func NewUberApplier(opts ApplierOptions) UberApplier {
 	ua := &uberApplier{
 		lg:                   opts.Logger,
 		alarmStore:           opts.AlarmStore,
+		cluster:              opts.Cluster,
 		warningApplyDuration: opts.WarningApplyDuration,
 		applyV3:              applyV3base,
 		applyV3base:          applyV3base,
+		compactionCtx:        opts.CompactionContext,
 	}
 	ua.restoreAlarms()
 	return ua
@@ -62,7 +68,7 @@ func newApplierV3(opts ApplierOptions) applierV3 {
 	applierBackend := newApplierV3Backend(opts)
 	return newAuthApplierV3(
 		opts.AuthStore,
-		newQuotaApplierV3(opts.Logger, opts.QuotaBackendBytesCfg, opts.Backend, applierBackend),
+		newQuotaApplierV3(opts.Logger, opts.QuotaBackendBytesCfg, opts.SoftQuotaRatio, opts.Backend, applierBackend),
 		opts.Lessor,
 	)
 }
@@ -70,10 +76,14 @@ func newApplierV3(opts ApplierOptions) applierV3 {
 func (a *uberApplier) restoreAlarms() {
 	noSpaceAlarms := len(a.alarmStore.Get(pb.AlarmType_NOSPACE)) > 0
 	corruptAlarms := len(a.alarmStore.Get(pb.AlarmType_CORRUPT)) > 0
+	readOnlyAlarms := len(a.alarmStore.Get(pb.AlarmType_READONLY)) > 0
 	a.applyV3 = a.applyV3base
 	if noSpaceAlarms {
 		a.applyV3 = newApplierV3Capped(a.applyV3)
 	}
+	if readOnlyAlarms {
+		a.applyV3 = newApplierV3ReadOnly(a.applyV3, a.cluster)
+	}
 	if corruptAlarms {
 		a.applyV3 = newApplierV3Corrupt(a.applyV3)
 	}
@@ -88,6 +98,15 @@ func (a *uberApplier) Apply(r *pb.InternalRaftRequest, shouldApplyV3 membership.
 	return a.applyV3.Apply(r, shouldApplyV3, a.dispatch)
 }
 
+// compactionContext returns the context bounding the physical compaction of
+// an applied compaction request.
+func (a *uberApplier) compactionContext() context.Context {
+	if a.compactionCtx == nil {
+		return context.Background()
+	}
+	return a.compactionCtx()
+}
+
 // dispatch translates the request (r) into appropriate call (like Put) on
 // the underlying applyV3 object.
 func (a *uberApplier) dispatch(r *pb.InternalRaftRequest, shouldApplyV3 membership.ShouldApplyV3) *Result {
@@ -142,7 +161,7 @@ func (a *uberApplier) dispatch(r *pb.InternalRaftRequest, shouldApplyV3 membersh
 		ar.Resp, ar.Trace, ar.Err = a.applyV3.Txn(r.Txn)
 	case r.Compaction != nil:
 		op = "Compaction"
-		ar.Resp, ar.Physc, ar.Trace, ar.Err = a.applyV3.Compaction(r.Compaction)
+		ar.Resp, ar.Physc, ar.Trace, ar.Err = a.applyV3.Compaction(a.compactionContext(), r.Compaction)
 	case r.LeaseGrant != nil:
 		op = "LeaseGrant"
 		ar.Resp, ar.Err = a.applyV3.LeaseGrant(r.LeaseGrant)
@@ -165,6 +184,7 @@ func (a *uberApplier) dispatch(r *pb.InternalRaftRequest, shouldApplyV3 membersh
 		op = "AuthDisable"
 		ar.Resp, ar.Err = a.applyV3.AuthDisable()
 	case r.AuthStatus != nil:
+		op = "AuthStatus"
 		ar.Resp, ar.Err = a.applyV3.AuthStatus()
 	case r.AuthUserAdd != nil:
 		op = "AuthUserAdd"
@@ -18,12 +18,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 	"golang.org/x/crypto/bcrypt"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/membershippb"
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3alarm"
@@ -246,6 +248,100 @@ func TestUberApplier_Alarm_Quota(t *testing.T) {
 	}
 }
 
+// TestUberApplier_Alarm_ReadOnly tests the applier returns ErrReadOnly for writes after alarm READONLY is activated
+func TestUberApplier_Alarm_ReadOnly(t *testing.T) {
+	rangeOp := &pb.RequestOp{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{Key: []byte(key)}}}
+	putOp := &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte(key)}}}
+	tcs := []struct {
+		name        string
+		request     *pb.InternalRaftRequest
+		expectError error
+	}{
+		{
+			name:        "Put request returns ErrReadOnly after alarm READONLY is activated",
+			request:     &pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte(key)}},
+			expectError: errors.ErrReadOnly,
+		},
+		{
+			name:        "DeleteRange request returns ErrReadOnly after alarm READONLY is activated",
+			request:     &pb.InternalRaftRequest{DeleteRange: &pb.DeleteRangeRequest{Key: []byte(key)}},
+			expectError: errors.ErrReadOnly,
+		},
+		{
+			name:        "Txn request with a put in either branch returns ErrReadOnly after alarm READONLY is activated",
+			request:     &pb.InternalRaftRequest{Txn: &pb.TxnRequest{Success: []*pb.RequestOp{rangeOp}, Failure: []*pb.RequestOp{putOp}}},
+			expectError: errors.ErrReadOnly,
+		},
+		{
+			name:        "Txn request with only ranges is still allowed after alarm READONLY is activated",
+			request:     &pb.InternalRaftRequest{Txn: &pb.TxnRequest{Success: []*pb.RequestOp{rangeOp}}},
+			expectError: nil,
+		},
+		{
+			name:        "Range request is still allowed after alarm READONLY is activated",
+			request:     &pb.InternalRaftRequest{Range: &pb.RangeRequest{Key: []byte(key)}},
+			expectError: nil,
+		},
+		{
+			name:        "LeaseGrant request is still allowed after alarm READONLY is activated",
+			request:     &pb.InternalRaftRequest{LeaseGrant: &pb.LeaseGrantRequest{ID: 1, TTL: 10}},
+			expectError: nil,
+		},
+	}
+
+	ua := defaultUberApplier(t)
+	result := ua.Apply(&pb.InternalRaftRequest{
+		ClusterVersionSet: &membershippb.ClusterVersionSetRequest{Ver: "3.7.0"},
+	}, membership.ApplyBoth)
+	require.NotNil(t, result)
+	require.NoError(t, result.Err)
+	result = ua.Apply(&pb.InternalRaftRequest{
+		Header: &pb.RequestHeader{},
+		Alarm: &pb.AlarmRequest{
+			Action:   pb.AlarmRequest_ACTIVATE,
+			MemberID: memberID,
+			Alarm:    pb.AlarmType_READONLY,
+		},
+	}, membership.ApplyBoth)
+	require.NotNil(t, result)
+	require.NoError(t, result.Err)
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			result = ua.Apply(tc.request, membership.ApplyBoth)
+			require.NotNil(t, result)
+			require.Equalf(t, tc.expectError, result.Err, "Apply: got %v, expect: %v", result.Err, tc.expectError)
+		})
+	}
+}
+
+// TestUberApplier_Alarm_ReadOnlyClusterVersion tests the applier keeps
+// applying writes under alarm READONLY until the cluster version is 3.7, as
+// older members do.
+func TestUberApplier_Alarm_ReadOnlyClusterVersion(t *testing.T) {
+	ua := defaultUberApplier(t)
+	apply := func(r *pb.InternalRaftRequest) error {
+		result := ua.Apply(r, membership.ApplyBoth)
+		require.NotNil(t, result)
+		return result.Err
+	}
+	put := &pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte(key)}}
+
+	require.NoError(t, apply(&pb.InternalRaftRequest{ClusterVersionSet: &membershippb.ClusterVersionSetRequest{Ver: "3.6.0"}}))
+	require.NoError(t, apply(&pb.InternalRaftRequest{
+		Header: &pb.RequestHeader{},
+		Alarm: &pb.AlarmRequest{
+			Action:   pb.AlarmRequest_ACTIVATE,
+			MemberID: memberID,
+			Alarm:    pb.AlarmType_READONLY,
+		},
+	}))
+	require.NoError(t, apply(put))
+
+	require.NoError(t, apply(&pb.InternalRaftRequest{ClusterVersionSet: &membershippb.ClusterVersionSetRequest{Ver: "3.7.0"}}))
+	require.ErrorIs(t, apply(put), errors.ErrReadOnly)
+}
+
 // TestUberApplier_Alarm_Deactivate tests the applier should be able to apply after alarm is deactivated
 func TestUberApplier_Alarm_Deactivate(t *testing.T) {
 	ua := defaultUberApplier(t)
@@ -279,3 +375,68 @@ func TestUberApplier_Alarm_Deactivate(t *testing.T) {
 	require.NotNil(t, result)
 	assert.NoError(t, result.Err)
 }
+
+// TestUberApplier_ApplyDurationMetric tests that applies are observed in the
+// apply duration histogram under their request type.
+func TestUberApplier_ApplyDurationMetric(t *testing.T) {
+	tcs := []struct {
+		op      string
+		request *pb.InternalRaftRequest
+	}{
+		{
+			op:      "Put",
+			request: &pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}},
+		},
+		{
+			op: "Txn",
+			request: &pb.InternalRaftRequest{Txn: &pb.TxnRequest{Success: []*pb.RequestOp{
+				{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("foo"), Value: []byte("baz")}}},
+			}}},
+		},
+		{
+			op:      "DeleteRange",
+			request: &pb.InternalRaftRequest{DeleteRange: &pb.DeleteRangeRequest{Key: []byte("foo")}},
+		},
+		{
+			op:      "Compaction",
+			request: &pb.InternalRaftRequest{Compaction: &pb.CompactionRequest{Revision: 1}},
+		},
+		{
+			op:      "LeaseGrant",
+			request: &pb.InternalRaftRequest{LeaseGrant: &pb.LeaseGrantRequest{ID: 1, TTL: 60}},
+		},
+	}
+
+	ua := defaultUberApplier(t)
+	for _, tc := range tcs {
+		t.Run(tc.op, func(t *testing.T) {
+			before := applyDurationSampleCount(t, tc.op)
+			result := ua.Apply(tc.request, membership.ApplyBoth)
+			require.NotNil(t, result)
+			require.NoError(t, result.Err)
+			require.Equal(t, before+1, applyDurationSampleCount(t, tc.op))
+		})
+	}
+}
+
+// applyDurationSampleCount returns the number of successful v3 applies of op
+// observed by etcd_server_apply_duration_seconds.
+func applyDurationSampleCount(t *testing.T, op string) uint64 {
+	mfs, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, mf := range mfs {
+		if mf.GetName() != "etcd_server_apply_duration_seconds" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["version"] == "v3" && labels["op"] == op && labels["success"] == "true" {
+				return m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
@@ -17,6 +17,8 @@ package etcdserver
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -115,7 +117,13 @@ func (cm *corruptionChecker) InitialCheck() error {
 				zap.Uint32("remote-peer-hash", p.resp.Hash),
 			}
 
-			if h.Hash != p.resp.Hash {
+			if alg := peerHashAlgorithm(p.resp); alg != cm.hasher.HashAlgorithm() {
+				cm.lg.Error("cannot detect corruption against remote peer computing hash values by a different hash algorithm",
+					append(fields, zap.String("local-member-hash-algorithm", cm.hasher.HashAlgorithm()), zap.String("remote-peer-hash-algorithm", alg))...)
+				continue
+			}
+
+			if !bytes.Equal(kvHashDigest(h), peerHashDigest(p.resp)) {
 				if h.CompactRevision == p.resp.CompactRevision {
 					cm.lg.Warn("found different hash values from remote peer", fields...)
 					mismatch++
@@ -204,7 +212,7 @@ func (cm *corruptionChecker) PeriodicCheck() error {
 		cm.hasher.TriggerCorruptAlarm(id)
 	}
 
-	if h2.Hash != h.Hash && h2.Revision == h.Revision && h.CompactRevision == h2.CompactRevision {
+	if !bytes.Equal(kvHashDigest(h2), kvHashDigest(h)) && h2.Revision == h.Revision && h.CompactRevision == h2.CompactRevision {
 		cm.lg.Warn(
 			"found hash mismatch",
 			zap.Int64("revision-1", h.Revision),
@@ -246,8 +254,18 @@ func (cm *corruptionChecker) PeriodicCheck() error {
 			mismatch(p.id)
 		}
 
+		if alg := peerHashAlgorithm(p.resp); alg != cm.hasher.HashAlgorithm() {
+			cm.lg.Error(
+				"cannot detect corruption against follower computing hash values by a different hash algorithm",
+				zap.String("leader-hash-algorithm", cm.hasher.HashAlgorithm()),
+				zap.String("follower-hash-algorithm", alg),
+				zap.String("follower-peer-id", p.id.String()),
+			)
+			continue
+		}
+
 		// follower's compact revision is leader's old one, then hashes must match
-		if p.resp.CompactRevision == h.CompactRevision && p.resp.Hash != h.Hash {
+		if p.resp.CompactRevision == h.CompactRevision && !bytes.Equal(kvHashDigest(h), peerHashDigest(p.resp)) {
 			cm.lg.Warn(
 				"same compact revision then hashes must match",
 				zap.Int64("leader-compact-revision", h2.CompactRevision),
@@ -301,7 +319,7 @@ func (cm *corruptionChecker) CompactHashCheck() {
 //	false: skipped some members, so need to check next hash
 func (cm *corruptionChecker) checkPeerHashes(leaderHash mvcc.KeyValueHash, peers []*peerHashKVResp) bool {
 	leaderID := cm.hasher.MemberID()
-	hash2members := map[uint32]types.IDSlice{leaderHash.Hash: {leaderID}}
+	hash2members := map[string]types.IDSlice{string(kvHashDigest(leaderHash)): {leaderID}}
 
 	peersChecked := 0
 	// group all peers by hash
@@ -316,6 +334,13 @@ func (cm *corruptionChecker) checkPeerHashes(leaderHash mvcc.KeyValueHash, peers
 			skipped = true
 			reason = fmt.Sprintf("the peer's CompactRevision %d doesn't match leader's CompactRevision %d",
 				peer.resp.CompactRevision, leaderHash.CompactRevision)
+		} else if alg := peerHashAlgorithm(peer.resp); alg != cm.hasher.HashAlgorithm() {
+			cm.lg.Error("cannot detect corruption against peer computing hash values by a different hash algorithm",
+				zap.String("leader-id", leaderID.String()),
+				zap.String("leader-hash-algorithm", cm.hasher.HashAlgorithm()),
+				zap.String("peer-id", peer.id.String()),
+				zap.String("peer-hash-algorithm", alg))
+			continue
 		}
 		if skipped {
 			cm.lg.Warn("Skipped peer's hash", zap.Int("number-of-peers", len(peers)),
@@ -326,11 +351,12 @@ func (cm *corruptionChecker) checkPeerHashes(leaderHash mvcc.KeyValueHash, peers
 		}
 
 		peersChecked++
-		if ids, ok := hash2members[peer.resp.Hash]; !ok {
-			hash2members[peer.resp.Hash] = []types.ID{peer.id}
+		digest := string(peerHashDigest(peer.resp))
+		if ids, ok := hash2members[digest]; !ok {
+			hash2members[digest] = []types.ID{peer.id}
 		} else {
 			ids = append(ids, peer.id)
-			hash2members[peer.resp.Hash] = ids
+			hash2members[digest] = ids
 		}
 	}
 
@@ -380,7 +406,8 @@ func (cm *corruptionChecker) checkPeerHashes(leaderHash mvcc.KeyValueHash, peers
 			zap.Int64("leader-revision", leaderHash.Revision),
 			zap.Int64("leader-compact-revision", leaderHash.CompactRevision),
 			zap.Uint32("leader-hash", leaderHash.Hash),
-			zap.Uint32("peer-hash", k),
+			zap.Uint32("peer-hash", binary.BigEndian.Uint32([]byte(k))),
+			zap.String("peer-hash-digest", hex.EncodeToString([]byte(k))),
 			zap.String("peer-ids", v.String()),
 			zap.Bool("quorum-exist", quorumExist),
 		)
@@ -447,6 +474,30 @@ type peerInfo struct {
 	eps []string
 }
 
+// peerHashAlgorithm returns the algorithm that computed the hash of a peer's
+// response. Peers older than v3.7 do not report it, and compute crc32.
+func peerHashAlgorithm(resp *pb.HashKVResponse) string {
+	if resp.HashAlgorithm == "" {
+		return mvcc.HashAlgorithmCRC32
+	}
+	return resp.HashAlgorithm
+}
+
+// kvHashDigest returns the full digest of a hash. Hashes of members older
+// than v3.7 carry no digest, and are crc32 checksums, whose digest is the
+// checksum itself.
+func kvHashDigest(h mvcc.KeyValueHash) []byte {
+	if len(h.Digest) > 0 {
+		return h.Digest
+	}
+	return binary.BigEndian.AppendUint32(nil, h.Hash)
+}
+
+// peerHashDigest returns the full digest of the hash of a peer's response.
+func peerHashDigest(resp *pb.HashKVResponse) []byte {
+	return kvHashDigest(mvcc.KeyValueHash{Hash: resp.Hash, Digest: resp.HashDigest})
+}
+
 type peerHashKVResp struct {
 	peerInfo
 	resp *pb.HashKVResponse
@@ -562,6 +613,8 @@ func (h *hashKVHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Hash:            hash.Hash,
 		CompactRevision: hash.CompactRevision,
 		HashRevision:    hash.Revision,
+		HashAlgorithm:   h.server.KV().HashStorage().HashAlgorithm(),
+		HashDigest:      hash.Digest,
 	}
 	respBytes, err := json.Marshal(resp)
 	if err != nil {
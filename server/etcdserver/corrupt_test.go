@@ -97,6 +97,11 @@ func TestInitialCheck(t *testing.T) {
 			hasher:        fakeHasher{hashByRevResponses: []hashByRev{{hash: mvcc.KeyValueHash{Hash: 1, CompactRevision: 1}}}, peerHashes: []*peerHashKVResp{{resp: &pb.HashKVResponse{Header: &pb.ResponseHeader{}, Hash: 2, CompactRevision: 2}}}},
 			expectActions: []string{"MemberID()", "ReqTimeout()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
 		},
+		{
+			name:          "Peer returned different hash computed by a different algorithm",
+			hasher:        fakeHasher{hashAlgorithm: mvcc.HashAlgorithmSHA256, hashByRevResponses: []hashByRev{{hash: mvcc.KeyValueHash{Hash: 1, CompactRevision: 1}}}, peerHashes: []*peerHashKVResp{{resp: &pb.HashKVResponse{Header: &pb.ResponseHeader{}, Hash: 2, CompactRevision: 1}}}},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
+		},
 		{
 			name: "Cluster ID Mismatch does not fail CorruptionChecker.InitialCheck()",
 			hasher: fakeHasher{
@@ -210,6 +215,24 @@ func TestPeriodicCheck(t *testing.T) {
 			expectActions: []string{"HashByRev(0)", "PeerHashByRev(1)", "ReqTimeout()", "LinearizableReadNotify()", "HashByRev(0)", "TriggerCorruptAlarm(666)"},
 			expectCorrupt: true,
 		},
+		{
+			name: "Peer with same hash but different digest",
+			hasher: fakeHasher{
+				hashAlgorithm:      mvcc.HashAlgorithmSHA256,
+				hashByRevResponses: []hashByRev{{hash: mvcc.KeyValueHash{Hash: 1, Digest: []byte{0, 0, 0, 1, 1}, CompactRevision: 1, Revision: 1}, revision: 1}, {hash: mvcc.KeyValueHash{Hash: 2, CompactRevision: 2}, revision: 2}},
+				peerHashes:         []*peerHashKVResp{{peerInfo: peerInfo{id: 666}, resp: &pb.HashKVResponse{Header: &pb.ResponseHeader{Revision: 1}, CompactRevision: 1, Hash: 1, HashAlgorithm: mvcc.HashAlgorithmSHA256, HashDigest: []byte{0, 0, 0, 1, 2}}}},
+			},
+			expectActions: []string{"HashByRev(0)", "PeerHashByRev(1)", "ReqTimeout()", "LinearizableReadNotify()", "HashByRev(0)", "TriggerCorruptAlarm(666)"},
+			expectCorrupt: true,
+		},
+		{
+			name: "Peer with different hash computed by a different algorithm",
+			hasher: fakeHasher{
+				hashByRevResponses: []hashByRev{{hash: mvcc.KeyValueHash{Hash: 1, CompactRevision: 1, Revision: 1}, revision: 1}, {hash: mvcc.KeyValueHash{Hash: 2, CompactRevision: 2}, revision: 2}},
+				peerHashes:         []*peerHashKVResp{{peerInfo: peerInfo{id: 666}, resp: &pb.HashKVResponse{Header: &pb.ResponseHeader{Revision: 1}, CompactRevision: 1, Hash: 2, HashAlgorithm: mvcc.HashAlgorithmSHA256}}},
+			},
+			expectActions: []string{"HashByRev(0)", "PeerHashByRev(1)", "ReqTimeout()", "LinearizableReadNotify()", "HashByRev(0)"},
+		},
 		{
 			name: "Multiple corrupted peers trigger one alarm",
 			hasher: fakeHasher{
@@ -287,6 +310,28 @@ func TestCompactHashCheck(t *testing.T) {
 			},
 			expectActions: []string{"MemberID()", "ReqTimeout()", "Hashes()", "PeerHashByRev(2)", "MemberID()", "PeerHashByRev(1)", "MemberID()"},
 		},
+		{
+			name: "Peer returned different hash algorithm is skipped",
+			hasher: fakeHasher{
+				hashAlgorithm: mvcc.HashAlgorithmSHA256,
+				hashes:        []mvcc.KeyValueHash{{Revision: 1, CompactRevision: 1, Hash: 1}, {Revision: 2, CompactRevision: 1, Hash: 1}},
+				peerHashes:    []*peerHashKVResp{{peerInfo: peerInfo{id: 42}, resp: &pb.HashKVResponse{CompactRevision: 1, Hash: 2}}},
+			},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "Hashes()", "PeerHashByRev(2)", "MemberID()", "PeerHashByRev(1)", "MemberID()"},
+		},
+		{
+			name: "Etcd can identify a corrupted member with same hash but different digest",
+			hasher: fakeHasher{
+				hashAlgorithm: mvcc.HashAlgorithmSHA256,
+				hashes:        []mvcc.KeyValueHash{{Revision: 1, CompactRevision: 1, Hash: 1, Digest: []byte{0, 0, 0, 1, 1}}, {Revision: 2, CompactRevision: 1, Hash: 1, Digest: []byte{0, 0, 0, 1, 1}}},
+				peerHashes: []*peerHashKVResp{
+					{peerInfo: peerInfo{id: 42}, resp: &pb.HashKVResponse{CompactRevision: 1, Hash: 1, HashAlgorithm: mvcc.HashAlgorithmSHA256, HashDigest: []byte{0, 0, 0, 1, 1}}},
+					{peerInfo: peerInfo{id: 43}, resp: &pb.HashKVResponse{CompactRevision: 1, Hash: 1, HashAlgorithm: mvcc.HashAlgorithmSHA256, HashDigest: []byte{0, 0, 0, 1, 2}}},
+				},
+			},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "Hashes()", "PeerHashByRev(2)", "MemberID()", "TriggerCorruptAlarm(43)"},
+			expectCorrupt: true,
+		},
 		{
 			name: "Etcd can identify two corrupted members in 5 member cluster",
 			hasher: fakeHasher{
@@ -447,6 +492,7 @@ type fakeHasher struct {
 	hashByRevResponses     []hashByRev
 	linearizableReadNotify error
 	hashes                 []mvcc.KeyValueHash
+	hashAlgorithm          string
 
 	alarmTriggered bool
 	actions        []string
@@ -482,6 +528,13 @@ func (f *fakeHasher) Hashes() []mvcc.KeyValueHash {
 	return f.hashes
 }
 
+func (f *fakeHasher) HashAlgorithm() string {
+	if f.hashAlgorithm == "" {
+		return mvcc.HashAlgorithmCRC32
+	}
+	return f.hashAlgorithm
+}
+
 func (f *fakeHasher) ReqTimeout() time.Duration {
 	f.actions = append(f.actions, "ReqTimeout()")
 	return time.Second
@@ -575,6 +628,8 @@ func TestHashKVHandler(t *testing.T) {
 			hashValue, _, err := etcdSrv.KV().HashStorage().HashByRev(int64(revision))
 			require.NoErrorf(t, err, "etcd server hash failed: %v", err)
 			require.Equalf(t, hashKVResponse.Hash, hashValue.Hash, "hash value inconsistent: %d != %d", hashKVResponse.Hash, hashValue)
+			require.Equal(t, mvcc.HashAlgorithmCRC32, hashKVResponse.HashAlgorithm)
+			require.Equal(t, hashValue.Digest, hashKVResponse.HashDigest)
 		})
 	}
 }
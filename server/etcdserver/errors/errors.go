@@ -35,13 +35,17 @@ var (
 	ErrNotLeader                   = errors.New("etcdserver: not leader")
 	ErrRequestTooLarge             = errors.New("etcdserver: request is too large")
 	ErrNoSpace                     = errors.New("etcdserver: no space")
+	ErrValueTooLarge               = errors.New("etcdserver: value is too large")
 	ErrTooManyRequests             = errors.New("etcdserver: too many requests")
 	ErrUnhealthy                   = errors.New("etcdserver: unhealthy cluster")
 	ErrCorrupt                     = errors.New("etcdserver: corrupt cluster")
+	ErrReadOnly                    = errors.New("etcdserver: cluster is read-only")
+	ErrReadOnlyUnsupported         = errors.New("etcdserver: the READONLY alarm requires cluster version 3.7 or later")
 	ErrBadLeaderTransferee         = errors.New("etcdserver: bad leader transferee")
 	ErrClusterVersionUnavailable   = errors.New("etcdserver: cluster version not found during downgrade")
 	ErrWrongDowngradeVersionFormat = errors.New("etcdserver: wrong downgrade target version format")
 	ErrKeyNotFound                 = errors.New("etcdserver: key not found")
+	ErrWatchIndexUnavailable       = errors.New("etcdserver: raft index is not available to watch from")
 )
 
 type DiscoveryError struct {
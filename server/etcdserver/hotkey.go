@@ -0,0 +1,127 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+)
+
+// maxTrackedHotKeys bounds the number of per-key limiters kept in memory.
+const maxTrackedHotKeys = 10000
+
+// hotKeyLimiter throttles the writes to individual keys this member
+// proposes. It runs before proposing, never on the apply path, so that
+// every member applies the same entries.
+type hotKeyLimiter struct {
+	mu       sync.Mutex
+	limit    rate.Limit
+	burst    int
+	limiters map[string]*rate.Limiter
+}
+
+// newHotKeyLimiter returns a hotKeyLimiter that allows up to writesPerSecond
+// writes to each key, with bursts of up to burst writes. A burst of 0 allows
+// one second worth of writes.
+func newHotKeyLimiter(writesPerSecond float64, burst int) *hotKeyLimiter {
+	if burst <= 0 {
+		burst = max(1, int(writesPerSecond))
+	}
+	return &hotKeyLimiter{
+		limit:    rate.Limit(writesPerSecond),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// allow reports whether a write to key may be proposed now.
+func (l *hotKeyLimiter) allow(key []byte) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lim, ok := l.limiters[string(key)]
+	if !ok {
+		if len(l.limiters) >= maxTrackedHotKeys {
+			l.evictIdle()
+		}
+		lim = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[string(key)] = lim
+	}
+	return lim.Allow()
+}
+
+// evictIdle drops the limiters of keys that have not been written recently
+// enough to hold back any tokens. If every key is still throttled, all
+// limiters are dropped to keep memory bounded.
+func (l *hotKeyLimiter) evictIdle() {
+	for k, lim := range l.limiters {
+		if lim.Tokens() >= float64(l.burst) {
+			delete(l.limiters, k)
+		}
+	}
+	if len(l.limiters) >= maxTrackedHotKeys {
+		clear(l.limiters)
+	}
+}
+
+// checkHotKeys returns ErrTooManyRequests if a write to one of keys is over
+// the hot key limit.
+func (s *EtcdServer) checkHotKeys(keys ...[]byte) error {
+	if s.hotKeys == nil {
+		return nil
+	}
+	for _, key := range keys {
+		if !s.hotKeys.allow(key) {
+			hotKeyThrottled.Inc()
+			return errors.ErrTooManyRequests
+		}
+	}
+	return nil
+}
+
+// txnWriteKeys returns the keys written by the puts and deletes in both
+// branches of rt, including nested transactions, each key once.
+func txnWriteKeys(rt *pb.TxnRequest) [][]byte {
+	seen := make(map[string]struct{})
+	var keys [][]byte
+	var walk func(rt *pb.TxnRequest)
+	walk = func(rt *pb.TxnRequest) {
+		for _, ops := range [][]*pb.RequestOp{rt.Success, rt.Failure} {
+			for _, op := range ops {
+				var key []byte
+				switch tv := op.Request.(type) {
+				case *pb.RequestOp_RequestPut:
+					key = tv.RequestPut.Key
+				case *pb.RequestOp_RequestDeleteRange:
+					key = tv.RequestDeleteRange.Key
+				case *pb.RequestOp_RequestTxn:
+					walk(tv.RequestTxn)
+					continue
+				default:
+					continue
+				}
+				if _, ok := seen[string(key)]; !ok {
+					seen[string(key)] = struct{}{}
+					keys = append(keys, key)
+				}
+			}
+		}
+	}
+	walk(rt)
+	return keys
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+)
+
+func TestCheckHotKeys(t *testing.T) {
+	const burst = 3
+	// the rate is low enough that no token is refilled during the test
+	s := &EtcdServer{hotKeys: newHotKeyLimiter(0.001, burst)}
+
+	before := testutil.ToFloat64(hotKeyThrottled)
+	for i := 0; i < burst; i++ {
+		require.NoError(t, s.checkHotKeys([]byte("hot")))
+	}
+	require.ErrorIs(t, s.checkHotKeys([]byte("hot")), errors.ErrTooManyRequests)
+	// a transaction writing the hot key is throttled as a whole
+	require.ErrorIs(t, s.checkHotKeys([]byte("cold0"), []byte("hot")), errors.ErrTooManyRequests)
+	assert.InDelta(t, 2, testutil.ToFloat64(hotKeyThrottled)-before, 0)
+
+	// other keys keep their own budget
+	for _, key := range []string{"cold1", "cold2"} {
+		for i := 0; i < burst; i++ {
+			require.NoError(t, s.checkHotKeys([]byte(key)))
+		}
+	}
+
+	// no limit is enforced without a limiter
+	require.NoError(t, (&EtcdServer{}).checkHotKeys([]byte("hot")))
+}
+
+func TestTxnWriteKeys(t *testing.T) {
+	put := func(key string) *pb.RequestOp {
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte(key)}}}
+	}
+	del := func(key string) *pb.RequestOp {
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: &pb.DeleteRangeRequest{Key: []byte(key)}}}
+	}
+	get := func(key string) *pb.RequestOp {
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{Key: []byte(key)}}}
+	}
+	rt := &pb.TxnRequest{
+		Success: []*pb.RequestOp{put("a"), get("b"), del("c")},
+		Failure: []*pb.RequestOp{
+			put("a"),
+			{Request: &pb.RequestOp_RequestTxn{RequestTxn: &pb.TxnRequest{
+				Success: []*pb.RequestOp{put("d")},
+				Failure: []*pb.RequestOp{del("e")},
+			}}},
+		},
+	}
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("c"), []byte("d"), []byte("e")}, txnWriteKeys(rt))
+}
+
+func TestHotKeyLimiterEvictsIdleKeys(t *testing.T) {
+	l := newHotKeyLimiter(0.001, 1)
+	for i := 0; i < maxTrackedHotKeys; i++ {
+		require.True(t, l.allow([]byte(fmt.Sprint(i))))
+	}
+	require.Len(t, l.limiters, maxTrackedHotKeys)
+	// every tracked key is throttled, so none can be evicted selectively
+	require.True(t, l.allow([]byte("new")))
+	assert.Len(t, l.limiters, 1)
+}
@@ -107,6 +107,12 @@ var (
 		Name:      "read_indexes_failed_total",
 		Help:      "The total number of failed read indexes seen.",
 	})
+	heartbeatQuorumAge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "heartbeat_quorum_age_seconds",
+		Help:      "The time since the leader last heard from a quorum of voting members, 0 on non-leaders. The leader steps down once it reaches the election timeout.",
+	})
 	leaseExpired = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd_debugging",
 		Subsystem: "server",
@@ -147,6 +153,12 @@ var (
 		},
 		[]string{"name", "stage"},
 	)
+	hotKeyThrottled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "server",
+		Name:      "hot_key_throttled_total",
+		Help:      "Total number of writes rejected by the hot key limiter.",
+	})
 	fdUsed = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: "os",
 		Subsystem: "fd",
@@ -173,6 +185,7 @@ func init() {
 	prometheus.MustRegister(proposalsFailed)
 	prometheus.MustRegister(slowReadIndex)
 	prometheus.MustRegister(readIndexFailed)
+	prometheus.MustRegister(heartbeatQuorumAge)
 	prometheus.MustRegister(leaseExpired)
 	prometheus.MustRegister(currentVersion)
 	prometheus.MustRegister(currentGoVersion)
@@ -180,6 +193,7 @@ func init() {
 	prometheus.MustRegister(serverFeatureEnabled)
 	prometheus.MustRegister(learnerPromoteSucceed)
 	prometheus.MustRegister(learnerPromoteFailed)
+	prometheus.MustRegister(hotKeyThrottled)
 	prometheus.MustRegister(fdUsed)
 	prometheus.MustRegister(fdLimit)
 
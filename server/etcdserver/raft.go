@@ -28,6 +28,7 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
 	serverstorage "go.etcd.io/etcd/server/v3/storage"
 	"go.etcd.io/raft/v3"
+	"go.etcd.io/raft/v3/quorum"
 	"go.etcd.io/raft/v3/raftpb"
 )
 
@@ -83,6 +84,9 @@ type raftNode struct {
 	tickMu *sync.RWMutex
 	// timestamp of the latest tick
 	latestTickTs time.Time
+	// timestamp at which the leader last saw a quorum of voting members
+	// active; only accessed by the raft node goroutine
+	quorumActiveTs time.Time
 	raftNodeConfig
 
 	// a chan to send/receive snapshot
@@ -162,6 +166,22 @@ func (r *raftNode) tick() {
 	r.tickMu.Unlock()
 }
 
+// updateHeartbeatQuorumAge records whether the leader has heard from a quorum
+// of voting members since raft last checked the quorum, and exposes the time
+// since it last did. Raft checks the quorum once per election timeout, so a
+// quorum is seen again within a heartbeat of each check when the followers
+// respond.
+func (r *raftNode) updateHeartbeatQuorumAge(st raft.Status, now time.Time) {
+	active := make(map[uint64]bool, len(st.Progress))
+	for id, pr := range st.Progress {
+		active[id] = pr.RecentActive || id == st.ID
+	}
+	if st.Config.Voters.VoteResult(active) == quorum.VoteWon {
+		r.quorumActiveTs = now
+	}
+	heartbeatQuorumAge.Set(now.Sub(r.quorumActiveTs).Seconds())
+}
+
 func (r *raftNode) getLatestTickTs() time.Time {
 	r.tickMu.RLock()
 	defer r.tickMu.RUnlock()
@@ -181,6 +201,9 @@ func (r *raftNode) start(rh *raftReadyHandler) {
 			select {
 			case <-r.ticker.C:
 				r.tick()
+				if islead {
+					r.updateHeartbeatQuorumAge(r.Status(), time.Now())
+				}
 			case rd := <-r.Ready():
 				if rd.SoftState != nil {
 					newLeader := rd.SoftState.Lead != raft.None && rh.getLead() != rd.SoftState.Lead
@@ -195,12 +218,20 @@ func (r *raftNode) start(rh *raftReadyHandler) {
 					}
 
 					rh.updateLead(rd.SoftState.Lead)
+					wasLead := islead
 					islead = rd.RaftState == raft.StateLeader
 					if islead {
 						isLeader.Set(1)
 					} else {
 						isLeader.Set(0)
 					}
+					if islead && !wasLead {
+						// winning the election is hearing from a quorum
+						r.quorumActiveTs = time.Now()
+					}
+					if !islead {
+						heartbeatQuorumAge.Set(0)
+					}
 					rh.updateLeadership(newLeader)
 					r.td.Reset()
 				}
@@ -22,6 +22,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zaptest"
 
 	"go.etcd.io/etcd/client/pkg/v3/types"
@@ -30,7 +32,9 @@ import (
 	"go.etcd.io/etcd/server/v3/mock/mockstorage"
 	serverstorage "go.etcd.io/etcd/server/v3/storage"
 	"go.etcd.io/raft/v3"
+	"go.etcd.io/raft/v3/quorum"
 	"go.etcd.io/raft/v3/raftpb"
+	"go.etcd.io/raft/v3/tracker"
 )
 
 func TestGetIDs(t *testing.T) {
@@ -339,3 +343,41 @@ func TestStopRaftNodeMoreThanOnce(t *testing.T) {
 		}
 	}
 }
+
+// TestHeartbeatQuorumAge ensures the heartbeat quorum age grows while the
+// leader hears from no quorum of voters, and resets once it does again.
+func TestHeartbeatQuorumAge(t *testing.T) {
+	r := newRaftNode(raftNodeConfig{
+		lg:          zaptest.NewLogger(t),
+		Node:        newNopReadyNode(),
+		storage:     mockstorage.NewStorageRecorder(""),
+		raftStorage: raft.NewMemoryStorage(),
+		transport:   newNopTransporter(),
+	})
+	status := func(active ...uint64) raft.Status {
+		st := raft.Status{
+			BasicStatus: raft.BasicStatus{ID: 1},
+			Config: tracker.Config{
+				Voters: quorum.JointConfig{quorum.MajorityConfig{1: {}, 2: {}, 3: {}}},
+			},
+			Progress: map[uint64]tracker.Progress{1: {}, 2: {}, 3: {}, 4: {IsLearner: true}},
+		}
+		for _, id := range active {
+			st.Progress[id] = tracker.Progress{RecentActive: true}
+		}
+		return st
+	}
+
+	start := time.Now()
+	r.updateHeartbeatQuorumAge(status(2), start)
+	assert.Zero(t, testutil.ToFloat64(heartbeatQuorumAge))
+
+	// an active learner does not make a quorum
+	r.updateHeartbeatQuorumAge(status(4), start.Add(300*time.Millisecond))
+	assert.InDelta(t, 0.3, testutil.ToFloat64(heartbeatQuorumAge), 1e-9)
+	r.updateHeartbeatQuorumAge(status(), start.Add(time.Second))
+	assert.InDelta(t, 1, testutil.ToFloat64(heartbeatQuorumAge), 1e-9)
+
+	r.updateHeartbeatQuorumAge(status(3), start.Add(1100*time.Millisecond))
+	assert.Zero(t, testutil.ToFloat64(heartbeatQuorumAge))
+}
@@ -210,6 +210,9 @@ type EtcdServer struct {
 	committedIndex    atomic.Uint64
 	term              atomic.Uint64
 	lead              atomic.Uint64
+	// raftStatusMu is held to update appliedIndex, committedIndex, term and
+	// lead, and to read them together in Status.
+	raftStatusMu sync.RWMutex
 
 	consistIndex cindex.ConsistentIndexer // consistIndex is used to get/set/save consistentIndex
 	r            raftNode                 // uses 64-bit atomics; keep 64-bit aligned.
@@ -239,6 +242,10 @@ type EtcdServer struct {
 	// leaderChanged is used to notify the linearizable read loop to drop the old read requests.
 	leaderChanged *notify.Notifier
 
+	// forceSnapshotc receives requests to save a disk snapshot on the apply
+	// path. The snapshot index is sent back on the provided channel.
+	forceSnapshotc chan chan uint64
+
 	errorc     chan error
 	memberID   types.ID
 	attributes membership.Attributes
@@ -251,6 +258,8 @@ type EtcdServer struct {
 	uberApply apply.UberApplier
 
 	applyWait wait.WaitTime
+	// appliedRevs maps the applied raft entries to the mvcc revisions they produced.
+	appliedRevs appliedRevisions
 
 	kv         mvcc.WatchableKV
 	lessor     lease.Lessor
@@ -281,11 +290,19 @@ type EtcdServer struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
+	// compactMu protects compactCtx and compactCancel.
+	compactMu sync.Mutex
+	// compactCtx bounds the physical compactions of applied compaction
+	// requests; it is canceled by CancelCompaction.
+	compactCtx    context.Context
+	compactCancel context.CancelFunc
+
 	leadTimeMu      sync.RWMutex
 	leadElectedTime time.Time
 
 	firstCommitInTerm     *notify.Notifier
 	clusterVersionChanged *notify.Notifier
+	authRevisionChanged   *notify.Notifier
 
 	*AccessController
 	// forceDiskSnapshot can force snapshot be triggered after apply, independent of the snapshotCount.
@@ -293,6 +310,10 @@ type EtcdServer struct {
 	// TODO: Replace with flush db in v3.7 assuming v3.6 bootstraps from db file.
 	forceDiskSnapshot bool
 	corruptionChecker CorruptionChecker
+
+	// hotKeys throttles the writes to hot keys before they are proposed;
+	// nil if the hot key limit is disabled.
+	hotKeys *hotKeyLimiter
 }
 
 // NewServer creates a new EtcdServer from the supplied configuration. The
@@ -321,6 +342,7 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 		lgMu:                  new(sync.RWMutex),
 		lg:                    cfg.Logger,
 		errorc:                make(chan error, 1),
+		forceSnapshotc:        make(chan chan uint64),
 		v2store:               b.storage.st,
 		snapshotter:           b.ss,
 		r:                     *b.raft.newRaftNode(b.ss, b.storage.wal.w, b.cluster.cl),
@@ -335,6 +357,11 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 		consistIndex:          b.storage.backend.ci,
 		firstCommitInTerm:     notify.NewNotifier(),
 		clusterVersionChanged: notify.NewNotifier(),
+		authRevisionChanged:   notify.NewNotifier(),
+	}
+
+	if cfg.HotKeyWriteRate > 0 {
+		srv.hotKeys = newHotKeyLimiter(cfg.HotKeyWriteRate, cfg.HotKeyWriteBurst)
 	}
 
 	addFeatureGateMetrics(cfg.ServerFeatureGate, serverFeatureEnabled)
@@ -366,8 +393,15 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 	}
 
 	mvccStoreConfig := mvcc.StoreConfig{
-		CompactionBatchLimit:    cfg.CompactionBatchLimit,
-		CompactionSleepInterval: cfg.CompactionSleepInterval,
+		CompactionBatchLimit:     cfg.CompactionBatchLimit,
+		CompactionSleepInterval:  cfg.CompactionSleepInterval,
+		HashAlgorithm:            cfg.HashAlgorithm,
+		MaxWatchResponseBytes:    cfg.MaxWatchResponseBytes,
+		WatchHistorySize:         cfg.WatchHistorySize,
+		WatchIDQuarantine:        cfg.WatchIDQuarantine,
+		WatchVictimRetryInterval: cfg.WatchVictimRetryInterval,
+		TombstoneRetention:       cfg.TombstoneRetention,
+		AlwaysSendPrevKVOnDelete: cfg.AlwaysSendPrevKVOnDelete,
 	}
 	srv.kv = mvcc.New(srv.Logger(), srv.be, srv.lessor, mvccStoreConfig)
 	srv.corruptionChecker = newCorruptionChecker(cfg.Logger, srv, srv.kv.HashStorage())
@@ -845,6 +879,15 @@ func (s *EtcdServer) run() {
 		case ap := <-s.r.apply():
 			f := schedule.NewJob("server_applyAll", func(context.Context) { s.applyAll(&ep, &ap) })
 			sched.Schedule(f)
+		case snapc := <-s.forceSnapshotc:
+			f := schedule.NewJob("server_forceSnapshot", func(context.Context) {
+				s.ForceSnapshot()
+				s.snapshotIfNeededAndCompactRaftLog(&ep)
+				// snapshot is skipped if nothing was applied since the last one
+				s.forceDiskSnapshot = false
+				snapc <- ep.diskSnapshotIndex
+			})
+			sched.Schedule(f)
 		case leases := <-expiredLeaseC:
 			s.revokeExpiredLeases(leases)
 		case err := <-s.errorc:
@@ -971,6 +1014,14 @@ func (s *EtcdServer) Defragment() error {
 	return s.be.Defrag()
 }
 
+// DefragmentWithProgress defragments the backend like Defragment, reporting
+// the copy progress to fn.
+func (s *EtcdServer) DefragmentWithProgress(fn backend.DefragProgressFunc) error {
+	s.bemu.Lock()
+	defer s.bemu.Unlock()
+	return s.be.DefragWithProgress(fn)
+}
+
 func (s *EtcdServer) applyAll(ep *etcdProgress, apply *toApply) {
 	s.applySnapshot(ep, apply)
 	s.applyEntries(ep, apply)
@@ -1055,6 +1106,7 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, toApply *toApply) {
 	// by the old value.
 	s.consistIndex.SetBackend(newbe)
 	verifySnapshotIndex(toApply.snapshot, s.consistIndex.ConsistentIndex())
+	backend.VerifyAllBuckets(newbe, lg)
 
 	// always recover lessor before kv. When we recover the mvcc.KV it will reattach keys to its leases.
 	// If we recover mvcc.KV first, it will attach the keys to the wrong lessor before it recovers.
@@ -1073,6 +1125,7 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, toApply *toApply) {
 	}
 
 	newbe.SetTxPostLockInsideApplyHook(s.getTxPostLockInsideApplyHook())
+	newbe.SetCommitMode(s.be.CommitMode())
 
 	lg.Info("restored mvcc store", zap.Uint64("consistent-index", s.consistIndex.ConsistentIndex()))
 
@@ -1170,7 +1223,11 @@ func (s *EtcdServer) NewUberApplier() apply.UberApplier {
 		TxnModeWriteWithSharedBuffer: s.Cfg.ServerFeatureGate.Enabled(features.TxnModeWriteWithSharedBuffer),
 		Backend:                      s.be,
 		QuotaBackendBytesCfg:         s.Cfg.QuotaBackendBytes,
+		SoftQuotaRatio:               s.Cfg.QuotaSoftRatio,
 		WarningApplyDuration:         s.Cfg.WarningApplyDuration,
+		AuditHook:                    s.Cfg.AuditHook,
+		AuthRevisionNotifier:         s.authRevisionChanged,
+		CompactionContext:            s.compactionContext,
 	}
 	return apply.NewUberApplier(opts)
 }
@@ -1225,6 +1282,28 @@ func (s *EtcdServer) ForceSnapshot() {
 	s.forceDiskSnapshot = true
 }
 
+// SaveSnapshot forces the member to save a snapshot to disk once all the
+// entries scheduled for apply are applied. It returns the index of the
+// latest disk snapshot.
+func (s *EtcdServer) SaveSnapshot(ctx context.Context) (uint64, error) {
+	snapc := make(chan uint64, 1)
+	select {
+	case s.forceSnapshotc <- snapc:
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-s.stopping:
+		return 0, errors.ErrStopped
+	}
+	select {
+	case index := <-snapc:
+		return index, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-s.stopping:
+		return 0, errors.ErrStopped
+	}
+}
+
 func (s *EtcdServer) snapshotIfNeededAndCompactRaftLog(ep *etcdProgress) {
 	// TODO: Remove disk snapshot in v3.7
 	shouldSnapshotToDisk := s.shouldSnapshotToDisk(ep)
@@ -1662,6 +1741,44 @@ func (s *EtcdServer) mayRemoveMember(id types.ID) error {
 	return nil
 }
 
+// RemoveMemberPreview is the outcome of the checks RemoveMember runs before
+// removing a member.
+type RemoveMemberPreview struct {
+	// Err is the error the removal would be rejected with, or nil.
+	Err error
+	// VotingMembers is the number of voting members after the removal.
+	VotingMembers int
+	// ActiveMembers is the number of voting members after the removal that
+	// the local member has been connected to for HealthInterval, including
+	// itself.
+	ActiveMembers int
+}
+
+// Quorum returns the number of voting members required for quorum after
+// the removal.
+func (p *RemoveMemberPreview) Quorum() int {
+	return p.VotingMembers/2 + 1
+}
+
+// PreviewRemoveMember runs the checks RemoveMember runs on the local member
+// before removing the member with the given id, without removing it.
+func (s *EtcdServer) PreviewRemoveMember(id uint64) (*RemoveMemberPreview, error) {
+	if s.cluster.Member(types.ID(id)) == nil {
+		return nil, membership.ErrIDNotFound
+	}
+	var remaining []*membership.Member
+	for _, m := range s.cluster.VotingMembers() {
+		if m.ID != types.ID(id) {
+			remaining = append(remaining, m)
+		}
+	}
+	return &RemoveMemberPreview{
+		Err:           s.mayRemoveMember(types.ID(id)),
+		VotingMembers: len(remaining),
+		ActiveMembers: numConnectedSince(s.r.transport, time.Now().Add(-HealthInterval), s.MemberID(), remaining),
+	}, nil
+}
+
 func (s *EtcdServer) UpdateMember(ctx context.Context, memb membership.Member) ([]*membership.Member, error) {
 	b, merr := json.Marshal(memb)
 	if merr != nil {
@@ -1680,6 +1797,8 @@ func (s *EtcdServer) UpdateMember(ctx context.Context, memb membership.Member) (
 }
 
 func (s *EtcdServer) setCommittedIndex(v uint64) {
+	s.raftStatusMu.Lock()
+	defer s.raftStatusMu.Unlock()
 	s.committedIndex.Store(v)
 }
 
@@ -1687,16 +1806,16 @@ func (s *EtcdServer) getCommittedIndex() uint64 {
 	return s.committedIndex.Load()
 }
 
-func (s *EtcdServer) setAppliedIndex(v uint64) {
-	s.appliedIndex.Store(v)
-}
-
 func (s *EtcdServer) getAppliedIndex() uint64 {
 	return s.appliedIndex.Load()
 }
 
-func (s *EtcdServer) setTerm(v uint64) {
-	s.term.Store(v)
+// setAppliedIndexAndTerm records that the entry at index of term is applied.
+func (s *EtcdServer) setAppliedIndexAndTerm(index, term uint64) {
+	s.raftStatusMu.Lock()
+	defer s.raftStatusMu.Unlock()
+	s.appliedIndex.Store(index)
+	s.term.Store(term)
 }
 
 func (s *EtcdServer) getTerm() uint64 {
@@ -1704,6 +1823,8 @@ func (s *EtcdServer) getTerm() uint64 {
 }
 
 func (s *EtcdServer) setLead(v uint64) {
+	s.raftStatusMu.Lock()
+	defer s.raftStatusMu.Unlock()
 	s.lead.Store(v)
 }
 
@@ -1723,6 +1844,12 @@ func (s *EtcdServer) FirstCommitInTermNotify() <-chan struct{} {
 	return s.firstCommitInTerm.Receive()
 }
 
+// AuthRevisionNotify returns channel that will be closed once an applied
+// auth request advances the auth revision.
+func (s *EtcdServer) AuthRevisionNotify() <-chan struct{} {
+	return s.authRevisionChanged.Receive()
+}
+
 // MemberId returns the ID of the local member.
 // Deprecated: Please use (*EtcdServer) MemberID instead.
 //
@@ -1743,6 +1870,18 @@ func (s *EtcdServer) AppliedIndex() uint64 { return s.getAppliedIndex() }
 
 func (s *EtcdServer) Term() uint64 { return s.getTerm() }
 
+func (s *EtcdServer) Status() apply.RaftStatus {
+	s.raftStatusMu.RLock()
+	defer s.raftStatusMu.RUnlock()
+	return apply.RaftStatus{
+		MemberID:       s.memberID,
+		Leader:         types.ID(s.getLead()),
+		CommittedIndex: s.getCommittedIndex(),
+		AppliedIndex:   s.getAppliedIndex(),
+		Term:           s.getTerm(),
+	}
+}
+
 type confChangeResponse struct {
 	membs        []*membership.Member
 	raftAdvanceC <-chan struct{}
@@ -1917,16 +2056,14 @@ func (s *EtcdServer) apply(
 		case raftpb.EntryNormal:
 			// gofail: var beforeApplyOneEntryNormal struct{}
 			s.applyEntryNormal(&e, shouldApplyV3)
-			s.setAppliedIndex(e.Index)
-			s.setTerm(e.Term)
+			s.setAppliedIndexAndTerm(e.Index, e.Term)
 
 		case raftpb.EntryConfChange:
 			// gofail: var beforeApplyOneConfChange struct{}
 			var cc raftpb.ConfChange
 			pbutil.MustUnmarshal(&cc, e.Data)
 			removedSelf, err := s.applyConfChange(cc, confState, shouldApplyV3)
-			s.setAppliedIndex(e.Index)
-			s.setTerm(e.Term)
+			s.setAppliedIndexAndTerm(e.Index, e.Term)
 			shouldStop = shouldStop || removedSelf
 			s.w.Trigger(cc.ID, &confChangeResponse{s.cluster.Members(), raftAdvancedC, err})
 
@@ -1937,6 +2074,11 @@ func (s *EtcdServer) apply(
 				zap.String("type", e.Type.String()),
 			)
 		}
+		// TODO: remove the nil checking
+		// current test utility does not provide the kv
+		if kv := s.KV(); shouldApplyV3 && kv != nil {
+			s.appliedRevs.record(e.Index, kv.Rev())
+		}
 		appliedi, appliedt = e.Index, e.Term
 	}
 	return appliedt, appliedi, shouldStop
@@ -2158,6 +2300,7 @@ func (s *EtcdServer) compactRaftLog(snapi uint64) {
 		}
 		lg.Panic("failed to compact", zap.Error(err))
 	}
+	s.appliedRevs.compact(compacti)
 	lg.Debug(
 		"compacted Raft logs",
 		zap.Uint64("compact-index", compacti),
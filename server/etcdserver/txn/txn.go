@@ -70,6 +70,9 @@ func Txn(ctx context.Context, lg *zap.Logger, rt *pb.TxnRequest, txnModeWriteWit
 	}
 	txnResp, err = txn(ctx, lg, txnWrite, rt, isWrite, txnPath)
 	txnWrite.End()
+	if !isWrite && IsTxnSerializable(rt) {
+		markRangesSerializable(txnResp)
+	}
 
 	trace.AddField(
 		traceutil.Field{Key: "number_of_response", Value: len(txnResp.Responses)},
@@ -324,6 +327,19 @@ func compareKV(c *pb.Compare, ckv mvccpb.KeyValue) bool {
 	return true
 }
 
+// markRangesSerializable reports the ranges of a serializable txn as served
+// serializably. The ranges of other txns are served at the linearizable
+// revision of the txn, serializable or not.
+func markRangesSerializable(txnResp *pb.TxnResponse) {
+	for _, resp := range txnResp.Responses {
+		if rr := resp.GetResponseRange(); rr != nil {
+			rr.Serializable = true
+		}
+	}
+}
+
+// IsTxnSerializable reports whether a txn is only made of serializable
+// ranges, in which case it is served without a linearizable read.
 func IsTxnSerializable(r *pb.TxnRequest) bool {
 	for _, u := range r.Success {
 		if r := u.GetRequestRange(); r == nil || !r.Serializable {
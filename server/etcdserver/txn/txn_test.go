@@ -17,6 +17,7 @@ package txn
 import (
 	"context"
 	"crypto/sha256"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -296,7 +297,7 @@ func setup(t *testing.T, setup testSetup) (mvcc.KV, lease.Lessor) {
 		for i := 0; int64(i) < setup.compactRevision; i++ {
 			s.Put([]byte("a"), []byte("b"), 0)
 		}
-		s.Compact(traceutil.TODO(), setup.compactRevision)
+		s.Compact(t.Context(), traceutil.TODO(), setup.compactRevision)
 	}
 	if setup.lease != 0 {
 		lessor.Grant(lease.LeaseID(setup.lease), 0)
@@ -339,6 +340,94 @@ func TestReadonlyTxnError(t *testing.T) {
 	}
 }
 
+// cancelAfterContext cancels itself once its Done channel has been polled
+// after times, as if the client went away in the middle of a scan.
+type cancelAfterContext struct {
+	context.Context
+	cancel context.CancelFunc
+	after  int
+	polls  int
+}
+
+func (c *cancelAfterContext) Done() <-chan struct{} {
+	if c.polls++; c.polls > c.after {
+		c.cancel()
+	}
+	return c.Context.Done()
+}
+
+// TestRangeCanceled ensures a large range canceled while scanning the keys
+// stops with the context error.
+func TestRangeCanceled(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, b)
+	s := mvcc.NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, mvcc.StoreConfig{})
+	defer s.Close()
+
+	keys := 1000
+	for i := 0; i < keys; i++ {
+		s.Put(fmt.Appendf(nil, "foo%04d", i), []byte("bar"), lease.NoLease)
+	}
+	r := &pb.RangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop")}
+
+	inner, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	ctx := &cancelAfterContext{Context: inner, cancel: cancel, after: 100}
+	resp, _, err := Range(ctx, zaptest.NewLogger(t), s, r)
+	require.ErrorIs(t, err, ctx.Err())
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, resp)
+	// the scan stopped soon after the cancellation
+	assert.Less(t, ctx.polls, keys/2)
+
+	resp, _, err = Range(t.Context(), zaptest.NewLogger(t), s, r)
+	require.NoError(t, err)
+	assert.Len(t, resp.Kvs, keys)
+}
+
+func TestTxnCountOnlyRange(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, b)
+	s := mvcc.NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, mvcc.StoreConfig{})
+	defer s.Close()
+
+	s.Put([]byte("foo1"), []byte("bar"), lease.NoLease)
+	s.Put([]byte("foo2"), []byte("bar"), lease.NoLease)
+	s.Put([]byte("foo3"), []byte("bar"), lease.NoLease)
+
+	txn := &pb.TxnRequest{
+		Compare: []*pb.Compare{
+			{
+				Key:         []byte("foo1"),
+				Target:      pb.Compare_VERSION,
+				Result:      pb.Compare_GREATER,
+				TargetUnion: &pb.Compare_Version{Version: 0},
+			},
+		},
+		Success: []*pb.RequestOp{
+			{
+				Request: &pb.RequestOp_RequestRange{
+					RequestRange: &pb.RangeRequest{
+						Key:       []byte("foo"),
+						RangeEnd:  []byte("fop"),
+						CountOnly: true,
+					},
+				},
+			},
+		},
+	}
+
+	resp, _, err := Txn(t.Context(), zaptest.NewLogger(t), txn, false, s, &lease.FakeLessor{})
+	require.NoError(t, err)
+	require.True(t, resp.Succeeded)
+	require.Len(t, resp.Responses, 1)
+	rresp := resp.Responses[0].GetResponseRange()
+	require.NotNil(t, rresp)
+	assert.Equal(t, int64(3), rresp.Count)
+	assert.Empty(t, rresp.Kvs)
+	assert.False(t, rresp.More)
+}
+
 func TestWriteTxnPanicWithoutApply(t *testing.T) {
 	b, bePath := betesting.NewDefaultTmpBackend(t)
 	s := mvcc.NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, mvcc.StoreConfig{})
@@ -81,6 +81,10 @@ type Lessor interface {
 
 	// LeaseLeases lists all leases.
 	LeaseLeases(ctx context.Context, r *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error)
+
+	// LeaseRevokedNotify returns a chan that receives the IDs of leases
+	// revoked on this member until ctx is done.
+	LeaseRevokedNotify(ctx context.Context) <-chan lease.LeaseID
 }
 
 type Authenticator interface {
@@ -130,7 +134,7 @@ func (s *EtcdServer) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeRe
 				traceutil.Field{Key: "response_revision", Value: resp.Header.Revision},
 			)
 		}
-		trace.LogIfLong(traceThreshold)
+		s.finishTrace(trace)
 	}(time.Now())
 
 	if !r.Serializable {
@@ -159,6 +163,12 @@ func (s *EtcdServer) Put(ctx context.Context, r *pb.PutRequest) (*pb.PutResponse
 	))
 	defer span.End()
 
+	if err := s.checkValueSize(r); err != nil {
+		return nil, err
+	}
+	if err := s.checkHotKeys(r.Key); err != nil {
+		return nil, err
+	}
 	ctx = context.WithValue(ctx, traceutil.StartTimeKey{}, time.Now())
 	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{Put: r})
 	if err != nil {
@@ -175,6 +185,9 @@ func (s *EtcdServer) DeleteRange(ctx context.Context, r *pb.DeleteRangeRequest)
 	))
 	defer span.End()
 
+	if err := s.checkHotKeys(r.Key); err != nil {
+		return nil, err
+	}
 	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{DeleteRange: r})
 	if err != nil {
 		return nil, err
@@ -217,7 +230,7 @@ func (s *EtcdServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse
 
 		defer func(start time.Time) {
 			txn.WarnOfExpensiveReadOnlyTxnRequest(s.Logger(), s.Cfg.WarningApplyDuration, start, r, resp, err)
-			trace.LogIfLong(traceThreshold)
+			s.finishTrace(trace)
 		}(time.Now())
 
 		get := func() {
@@ -229,6 +242,12 @@ func (s *EtcdServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse
 		return resp, err
 	}
 
+	if err := s.checkTxnValueSize(r); err != nil {
+		return nil, err
+	}
+	if err := s.checkHotKeys(txnWriteKeys(r)...); err != nil {
+		return nil, err
+	}
 	ctx = context.WithValue(ctx, traceutil.StartTimeKey{}, time.Now())
 	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{Txn: r})
 	if err != nil {
@@ -237,21 +256,72 @@ func (s *EtcdServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse
 	return resp.(*pb.TxnResponse), nil
 }
 
+// CancelCompaction aborts the physical compactions running or scheduled on
+// this member. The store stays consistent, with the keys of the batches not
+// yet compacted left in place; they are freed when the member restarts or by
+// the next compaction, which is not affected.
+func (s *EtcdServer) CancelCompaction() {
+	s.compactMu.Lock()
+	defer s.compactMu.Unlock()
+	if s.compactCancel != nil {
+		s.compactCancel()
+	}
+}
+
+// compactionContext returns the context bounding the physical compaction of
+// an applied compaction request, renewed once canceled.
+func (s *EtcdServer) compactionContext() context.Context {
+	s.compactMu.Lock()
+	defer s.compactMu.Unlock()
+	if s.compactCtx == nil || s.compactCtx.Err() != nil {
+		s.compactCtx, s.compactCancel = context.WithCancel(context.Background())
+	}
+	return s.compactCtx
+}
+
 func (s *EtcdServer) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.CompactionResponse, error) {
 	var span trace.Span
 	ctx, span = traceutil.Tracer.Start(ctx, "compact", trace.WithAttributes(
 		attribute.Bool("is_physical", r.GetPhysical()),
+		attribute.Bool("is_dry_run", r.GetDryRun()),
 		attribute.Int64("rev", r.GetRevision()),
 	))
 	defer span.End()
 
+	if r.DryRun {
+		// an estimate changes nothing, so this member serves it without
+		// proposing it, once it has caught up with the leader
+		if err := s.linearizableReadNotify(ctx); err != nil {
+			return nil, err
+		}
+		reclaimable, err := s.KV().CompactEstimate(r.Revision)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.CompactionResponse{
+			Header:           &pb.ResponseHeader{Revision: s.kv.Rev()},
+			ReclaimableBytes: reclaimable,
+		}, nil
+	}
+
+	if r.BoundByWatchers {
+		// Only this member's watchers are known; those on other members
+		// may still lag behind the compaction.
+		if minRev, ok := s.kv.MinUnsyncedRev(); ok && r.Revision > minRev {
+			return &pb.CompactionResponse{
+				Header:           &pb.ResponseHeader{Revision: s.kv.Rev()},
+				BlockingRevision: minRev,
+			}, nil
+		}
+	}
+
 	startTime := time.Now()
 	ctx, trace := traceutil.EnsureTrace(ctx, s.Logger(), "compact")
 	result, err := s.processInternalRaftRequestOnce(ctx, pb.InternalRaftRequest{Compaction: r})
 	if result != nil && result.Trace != nil {
 		trace = result.Trace
 		defer func() {
-			trace.LogIfLong(traceThreshold)
+			s.finishTrace(trace)
 		}()
 		applyStart := result.Trace.GetStartTime()
 		result.Trace.SetStartTime(startTime)
@@ -536,6 +606,10 @@ func (s *EtcdServer) LeaseLeases(_ context.Context, _ *pb.LeaseLeasesRequest) (*
 	return &pb.LeaseLeasesResponse{Header: s.newHeader(), Leases: lss}, nil
 }
 
+func (s *EtcdServer) LeaseRevokedNotify(ctx context.Context) <-chan lease.LeaseID {
+	return s.lessor.RevokedNotify(ctx)
+}
+
 func (s *EtcdServer) waitLeader(ctx context.Context) (*membership.Member, error) {
 	leader := s.cluster.Member(s.Leader())
 	for leader == nil {
@@ -557,6 +631,12 @@ func (s *EtcdServer) waitLeader(ctx context.Context) (*membership.Member, error)
 }
 
 func (s *EtcdServer) Alarm(ctx context.Context, r *pb.AlarmRequest) (*pb.AlarmResponse, error) {
+	if r.Action == pb.AlarmRequest_ACTIVATE && r.Alarm == pb.AlarmType_READONLY {
+		// members older than 3.7 would keep applying writes
+		if cv := s.ClusterVersion(); cv == nil || cv.LessThan(version.V3_7) {
+			return nil, errors.ErrReadOnlyUnsupported
+		}
+	}
 	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{Alarm: r})
 	if err != nil {
 		return nil, err
@@ -780,11 +860,21 @@ func (s *EtcdServer) raftRequestOnce(ctx context.Context, r pb.InternalRaftReque
 		// and toApply start time
 		result.Trace.SetStartTime(startTime)
 		result.Trace.InsertStep(0, applyStart, "process raft request")
-		result.Trace.LogIfLong(traceThreshold)
+		s.finishTrace(result.Trace)
 	}
 	return result.Resp, nil
 }
 
+// finishTrace logs the trace of a request if it took longer than
+// traceThreshold, and exports it to the configured sink if it took longer
+// than the export threshold.
+func (s *EtcdServer) finishTrace(trace *traceutil.Trace) {
+	trace.LogIfLong(traceThreshold)
+	if err := trace.ExportIfLong(s.Cfg.TraceExportThreshold, s.Cfg.TraceExportSink); err != nil {
+		s.Logger().Warn("failed to export trace", zap.Error(err))
+	}
+}
+
 func (s *EtcdServer) raftRequest(ctx context.Context, r pb.InternalRaftRequest) (proto.Message, error) {
 	return s.raftRequestOnce(ctx, r)
 }
@@ -0,0 +1,57 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"fmt"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+)
+
+// checkValueSize returns an error if the value of p exceeds MaxValueBytes.
+// Like MaxRequestBytes, the limit is local configuration, so it is checked
+// before proposing rather than when applying.
+func (s *EtcdServer) checkValueSize(p *pb.PutRequest) error {
+	limit := s.Cfg.MaxValueBytes
+	if limit <= 0 || len(p.Value) <= limit {
+		return nil
+	}
+	return fmt.Errorf("%w: value of key %q is %d bytes, limit is %d bytes",
+		errors.ErrValueTooLarge, p.Key, len(p.Value), limit)
+}
+
+// checkTxnValueSize checks the value of every put in both branches of rt,
+// including nested transactions.
+func (s *EtcdServer) checkTxnValueSize(rt *pb.TxnRequest) error {
+	if s.Cfg.MaxValueBytes <= 0 {
+		return nil
+	}
+	for _, ops := range [][]*pb.RequestOp{rt.Success, rt.Failure} {
+		for _, op := range ops {
+			var err error
+			switch tv := op.Request.(type) {
+			case *pb.RequestOp_RequestPut:
+				err = s.checkValueSize(tv.RequestPut)
+			case *pb.RequestOp_RequestTxn:
+				err = s.checkTxnValueSize(tv.RequestTxn)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
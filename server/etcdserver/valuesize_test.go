@@ -0,0 +1,80 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/config"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+)
+
+func TestCheckValueSize(t *testing.T) {
+	const limit = 16
+	s := &EtcdServer{Cfg: config.ServerConfig{MaxValueBytes: limit}}
+
+	put := func(size int) *pb.PutRequest {
+		return &pb.PutRequest{Key: []byte("foo"), Value: make([]byte, size)}
+	}
+	putOp := func(size int) *pb.RequestOp {
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: put(size)}}
+	}
+	nestedOp := func(size int) *pb.RequestOp {
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestTxn{RequestTxn: &pb.TxnRequest{
+			Success: []*pb.RequestOp{putOp(size)},
+		}}}
+	}
+
+	require.NoError(t, s.checkValueSize(put(limit)))
+	require.ErrorIs(t, s.checkValueSize(put(limit+1)), errors.ErrValueTooLarge)
+
+	tcs := []struct {
+		name string
+		txn  func(size int) *pb.TxnRequest
+	}{
+		{
+			name: "success",
+			txn: func(size int) *pb.TxnRequest {
+				return &pb.TxnRequest{Success: []*pb.RequestOp{putOp(1), putOp(size)}}
+			},
+		},
+		{
+			name: "failure",
+			txn: func(size int) *pb.TxnRequest {
+				return &pb.TxnRequest{Failure: []*pb.RequestOp{putOp(size)}}
+			},
+		},
+		{
+			name: "nested",
+			txn: func(size int) *pb.TxnRequest {
+				return &pb.TxnRequest{Failure: []*pb.RequestOp{nestedOp(size)}}
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, s.checkTxnValueSize(tc.txn(limit)))
+			require.ErrorIs(t, s.checkTxnValueSize(tc.txn(limit+1)), errors.ErrValueTooLarge)
+		})
+	}
+
+	// no limit is enforced by default
+	s = &EtcdServer{}
+	require.NoError(t, s.checkValueSize(put(limit+1)))
+	require.NoError(t, s.checkTxnValueSize(tcs[0].txn(limit+1)))
+}
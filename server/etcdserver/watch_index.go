@@ -0,0 +1,135 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+// indexRevision is the mvcc revision of the store right after the raft
+// entry at index was applied.
+type indexRevision struct {
+	index uint64
+	rev   int64
+}
+
+// appliedRevisions records the mvcc revision produced by each raft entry
+// applied by this member, for the entries still kept in the raft log.
+type appliedRevisions struct {
+	mu   sync.RWMutex
+	revs []indexRevision
+}
+
+func (ar *appliedRevisions) record(index uint64, rev int64) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	if n := len(ar.revs); n > 0 && ar.revs[n-1].index+1 != index {
+		// the store was restored from a snapshot in between
+		ar.revs = ar.revs[:0]
+	}
+	ar.revs = append(ar.revs, indexRevision{index: index, rev: rev})
+}
+
+// compact drops the records of the entries before compacti.
+func (ar *appliedRevisions) compact(compacti uint64) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	i := sort.Search(len(ar.revs), func(i int) bool { return ar.revs[i].index >= compacti })
+	ar.revs = append(ar.revs[:0], ar.revs[i:]...)
+}
+
+// since returns the revision before the entry at startIndex was applied,
+// followed by the records of all the entries applied from startIndex on.
+func (ar *appliedRevisions) since(startIndex uint64) (int64, []indexRevision, error) {
+	ar.mu.RLock()
+	defer ar.mu.RUnlock()
+	i := sort.Search(len(ar.revs), func(i int) bool { return ar.revs[i].index >= startIndex })
+	if i == 0 || ar.revs[i-1].index != startIndex-1 {
+		return 0, nil, errors.ErrWatchIndexUnavailable
+	}
+	return ar.revs[i-1].rev, append([]indexRevision(nil), ar.revs[i:]...), nil
+}
+
+// WatchFromIndex streams the mvcc events a watcher on the whole keyspace
+// observed from the raft entry at startIndex up to the last applied entry.
+// f is called in order with the events of each entry that produced some.
+//
+// The entries are not applied again: their events are read back from the
+// mvcc store by watching from the revision it had before the entry at
+// startIndex was applied. Therefore:
+//   - it fails with mvcc.ErrCompacted once that revision is compacted;
+//   - it fails with ErrWatchIndexUnavailable for the entries applied before
+//     this member started or was restored from a snapshot, and for the
+//     entries compacted from the raft log.
+//
+// It is meant for debugging embedded servers; no RPC exposes it.
+func (s *EtcdServer) WatchFromIndex(ctx context.Context, startIndex uint64, f func(index uint64, events []mvccpb.Event) error) error {
+	prevRev, revs, err := s.appliedRevs.since(startIndex)
+	if err != nil {
+		return err
+	}
+	if len(revs) == 0 || revs[len(revs)-1].rev == prevRev {
+		return nil
+	}
+	endRev := revs[len(revs)-1].rev
+
+	ws := s.KV().NewWatchStream()
+	defer ws.Close()
+	// []byte{} as range end watches all the keys >= key
+	if _, err = ws.Watch(ctx, 0, []byte{0}, []byte{}, prevRev+1); err != nil {
+		return err
+	}
+
+	var events []mvccpb.Event
+	for {
+		select {
+		case wr, ok := <-ws.Chan():
+			if !ok {
+				return errors.ErrStopped
+			}
+			if wr.CompactRevision != 0 {
+				return mvcc.ErrCompacted
+			}
+			for _, ev := range wr.Events {
+				if ev.Kv.ModRevision > endRev {
+					break
+				}
+				for len(revs) > 0 && ev.Kv.ModRevision > revs[0].rev {
+					if len(events) > 0 {
+						if err = f(revs[0].index, events); err != nil {
+							return err
+						}
+						events = nil
+					}
+					revs = revs[1:]
+				}
+				events = append(events, ev)
+			}
+			if len(wr.Events) > 0 && wr.Events[len(wr.Events)-1].Kv.ModRevision >= endRev {
+				return f(revs[0].index, events)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.stopping:
+			return errors.ErrStopped
+		}
+	}
+}
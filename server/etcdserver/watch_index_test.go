@@ -0,0 +1,58 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+)
+
+func TestAppliedRevisions(t *testing.T) {
+	var ar appliedRevisions
+	for i := uint64(1); i <= 5; i++ {
+		ar.record(i, int64(i/2+1))
+	}
+
+	prev, revs, err := ar.since(3)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), prev)
+	require.Equal(t, []indexRevision{{3, 2}, {4, 3}, {5, 3}}, revs)
+
+	_, _, err = ar.since(1)
+	require.ErrorIs(t, err, errors.ErrWatchIndexUnavailable)
+
+	prev, revs, err = ar.since(6)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), prev)
+	require.Empty(t, revs)
+
+	ar.compact(4)
+	_, _, err = ar.since(4)
+	require.ErrorIs(t, err, errors.ErrWatchIndexUnavailable)
+	_, revs, err = ar.since(5)
+	require.NoError(t, err)
+	require.Equal(t, []indexRevision{{5, 3}}, revs)
+
+	// a gap in the applied entries drops the previous records
+	ar.record(10, 7)
+	_, _, err = ar.since(5)
+	require.ErrorIs(t, err, errors.ErrWatchIndexUnavailable)
+	prev, _, err = ar.since(11)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), prev)
+}
@@ -79,6 +79,9 @@ const (
 	// beta: v3.7
 	// main PR: https://github.com/etcd-io/etcd/pull/20589
 	FastLeaseKeepAlive featuregate.Feature = "FastLeaseKeepAlive"
+	// RuntimeCommitMode enables switching the backend between batched and synchronous commits through the SetCommitMode maintenance RPC.
+	// alpha: v3.7
+	RuntimeCommitMode featuregate.Feature = "RuntimeCommitMode"
 )
 
 var DefaultEtcdServerFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
@@ -90,6 +93,7 @@ var DefaultEtcdServerFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 	LeaseCheckpointPersist:       {Default: false, PreRelease: featuregate.Alpha},
 	SetMemberLocalAddr:           {Default: false, PreRelease: featuregate.Alpha},
 	FastLeaseKeepAlive:           {Default: true, PreRelease: featuregate.Beta},
+	RuntimeCommitMode:            {Default: false, PreRelease: featuregate.Alpha},
 }
 
 func NewDefaultServerFeatureGate(name string, lg *zap.Logger) featuregate.FeatureGate {
@@ -132,6 +132,11 @@ type Lessor interface {
 	// ExpiredLeasesC returns a chan that is used to receive expired leases.
 	ExpiredLeasesC() <-chan []*Lease
 
+	// RevokedNotify returns a chan that receives the IDs of leases revoked
+	// after the call, whether they expired or were revoked explicitly. The
+	// chan is closed once ctx is done or if the receiver falls behind.
+	RevokedNotify(ctx context.Context) <-chan LeaseID
+
 	// Recover recovers the lessor state from the given backend and RangeDeleter.
 	Recover(b backend.Backend, rd RangeDeleter)
 
@@ -189,6 +194,11 @@ type lessor struct {
 	checkpointPersist bool
 	// cluster is used to adapt lessor logic based on cluster version
 	cluster cluster
+
+	// revokedMu protects revokedSubs
+	revokedMu sync.Mutex
+	// revokedSubs are the chans registered by RevokedNotify
+	revokedSubs map[chan LeaseID]struct{}
 }
 
 type cluster interface {
@@ -239,6 +249,8 @@ func newLessor(lg *zap.Logger, b backend.Backend, cluster cluster, cfg LessorCon
 		doneC:    make(chan struct{}),
 		lg:       lg,
 		cluster:  cluster,
+
+		revokedSubs: make(map[chan LeaseID]struct{}),
 	}
 	l.initAndRecover()
 
@@ -361,9 +373,47 @@ func (le *lessor) Revoke(id LeaseID) error {
 	txn.End()
 
 	leaseRevoked.Inc()
+	le.notifyRevoked(l.ID)
 	return nil
 }
 
+// revokedNotifyBufLen is the number of revocations a RevokedNotify receiver
+// may fall behind before its chan is closed.
+const revokedNotifyBufLen = 128
+
+func (le *lessor) RevokedNotify(ctx context.Context) <-chan LeaseID {
+	ch := make(chan LeaseID, revokedNotifyBufLen)
+	le.revokedMu.Lock()
+	le.revokedSubs[ch] = struct{}{}
+	le.revokedMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		le.revokedMu.Lock()
+		defer le.revokedMu.Unlock()
+		if _, ok := le.revokedSubs[ch]; ok {
+			delete(le.revokedSubs, ch)
+			close(ch)
+		}
+	}()
+	return ch
+}
+
+// notifyRevoked sends id to all RevokedNotify receivers without blocking;
+// receivers that fall behind are dropped so revocations are never delayed.
+func (le *lessor) notifyRevoked(id LeaseID) {
+	le.revokedMu.Lock()
+	defer le.revokedMu.Unlock()
+	for ch := range le.revokedSubs {
+		select {
+		case ch <- id:
+		default:
+			delete(le.revokedSubs, ch)
+			close(ch)
+		}
+	}
+}
+
 func (le *lessor) Checkpoint(id LeaseID, remainingTTL int64) error {
 	le.mu.Lock()
 	defer le.mu.Unlock()
@@ -875,6 +925,8 @@ func (fl *FakeLessor) Leases() []*Lease { return nil }
 
 func (fl *FakeLessor) ExpiredLeasesC() <-chan []*Lease { return nil }
 
+func (fl *FakeLessor) RevokedNotify(ctx context.Context) <-chan LeaseID { return nil }
+
 func (fl *FakeLessor) Recover(b backend.Backend, rd RangeDeleter) {}
 
 func (fl *FakeLessor) Stop() {}
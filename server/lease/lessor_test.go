@@ -209,6 +209,59 @@ func TestLessorRevoke(t *testing.T) {
 	}
 }
 
+// TestLessorRevokedNotify ensures RevokedNotify receivers are told about
+// revocations until their context is done, and dropped when they fall behind.
+func TestLessorRevokedNotify(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+	le.SetRangeDeleter(func() TxnDelete { return newFakeDeleter(be) })
+
+	ctx, cancel := context.WithCancel(t.Context())
+	ch := le.RevokedNotify(ctx)
+	slow := le.RevokedNotify(t.Context())
+
+	for id := LeaseID(1); id <= revokedNotifyBufLen+1; id++ {
+		if _, err := le.Grant(id, 100); err != nil {
+			t.Fatal(err)
+		}
+		if err := le.Revoke(id); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case got := <-ch:
+			if got != id {
+				t.Fatalf("revoked = %x, want %x", got, id)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for revocation of %x", id)
+		}
+	}
+
+	// slow never received, so it was dropped once its buffer was full
+	n := 0
+	for range slow {
+		n++
+	}
+	if n != revokedNotifyBufLen {
+		t.Errorf("slow receiver got %d revocations, want %d", n, revokedNotifyBufLen)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("unexpected revocation after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("chan not closed after cancel")
+	}
+}
+
 func renew(t *testing.T, le *lessor, id LeaseID) int64 {
 	ch := make(chan int64, 1)
 	errch := make(chan error, 1)
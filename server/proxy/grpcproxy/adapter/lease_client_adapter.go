@@ -45,6 +45,13 @@ func (c *ls2lc) LeaseKeepAlive(ctx context.Context, opts ...grpc.CallOption) (pb
 	return &ls2lcClientStream{cs}, nil
 }
 
+func (c *ls2lc) LeaseExpirations(ctx context.Context, in *pb.LeaseExpirationsRequest, opts ...grpc.CallOption) (pb.Lease_LeaseExpirationsClient, error) {
+	cs := newPipeStream(ctx, func(ss chanServerStream) error {
+		return c.leaseServer.LeaseExpirations(in, &le2lecServerStream{ss})
+	})
+	return &le2lecClientStream{cs}, nil
+}
+
 func (c *ls2lc) LeaseTimeToLive(ctx context.Context, in *pb.LeaseTimeToLiveRequest, opts ...grpc.CallOption) (*pb.LeaseTimeToLiveResponse, error) {
 	return c.leaseServer.LeaseTimeToLive(ctx, in)
 }
@@ -82,3 +89,21 @@ func (s *ls2lcServerStream) Recv() (*pb.LeaseKeepAliveRequest, error) {
 	}
 	return v.(*pb.LeaseKeepAliveRequest), nil
 }
+
+// le2lecClientStream implements Lease_LeaseExpirationsClient
+type le2lecClientStream struct{ chanClientStream }
+
+// le2lecServerStream implements Lease_LeaseExpirationsServer
+type le2lecServerStream struct{ chanServerStream }
+
+func (s *le2lecClientStream) Recv() (*pb.LeaseExpirationsResponse, error) {
+	var v any
+	if err := s.RecvMsg(&v); err != nil {
+		return nil, err
+	}
+	return v.(*pb.LeaseExpirationsResponse), nil
+}
+
+func (s *le2lecServerStream) Send(rr *pb.LeaseExpirationsResponse) error {
+	return s.SendMsg(rr)
+}
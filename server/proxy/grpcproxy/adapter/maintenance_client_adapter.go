@@ -56,6 +56,63 @@ func (s *mts2mtc) Downgrade(ctx context.Context, r *pb.DowngradeRequest, opts ..
 	return s.mts.Downgrade(ctx, r)
 }
 
+func (s *mts2mtc) ForceSnapshot(ctx context.Context, r *pb.ForceSnapshotRequest, opts ...grpc.CallOption) (*pb.ForceSnapshotResponse, error) {
+	return s.mts.ForceSnapshot(ctx, r)
+}
+
+func (s *mts2mtc) DefragmentWithProgress(ctx context.Context, in *pb.DefragmentRequest, opts ...grpc.CallOption) (pb.Maintenance_DefragmentWithProgressClient, error) {
+	cs := newPipeStream(ctx, func(ss chanServerStream) error {
+		return s.mts.DefragmentWithProgress(in, &dps2dpcServerStream{ss})
+	})
+	return &dps2dpcClientStream{cs}, nil
+}
+
+// dps2dpcClientStream implements Maintenance_DefragmentWithProgressClient
+type dps2dpcClientStream struct{ chanClientStream }
+
+// dps2dpcServerStream implements Maintenance_DefragmentWithProgressServer
+type dps2dpcServerStream struct{ chanServerStream }
+
+func (s *dps2dpcClientStream) Recv() (*pb.DefragmentProgressResponse, error) {
+	var v any
+	if err := s.RecvMsg(&v); err != nil {
+		return nil, err
+	}
+	return v.(*pb.DefragmentProgressResponse), nil
+}
+
+func (s *dps2dpcServerStream) Send(rr *pb.DefragmentProgressResponse) error {
+	return s.SendMsg(rr)
+}
+
+func (s *mts2mtc) MemberRemovePreview(ctx context.Context, r *pb.MemberRemovePreviewRequest, opts ...grpc.CallOption) (*pb.MemberRemovePreviewResponse, error) {
+	return s.mts.MemberRemovePreview(ctx, r)
+}
+
+func (s *mts2mtc) LinearizableProbe(ctx context.Context, r *pb.LinearizableProbeRequest, opts ...grpc.CallOption) (*pb.LinearizableProbeResponse, error) {
+	return s.mts.LinearizableProbe(ctx, r)
+}
+
+func (s *mts2mtc) BucketStats(ctx context.Context, r *pb.BucketStatsRequest, opts ...grpc.CallOption) (*pb.BucketStatsResponse, error) {
+	return s.mts.BucketStats(ctx, r)
+}
+
+func (s *mts2mtc) SetCommitMode(ctx context.Context, r *pb.SetCommitModeRequest, opts ...grpc.CallOption) (*pb.SetCommitModeResponse, error) {
+	return s.mts.SetCommitMode(ctx, r)
+}
+
+func (s *mts2mtc) RevisionSince(ctx context.Context, r *pb.RevisionSinceRequest, opts ...grpc.CallOption) (*pb.RevisionSinceResponse, error) {
+	return s.mts.RevisionSince(ctx, r)
+}
+
+func (s *mts2mtc) StoreRevision(ctx context.Context, r *pb.StoreRevisionRequest, opts ...grpc.CallOption) (*pb.StoreRevisionResponse, error) {
+	return s.mts.StoreRevision(ctx, r)
+}
+
+func (s *mts2mtc) CancelCompaction(ctx context.Context, r *pb.CancelCompactionRequest, opts ...grpc.CallOption) (*pb.CancelCompactionResponse, error) {
+	return s.mts.CancelCompaction(ctx, r)
+}
+
 func (s *mts2mtc) Snapshot(ctx context.Context, in *pb.SnapshotRequest, opts ...grpc.CallOption) (pb.Maintenance_SnapshotClient, error) {
 	cs := newPipeStream(ctx, func(ss chanServerStream) error {
 		return s.mts.Snapshot(in, &ss2scServerStream{ss})
@@ -131,6 +131,25 @@ func (lp *leaseProxy) LeaseLeases(ctx context.Context, rr *pb.LeaseLeasesRequest
 	return rp, err
 }
 
+func (lp *leaseProxy) LeaseExpirations(rr *pb.LeaseExpirationsRequest, stream pb.Lease_LeaseExpirationsServer) error {
+	sc, err := lp.leaseClient.LeaseExpirations(stream.Context(), rr)
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := sc.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
 func (lp *leaseProxy) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) error {
 	lp.mu.Lock()
 	select {
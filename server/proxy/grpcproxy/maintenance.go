@@ -37,6 +37,32 @@ func (mp *maintenanceProxy) Defragment(ctx context.Context, dr *pb.DefragmentReq
 	return mp.maintenanceClient.Defragment(ctx, dr)
 }
 
+func (mp *maintenanceProxy) DefragmentWithProgress(dr *pb.DefragmentRequest, stream pb.Maintenance_DefragmentWithProgressServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	ctx = withClientAuthToken(ctx, stream.Context())
+
+	sc, err := mp.maintenanceClient.DefragmentWithProgress(ctx, dr)
+	if err != nil {
+		return err
+	}
+
+	for {
+		rr, err := sc.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		err = stream.Send(rr)
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func (mp *maintenanceProxy) Snapshot(sr *pb.SnapshotRequest, stream pb.Maintenance_SnapshotServer) error {
 	ctx, cancel := context.WithCancel(stream.Context())
 	defer cancel()
@@ -86,3 +112,35 @@ func (mp *maintenanceProxy) MoveLeader(ctx context.Context, r *pb.MoveLeaderRequ
 func (mp *maintenanceProxy) Downgrade(ctx context.Context, r *pb.DowngradeRequest) (*pb.DowngradeResponse, error) {
 	return mp.maintenanceClient.Downgrade(ctx, r)
 }
+
+func (mp *maintenanceProxy) ForceSnapshot(ctx context.Context, r *pb.ForceSnapshotRequest) (*pb.ForceSnapshotResponse, error) {
+	return mp.maintenanceClient.ForceSnapshot(ctx, r)
+}
+
+func (mp *maintenanceProxy) MemberRemovePreview(ctx context.Context, r *pb.MemberRemovePreviewRequest) (*pb.MemberRemovePreviewResponse, error) {
+	return mp.maintenanceClient.MemberRemovePreview(ctx, r)
+}
+
+func (mp *maintenanceProxy) LinearizableProbe(ctx context.Context, r *pb.LinearizableProbeRequest) (*pb.LinearizableProbeResponse, error) {
+	return mp.maintenanceClient.LinearizableProbe(ctx, r)
+}
+
+func (mp *maintenanceProxy) BucketStats(ctx context.Context, r *pb.BucketStatsRequest) (*pb.BucketStatsResponse, error) {
+	return mp.maintenanceClient.BucketStats(ctx, r)
+}
+
+func (mp *maintenanceProxy) SetCommitMode(ctx context.Context, r *pb.SetCommitModeRequest) (*pb.SetCommitModeResponse, error) {
+	return mp.maintenanceClient.SetCommitMode(ctx, r)
+}
+
+func (mp *maintenanceProxy) RevisionSince(ctx context.Context, r *pb.RevisionSinceRequest) (*pb.RevisionSinceResponse, error) {
+	return mp.maintenanceClient.RevisionSince(ctx, r)
+}
+
+func (mp *maintenanceProxy) StoreRevision(ctx context.Context, r *pb.StoreRevisionRequest) (*pb.StoreRevisionResponse, error) {
+	return mp.maintenanceClient.StoreRevision(ctx, r)
+}
+
+func (mp *maintenanceProxy) CancelCompaction(ctx context.Context, r *pb.CancelCompactionRequest) (*pb.CancelCompactionResponse, error) {
+	return mp.maintenanceClient.CancelCompaction(ctx, r)
+}
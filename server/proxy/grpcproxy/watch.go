@@ -246,6 +246,18 @@ func (wps *watchProxyStream) recvLoop() error {
 				continue
 			}
 
+			if cr.AuthRevisionNotify {
+				// coalesced watchers cannot report the auth revision
+				wps.watchCh <- &pb.WatchResponse{
+					Header:       &pb.ResponseHeader{},
+					WatchId:      clientv3.InvalidWatchID,
+					Created:      true,
+					Canceled:     true,
+					CancelReason: "auth revision watchers are not supported by gRPC proxy",
+				}
+				continue
+			}
+
 			if err := wps.checkPermissionForWatch(cr.Key, cr.RangeEnd); err != nil {
 				wps.watchCh <- &pb.WatchResponse{
 					Header:       &pb.ResponseHeader{},
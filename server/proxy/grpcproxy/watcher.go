@@ -43,6 +43,8 @@ type watcher struct {
 	id int64
 	// nextrev is the minimum expected next event revision.
 	nextrev int64
+	// seq is the sequence number of the last event sent over the stream.
+	seq uint64
 	// lastHeader has the last header sent over the stream.
 	lastHeader pb.ResponseHeader
 
@@ -88,12 +90,15 @@ func (w *watcher) send(wr clientv3.WatchResponse) {
 			continue
 		}
 
+		// the events are shared by the watchers of the broadcast, and are
+		// numbered for the proxy's own watch; renumber a copy.
+		evCopy := *ev
 		if !w.prevKV {
-			evCopy := *ev
 			evCopy.PrevKv = nil
-			ev = &evCopy
 		}
-		events = append(events, ev)
+		w.seq++
+		evCopy.Seq = w.seq
+		events = append(events, &evCopy)
 	}
 
 	if lastRev >= w.nextrev {
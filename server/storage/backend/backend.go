@@ -15,6 +15,7 @@
 package backend
 
 import (
+	"errors"
 	"fmt"
 	"hash/crc32"
 	"io"
@@ -32,6 +33,10 @@ import (
 )
 
 var (
+	// ErrSnapshotNoSpace is returned by ConcurrentSnapshot when there is not
+	// enough free disk space to copy the database.
+	ErrSnapshotNoSpace = errors.New("backend: not enough free space to copy database for snapshot")
+
 	defaultBatchLimit    = 10000
 	defaultBatchInterval = 100 * time.Millisecond
 
@@ -54,6 +59,14 @@ type Backend interface {
 	ConcurrentReadTx() ReadTx
 
 	Snapshot() Snapshot
+	// ConcurrentSnapshot is like Snapshot, but copies the database into a
+	// temporary file next to it and reads the snapshot from the copy. The
+	// read transaction of the snapshot is closed once the copy is done
+	// rather than once the snapshot is read, so that a slow reader does not
+	// block the writes growing the database file. Defragmentation is still
+	// blocked while the copy is written. It returns ErrSnapshotNoSpace if
+	// the copy would leave less free space than the size of the database.
+	ConcurrentSnapshot() (Snapshot, error)
 	Hash(ignores func(bucketName, keyName []byte) bool) (uint32, error)
 	// Size returns the current size of the backend physically allocated.
 	// The backend can hold DB space that is not utilized at the moment,
@@ -66,14 +79,53 @@ type Backend interface {
 	SizeInUse() int64
 	// OpenReadTxN returns the number of currently open read transactions in the backend.
 	OpenReadTxN() int64
+	// BucketStats returns the number of keys in the given bucket and the total
+	// size of its keys and values in bytes, including uncommitted writes.
+	BucketStats(bucket Bucket) (keys int64, bytes int64)
 	Defrag() error
+	// DefragWithProgress defragments the backend like Defrag, reporting the
+	// copy progress to fn as it goes.
+	DefragWithProgress(fn DefragProgressFunc) error
 	ForceCommit()
+	// CommitMode returns the mode in which the backend commits its batch transaction.
+	CommitMode() CommitMode
+	// SetCommitMode switches the backend to the given commit mode and returns
+	// the previous one.
+	SetCommitMode(mode CommitMode) CommitMode
 	Close() error
 
 	// SetTxPostLockInsideApplyHook sets a txPostLockInsideApplyHook.
 	SetTxPostLockInsideApplyHook(func())
 }
 
+// DefragProgressFunc is called during defragmentation with the number of key
+// and value bytes copied into the new database file so far, and the total
+// number of key and value bytes to copy.
+type DefragProgressFunc func(processed, total int64)
+
+// CommitMode controls when the backend commits its batch transaction.
+type CommitMode int32
+
+const (
+	// CommitModeBatched commits the batch transaction every BatchInterval or
+	// once it holds BatchLimit pending operations.
+	CommitModeBatched CommitMode = iota
+	// CommitModeSync commits the batch transaction each time a write
+	// transaction with pending operations is unlocked.
+	CommitModeSync
+)
+
+func (m CommitMode) String() string {
+	switch m {
+	case CommitModeBatched:
+		return "batched"
+	case CommitModeSync:
+		return "sync"
+	default:
+		return fmt.Sprintf("CommitMode(%d)", int32(m))
+	}
+}
+
 type Snapshot interface {
 	// Size gets the size of the snapshot.
 	Size() int64
@@ -111,6 +163,8 @@ type backend struct {
 	batchInterval time.Duration
 	batchLimit    int
 	batchTx       *batchTxBuffered
+	// commitMode holds the CommitMode of the batch transaction.
+	commitMode atomic.Int32
 
 	readTx *readTx
 	// txReadBufferCache mirrors "txReadBuffer" within "readTx" -- readTx.baseReadTx.buf.
@@ -127,6 +181,10 @@ type backend struct {
 	// txPostLockInsideApplyHook is called each time right after locking the tx.
 	txPostLockInsideApplyHook func()
 
+	// buckets maps the names of buckets created through the backend to
+	// their Bucket, so buckets can be enumerated with their ID.
+	buckets sync.Map
+
 	lg *zap.Logger
 }
 
@@ -214,6 +272,7 @@ func newBackend(bcfg BackendConfig) *backend {
 	if err != nil {
 		bcfg.Logger.Panic("failed to open database", zap.String("path", bcfg.Path), zap.Error(err))
 	}
+	removeSnapshotCopies(bcfg.Logger, filepath.Dir(bcfg.Path))
 
 	// In future, may want to make buffering optional for low-concurrency systems
 	// or dynamically swap between buffered/non-buffered depending on workload.
@@ -400,6 +459,46 @@ func (b *backend) Snapshot() Snapshot {
 	return &snapshot{tx, stopc, donec}
 }
 
+func (b *backend) ConcurrentSnapshot() (Snapshot, error) {
+	b.batchTx.Commit()
+
+	dir := filepath.Dir(b.db.Path())
+	free, err := freeSpace(dir)
+	if err != nil {
+		return nil, err
+	}
+	// the copy needs the size of the database, and the live database must
+	// still be able to grow as much once the copy is written
+	if size := b.Size(); size < 0 || free < 2*uint64(size) {
+		return nil, ErrSnapshotNoSpace
+	}
+
+	f, err := os.CreateTemp(dir, snapshotCopyPattern)
+	if err != nil {
+		return nil, err
+	}
+	snap := &fileSnapshot{f: f}
+	if snap.size, err = b.copyTo(f); err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		snap.Close()
+		return nil, err
+	}
+	return snap, nil
+}
+
+// copyTo writes a consistent view of the database to w.
+func (b *backend) copyTo(w io.Writer) (n int64, err error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	err = b.db.View(func(tx *bolt.Tx) error {
+		n, err = tx.WriteTo(w)
+		return err
+	})
+	return n, err
+}
+
 func (b *backend) Hash(ignores func(bucketName, keyName []byte) bool) (uint32, error) {
 	h := crc32.New(crc32.MakeTable(crc32.Castagnoli))
 
@@ -438,6 +537,26 @@ func (b *backend) SizeInUse() int64 {
 	return atomic.LoadInt64(&b.sizeInUse)
 }
 
+func (b *backend) BucketStats(bucket Bucket) (keys int64, bytes int64) {
+	tx := b.ConcurrentReadTx()
+	tx.RLock()
+	defer tx.RUnlock()
+	tx.UnsafeForEach(bucket, func(k, v []byte) error {
+		keys++
+		bytes += int64(len(k) + len(v))
+		return nil
+	})
+	return keys, bytes
+}
+
+func (b *backend) CommitMode() CommitMode {
+	return CommitMode(b.commitMode.Load())
+}
+
+func (b *backend) SetCommitMode(mode CommitMode) CommitMode {
+	return CommitMode(b.commitMode.Swap(int32(mode)))
+}
+
 func (b *backend) run() {
 	defer close(b.donec)
 	t := time.NewTimer(b.batchInterval)
@@ -464,16 +583,38 @@ func (b *backend) Close() error {
 	return b.db.Close()
 }
 
+// bucket returns the Bucket created through the backend with the given name.
+// Buckets only present in the database file are returned without an ID.
+func (b *backend) bucket(name []byte) Bucket {
+	if bkt, ok := b.buckets.Load(string(name)); ok {
+		return bkt.(Bucket)
+	}
+	return unregisteredBucket(name)
+}
+
+// unregisteredBucket is a bucket the backend has no ID for. Its ID matches
+// no buffered writes, so only the database file is read for it.
+type unregisteredBucket []byte
+
+func (b unregisteredBucket) ID() BucketID            { return -1 }
+func (b unregisteredBucket) Name() []byte            { return b }
+func (b unregisteredBucket) String() string          { return string(b) }
+func (b unregisteredBucket) IsSafeRangeBucket() bool { return false }
+
 // Commits returns total number of commits since start
 func (b *backend) Commits() int64 {
 	return atomic.LoadInt64(&b.commits)
 }
 
 func (b *backend) Defrag() error {
-	return b.defrag()
+	return b.defrag(nil)
+}
+
+func (b *backend) DefragWithProgress(fn DefragProgressFunc) error {
+	return b.defrag(fn)
 }
 
-func (b *backend) defrag() error {
+func (b *backend) defrag(progress DefragProgressFunc) error {
 	verify.Assert(b.lg != nil, "the logger should not be nil")
 	now := time.Now()
 	isDefragActive.Set(1)
@@ -553,7 +694,7 @@ func (b *backend) defrag() error {
 	b.batchTx.tx = nil
 
 	// gofail: var defragBeforeCopy struct{}
-	err = defragdb(b.db, tmpdb, defragLimit)
+	err = defragdb(b.db, tmpdb, defragLimit, progress)
 	if err != nil {
 		tmpdb.Close()
 		if rmErr := os.RemoveAll(tmpdb.Path()); rmErr != nil {
@@ -613,7 +754,7 @@ func (b *backend) defrag() error {
 	return nil
 }
 
-func defragdb(odb, tmpdb *bolt.DB, limit int) error {
+func defragdb(odb, tmpdb *bolt.DB, limit int, progress DefragProgressFunc) error {
 	// gofail: var defragdbFail string
 	// return fmt.Errorf(defragdbFail)
 
@@ -635,6 +776,14 @@ func defragdb(odb, tmpdb *bolt.DB, limit int) error {
 	}
 	defer tx.Rollback()
 
+	var processed, total int64
+	if progress != nil {
+		if total, err = defragTotalBytes(tx); err != nil {
+			return err
+		}
+		progress(processed, total)
+	}
+
 	c := tx.Cursor()
 
 	count := 0
@@ -665,14 +814,37 @@ func defragdb(odb, tmpdb *bolt.DB, limit int) error {
 				tmpb.FillPercent = 0.9 // for bucket2seq write in for each
 
 				count = 0
+				if progress != nil {
+					progress(processed, total)
+				}
 			}
+			processed += int64(len(k) + len(v))
 			return tmpb.Put(k, v)
 		}); err != nil {
 			return err
 		}
 	}
 
-	return tmptx.Commit()
+	if err = tmptx.Commit(); err != nil {
+		return err
+	}
+	if progress != nil {
+		progress(processed, total)
+	}
+	return nil
+}
+
+// defragTotalBytes returns the number of key and value bytes defragdb copies
+// out of tx.
+func defragTotalBytes(tx *bolt.Tx) (int64, error) {
+	var total int64
+	err := tx.ForEach(func(_ []byte, b *bolt.Bucket) error {
+		return b.ForEach(func(k, v []byte) error {
+			total += int64(len(k) + len(v))
+			return nil
+		})
+	})
+	return total, err
 }
 
 func (b *backend) begin(write bool) *bolt.Tx {
@@ -716,6 +888,48 @@ func (s *snapshot) Close() error {
 	return s.Tx.Rollback()
 }
 
+// fileSnapshot is a snapshot read from a temporary copy of the database,
+// which is removed on Close.
+// snapshotCopyPattern is the name pattern of the temporary database copies
+// written by ConcurrentSnapshot.
+const snapshotCopyPattern = "db.snapshot.*"
+
+// removeSnapshotCopies removes the database copies left in dir by
+// snapshots interrupted by a crash.
+func removeSnapshotCopies(lg *zap.Logger, dir string) {
+	copies, err := filepath.Glob(filepath.Join(dir, snapshotCopyPattern))
+	if err != nil {
+		lg.Warn("failed to list leftover snapshot copies", zap.String("dir", dir), zap.Error(err))
+		return
+	}
+	for _, c := range copies {
+		if err := os.Remove(c); err != nil {
+			lg.Warn("failed to remove leftover snapshot copy", zap.String("path", c), zap.Error(err))
+			continue
+		}
+		lg.Info("removed leftover snapshot copy", zap.String("path", c))
+	}
+}
+
+type fileSnapshot struct {
+	f    *os.File
+	size int64
+}
+
+func (s *fileSnapshot) Size() int64 { return s.size }
+
+func (s *fileSnapshot) WriteTo(w io.Writer) (int64, error) {
+	return io.Copy(w, s.f)
+}
+
+func (s *fileSnapshot) Close() error {
+	err := s.f.Close()
+	if rmErr := os.Remove(s.f.Name()); err == nil {
+		err = rmErr
+	}
+	return err
+}
+
 func newBoltLoggerZap(bcfg BackendConfig) bolt.Logger {
 	lg := bcfg.Logger.Named("bbolt")
 	return &zapBoltLogger{lg.WithOptions(zap.AddCallerSkip(1)).Sugar()}
@@ -17,6 +17,7 @@ package backend_test
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -88,6 +89,66 @@ func TestBackendSnapshot(t *testing.T) {
 	newTx.Unlock()
 }
 
+func TestBackendRemoveSnapshotCopies(t *testing.T) {
+	dir := t.TempDir()
+	leftover := filepath.Join(dir, "db.snapshot.123")
+	require.NoError(t, os.WriteFile(leftover, []byte("partial"), 0o600))
+
+	bcfg := backend.DefaultBackendConfig(zaptest.NewLogger(t))
+	bcfg.Path = filepath.Join(dir, "db")
+	b := backend.New(bcfg)
+	defer betesting.Close(t, b)
+
+	_, err := os.Stat(leftover)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = os.Stat(bcfg.Path)
+	require.NoError(t, err)
+}
+
+func TestBackendConcurrentSnapshot(t *testing.T) {
+	b, tmpPath := betesting.NewTmpBackend(t, time.Hour, 10000)
+	defer betesting.Close(t, b)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(schema.Test)
+	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
+	tx.Unlock()
+
+	snap, err := b.ConcurrentSnapshot()
+	require.NoError(t, err)
+	tmps, err := filepath.Glob(filepath.Join(filepath.Dir(tmpPath), "db.snapshot.*"))
+	require.NoError(t, err)
+	require.Len(t, tmps, 1)
+
+	// writes after the snapshot do not change it
+	tx.Lock()
+	tx.UnsafePut(schema.Test, []byte("foo2"), []byte("bar2"))
+	tx.Unlock()
+	b.ForceCommit()
+
+	f, err := os.CreateTemp(t.TempDir(), "etcd_backend_test")
+	require.NoError(t, err)
+	n, err := snap.WriteTo(f)
+	require.NoError(t, err)
+	require.Equal(t, snap.Size(), n)
+	require.NoError(t, f.Close())
+	require.NoError(t, snap.Close())
+	_, err = os.Stat(tmps[0])
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	bcfg := backend.DefaultBackendConfig(zaptest.NewLogger(t))
+	bcfg.Path, bcfg.BatchInterval, bcfg.BatchLimit = f.Name(), time.Hour, 10000
+	nb := backend.New(bcfg)
+	defer betesting.Close(t, nb)
+
+	newTx := nb.BatchTx()
+	newTx.Lock()
+	ks, _ := newTx.UnsafeRange(schema.Test, []byte("foo"), []byte("goo"), 0)
+	newTx.Unlock()
+	require.Equal(t, [][]byte{[]byte("foo")}, ks)
+}
+
 func TestBackendBatchIntervalCommit(t *testing.T) {
 	// start backend with super short batch interval so
 	// we do not need to wait long before commit to happen.
@@ -195,6 +256,132 @@ func TestBackendDefrag(t *testing.T) {
 	b.ForceCommit()
 }
 
+// TestBackendDefragWithProgress ensures defragmenting a database spanning
+// several copy batches reports monotonically increasing progress that ends
+// with all bytes copied.
+func TestBackendDefragWithProgress(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, b)
+
+	var want int64
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(schema.Test)
+	for i := 0; i < 3*backend.DefragLimitForTest()+100; i++ {
+		k, v := []byte(fmt.Sprintf("foo_%d", i)), []byte("bar")
+		tx.UnsafePut(schema.Test, k, v)
+		want += int64(len(k) + len(v))
+	}
+	tx.Unlock()
+	b.ForceCommit()
+
+	type update struct{ processed, total int64 }
+	var updates []update
+	err := b.DefragWithProgress(func(processed, total int64) {
+		updates = append(updates, update{processed, total})
+	})
+	require.NoError(t, err)
+
+	// one update before copying, one per committed batch and one at the end
+	require.GreaterOrEqual(t, len(updates), 5)
+	require.Equal(t, int64(0), updates[0].processed)
+	for i, u := range updates {
+		require.Equal(t, updates[0].total, u.total)
+		if i > 0 {
+			require.GreaterOrEqual(t, u.processed, updates[i-1].processed)
+		}
+	}
+	last := updates[len(updates)-1]
+	require.Equal(t, last.total, last.processed)
+	require.GreaterOrEqual(t, last.total, want)
+}
+
+func TestBackendBucketStats(t *testing.T) {
+	b, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
+	defer betesting.Close(t, b)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(schema.Key)
+	tx.UnsafeCreateBucket(schema.Test)
+	tx.UnsafePut(schema.Key, []byte("abc"), []byte("ABC"))
+	tx.UnsafePut(schema.Key, []byte("overwrite"), []byte("1"))
+	for i := 0; i < 10; i++ {
+		tx.UnsafePut(schema.Test, []byte(fmt.Sprintf("foo_%d", i)), []byte("bar"))
+	}
+	tx.Unlock()
+	b.ForceCommit()
+
+	// buffered writes are counted once, replacing committed ones
+	tx.Lock()
+	tx.UnsafePut(schema.Key, []byte("def"), []byte("DEF"))
+	tx.UnsafePut(schema.Key, []byte("overwrite"), []byte("22"))
+	tx.Unlock()
+
+	keys, bytes := b.BucketStats(schema.Key)
+	assert.Equal(t, int64(3), keys)
+	assert.Equal(t, int64(len("abcABCdefDEFoverwrite22")), bytes)
+
+	keys, bytes = b.BucketStats(schema.Test)
+	assert.Equal(t, int64(10), keys)
+	assert.Equal(t, int64(10*len("foo_0bar")), bytes)
+
+	keys, bytes = b.BucketStats(schema.Lease)
+	assert.Zero(t, keys)
+	assert.Zero(t, bytes)
+}
+
+func TestBackendCommitMode(t *testing.T) {
+	b, tmpPath := betesting.NewTmpBackend(t, time.Hour, 10000)
+	defer betesting.Close(t, b)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(schema.Test)
+	tx.Unlock()
+	b.ForceCommit()
+
+	put := func(key string) {
+		tx.Lock()
+		tx.UnsafePut(schema.Test, []byte(key), []byte("bar"))
+		tx.Unlock()
+	}
+
+	require.Equal(t, backend.CommitModeBatched, b.CommitMode())
+	put("batched")
+	assert.Empty(t, crashedKeys(t, tmpPath))
+
+	require.Equal(t, backend.CommitModeBatched, b.SetCommitMode(backend.CommitModeSync))
+	put("sync")
+	assert.Equal(t, []string{"batched", "sync"}, crashedKeys(t, tmpPath))
+
+	require.Equal(t, backend.CommitModeSync, b.SetCommitMode(backend.CommitModeBatched))
+	put("batched-again")
+	assert.Equal(t, []string{"batched", "sync"}, crashedKeys(t, tmpPath))
+}
+
+// crashedKeys returns the keys of the test bucket that would survive a crash
+// of the backend at path, by reading a copy of its file as it is on disk.
+func crashedKeys(t *testing.T, path string) []string {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	copyPath := path + ".crashed"
+	require.NoError(t, os.WriteFile(copyPath, data, 0o600))
+	defer os.Remove(copyPath)
+
+	db, err := bolt.Open(copyPath, 0o600, &bolt.Options{ReadOnly: true})
+	require.NoError(t, err)
+	defer db.Close()
+	var keys []string
+	require.NoError(t, db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(schema.Test.Name()).ForEach(func(k, _ []byte) error {
+			keys = append(keys, string(k))
+			return nil
+		})
+	}))
+	return keys
+}
+
 // TestBackendWriteback ensures writes are stored to the read txn on write txn unlock.
 func TestBackendWriteback(t *testing.T) {
 	b, _ := betesting.NewDefaultTmpBackend(t)
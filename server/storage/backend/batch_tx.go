@@ -107,7 +107,7 @@ func (t *batchTx) LockOutsideApply() {
 }
 
 func (t *batchTx) Unlock() {
-	if t.pending >= t.backend.batchLimit {
+	if t.pending >= t.backend.batchLimit || (t.pending != 0 && t.backend.CommitMode() == CommitModeSync) {
 		t.commit(false)
 	}
 	t.Mutex.Unlock()
@@ -121,6 +121,7 @@ func (t *batchTx) UnsafeCreateBucket(bucket Bucket) {
 			zap.Error(err),
 		)
 	}
+	t.backend.buckets.Store(string(bucket.Name()), bucket)
 	t.pending++
 }
 
@@ -332,7 +333,9 @@ func (t *batchTxBuffered) Unlock() {
 		//
 		// Please also refer to
 		// https://github.com/etcd-io/etcd/pull/17119#issuecomment-1857547158
-		if t.pending >= t.backend.batchLimit || t.pendingDeleteOperations > 0 {
+		//
+		// In CommitModeSync every transaction is committed right away.
+		if t.pending >= t.backend.batchLimit || t.pendingDeleteOperations > 0 || t.backend.CommitMode() == CommitModeSync {
 			t.commit(false)
 		}
 	}
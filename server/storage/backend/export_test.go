@@ -27,3 +27,9 @@ func DefragLimitForTest() int {
 func CommitsForTest(b Backend) int64 {
 	return b.(*backend).Commits()
 }
+
+// UnbufferedPutForTest writes the key to the database file without buffering
+// it for read transactions. The caller must hold the batch tx lock.
+func UnbufferedPutForTest(b Backend, bucket Bucket, key, value []byte) {
+	b.(*backend).batchTx.batchTx.UnsafePut(bucket, key, value)
+}
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
+	bolt "go.etcd.io/bbolt"
 	"go.uber.org/zap"
 
 	"go.etcd.io/etcd/client/pkg/v3/verify"
@@ -94,6 +95,42 @@ func VerifyBackendConsistency(b Backend, lg *zap.Logger, skipSafeRangeBucket boo
 	})
 }
 
+// VerifyAllBuckets verifies data in ReadTx and BatchTx are consistent for
+// every bucket in the backend, e.g. after restoring a snapshot.
+func VerifyAllBuckets(b Backend, lg *zap.Logger) {
+	verify.Verify("bucket data mismatch", func() (bool, map[string]any) {
+		be, ok := b.(*backend)
+		if !ok {
+			return true, nil
+		}
+		if lg != nil {
+			lg.Debug("verifyAllBuckets")
+		}
+		be.BatchTx().LockOutsideApply()
+		defer be.BatchTx().Unlock()
+		be.ReadTx().RLock()
+		defer be.ReadTx().RUnlock()
+		return unsafeVerifyAllBuckets(be)
+	})
+}
+
+// unsafeVerifyAllBuckets returns the combined details of all buckets whose
+// data differ between ReadTx and BatchTx, keyed by bucket name.
+func unsafeVerifyAllBuckets(be *backend) (bool, map[string]any) {
+	var buckets []Bucket
+	be.batchTx.tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+		buckets = append(buckets, be.bucket(name))
+		return nil
+	})
+	mismatches := map[string]any{}
+	for _, bkt := range buckets {
+		if ok, details := unsafeVerifyTxConsistency(be, bkt); !ok {
+			mismatches[bkt.String()] = details
+		}
+	}
+	return len(mismatches) == 0, mismatches
+}
+
 func unsafeVerifyTxConsistency(b Backend, bucket Bucket) (bool, map[string]any) {
 	dataFromWriteTxn := map[string]string{}
 	b.BatchTx().UnsafeForEach(bucket, func(k, v []byte) error {
@@ -15,12 +15,17 @@
 package backend_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"go.uber.org/zap/zaptest"
+
 	"go.etcd.io/etcd/client/pkg/v3/verify"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+	"go.etcd.io/etcd/server/v3/storage/schema"
 )
 
 func TestLockVerify(t *testing.T) {
@@ -90,6 +95,43 @@ func TestLockVerify(t *testing.T) {
 	}
 }
 
+func TestVerifyAllBuckets(t *testing.T) {
+	revertVerifyFunc := verify.EnableAllVerifications()
+	defer revertVerifyFunc()
+
+	be, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
+	defer betesting.Close(t, be)
+
+	tx := be.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(schema.Key)
+	tx.UnsafeCreateBucket(schema.Test)
+	tx.UnsafePut(schema.Key, []byte("foo"), []byte("bar"))
+	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
+	tx.Unlock()
+
+	if p := handlePanic(func() { backend.VerifyAllBuckets(be, zaptest.NewLogger(t)) }); p != nil {
+		t.Fatalf("unexpected mismatch: %v", p)
+	}
+
+	// corrupt the test bucket by writing a key read transactions do not see
+	tx.Lock()
+	backend.UnbufferedPutForTest(be, schema.Test, []byte("baz"), []byte("corrupted"))
+	tx.Unlock()
+
+	p := handlePanic(func() { backend.VerifyAllBuckets(be, zaptest.NewLogger(t)) })
+	if p == nil {
+		t.Fatal("expected mismatch to be reported")
+	}
+	msg := fmt.Sprint(p)
+	if !strings.Contains(msg, schema.Test.String()) || !strings.Contains(msg, "corrupted") {
+		t.Errorf("mismatch %q does not report the corrupted bucket", msg)
+	}
+	if strings.Contains(msg, schema.Key.String()+":") {
+		t.Errorf("mismatch %q reports the consistent bucket", msg)
+	}
+}
+
 func handlePanic(f func()) (result any) {
 	defer func() {
 		result = recover()
@@ -0,0 +1,75 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+// eventHistory keeps the most recent events in memory so unsynced
+// watchers resuming from a recent revision can be served without
+// scanning the backend.
+type eventHistory struct {
+	// buf is a ring buffer of revision-ordered events
+	buf []mvccpb.Event
+	// start is the index of the oldest event in buf
+	start int
+	// size is the number of events held in buf
+	size int
+	// firstRev is the lowest revision whose events are all held in buf
+	firstRev int64
+}
+
+func newEventHistory(capacity int, firstRev int64) *eventHistory {
+	return &eventHistory{
+		buf:      make([]mvccpb.Event, capacity),
+		firstRev: firstRev,
+	}
+}
+
+// add appends the events of a committed revision, evicting the oldest
+// events once the buffer is full.
+func (h *eventHistory) add(evs []mvccpb.Event) {
+	for _, ev := range evs {
+		if h.size < len(h.buf) {
+			h.buf[(h.start+h.size)%len(h.buf)] = ev
+			h.size++
+			continue
+		}
+		h.firstRev = h.buf[h.start].Kv.ModRevision + 1
+		h.buf[h.start] = ev
+		h.start = (h.start + 1) % len(h.buf)
+	}
+}
+
+// rangeEvents returns events in range [minRev, maxRev). The boolean is
+// false if some of those events were already evicted from the history.
+func (h *eventHistory) rangeEvents(minRev, maxRev int64) ([]mvccpb.Event, bool) {
+	if minRev < h.firstRev {
+		return nil, false
+	}
+	var evs []mvccpb.Event
+	for i := 0; i < h.size; i++ {
+		ev := h.buf[(h.start+i)%len(h.buf)]
+		if ev.Kv.ModRevision < minRev {
+			continue
+		}
+		if ev.Kv.ModRevision >= maxRev {
+			break
+		}
+		evs = append(evs, ev)
+	}
+	return evs, true
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestEventHistory(t *testing.T) {
+	evAt := func(rev int64) mvccpb.Event {
+		return mvccpb.Event{Kv: &mvccpb.KeyValue{ModRevision: rev}}
+	}
+	tests := []struct {
+		name     string
+		capacity int
+		revs     [][]int64
+		minRev   int64
+		maxRev   int64
+
+		wantRevs []int64
+		wantOK   bool
+	}{
+		{
+			name:     "all events held",
+			capacity: 4,
+			revs:     [][]int64{{2}, {3}, {4}},
+			minRev:   2,
+			maxRev:   5,
+			wantRevs: []int64{2, 3, 4},
+			wantOK:   true,
+		},
+		{
+			name:     "range limited by maxRev",
+			capacity: 4,
+			revs:     [][]int64{{2}, {3}, {4}},
+			minRev:   3,
+			maxRev:   4,
+			wantRevs: []int64{3},
+			wantOK:   true,
+		},
+		{
+			name:     "no events after minRev",
+			capacity: 4,
+			revs:     [][]int64{{2}, {3}},
+			minRev:   4,
+			maxRev:   4,
+			wantOK:   true,
+		},
+		{
+			name:     "before first revision",
+			capacity: 4,
+			revs:     [][]int64{{2}},
+			minRev:   1,
+			maxRev:   3,
+			wantOK:   false,
+		},
+		{
+			name:     "evicted revision",
+			capacity: 2,
+			revs:     [][]int64{{2}, {3}, {4}},
+			minRev:   2,
+			maxRev:   5,
+			wantOK:   false,
+		},
+		{
+			name:     "after evicted revision",
+			capacity: 2,
+			revs:     [][]int64{{2}, {3}, {4}},
+			minRev:   3,
+			maxRev:   5,
+			wantRevs: []int64{3, 4},
+			wantOK:   true,
+		},
+		{
+			name:     "partially evicted revision",
+			capacity: 3,
+			revs:     [][]int64{{2, 2}, {3, 3}},
+			minRev:   3,
+			maxRev:   4,
+			wantRevs: []int64{3, 3},
+			wantOK:   true,
+		},
+		{
+			name:     "resume from partially evicted revision",
+			capacity: 3,
+			revs:     [][]int64{{2, 2}, {3, 3}},
+			minRev:   2,
+			maxRev:   4,
+			wantOK:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newEventHistory(tt.capacity, 2)
+			for _, revs := range tt.revs {
+				var evs []mvccpb.Event
+				for _, rev := range revs {
+					evs = append(evs, evAt(rev))
+				}
+				h.add(evs)
+			}
+			evs, ok := h.rangeEvents(tt.minRev, tt.maxRev)
+			assert.Equal(t, tt.wantOK, ok)
+			var revs []int64
+			for _, ev := range evs {
+				revs = append(revs, ev.Kv.ModRevision)
+			}
+			assert.Equal(t, tt.wantRevs, revs)
+		})
+	}
+}
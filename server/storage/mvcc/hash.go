@@ -15,6 +15,9 @@
 package mvcc
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
 	"hash"
 	"hash/crc32"
 	"sort"
@@ -30,8 +33,40 @@ const (
 	hashStorageMaxSize = 10
 )
 
-func unsafeHashByRev(tx backend.UnsafeReader, compactRevision, revision int64, keep map[Revision]struct{}) (KeyValueHash, error) {
-	h := newKVHasher(compactRevision, revision, keep)
+// Hash algorithms that can compute a KeyValueHash.
+const (
+	// HashAlgorithmCRC32 is CRC-32 with the Castagnoli polynomial. It is the
+	// default, and the only algorithm known to members older than v3.7.
+	HashAlgorithmCRC32 = "crc32"
+	// HashAlgorithmSHA256 is SHA-256. The Hash of a KeyValueHash holds the
+	// first 4 bytes of its digest, big endian, and the Digest all of it.
+	HashAlgorithmSHA256 = "sha256"
+)
+
+// NewHash returns a new hash.Hash computing the given algorithm. An empty
+// algorithm is HashAlgorithmCRC32.
+func NewHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "", HashAlgorithmCRC32:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli)), nil
+	case HashAlgorithmSHA256:
+		return sha256.New(), nil
+	default:
+		return nil, fmt.Errorf("unknown hash algorithm %q", algorithm)
+	}
+}
+
+// Sum32 returns the hash value of h as a uint32: the checksum of a hash.Hash32,
+// or else the first 4 bytes of the digest, big endian.
+func Sum32(h hash.Hash) uint32 {
+	if h32, ok := h.(hash.Hash32); ok {
+		return h32.Sum32()
+	}
+	return binary.BigEndian.Uint32(h.Sum(nil))
+}
+
+func unsafeHashByRev(tx backend.UnsafeReader, algorithm string, compactRevision, revision int64, keep map[Revision]struct{}) (KeyValueHash, error) {
+	h := newKVHasher(algorithm, compactRevision, revision, keep)
 	err := tx.UnsafeForEach(schema.Key, func(k, v []byte) error {
 		h.WriteKeyValue(k, v)
 		return nil
@@ -40,14 +75,17 @@ func unsafeHashByRev(tx backend.UnsafeReader, compactRevision, revision int64, k
 }
 
 type kvHasher struct {
-	hash            hash.Hash32
+	hash            hash.Hash
 	compactRevision int64
 	revision        int64
 	keep            map[Revision]struct{}
 }
 
-func newKVHasher(compactRev, rev int64, keep map[Revision]struct{}) kvHasher {
-	h := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+func newKVHasher(algorithm string, compactRev, rev int64, keep map[Revision]struct{}) kvHasher {
+	h, err := NewHash(algorithm)
+	if err != nil {
+		panic(err)
+	}
 	h.Write(schema.Key.Name())
 	return kvHasher{
 		hash:            h,
@@ -90,11 +128,14 @@ func (h *kvHasher) WriteKeyValue(k, v []byte) {
 }
 
 func (h *kvHasher) Hash() KeyValueHash {
-	return KeyValueHash{Hash: h.hash.Sum32(), CompactRevision: h.compactRevision, Revision: h.revision}
+	return KeyValueHash{Hash: Sum32(h.hash), Digest: h.hash.Sum(nil), CompactRevision: h.compactRevision, Revision: h.revision}
 }
 
 type KeyValueHash struct {
-	Hash            uint32
+	Hash uint32
+	// Digest is the full digest of the hash algorithm, of which Hash holds
+	// at most 4 bytes.
+	Digest          []byte
 	CompactRevision int64
 	Revision        int64
 }
@@ -116,6 +157,10 @@ type HashStorage interface {
 
 	// Hashes returns list of up to `hashStorageMaxSize` newest previously stored hashes.
 	Hashes() []KeyValueHash
+
+	// HashAlgorithm returns the algorithm computing the hashes returned by
+	// HashByRev and Hashes.
+	HashAlgorithm() string
 }
 
 type hashStorage struct {
@@ -170,6 +215,13 @@ func (s *hashStorage) Store(hash KeyValueHash) {
 	}
 }
 
+func (s *hashStorage) HashAlgorithm() string {
+	if s.store.cfg.HashAlgorithm == "" {
+		return HashAlgorithmCRC32
+	}
+	return s.store.cfg.HashAlgorithm
+}
+
 func (s *hashStorage) Hashes() []KeyValueHash {
 	s.hashMu.RLock()
 	// Copy out hashes under lock just to be safe
@@ -16,6 +16,7 @@ package mvcc
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"testing"
 
@@ -51,25 +52,25 @@ func TestHashByRevValue(t *testing.T) {
 	hash := testHashByRev(t, s, rev+totalRevisions/2)
 	got = append(got, hash)
 	assert.Equal(t, []KeyValueHash{
-		{4082599214, -1, 35},
-		{2279933401, 35, 106},
-		{3284231217, 106, 177},
-		{126286495, 177, 248},
-		{900108730, 248, 319},
-		{2475485232, 319, 390},
-		{1226296507, 390, 461},
-		{2503661030, 461, 532},
-		{4155130747, 532, 603},
-		{106915399, 603, 674},
-		{406914006, 674, 745},
-		{1882211381, 745, 816},
-		{806177088, 816, 887},
-		{664311366, 887, 958},
-		{1496914449, 958, 1029},
-		{2434525091, 1029, 1100},
-		{3988652253, 1100, 1171},
-		{1122462288, 1171, 1242},
-		{724436716, 1242, 1883},
+		{Hash: 4082599214, CompactRevision: -1, Revision: 35},
+		{Hash: 2279933401, CompactRevision: 35, Revision: 106},
+		{Hash: 3284231217, CompactRevision: 106, Revision: 177},
+		{Hash: 126286495, CompactRevision: 177, Revision: 248},
+		{Hash: 900108730, CompactRevision: 248, Revision: 319},
+		{Hash: 2475485232, CompactRevision: 319, Revision: 390},
+		{Hash: 1226296507, CompactRevision: 390, Revision: 461},
+		{Hash: 2503661030, CompactRevision: 461, Revision: 532},
+		{Hash: 4155130747, CompactRevision: 532, Revision: 603},
+		{Hash: 106915399, CompactRevision: 603, Revision: 674},
+		{Hash: 406914006, CompactRevision: 674, Revision: 745},
+		{Hash: 1882211381, CompactRevision: 745, Revision: 816},
+		{Hash: 806177088, CompactRevision: 816, Revision: 887},
+		{Hash: 664311366, CompactRevision: 887, Revision: 958},
+		{Hash: 1496914449, CompactRevision: 958, Revision: 1029},
+		{Hash: 2434525091, CompactRevision: 1029, Revision: 1100},
+		{Hash: 3988652253, CompactRevision: 1100, Revision: 1171},
+		{Hash: 1122462288, CompactRevision: 1171, Revision: 1242},
+		{Hash: 724436716, CompactRevision: 1242, Revision: 1883},
 	}, got)
 }
 
@@ -92,25 +93,25 @@ func TestHashByRevValueLastRevision(t *testing.T) {
 	hash := testHashByRev(t, s, 0)
 	got = append(got, hash)
 	assert.Equal(t, []KeyValueHash{
-		{1913897190, -1, 73},
-		{224860069, 73, 145},
-		{1565167519, 145, 217},
-		{1566261620, 217, 289},
-		{2037173024, 289, 361},
-		{691659396, 361, 433},
-		{2713730748, 433, 505},
-		{3919322507, 505, 577},
-		{769967540, 577, 649},
-		{2909194793, 649, 721},
-		{1576921157, 721, 793},
-		{4067701532, 793, 865},
-		{2226384237, 865, 937},
-		{2923408134, 937, 1009},
-		{2680329256, 1009, 1081},
-		{1546717673, 1081, 1153},
-		{2713657846, 1153, 1225},
-		{1046575299, 1225, 1297},
-		{2017735779, 1297, 2508},
+		{Hash: 1913897190, CompactRevision: -1, Revision: 73},
+		{Hash: 224860069, CompactRevision: 73, Revision: 145},
+		{Hash: 1565167519, CompactRevision: 145, Revision: 217},
+		{Hash: 1566261620, CompactRevision: 217, Revision: 289},
+		{Hash: 2037173024, CompactRevision: 289, Revision: 361},
+		{Hash: 691659396, CompactRevision: 361, Revision: 433},
+		{Hash: 2713730748, CompactRevision: 433, Revision: 505},
+		{Hash: 3919322507, CompactRevision: 505, Revision: 577},
+		{Hash: 769967540, CompactRevision: 577, Revision: 649},
+		{Hash: 2909194793, CompactRevision: 649, Revision: 721},
+		{Hash: 1576921157, CompactRevision: 721, Revision: 793},
+		{Hash: 4067701532, CompactRevision: 793, Revision: 865},
+		{Hash: 2226384237, CompactRevision: 865, Revision: 937},
+		{Hash: 2923408134, CompactRevision: 937, Revision: 1009},
+		{Hash: 2680329256, CompactRevision: 1009, Revision: 1081},
+		{Hash: 1546717673, CompactRevision: 1081, Revision: 1153},
+		{Hash: 2713657846, CompactRevision: 1153, Revision: 1225},
+		{Hash: 1046575299, CompactRevision: 1225, Revision: 1297},
+		{Hash: 2017735779, CompactRevision: 1297, Revision: 2508},
 	}, got)
 }
 
@@ -126,8 +127,10 @@ func testHashByRev(t *testing.T, s *store, rev int64) KeyValueHash {
 	}
 	hash, _, err := s.hashByRev(rev)
 	require.NoErrorf(t, err, "error on rev %v", rev)
-	_, err = s.Compact(traceutil.TODO(), rev)
+	_, err = s.Compact(t.Context(), traceutil.TODO(), rev)
 	assert.NoErrorf(t, err, "error on compact %v", rev)
+	// the digest of a crc32 hash is the hash itself
+	hash.Digest = nil
 	return hash
 }
 
@@ -141,6 +144,46 @@ func TestCompactionHash(t *testing.T) {
 	testutil.TestCompactionHash(t.Context(), t, hashTestCase{s}, s.cfg.CompactionBatchLimit)
 }
 
+// TestHashAlgorithm tests that each hash algorithm computes its own hash of
+// the same key-value store, and that compaction computes the same hash.
+func TestHashAlgorithm(t *testing.T) {
+	tcs := []struct {
+		algorithm  string
+		wantHash   uint32
+		digestSize int
+	}{
+		{algorithm: "", wantHash: 0x82a92a66, digestSize: 4},
+		{algorithm: HashAlgorithmCRC32, wantHash: 0x82a92a66, digestSize: 4},
+		{algorithm: HashAlgorithmSHA256, wantHash: 0x538b3cc2, digestSize: 32},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.algorithm, func(t *testing.T) {
+			b, _ := betesting.NewDefaultTmpBackend(t)
+			s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{HashAlgorithm: tc.algorithm})
+			defer cleanup(s, b)
+
+			putKVs(s, 0, 1210)
+			hash, _, err := s.HashStorage().HashByRev(0)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantHash, hash.Hash)
+			require.Len(t, hash.Digest, tc.digestSize)
+			assert.Equal(t, tc.wantHash, binary.BigEndian.Uint32(hash.Digest))
+
+			testutil.TestCompactionHash(t.Context(), t, hashTestCase{s}, s.cfg.CompactionBatchLimit)
+		})
+	}
+}
+
+func TestNewHash(t *testing.T) {
+	h, err := NewHash(HashAlgorithmSHA256)
+	require.NoError(t, err)
+	h.Write([]byte("foo"))
+	assert.Equal(t, uint32(0x2c26b46b), Sum32(h))
+
+	_, err = NewHash("md5")
+	require.ErrorContains(t, err, `unknown hash algorithm "md5"`)
+}
+
 type hashTestCase struct {
 	*store
 }
@@ -165,7 +208,7 @@ func (tc hashTestCase) Defrag(ctx context.Context) error {
 }
 
 func (tc hashTestCase) Compact(ctx context.Context, rev int64) error {
-	done, err := tc.store.Compact(traceutil.TODO(), rev)
+	done, err := tc.store.Compact(ctx, traceutil.TODO(), rev)
 	if err != nil {
 		return err
 	}
@@ -16,6 +16,7 @@ package mvcc
 
 import (
 	"context"
+	"time"
 
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
@@ -123,7 +124,21 @@ type KV interface {
 	HashStorage() HashStorage
 
 	// Compact frees all superseded keys with revisions less than rev.
-	Compact(trace *traceutil.Trace, rev int64) (<-chan struct{}, error)
+	// Keys are freed in batches by a physical compaction scheduled in the
+	// background, which stops after the current batch once ctx is done. An
+	// interrupted compaction leaves the keys of the remaining batches in
+	// place; they are freed when the store is restored or by the next
+	// compaction.
+	Compact(ctx context.Context, trace *traceutil.Trace, rev int64) (<-chan struct{}, error)
+
+	// CompactEstimate returns the estimated number of bytes a compaction at
+	// rev would free, without compacting.
+	CompactEstimate(rev int64) (int64, error)
+
+	// RevisionSince returns the first revision committed at or after t, or
+	// the revision to be committed next if there is none. Commit times are
+	// only known for revisions committed since the store was restored.
+	RevisionSince(t time.Time) (int64, error)
 
 	// Commit commits outstanding txns into the underlying backend.
 	Commit()
@@ -137,6 +152,10 @@ type KV interface {
 type WatchableKV interface {
 	KV
 	Watchable
+
+	// MinUnsyncedRev returns the lowest revision whose events some watcher
+	// has not yet received, and false if all watchers are up to date.
+	MinUnsyncedRev() (int64, bool)
 }
 
 // Watchable is the interface that wraps the NewWatchStream function.
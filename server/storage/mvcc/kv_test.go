@@ -187,7 +187,7 @@ func testKVRangeBadRev(t *testing.T, f rangeFunc) {
 	defer cleanup(s, b)
 
 	put3TestKVs(s)
-	if _, err := s.Compact(traceutil.TODO(), 4); err != nil {
+	if _, err := s.Compact(t.Context(), traceutil.TODO(), 4); err != nil {
 		t.Fatalf("compact error (%v)", err)
 	}
 
@@ -591,7 +591,7 @@ func TestKVCompactReserveLastValue(t *testing.T) {
 		},
 	}
 	for i, tt := range tests {
-		_, err := s.Compact(traceutil.TODO(), tt.rev)
+		_, err := s.Compact(t.Context(), traceutil.TODO(), tt.rev)
 		if err != nil {
 			t.Errorf("#%d: unexpect compact error %v", i, err)
 		}
@@ -627,7 +627,7 @@ func TestKVCompactBad(t *testing.T) {
 		{100, ErrFutureRev},
 	}
 	for i, tt := range tests {
-		_, err := s.Compact(traceutil.TODO(), tt.rev)
+		_, err := s.Compact(t.Context(), traceutil.TODO(), tt.rev)
 		if !errors.Is(err, tt.werr) {
 			t.Errorf("#%d: compact error = %v, want %v", i, err, tt.werr)
 		}
@@ -675,7 +675,7 @@ func TestKVRestore(t *testing.T) {
 		func(kv KV) {
 			kv.Put([]byte("foo"), []byte("bar0"), 1)
 			kv.Put([]byte("foo"), []byte("bar1"), 2)
-			kv.Compact(traceutil.TODO(), 1)
+			kv.Compact(t.Context(), traceutil.TODO(), 1)
 		},
 		func(kv KV) { // after restore, foo1 key only has tombstone revision
 			kv.Put([]byte("foo1"), []byte("bar1"), 0)
@@ -687,7 +687,7 @@ func TestKVRestore(t *testing.T) {
 			assert.Equal(t, int64(7), delAtRev)
 
 			// after compaction and restore, foo1 key only has tombstone revision
-			ch, _ := kv.Compact(traceutil.TODO(), delAtRev)
+			ch, _ := kv.Compact(t.Context(), traceutil.TODO(), delAtRev)
 			<-ch
 		},
 	}
@@ -823,9 +823,10 @@ func TestWatchableKVWatch(t *testing.T) {
 		if resp.WatchID != wid {
 			t.Errorf("resp.WatchID got = %d, want = %d", resp.WatchID, wid)
 		}
-		ev := resp.Events[0]
-		if !reflect.DeepEqual(ev, wev[0]) {
-			t.Errorf("watched event = %+v, want %+v", ev, wev[0])
+		ev, want := resp.Events[0], wev[0]
+		want.Seq = 1
+		if !reflect.DeepEqual(ev, want) {
+			t.Errorf("watched event = %+v, want %+v", ev, want)
 		}
 	case <-time.After(5 * time.Second):
 		// CPU might be too slow, and the routine is not able to switch around
@@ -838,9 +839,10 @@ func TestWatchableKVWatch(t *testing.T) {
 		if resp.WatchID != wid {
 			t.Errorf("resp.WatchID got = %d, want = %d", resp.WatchID, wid)
 		}
-		ev := resp.Events[0]
-		if !reflect.DeepEqual(ev, wev[1]) {
-			t.Errorf("watched event = %+v, want %+v", ev, wev[1])
+		ev, want := resp.Events[0], wev[1]
+		want.Seq = 2
+		if !reflect.DeepEqual(ev, want) {
+			t.Errorf("watched event = %+v, want %+v", ev, want)
 		}
 	case <-time.After(5 * time.Second):
 		testutil.FatalStack(t, "failed to watch the event")
@@ -854,9 +856,10 @@ func TestWatchableKVWatch(t *testing.T) {
 		if resp.WatchID != wid {
 			t.Errorf("resp.WatchID got = %d, want = %d", resp.WatchID, wid)
 		}
-		ev := resp.Events[0]
-		if !reflect.DeepEqual(ev, wev[1]) {
-			t.Errorf("watched event = %+v, want %+v", ev, wev[1])
+		ev, want := resp.Events[0], wev[1]
+		want.Seq = 1
+		if !reflect.DeepEqual(ev, want) {
+			t.Errorf("watched event = %+v, want %+v", ev, want)
 		}
 	case <-time.After(5 * time.Second):
 		testutil.FatalStack(t, "failed to watch the event")
@@ -868,9 +871,10 @@ func TestWatchableKVWatch(t *testing.T) {
 		if resp.WatchID != wid {
 			t.Errorf("resp.WatchID got = %d, want = %d", resp.WatchID, wid)
 		}
-		ev := resp.Events[0]
-		if !reflect.DeepEqual(ev, wev[2]) {
-			t.Errorf("watched event = %+v, want %+v", ev, wev[2])
+		ev, want := resp.Events[0], wev[2]
+		want.Seq = 2
+		if !reflect.DeepEqual(ev, want) {
+			t.Errorf("watched event = %+v, want %+v", ev, want)
 		}
 	case <-time.After(5 * time.Second):
 		testutil.FatalStack(t, "failed to watch the event")
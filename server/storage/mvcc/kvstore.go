@@ -36,6 +36,10 @@ import (
 var (
 	ErrCompacted = errors.New("mvcc: required revision has been compacted")
 	ErrFutureRev = errors.New("mvcc: required revision is a future revision")
+	// ErrRevisionTimeUnknown is returned when no revision is known to have
+	// been committed at or after a given time, because the time precedes the
+	// start of the store.
+	ErrRevisionTimeUnknown = errors.New("mvcc: no revision is known for the given time")
 )
 
 var (
@@ -47,6 +51,39 @@ var (
 type StoreConfig struct {
 	CompactionBatchLimit    int
 	CompactionSleepInterval time.Duration
+	// WatchHistorySize is the number of most recent events kept in memory
+	// to resume unsynced watchers without a backend scan. 0 disables it.
+	WatchHistorySize int
+	// WatchIDQuarantine is the number of subsequent watcher creations on a
+	// watch stream during which a canceled WatchID is not auto-assigned
+	// again. 0 disables the quarantine.
+	WatchIDQuarantine int
+	// WatchVictimRetryInterval is the interval at which pending responses
+	// are resent to watchers whose channel was full. 0 uses the default
+	// of 10ms.
+	WatchVictimRetryInterval time.Duration
+	// TombstoneRetention is the number of revisions up to a compaction
+	// revision whose deletes the compaction keeps in the backend, so that
+	// watchers filtering out puts can resume from them and still receive the
+	// deletes. Other watchers are compacted at the compaction revision as
+	// they would miss the compacted puts. It is counted in revisions rather
+	// than time so that every member keeps the same tombstones. 0 disables
+	// it.
+	TombstoneRetention int64
+	// HashAlgorithm is the algorithm computing the hashes of the key-value
+	// store used to detect corruption, one of HashAlgorithmCRC32 and
+	// HashAlgorithmSHA256. Empty means HashAlgorithmCRC32.
+	HashAlgorithm string
+	// AlwaysSendPrevKVOnDelete makes the delete events sent to watchers
+	// carry the previous key-value of the deleted key, as if every watcher
+	// requested it.
+	AlwaysSendPrevKVOnDelete bool
+	// MaxWatchResponseBytes is the maximum encoded size of the events of a
+	// watch response. Larger responses are split into several responses
+	// carrying the same revision, in order, all but the last one marked as
+	// a fragment; an event larger than the limit is sent alone. 0 disables
+	// it.
+	MaxWatchResponseBytes int
 }
 
 type store struct {
@@ -63,7 +100,8 @@ type store struct {
 
 	le lease.Lessor
 
-	// revMuLock protects currentRev and compactMainRev.
+	// revMuLock protects currentRev, compactMainRev, retainMainRev and
+	// revTimes.
 	// Locked at end of write txn and released after write txn unlock lock.
 	// Locked before locking read txn and released after locking.
 	revMu sync.RWMutex
@@ -71,6 +109,13 @@ type store struct {
 	currentRev int64
 	// compactMainRev is the main revision of the last compaction.
 	compactMainRev int64
+	// retainMainRev is the lowest revision from which every delete is still
+	// in the backend. Watchers filtering out puts from it on are synced
+	// rather than compacted.
+	retainMainRev int64
+	// revTimes records when the revisions since the store was restored
+	// were committed.
+	revTimes revisionTimes
 
 	fifoSched schedule.Scheduler
 
@@ -102,6 +147,7 @@ func NewStore(lg *zap.Logger, b backend.Backend, le lease.Lessor, cfg StoreConfi
 
 		currentRev:     1,
 		compactMainRev: -1,
+		retainMainRev:  -1,
 
 		fifoSched: schedule.NewFIFOScheduler(lg),
 
@@ -188,7 +234,7 @@ func (s *store) hashByRev(rev int64) (hash KeyValueHash, currentRev int64, err e
 	tx.RLock()
 	defer tx.RUnlock()
 	s.mu.RUnlock()
-	hash, err = unsafeHashByRev(tx, compactRev, rev, keep)
+	hash, err = unsafeHashByRev(tx, s.cfg.HashAlgorithm, compactRev, rev, keep)
 	hashRevSec.Observe(time.Since(start).Seconds())
 	return hash, currentRev, err
 }
@@ -208,6 +254,13 @@ func (s *store) updateCompactRev(rev int64) (<-chan struct{}, int64, error) {
 	}
 	compactMainRev := s.compactMainRev
 	s.compactMainRev = rev
+	if s.cfg.TombstoneRetention > 0 {
+		// deletes from retainMainRev on were kept by the previous compactions
+		s.retainMainRev = min(rev, max(s.retainMainRev, rev-s.cfg.TombstoneRetention+1))
+	} else {
+		s.retainMainRev = rev
+	}
+	s.revTimes.compact(rev)
 
 	SetScheduledCompact(s.b.BatchTx(), rev)
 	// ensure that desired compaction is persisted
@@ -230,14 +283,14 @@ func (s *store) checkPrevCompactionCompleted() bool {
 	return scheduledCompact == finishedCompact && scheduledCompactFound == finishedCompactFound
 }
 
-func (s *store) compact(trace *traceutil.Trace, rev, prevCompactRev int64, prevCompactionCompleted bool) <-chan struct{} {
+func (s *store) compact(ctx context.Context, trace *traceutil.Trace, rev, prevCompactRev int64, prevCompactionCompleted bool) <-chan struct{} {
 	ch := make(chan struct{})
-	j := schedule.NewJob("kvstore_compact", func(ctx context.Context) {
-		if ctx.Err() != nil {
-			s.compactBarrier(ctx, ch)
+	j := schedule.NewJob("kvstore_compact", func(jctx context.Context) {
+		if jctx.Err() != nil {
+			s.compactBarrier(jctx, ch)
 			return
 		}
-		hash, err := s.scheduleCompaction(rev, prevCompactRev)
+		hash, err := s.scheduleCompaction(ctx, rev, prevCompactRev)
 		if err != nil {
 			s.lg.Warn("Failed compaction", zap.Error(err))
 			s.compactBarrier(context.TODO(), ch)
@@ -265,10 +318,10 @@ func (s *store) compactLockfree(rev int64) (<-chan struct{}, error) {
 		return ch, err
 	}
 
-	return s.compact(traceutil.TODO(), rev, prevCompactRev, prevCompactionCompleted), nil
+	return s.compact(context.Background(), traceutil.TODO(), rev, prevCompactRev, prevCompactionCompleted), nil
 }
 
-func (s *store) Compact(trace *traceutil.Trace, rev int64) (<-chan struct{}, error) {
+func (s *store) Compact(ctx context.Context, trace *traceutil.Trace, rev int64) (<-chan struct{}, error) {
 	s.mu.Lock()
 	prevCompactionCompleted := s.checkPrevCompactionCompleted()
 	ch, prevCompactRev, err := s.updateCompactRev(rev)
@@ -279,7 +332,49 @@ func (s *store) Compact(trace *traceutil.Trace, rev int64) (<-chan struct{}, err
 	}
 	s.mu.Unlock()
 
-	return s.compact(trace, rev, prevCompactRev, prevCompactionCompleted), nil
+	return s.compact(ctx, trace, rev, prevCompactRev, prevCompactionCompleted), nil
+}
+
+func (s *store) CompactEstimate(rev int64) (int64, error) {
+	s.mu.RLock()
+	s.revMu.RLock()
+	compactRev, currentRev := s.compactMainRev, s.currentRev
+	s.revMu.RUnlock()
+
+	if rev <= compactRev {
+		s.mu.RUnlock()
+		return 0, ErrCompacted
+	}
+	if rev > currentRev {
+		s.mu.RUnlock()
+		return 0, ErrFutureRev
+	}
+	keep := s.kvindex.Keep(rev)
+
+	// the scan may be long, so it must not block writes
+	tx := s.b.ConcurrentReadTx()
+	tx.RLock()
+	defer tx.RUnlock()
+	s.mu.RUnlock()
+
+	var reclaimable int64
+	err := tx.UnsafeForEach(schema.Key, func(k, v []byte) error {
+		kr := BytesToRev(k)
+		if kr.Main > rev {
+			return nil
+		}
+		if _, ok := keep[kr]; !ok {
+			reclaimable += int64(len(k) + len(v))
+		}
+		return nil
+	})
+	return reclaimable, err
+}
+
+func (s *store) RevisionSince(t time.Time) (int64, error) {
+	s.revMu.RLock()
+	defer s.revMu.RUnlock()
+	return s.revTimes.since(t)
 }
 
 func (s *store) Commit() {
@@ -303,6 +398,7 @@ func (s *store) Restore(b backend.Backend) error {
 		s.revMu.Lock()
 		s.currentRev = 1
 		s.compactMainRev = -1
+		s.retainMainRev = -1
 		s.revMu.Unlock()
 	}
 
@@ -339,6 +435,10 @@ func (s *store) restore() error {
 		s.revMu.Unlock()
 	}
 	scheduledCompact, _ := UnsafeReadScheduledCompact(tx)
+	// Compactions delete the tombstones up to a revision below the retention
+	// window, so the tombstones left at or below the compaction revision are
+	// all the deletes from the oldest of them on.
+	retainMainRev, findRetained := finishedCompact, found
 	// index keys concurrently as they're loaded in from tx
 	keysGauge.Set(0)
 	rkvc, revc := restoreIntoIndex(s.lg, s.kvindex)
@@ -347,6 +447,13 @@ func (s *store) restore() error {
 		if len(keys) == 0 {
 			break
 		}
+		for i := 0; findRetained && i < len(keys); i++ {
+			if rev := BytesToRev(keys[i]); rev.Main > finishedCompact {
+				findRetained = false
+			} else if isTombstone(keys[i]) {
+				retainMainRev, findRetained = rev.Main, false
+			}
+		}
 		// rkvc blocks if the total pending keys exceeds the restore
 		// chunk size to keep keys from consuming too much memory.
 		restoreChunk(s.lg, rkvc, keys, vals, keyToLease)
@@ -382,6 +489,10 @@ func (s *store) restore() error {
 		if s.currentRev < scheduledCompact {
 			s.currentRev = scheduledCompact
 		}
+		if found {
+			s.retainMainRev = retainMainRev
+		}
+		s.revTimes.reset(s.currentRev+1, time.Now())
 		s.revMu.Unlock()
 	}
 
@@ -15,6 +15,7 @@
 package mvcc
 
 import (
+	"context"
 	"encoding/binary"
 	"fmt"
 	"time"
@@ -25,7 +26,7 @@ import (
 	"go.etcd.io/etcd/server/v3/storage/schema"
 )
 
-func (s *store) scheduleCompaction(compactMainRev, prevCompactRev int64) (KeyValueHash, error) {
+func (s *store) scheduleCompaction(ctx context.Context, compactMainRev, prevCompactRev int64) (KeyValueHash, error) {
 	totalStart := time.Now()
 	keep := s.kvindex.Compact(compactMainRev)
 	indexCompactionPauseMs.Observe(float64(time.Since(totalStart) / time.Millisecond))
@@ -39,8 +40,12 @@ func (s *store) scheduleCompaction(compactMainRev, prevCompactRev int64) (KeyVal
 	end := make([]byte, 8)
 	binary.BigEndian.PutUint64(end, uint64(compactMainRev+1))
 
+	// deletes after retainRev are kept for watchers resuming from them
+	retainRev := compactMainRev - s.cfg.TombstoneRetention
+	tombstonesRetained := 0
+
 	batchNum := s.cfg.CompactionBatchLimit
-	h := newKVHasher(prevCompactRev, compactMainRev, keep)
+	h := newKVHasher(s.cfg.HashAlgorithm, prevCompactRev, compactMainRev, keep)
 	last := make([]byte, 8+1+8)
 	for {
 		var rev Revision
@@ -54,8 +59,12 @@ func (s *store) scheduleCompaction(compactMainRev, prevCompactRev int64) (KeyVal
 		for i := range keys {
 			rev = BytesToRev(keys[i])
 			if _, ok := keep[rev]; !ok {
-				tx.UnsafeDelete(schema.Key, keys[i])
-				keyCompactions++
+				if rev.Main > retainRev && isTombstone(keys[i]) {
+					tombstonesRetained++
+				} else {
+					tx.UnsafeDelete(schema.Key, keys[i])
+					keyCompactions++
+				}
 			}
 			h.WriteKeyValue(keys[i], values[i])
 		}
@@ -73,6 +82,7 @@ func (s *store) scheduleCompaction(compactMainRev, prevCompactRev int64) (KeyVal
 				zap.Int64("compact-revision", compactMainRev),
 				zap.Duration("took", time.Since(totalStart)),
 				zap.Int("number-of-keys-compacted", keyCompactions),
+				zap.Int("number-of-tombstones-retained", tombstonesRetained),
 				zap.Uint32("hash", hash.Hash),
 				zap.Int64("current-db-size-bytes", size),
 				zap.String("current-db-size", humanize.Bytes(uint64(size))),
@@ -95,6 +105,8 @@ func (s *store) scheduleCompaction(compactMainRev, prevCompactRev int64) (KeyVal
 		case <-time.After(s.cfg.CompactionSleepInterval):
 		case <-s.stopc:
 			return KeyValueHash{}, fmt.Errorf("interrupted due to stop signal")
+		case <-ctx.Done():
+			return KeyValueHash{}, fmt.Errorf("interrupted: %w", ctx.Err())
 		}
 	}
 }
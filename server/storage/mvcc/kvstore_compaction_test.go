@@ -15,6 +15,7 @@
 package mvcc
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -82,7 +83,7 @@ func TestScheduleCompaction(t *testing.T) {
 		}
 		tx.Unlock()
 
-		_, err := s.scheduleCompaction(tt.rev, 0)
+		_, err := s.scheduleCompaction(t.Context(), tt.rev, 0)
 		if err != nil {
 			t.Error(err)
 		}
@@ -118,7 +119,7 @@ func TestCompactAllAndRestore(t *testing.T) {
 
 	rev := s0.Rev()
 	// compact all keys
-	done, err := s0.Compact(traceutil.TODO(), rev)
+	done, err := s0.Compact(t.Context(), traceutil.TODO(), rev)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -147,3 +148,97 @@ func TestCompactAllAndRestore(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestCompactEstimate(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s.Put([]byte("foo"), []byte("bar1"), lease.NoLease)
+	s.Put([]byte("foo"), []byte("bar2"), lease.NoLease)
+	s.Put([]byte("baz"), []byte("bar"), lease.NoLease)
+
+	var want int64
+	tx := s.b.ReadTx()
+	tx.RLock()
+	for _, rev := range []Revision{{Main: 2}, {Main: 3}} {
+		k := RevToBytes(rev, NewRevBytes())
+		_, vals := tx.UnsafeRange(schema.Key, k, nil, 0)
+		if len(vals) != 1 {
+			tx.RUnlock()
+			t.Fatalf("len(vals) = %d, want 1", len(vals))
+		}
+		want += int64(len(k) + len(vals[0]))
+	}
+	tx.RUnlock()
+
+	got, err := s.CompactEstimate(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("reclaimable = %d, want %d", got, want)
+	}
+	// the estimate must not compact
+	if _, err = s.Range(t.Context(), []byte("foo"), nil, RangeOptions{Rev: 2}); err != nil {
+		t.Errorf("unexpected range error %v", err)
+	}
+
+	if _, err = s.CompactEstimate(6); err != ErrFutureRev {
+		t.Errorf("err = %v, want %v", err, ErrFutureRev)
+	}
+	done, err := s.Compact(t.Context(), traceutil.TODO(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-done
+	if _, err = s.CompactEstimate(4); err != ErrCompacted {
+		t.Errorf("err = %v, want %v", err, ErrCompacted)
+	}
+}
+
+func TestCompactionBatchesYieldToRange(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{
+		CompactionBatchLimit:    100,
+		CompactionSleepInterval: time.Millisecond,
+	})
+	defer cleanup(s, b)
+
+	for i := 0; i < 5000; i++ {
+		s.Put([]byte(fmt.Sprintf("foo%d", i%100)), []byte("bar"), lease.NoLease)
+	}
+	s.Commit()
+
+	done, err := s.Compact(t.Context(), traceutil.TODO(), s.Rev())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ranges interleave with the compaction batches instead of waiting
+	// for the whole keyspace to be compacted
+	const maxLatency = 500 * time.Millisecond
+	timeout := time.After(10 * time.Second)
+	ranges := 0
+	for {
+		select {
+		case <-done:
+			if ranges == 0 {
+				t.Fatal("compaction finished before any range was served")
+			}
+			return
+		case <-timeout:
+			t.Fatal("timeout waiting for compaction to finish")
+		default:
+		}
+		start := time.Now()
+		if _, err = s.Range(t.Context(), []byte("foo0"), nil, RangeOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if took := time.Since(start); took > maxLatency {
+			t.Fatalf("range took %v during compaction, want at most %v", took, maxLatency)
+		}
+		ranges++
+	}
+}
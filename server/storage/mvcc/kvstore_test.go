@@ -341,7 +341,7 @@ func TestStoreCompact(t *testing.T) {
 	b.tx.rangeRespc <- rangeResp{[][]byte{}, [][]byte{}}
 	b.tx.rangeRespc <- rangeResp{[][]byte{key1, key2}, [][]byte{[]byte("alice"), []byte("bob")}}
 
-	s.Compact(traceutil.TODO(), 3)
+	s.Compact(t.Context(), traceutil.TODO(), 3)
 	s.fifoSched.WaitFinish(1)
 
 	if s.compactMainRev != 3 {
@@ -623,7 +623,7 @@ func TestHashKVWhenCompacting(t *testing.T) {
 			default:
 			}
 
-			_, err := s.Compact(traceutil.TODO(), int64(rev-i))
+			_, err := s.Compact(t.Context(), traceutil.TODO(), int64(rev-i))
 			if err != nil {
 				t.Error(err)
 			}
@@ -659,7 +659,7 @@ func TestHashKVWithCompactedAndFutureRevisions(t *testing.T) {
 	for i := 2; i <= rev; i++ {
 		s.Put([]byte("foo"), []byte(fmt.Sprintf("bar%d", i)), lease.NoLease)
 	}
-	if _, err := s.Compact(traceutil.TODO(), int64(compactRev)); err != nil {
+	if _, err := s.Compact(t.Context(), traceutil.TODO(), int64(compactRev)); err != nil {
 		t.Fatal(err)
 	}
 
@@ -690,7 +690,7 @@ func TestHashKVZeroRevision(t *testing.T) {
 	for i := 2; i <= rev; i++ {
 		s.Put([]byte("foo"), []byte(fmt.Sprintf("bar%d", i)), lease.NoLease)
 	}
-	if _, err := s.Compact(traceutil.TODO(), int64(rev/2)); err != nil {
+	if _, err := s.Compact(t.Context(), traceutil.TODO(), int64(rev/2)); err != nil {
 		t.Fatal(err)
 	}
 
@@ -703,7 +703,7 @@ func TestHashKVZeroRevision(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if hash1 != hash2 {
+	if !reflect.DeepEqual(hash1, hash2) {
 		t.Errorf("hash %d (rev %d) != hash %d (rev 0)", hash1, rev, hash2)
 	}
 }
@@ -997,9 +997,14 @@ func (b *fakeBackend) Hash(func(bucketName, keyName []byte) bool) (uint32, error
 func (b *fakeBackend) Size() int64                                                { return 0 }
 func (b *fakeBackend) SizeInUse() int64                                           { return 0 }
 func (b *fakeBackend) OpenReadTxN() int64                                         { return 0 }
+func (b *fakeBackend) BucketStats(backend.Bucket) (int64, int64)                  { return 0, 0 }
 func (b *fakeBackend) Snapshot() backend.Snapshot                                 { return nil }
+func (b *fakeBackend) ConcurrentSnapshot() (backend.Snapshot, error)              { return nil, nil }
 func (b *fakeBackend) ForceCommit()                                               {}
+func (b *fakeBackend) CommitMode() backend.CommitMode                             { return 0 }
+func (b *fakeBackend) SetCommitMode(backend.CommitMode) backend.CommitMode        { return 0 }
 func (b *fakeBackend) Defrag() error                                              { return nil }
+func (b *fakeBackend) DefragWithProgress(backend.DefragProgressFunc) error        { return nil }
 func (b *fakeBackend) Close() error                                               { return nil }
 func (b *fakeBackend) SetTxPostLockInsideApplyHook(func())                        {}
 
@@ -17,6 +17,7 @@ package mvcc
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -80,6 +81,11 @@ func (tr *storeTxnCommon) rangeKeys(ctx context.Context, key, end []byte, curRev
 	if rev < tr.s.compactMainRev {
 		return &RangeResult{KVs: nil, Count: -1, Rev: 0}, ErrCompacted
 	}
+	// the in-memory index walk below does not check ctx; skip it if the
+	// request is already gone.
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("rangeKeys: context cancelled: %w", err)
+	}
 	if ro.Count {
 		total := tr.s.kvindex.CountRevisions(key, end, rev)
 		tr.trace.Step("count revisions from in-memory index tree")
@@ -185,6 +191,7 @@ func (tw *storeTxnWrite) End() {
 		// hold revMu lock to prevent new read txns from opening until writeback.
 		tw.s.revMu.Lock()
 		tw.s.currentRev++
+		tw.s.revTimes.record(tw.s.currentRev, time.Now())
 	}
 	tw.tx.Unlock()
 	if len(tw.changes) != 0 {
@@ -0,0 +1,99 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"sort"
+	"time"
+)
+
+// maxRevisionTimes bounds the number of revisions whose commit time is kept
+// when they are not compacted.
+const maxRevisionTimes = 1 << 21
+
+// revisionTimes records the time at which each revision was committed by
+// this member, so that a revision can be looked up by time. It is held in
+// memory only; revisions committed before the store was opened or restored
+// have no known time.
+type revisionTimes struct {
+	// start is the time, in unix nanoseconds, from which every committed
+	// revision is recorded.
+	start int64
+	// compacted is true if start was last moved forward by a compaction.
+	compacted bool
+	// first is the revision committed at times[0].
+	first int64
+	// times holds the commit time, in unix nanoseconds, of each revision
+	// from first on. It never decreases, even if the wall clock does.
+	times []int64
+}
+
+// reset discards all recorded times, recording from next, the revision to be
+// committed next, at t.
+func (rt *revisionTimes) reset(next int64, t time.Time) {
+	*rt = revisionTimes{start: t.UnixNano(), first: next}
+}
+
+// record records that rev was committed at t.
+func (rt *revisionTimes) record(rev int64, t time.Time) {
+	if rev != rt.first+int64(len(rt.times)) {
+		rt.reset(rev, t)
+	}
+	if len(rt.times) >= maxRevisionTimes {
+		rt.drop(maxRevisionTimes / 4)
+		rt.compacted = false
+	}
+	ts := t.UnixNano()
+	if n := len(rt.times); n > 0 && ts < rt.times[n-1] {
+		ts = rt.times[n-1]
+	}
+	rt.times = append(rt.times, ts)
+}
+
+// compact discards the times of revisions up to and including rev.
+func (rt *revisionTimes) compact(rev int64) {
+	n := rev - rt.first + 1
+	if n <= 0 {
+		return
+	}
+	rt.drop(int(min(n, int64(len(rt.times)))))
+	rt.compacted = true
+}
+
+// drop discards the oldest n recorded times.
+func (rt *revisionTimes) drop(n int) {
+	if n == 0 {
+		return
+	}
+	rt.start = rt.times[n-1] + 1
+	rt.first += int64(n)
+	rt.times = append([]int64(nil), rt.times[n:]...)
+}
+
+// since returns the first revision committed at or after t, which is the
+// revision to be committed next if none was. It returns ErrCompacted or
+// ErrRevisionTimeUnknown if the revisions committed around t are no longer
+// or were never recorded.
+func (rt *revisionTimes) since(t time.Time) (int64, error) {
+	ts := t.UnixNano()
+	if ts < rt.start {
+		if rt.compacted {
+			return 0, ErrCompacted
+		}
+		return 0, ErrRevisionTimeUnknown
+	}
+	i := sort.Search(len(rt.times), func(i int) bool { return rt.times[i] >= ts })
+	return rt.first + int64(i), nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/lease"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+)
+
+func TestRevisionTimes(t *testing.T) {
+	base := time.Unix(1000, 0)
+	at := func(sec int) time.Time { return base.Add(time.Duration(sec) * time.Second) }
+
+	var rt revisionTimes
+	rt.reset(5, at(0))
+	rt.record(5, at(10))
+	rt.record(6, at(20))
+	// the clock going backward does not break the ordering
+	rt.record(7, at(15))
+	rt.record(8, at(30))
+
+	tcs := []struct {
+		t       time.Time
+		wantRev int64
+		wantErr error
+	}{
+		{t: at(-1), wantErr: ErrRevisionTimeUnknown},
+		{t: at(0), wantRev: 5},
+		{t: at(10), wantRev: 5},
+		{t: at(11), wantRev: 6},
+		{t: at(20), wantRev: 6},
+		{t: at(21), wantRev: 8},
+		{t: at(31), wantRev: 9},
+	}
+	for _, tc := range tcs {
+		rev, err := rt.since(tc.t)
+		require.ErrorIs(t, err, tc.wantErr)
+		assert.Equal(t, tc.wantRev, rev, "time %v", tc.t)
+	}
+
+	rt.compact(5)
+	_, err := rt.since(at(10))
+	require.ErrorIs(t, err, ErrCompacted)
+	rev, err := rt.since(at(10).Add(time.Nanosecond))
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), rev)
+
+	// a revision out of sequence restarts the recording
+	rt.record(20, at(40))
+	_, err = rt.since(at(30))
+	require.ErrorIs(t, err, ErrRevisionTimeUnknown)
+	rev, err = rt.since(at(40))
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), rev)
+}
+
+func TestStoreRevisionSince(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	var times []time.Time
+	for i := 0; i < 3; i++ {
+		times = append(times, time.Now())
+		s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	}
+
+	for i, ti := range times {
+		rev, err := s.RevisionSince(ti)
+		require.NoError(t, err)
+		assert.Equal(t, int64(i+2), rev)
+	}
+	rev, err := s.RevisionSince(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), rev)
+
+	_, err = s.RevisionSince(times[0].Add(-time.Hour))
+	require.ErrorIs(t, err, ErrRevisionTimeUnknown)
+
+	_, err = s.Compact(t.Context(), traceutil.TODO(), 3)
+	require.NoError(t, err)
+	_, err = s.RevisionSince(times[1])
+	require.ErrorIs(t, err, ErrCompacted)
+	rev, err = s.RevisionSince(times[2])
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), rev)
+}
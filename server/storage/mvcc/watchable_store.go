@@ -41,14 +41,20 @@ var (
 
 	// maxResyncPeriod is the period of executing resync.
 	watchResyncPeriod = 100 * time.Millisecond
+
+	// defaultWatchVictimRetryInterval is the default period of resending
+	// responses to victim watchers.
+	defaultWatchVictimRetryInterval = 10 * time.Millisecond
 )
 
 func ChanBufLen() int { return chanBufLen }
 
 type watchable interface {
-	watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc)
+	watch(ranges []KeyRange, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc)
 	progress(w *watcher)
+	progressFrom(w *watcher, minRev int64)
 	progressAll(watchers map[WatchID]*watcher) bool
+	flushed(watchers []*watcher) bool
 	rev() int64
 }
 
@@ -70,6 +76,9 @@ type watchableStore struct {
 	// The key of the map is the key that the watcher watches on.
 	synced watcherGroup
 
+	// history holds recent events to sync watchers from; nil if disabled.
+	history *eventHistory
+
 	stopc chan struct{}
 	wg    sync.WaitGroup
 }
@@ -101,6 +110,7 @@ func newWatchableStore(lg *zap.Logger, b backend.Backend, le lease.Lessor, cfg S
 	}
 	s.store.ReadView = &readView{s}
 	s.store.WriteView = &writeView{s}
+	s.resetHistory()
 	if s.le != nil {
 		// use this store as the deleter so revokes trigger watch events
 		s.le.SetRangeDeleter(func() lease.TxnDelete { return s.Write(traceutil.TODO()) })
@@ -117,26 +127,34 @@ func (s *watchableStore) Close() error {
 func (s *watchableStore) NewWatchStream() WatchStream {
 	watchStreamGauge.Inc()
 	return &watchStream{
-		watchable: s,
-		ch:        make(chan WatchResponse, chanBufLen),
-		cancels:   make(map[WatchID]cancelFunc),
-		watchers:  make(map[WatchID]*watcher),
+		watchable:  s,
+		ch:         make(chan WatchResponse, chanBufLen),
+		cancels:    make(map[WatchID]cancelFunc),
+		watchers:   make(map[WatchID]*watcher),
+		quarantine: s.store.cfg.WatchIDQuarantine,
 	}
 }
 
-func (s *watchableStore) watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc) {
+func (s *watchableStore) watch(ranges []KeyRange, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc) {
 	wa := &watcher{
-		key:      key,
-		end:      end,
+		key:      ranges[0].Key,
+		end:      ranges[0].End,
 		startRev: startRev,
 		minRev:   startRev,
 		id:       id,
 		ch:       ch,
 		fcs:      fcs,
+
+		deletesOnly:      filtersPuts(fcs),
+		maxResponseBytes: s.store.cfg.MaxWatchResponseBytes,
+	}
+	if len(ranges) > 1 {
+		wa.ranges = ranges
 	}
 
 	s.mu.Lock()
 	s.revMu.RLock()
+	wa.createdRev = s.store.currentRev
 	synced := startRev > s.store.currentRev || startRev == 0
 	if synced {
 		wa.minRev = s.store.currentRev + 1
@@ -156,6 +174,22 @@ func (s *watchableStore) watch(key, end []byte, startRev int64, id WatchID, ch c
 	return wa, func() { s.cancelWatcher(wa) }
 }
 
+// flushed returns true if no events are pending for the given watchers,
+// that is, each of them is either synced or canceled.
+func (s *watchableStore) flushed(watchers []*watcher) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, w := range watchers {
+		if w.compacted || w.ch == nil {
+			continue
+		}
+		if _, ok := s.synced.watchers[w]; !ok || w.victim {
+			return false
+		}
+	}
+	return true
+}
+
 // cancelWatcher removes references of the watcher from the watchableStore
 func (s *watchableStore) cancelWatcher(wa *watcher) {
 	for {
@@ -189,6 +223,7 @@ func (s *watchableStore) cancelWatcher(wa *watcher) {
 		}
 		if victimBatch != nil {
 			slowWatcherGauge.Dec()
+			victimWatcherGauge.Dec()
 			watcherGauge.Dec()
 			delete(victimBatch, wa)
 			break
@@ -216,9 +251,21 @@ func (s *watchableStore) Restore(b backend.Backend) error {
 		s.unsynced.add(wa)
 	}
 	s.synced = newWatcherGroup()
+	s.resetHistory()
 	return nil
 }
 
+// resetHistory drops all buffered events; the history then only covers
+// revisions after the current store revision.
+func (s *watchableStore) resetHistory() {
+	if s.store.cfg.WatchHistorySize <= 0 {
+		return
+	}
+	s.store.revMu.RLock()
+	s.history = newEventHistory(s.store.cfg.WatchHistorySize, s.store.currentRev+1)
+	s.store.revMu.RUnlock()
+}
+
 // syncWatchersLoop syncs the watcher in the unsynced map every 100ms.
 func (s *watchableStore) syncWatchersLoop() {
 	defer s.wg.Done()
@@ -269,7 +316,7 @@ func (s *watchableStore) syncVictimsLoop() {
 
 		var tickc <-chan time.Time
 		if !isEmpty {
-			tickc = time.After(10 * time.Millisecond)
+			tickc = time.After(s.victimRetryInterval())
 		}
 
 		select {
@@ -281,6 +328,13 @@ func (s *watchableStore) syncVictimsLoop() {
 	}
 }
 
+func (s *watchableStore) victimRetryInterval() time.Duration {
+	if s.store.cfg.WatchVictimRetryInterval > 0 {
+		return s.store.cfg.WatchVictimRetryInterval
+	}
+	return defaultWatchVictimRetryInterval
+}
+
 // moveVictims tries to update watches with already pending event data
 func (s *watchableStore) moveVictims() (moved int) {
 	s.mu.Lock()
@@ -315,6 +369,7 @@ func (s *watchableStore) moveVictims() (moved int) {
 				continue
 			}
 			w.victim = false
+			victimWatcherGauge.Dec()
 			if eb.moreRev != 0 {
 				w.minRev = eb.moreRev
 			}
@@ -359,14 +414,20 @@ func (s *watchableStore) syncWatchers(evs []mvccpb.Event) (int, []mvccpb.Event)
 	// query the backend store of key-value pairs
 	curRev := s.store.currentRev
 	compactionRev := s.store.compactMainRev
+	retainRev := s.store.retainMainRev
 
-	wg, minRev := s.unsynced.choose(maxWatchersPerSync, curRev, compactionRev)
-	evs = rangeEventsWithReuse(s.store.lg, s.store.b, evs, minRev, curRev+1)
+	wg, minRev := s.unsynced.choose(maxWatchersPerSync, curRev, compactionRev, retainRev)
+	if hevs, ok := s.historyEvents(minRev, curRev+1); ok {
+		evs = hevs
+	} else {
+		evs = rangeEventsWithReuse(s.store.lg, s.store.b, evs, minRev, curRev+1)
+		s.fillPrevKVsOnDelete(evs)
+	}
 
 	victims := make(watcherBatch)
 	wb := newWatcherBatch(wg, evs)
 	for w := range wg.watchers {
-		if w.minRev < compactionRev {
+		if w.minRev < w.compactRev(compactionRev, retainRev) {
 			// Skip the watcher that failed to send compacted watch response due to w.ch is full.
 			// Next retry of syncWatchers would try to resend the compacted watch response to w.ch
 			continue
@@ -389,6 +450,7 @@ func (s *watchableStore) syncWatchers(evs []mvccpb.Event) (int, []mvccpb.Event)
 			pendingEventsGauge.Add(float64(len(eb.evs)))
 		} else {
 			w.victim = true
+			victimWatcherGauge.Inc()
 		}
 
 		if w.victim {
@@ -413,6 +475,15 @@ func (s *watchableStore) syncWatchers(evs []mvccpb.Event) (int, []mvccpb.Event)
 	return s.unsynced.size(), evs
 }
 
+// historyEvents returns events in range [minRev, maxRev) from the in-memory
+// history, if it is enabled and still holds all of them.
+func (s *watchableStore) historyEvents(minRev, maxRev int64) ([]mvccpb.Event, bool) {
+	if s.history == nil {
+		return nil, false
+	}
+	return s.history.rangeEvents(minRev, maxRev)
+}
+
 // rangeEventsWithReuse returns events in range [minRev, maxRev), while reusing already provided events.
 func rangeEventsWithReuse(lg *zap.Logger, b backend.Backend, evs []mvccpb.Event, minRev, maxRev int64) []mvccpb.Event {
 	if len(evs) == 0 {
@@ -487,6 +558,42 @@ func kvsToEvents(lg *zap.Logger, revs, vals [][]byte) (evs []mvccpb.Event) {
 	return evs
 }
 
+// fillPrevKVsOnDelete sets the previous key-values of the delete events in
+// evs that have none, if the store is configured to always send them.
+func (s *watchableStore) fillPrevKVsOnDelete(evs []mvccpb.Event) {
+	if !s.store.cfg.AlwaysSendPrevKVOnDelete {
+		return
+	}
+	for i := range evs {
+		if evs[i].Type == mvccpb.DELETE && evs[i].PrevKv == nil {
+			evs[i].PrevKv = s.prevKV(evs[i].Kv.Key, evs[i].Kv.ModRevision-1)
+		}
+	}
+}
+
+// prevKV returns the key-value of key at revision rev, or nil if the key
+// does not exist at rev or its key-value was compacted.
+func (s *watchableStore) prevKV(key []byte, rev int64) *mvccpb.KeyValue {
+	modified, _, _, err := s.store.kvindex.Get(key, rev)
+	if err != nil {
+		return nil
+	}
+	revBytes := RevToBytes(modified, NewRevBytes())
+
+	tx := s.store.b.ReadTx()
+	tx.RLock()
+	defer tx.RUnlock()
+	_, vs := tx.UnsafeRange(schema.Key, revBytes, nil, 0)
+	if len(vs) != 1 {
+		return nil
+	}
+	var kv mvccpb.KeyValue
+	if err := kv.Unmarshal(vs[0]); err != nil {
+		s.store.lg.Panic("failed to unmarshal mvccpb.KeyValue", zap.Error(err))
+	}
+	return &kv
+}
+
 // notify notifies the fact that given event at the given rev just happened to
 // watchers that watch on the key of the event.
 func (s *watchableStore) notify(rev int64, evs []mvccpb.Event) {
@@ -503,6 +610,7 @@ func (s *watchableStore) notify(rev int64, evs []mvccpb.Event) {
 		} else {
 			// move slow watcher to victims
 			w.victim = true
+			victimWatcherGauge.Inc()
 			victim[w] = eb
 			s.synced.delete(w)
 			slowWatcherGauge.Inc()
@@ -528,19 +636,62 @@ func (s *watchableStore) addVictim(victim watcherBatch) {
 
 func (s *watchableStore) rev() int64 { return s.store.Rev() }
 
+// MinUnsyncedRev returns the lowest revision a watcher has yet to receive
+// the events of, and false if every watcher has caught up with the store.
+// Events already queued for a blocked watcher count as not received.
+func (s *watchableStore) MinUnsyncedRev() (int64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.store.revMu.RLock()
+	compactRev, retainRev := s.store.compactMainRev, s.store.retainMainRev
+	s.store.revMu.RUnlock()
+
+	minRev, ok := int64(0), false
+	observe := func(rev int64) {
+		if !ok || rev < minRev {
+			minRev, ok = rev, true
+		}
+	}
+	for w := range s.unsynced.watchers {
+		if w.compacted || w.minRev < w.compactRev(compactRev, retainRev) {
+			// the watcher only waits for its compacted response
+			continue
+		}
+		observe(w.minRev)
+	}
+	for _, wb := range s.victims {
+		for w, eb := range wb {
+			if len(eb.evs) != 0 {
+				observe(eb.evs[0].Kv.ModRevision)
+			} else {
+				observe(w.minRev)
+			}
+		}
+	}
+	return minRev, ok
+}
+
 func (s *watchableStore) progress(w *watcher) {
-	s.progressIfSync(map[WatchID]*watcher{w.id: w}, w.id)
+	s.progressIfSync(map[WatchID]*watcher{w.id: w}, w.id, 0)
+}
+
+func (s *watchableStore) progressFrom(w *watcher, minRev int64) {
+	s.progressIfSync(map[WatchID]*watcher{w.id: w}, w.id, minRev)
 }
 
 func (s *watchableStore) progressAll(watchers map[WatchID]*watcher) bool {
-	return s.progressIfSync(watchers, clientv3.InvalidWatchID)
+	return s.progressIfSync(watchers, clientv3.InvalidWatchID, 0)
 }
 
-func (s *watchableStore) progressIfSync(watchers map[WatchID]*watcher, responseWatchID WatchID) bool {
+func (s *watchableStore) progressIfSync(watchers map[WatchID]*watcher, responseWatchID WatchID, minRev int64) bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	rev := s.rev()
+	// Synced watchers have observed every revision up to rev.
+	if rev < minRev {
+		return false
+	}
 	// Any watcher unsynced?
 	for _, w := range watchers {
 		if _, ok := s.synced.watchers[w]; !ok {
@@ -569,6 +720,9 @@ type watcher struct {
 	// end indicates the end of the range to watch.
 	// If end is set, the watcher is on a range.
 	end []byte
+	// ranges holds all key ranges of a watcher created over multiple
+	// ranges, the first one being [key, end). It is nil otherwise.
+	ranges []KeyRange
 
 	// victim is set when ch is blocked and undergoing victim processing
 	victim bool
@@ -585,16 +739,51 @@ type watcher struct {
 	restore bool
 
 	startRev int64
+	// createdRev is the store revision when the watcher was created
+	createdRev int64
 	// minRev is the minimum revision update the watcher will accept
 	minRev int64
 	id     WatchID
 
 	fcs []FilterFunc
+	// deletesOnly is set if fcs filter out puts. Such a watcher misses no
+	// events when the puts are compacted, so it can start from the deletes
+	// retained past a compaction.
+	deletesOnly bool
+	// seq is the sequence number of the last event sent to the watcher.
+	seq uint64
+	// maxResponseBytes is the size above which the events of a response are
+	// split into several responses; 0 disables it.
+	maxResponseBytes int
+	// sent is the number of events of a split response that were sent
+	// before ch was full; they are skipped when the response is retried.
+	sent int
 	// a chan to send out the watch response.
 	// The chan might be shared with other watchers.
 	ch chan<- WatchResponse
 }
 
+// filtersPuts reports whether fcs filter out put events. Watch filters select
+// events by type, so it is enough to check a single put.
+func filtersPuts(fcs []FilterFunc) bool {
+	put := mvccpb.Event{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{}}
+	for _, filter := range fcs {
+		if filter(put) {
+			return true
+		}
+	}
+	return false
+}
+
+// compactRev returns the lowest revision w can be synced from, given the
+// compaction revision and the lowest revision whose deletes are retained.
+func (w *watcher) compactRev(compactRev, retainRev int64) int64 {
+	if w.deletesOnly {
+		return retainRev
+	}
+	return compactRev
+}
+
 func (w *watcher) send(wr WatchResponse) bool {
 	progressEvent := len(wr.Events) == 0
 
@@ -634,10 +823,57 @@ func (w *watcher) send(wr WatchResponse) bool {
 	if !progressEvent && len(wr.Events) == 0 {
 		return true
 	}
+	if progressEvent {
+		return w.trySend(wr)
+	}
+
+	// a victim watcher is only sent the response it is retrying, so the
+	// events sent before ch was full are the first ones.
+	evs := wr.Events[w.sent:]
+	// the events are the watcher's own copies; a response that could not
+	// be sent is numbered again when it is retried.
+	for i := range evs {
+		evs[i].Seq = w.seq + uint64(i) + 1
+	}
+	parts := splitEvents(evs, w.maxResponseBytes)
+	for i, part := range parts {
+		wr.Events = part
+		wr.Fragment = i < len(parts)-1
+		if !w.trySend(wr) {
+			return false
+		}
+		w.sent += len(part)
+	}
+	w.sent = 0
+	return true
+}
+
+func (w *watcher) trySend(wr WatchResponse) bool {
 	select {
 	case w.ch <- wr:
+		w.seq += uint64(len(wr.Events))
 		return true
 	default:
 		return false
 	}
 }
+
+// splitEvents splits evs into consecutive parts whose encoded size does not
+// exceed maxBytes, except for parts of a single larger event. evs is
+// returned whole if maxBytes is 0.
+func splitEvents(evs []mvccpb.Event, maxBytes int) [][]mvccpb.Event {
+	if maxBytes <= 0 {
+		return [][]mvccpb.Event{evs}
+	}
+	var parts [][]mvccpb.Event
+	start, size := 0, 0
+	for i := range evs {
+		sz := evs[i].Size()
+		if i > start && size+sz > maxBytes {
+			parts = append(parts, evs[start:i])
+			start, size = i, 0
+		}
+		size += sz
+	}
+	return append(parts, evs[start:])
+}
@@ -15,6 +15,7 @@
 package mvcc
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 	"strings"
@@ -31,6 +32,7 @@ import (
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/backend"
 	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
 )
 
@@ -141,7 +143,7 @@ etcd_debugging_mvcc_watcher_total %d
 		rev := s.Put(testKey, testValue, lease.NoLease)
 
 		// compact up to the revision of the key we just put
-		_, err := s.Compact(traceutil.TODO(), rev)
+		_, err := s.Compact(t.Context(), traceutil.TODO(), rev)
 		if err != nil {
 			t.Error(err)
 		}
@@ -196,7 +198,7 @@ etcd_debugging_mvcc_watcher_total %d
 		rev := s.Put(testKey, testValue, lease.NoLease)
 
 		// compact up to the revision of the key we just put
-		_, err := s.Compact(traceutil.TODO(), rev)
+		_, err := s.Compact(t.Context(), traceutil.TODO(), rev)
 		if err != nil {
 			t.Error(err)
 		}
@@ -331,11 +333,136 @@ func TestSyncWatchers(t *testing.T) {
 					Version:        1,
 					Value:          testValue,
 				},
+				Seq: 1,
 			},
 		}, events)
 	}
 }
 
+// TestMinUnsyncedRev ensures the lowest revision not yet received by a
+// lagging or blocked watcher is reported.
+func TestMinUnsyncedRev(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := newWatchableStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	testKey := []byte("foo")
+	for i := 0; i < 3; i++ {
+		s.Put(testKey, []byte("bar"), lease.NoLease)
+	}
+	_, ok := s.MinUnsyncedRev()
+	require.False(t, ok)
+
+	// a synced watcher with no room for events becomes a victim
+	ranges := []KeyRange{{Key: testKey}}
+	_, cancelBlocked := s.watch(ranges, 0, 1, make(chan WatchResponse))
+	_, ok = s.MinUnsyncedRev()
+	require.False(t, ok)
+	s.Put(testKey, []byte("bar"), lease.NoLease)
+	require.Len(t, s.victims, 1)
+	rev, ok := s.MinUnsyncedRev()
+	require.True(t, ok)
+	assert.Equal(t, int64(5), rev)
+
+	_, cancelUnsynced := s.watch(ranges, 3, 2, make(chan WatchResponse, 1))
+	rev, ok = s.MinUnsyncedRev()
+	require.True(t, ok)
+	assert.Equal(t, int64(3), rev)
+
+	cancelUnsynced()
+	rev, ok = s.MinUnsyncedRev()
+	require.True(t, ok)
+	assert.Equal(t, int64(5), rev)
+	cancelBlocked()
+	_, ok = s.MinUnsyncedRev()
+	require.False(t, ok)
+}
+
+// rangeCountingBackend counts read transactions so tests can tell
+// whether events were read from the backend.
+type rangeCountingBackend struct {
+	backend.Backend
+	reads int
+}
+
+func (b *rangeCountingBackend) ReadTx() backend.ReadTx {
+	b.reads++
+	return b.Backend.ReadTx()
+}
+
+// TestSyncWatchersFromHistory ensures a watcher resuming within the event
+// history is synced from memory without a backend range scan.
+func TestSyncWatchersFromHistory(t *testing.T) {
+	tb, _ := betesting.NewDefaultTmpBackend(t)
+	b := &rangeCountingBackend{Backend: tb}
+	s := newWatchableStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{WatchHistorySize: 10})
+	defer cleanup(s, tb)
+
+	testKey := []byte("foo")
+	w := s.NewWatchStream()
+	defer w.Close()
+	id, err := w.Watch(t.Context(), 0, testKey, nil, 0)
+	require.NoError(t, err)
+	s.Put(testKey, []byte("bar0"), lease.NoLease)
+	resp := <-w.Chan()
+	require.Len(t, resp.Events, 1)
+	lastRev := resp.Events[0].Kv.ModRevision
+
+	// disconnect the watcher and miss a few updates
+	require.NoError(t, w.Cancel(id))
+	for i := 1; i <= 3; i++ {
+		s.Put(testKey, []byte(fmt.Sprintf("bar%d", i)), lease.NoLease)
+	}
+
+	// resume from the first missed revision
+	_, err = w.Watch(t.Context(), 0, testKey, nil, lastRev+1)
+	require.NoError(t, err)
+	require.Len(t, s.unsynced.watcherSetByKey(string(testKey)), 1)
+
+	b.reads = 0
+	s.syncWatchers(nil)
+	assert.Zero(t, b.reads, "expected resume to be served from history")
+	assert.Len(t, s.synced.watcherSetByKey(string(testKey)), 1)
+	assert.Empty(t, s.unsynced.watcherSetByKey(string(testKey)))
+
+	resp = <-w.Chan()
+	require.Len(t, resp.Events, 3)
+	for i, ev := range resp.Events {
+		assert.Equal(t, lastRev+int64(i)+1, ev.Kv.ModRevision)
+		assert.Equal(t, fmt.Sprintf("bar%d", i+1), string(ev.Kv.Value))
+	}
+}
+
+// TestSyncWatchersHistoryEvicted ensures a watcher resuming from a revision
+// already evicted from the event history is synced from the backend.
+func TestSyncWatchersHistoryEvicted(t *testing.T) {
+	tb, _ := betesting.NewDefaultTmpBackend(t)
+	b := &rangeCountingBackend{Backend: tb}
+	s := newWatchableStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{WatchHistorySize: 2})
+	defer cleanup(s, tb)
+
+	testKey := []byte("foo")
+	startRev := s.Put(testKey, []byte("bar0"), lease.NoLease)
+	for i := 1; i <= 3; i++ {
+		s.Put(testKey, []byte(fmt.Sprintf("bar%d", i)), lease.NoLease)
+	}
+
+	w := s.NewWatchStream()
+	defer w.Close()
+	_, err := w.Watch(t.Context(), 0, testKey, nil, startRev)
+	require.NoError(t, err)
+
+	b.reads = 0
+	s.syncWatchers(nil)
+	assert.NotZero(t, b.reads, "expected resume to fall back to the backend")
+
+	resp := <-w.Chan()
+	require.Len(t, resp.Events, 4)
+	for i, ev := range resp.Events {
+		assert.Equal(t, startRev+int64(i), ev.Kv.ModRevision)
+	}
+}
+
 func TestRangeEvents(t *testing.T) {
 	b, _ := betesting.NewDefaultTmpBackend(t)
 	lg := zaptest.NewLogger(t)
@@ -455,7 +582,7 @@ func TestWatchCompacted(t *testing.T) {
 	for i := 0; i < maxRev; i++ {
 		s.Put(testKey, testValue, lease.NoLease)
 	}
-	_, err := s.Compact(traceutil.TODO(), compactRev)
+	_, err := s.Compact(t.Context(), traceutil.TODO(), compactRev)
 	if err != nil {
 		t.Fatalf("failed to compact kv (%v)", err)
 	}
@@ -477,6 +604,206 @@ func TestWatchCompacted(t *testing.T) {
 	}
 }
 
+// TestWatchTombstoneRetention ensures a watcher filtering out puts resuming
+// from a compacted revision within the tombstone retention window still
+// receives deletes, while other watchers are compacted.
+func TestWatchTombstoneRetention(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{TombstoneRetention: 3})
+	defer cleanup(s, b)
+
+	putTombstoneRetentionTestKVs(t, s)
+
+	_, err := s.Range(t.Context(), []byte("foo"), nil, RangeOptions{Rev: 5})
+	require.ErrorIs(t, err, ErrCompacted)
+
+	resp := watchFirstResponse(t, s, 5, filterNoPut)
+	require.Zero(t, resp.CompactRevision)
+	require.Len(t, resp.Events, 1)
+	assert.Equal(t, mvccpb.DELETE, resp.Events[0].Type)
+	assert.Equal(t, int64(5), resp.Events[0].Kv.ModRevision)
+
+	// watchers receiving puts would miss the compacted ones
+	resp = watchFirstResponse(t, s, 5)
+	assert.Equal(t, int64(7), resp.CompactRevision)
+
+	// the delete is out of the window of watchers from before it
+	resp = watchFirstResponse(t, s, 4, filterNoPut)
+	assert.Equal(t, int64(5), resp.CompactRevision)
+
+	// the window moves on with the next compaction
+	for i := 0; i < 3; i++ {
+		s.Put([]byte("x"), []byte("5"), lease.NoLease) // 8, 9, 10
+	}
+	done, err := s.Compact(t.Context(), traceutil.TODO(), 10)
+	require.NoError(t, err)
+	<-done
+	resp = watchFirstResponse(t, s, 5, filterNoPut)
+	assert.Equal(t, int64(8), resp.CompactRevision)
+}
+
+// TestWatchTombstoneRetentionRestore ensures the tombstone retention window
+// is rebuilt from the retained deletes when the store is restored.
+func TestWatchTombstoneRetentionRestore(t *testing.T) {
+	for _, test := range []string{"recreate", "restore"} {
+		t.Run(test, func(t *testing.T) {
+			b, _ := betesting.NewDefaultTmpBackend(t)
+			cfg := StoreConfig{TombstoneRetention: 3}
+			s0 := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, cfg)
+			putTombstoneRetentionTestKVs(t, s0)
+
+			s := s0
+			switch test {
+			case "recreate":
+				s0.Close()
+				s = New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, cfg)
+			case "restore":
+				require.NoError(t, s0.Restore(b))
+			}
+			defer cleanup(s, b)
+
+			resp := watchFirstResponse(t, s, 5, filterNoPut)
+			require.Zero(t, resp.CompactRevision)
+			require.Len(t, resp.Events, 1)
+			assert.Equal(t, mvccpb.DELETE, resp.Events[0].Type)
+			assert.Equal(t, int64(5), resp.Events[0].Kv.ModRevision)
+
+			resp = watchFirstResponse(t, s, 4, filterNoPut)
+			assert.Equal(t, int64(5), resp.CompactRevision)
+			resp = watchFirstResponse(t, s, 5)
+			assert.Equal(t, int64(7), resp.CompactRevision)
+		})
+	}
+}
+
+// putTombstoneRetentionTestKVs deletes "foo" at revision 5 between puts on
+// another key, and compacts the store at revision 7.
+func putTombstoneRetentionTestKVs(t *testing.T, s WatchableKV) {
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease) // 2
+	s.Put([]byte("x"), []byte("1"), lease.NoLease)     // 3
+	s.Put([]byte("x"), []byte("2"), lease.NoLease)     // 4
+	s.DeleteRange([]byte("foo"), nil)                  // 5
+	s.Put([]byte("x"), []byte("3"), lease.NoLease)     // 6
+	s.Put([]byte("x"), []byte("4"), lease.NoLease)     // 7
+	done, err := s.Compact(t.Context(), traceutil.TODO(), 7)
+	require.NoError(t, err)
+	<-done
+}
+
+func filterNoPut(e mvccpb.Event) bool { return e.Type == mvccpb.PUT }
+
+// watchFirstResponse watches "foo" on s from rev and returns the first
+// response.
+func watchFirstResponse(t *testing.T, s WatchableKV, rev int64, fcs ...FilterFunc) WatchResponse {
+	w := s.NewWatchStream()
+	defer w.Close()
+	_, err := w.Watch(t.Context(), 0, []byte("foo"), nil, rev, fcs...)
+	require.NoError(t, err)
+	select {
+	case resp := <-w.Chan():
+		return resp
+	case <-time.After(time.Second):
+		t.Fatalf("failed to receive response (timeout)")
+	}
+	return WatchResponse{}
+}
+
+// TestWatchAlwaysSendPrevKVOnDelete ensures delete events carry the previous
+// key-value of the deleted key for both synced and unsynced watchers.
+func TestWatchAlwaysSendPrevKVOnDelete(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{AlwaysSendPrevKVOnDelete: true})
+	defer cleanup(s, b)
+
+	recv := func(w WatchStream) []mvccpb.Event {
+		select {
+		case resp := <-w.Chan():
+			return resp.Events
+		case <-time.After(time.Second):
+			t.Fatalf("failed to receive response (timeout)")
+		}
+		return nil
+	}
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease) // 2
+	s.Put([]byte("foo"), []byte("baz"), lease.NoLease) // 3
+
+	synced := s.NewWatchStream()
+	defer synced.Close()
+	_, err := synced.Watch(t.Context(), 0, []byte("foo"), nil, 0)
+	require.NoError(t, err)
+
+	s.DeleteRange([]byte("foo"), nil) // 4
+	evs := recv(synced)
+	require.Len(t, evs, 1)
+	assert.Equal(t, mvccpb.DELETE, evs[0].Type)
+	require.NotNil(t, evs[0].PrevKv)
+	assert.Equal(t, []byte("baz"), evs[0].PrevKv.Value)
+	assert.Equal(t, int64(3), evs[0].PrevKv.ModRevision)
+
+	unsynced := s.NewWatchStream()
+	defer unsynced.Close()
+	_, err = unsynced.Watch(t.Context(), 0, []byte("foo"), nil, 2)
+	require.NoError(t, err)
+	evs = recv(unsynced)
+	require.Len(t, evs, 3)
+	for _, ev := range evs[:2] {
+		assert.Nil(t, ev.PrevKv)
+	}
+	require.NotNil(t, evs[2].PrevKv)
+	assert.Equal(t, []byte("baz"), evs[2].PrevKv.Value)
+}
+
+// TestWatchEventSeq ensures the events sent to a watcher are numbered one by
+// one, whether they share a revision or not, and independently of the events
+// sent to other watchers.
+func TestWatchEventSeq(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	recv := func(w WatchStream) []mvccpb.Event {
+		select {
+		case resp := <-w.Chan():
+			return resp.Events
+		case <-time.After(time.Second):
+			t.Fatalf("failed to receive response (timeout)")
+		}
+		return nil
+	}
+	seqs := func(evs []mvccpb.Event) []uint64 {
+		var seqs []uint64
+		for _, ev := range evs {
+			seqs = append(seqs, ev.Seq)
+		}
+		return seqs
+	}
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease) // 2
+
+	all := s.NewWatchStream()
+	defer all.Close()
+	_, err := all.Watch(t.Context(), 0, []byte("a"), []byte("z"), 0)
+	require.NoError(t, err)
+
+	txn := s.Write(traceutil.TODO())
+	txn.Put([]byte("foo"), []byte("bar1"), lease.NoLease)
+	txn.Put([]byte("goo"), []byte("bar1"), lease.NoLease)
+	txn.DeleteRange([]byte("foo"), nil)
+	txn.End() // 3
+	assert.Equal(t, []uint64{1, 2, 3}, seqs(recv(all)))
+
+	s.Put([]byte("goo"), []byte("bar2"), lease.NoLease) // 4
+	assert.Equal(t, []uint64{4}, seqs(recv(all)))
+
+	// an unsynced watcher numbers the events it catches up on from 1
+	unsynced := s.NewWatchStream()
+	defer unsynced.Close()
+	_, err = unsynced.Watch(t.Context(), 0, []byte("goo"), nil, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, seqs(recv(unsynced)))
+}
+
 func TestWatchNoEventLossOnCompact(t *testing.T) {
 	oldChanBufLen, oldMaxWatchersPerSync := chanBufLen, maxWatchersPerSync
 
@@ -497,7 +824,7 @@ func TestWatchNoEventLossOnCompact(t *testing.T) {
 	for i := 0; i < maxRev; i++ {
 		s.Put(testKey, testValue, lease.NoLease)
 	}
-	_, err := s.Compact(traceutil.TODO(), compactRev)
+	_, err := s.Compact(t.Context(), traceutil.TODO(), compactRev)
 	require.NoErrorf(t, err, "failed to compact kv (%v)", err)
 
 	w := s.NewWatchStream()
@@ -608,31 +935,31 @@ func testWatchRestore(t *testing.T, delayBeforeRestore, delayAfterRestore time.D
 			name:          "zero revision",
 			startRevision: 0,
 			wantEvents: []mvccpb.Event{
-				{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: testKey, Value: testValue, CreateRevision: 2, ModRevision: 2, Version: 1}},
-				{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: testKey, ModRevision: 3}},
+				{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: testKey, Value: testValue, CreateRevision: 2, ModRevision: 2, Version: 1}, Seq: 1},
+				{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: testKey, ModRevision: 3}, Seq: 2},
 			},
 		},
 		{
 			name:          "revision before first write",
 			startRevision: 1,
 			wantEvents: []mvccpb.Event{
-				{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: testKey, Value: testValue, CreateRevision: 2, ModRevision: 2, Version: 1}},
-				{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: testKey, ModRevision: 3}},
+				{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: testKey, Value: testValue, CreateRevision: 2, ModRevision: 2, Version: 1}, Seq: 1},
+				{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: testKey, ModRevision: 3}, Seq: 2},
 			},
 		},
 		{
 			name:          "revision of first write",
 			startRevision: 2,
 			wantEvents: []mvccpb.Event{
-				{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: testKey, Value: testValue, CreateRevision: 2, ModRevision: 2, Version: 1}},
-				{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: testKey, ModRevision: 3}},
+				{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: testKey, Value: testValue, CreateRevision: 2, ModRevision: 2, Version: 1}, Seq: 1},
+				{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: testKey, ModRevision: 3}, Seq: 2},
 			},
 		},
 		{
 			name:          "current revision",
 			startRevision: 3,
 			wantEvents: []mvccpb.Event{
-				{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: testKey, ModRevision: 3}},
+				{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: testKey, ModRevision: 3}, Seq: 1},
 			},
 		},
 		{
@@ -938,6 +1265,122 @@ func TestWatchVictims(t *testing.T) {
 	}
 }
 
+// TestWatchMaxResponseBytes ensures the events of a revision larger than
+// MaxWatchResponseBytes are sent in order over several responses, also when
+// the watcher channel fills up in between and they are sent as a victim.
+func TestWatchMaxResponseBytes(t *testing.T) {
+	oldChanBufLen := chanBufLen
+	defer func() { chanBufLen = oldChanBufLen }()
+	chanBufLen = 1
+
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{MaxWatchResponseBytes: 1024})
+	defer cleanup(s, b)
+
+	const keys = 5
+	value := bytes.Repeat([]byte("v"), 400)
+	// recv receives the events of revision 2, checking that no response
+	// holds more than two of them and that all but the last response are
+	// fragments.
+	recv := func(w WatchStream) (evs []mvccpb.Event) {
+		for len(evs) < keys {
+			select {
+			case resp := <-w.Chan():
+				assert.Equal(t, int64(2), resp.Revision)
+				assert.LessOrEqual(t, len(resp.Events), 2)
+				evs = append(evs, resp.Events...)
+				assert.Equal(t, len(evs) < keys, resp.Fragment)
+			case <-time.After(time.Second):
+				t.Fatalf("failed to receive response (timeout)")
+			}
+		}
+		select {
+		case resp := <-w.Chan():
+			t.Fatalf("unexpected response %+v", resp)
+		case <-time.After(50 * time.Millisecond):
+		}
+		return evs
+	}
+	check := func(evs []mvccpb.Event) {
+		require.Len(t, evs, keys)
+		for i, ev := range evs {
+			assert.Equal(t, fmt.Sprintf("foo%d", i), string(ev.Kv.Key))
+			assert.Equal(t, value, ev.Kv.Value)
+			assert.Equal(t, uint64(i+1), ev.Seq)
+		}
+	}
+
+	synced := s.NewWatchStream()
+	defer synced.Close()
+	_, err := synced.Watch(t.Context(), 0, []byte("foo"), []byte("fop"), 0)
+	require.NoError(t, err)
+
+	txn := s.Write(traceutil.TODO())
+	for i := range keys {
+		txn.Put(fmt.Appendf(nil, "foo%d", i), value, lease.NoLease)
+	}
+	txn.End() // 2
+	check(recv(synced))
+
+	unsynced := s.NewWatchStream()
+	defer unsynced.Close()
+	_, err = unsynced.Watch(t.Context(), 0, []byte("foo"), []byte("fop"), 1)
+	require.NoError(t, err)
+	check(recv(unsynced))
+}
+
+// TestWatchVictimsMetric tests that watchers blocked on a full channel are
+// reported as victims until they are canceled, and are not retried before
+// the configured retry interval.
+func TestWatchVictimsMetric(t *testing.T) {
+	oldChanBufLen := chanBufLen
+	chanBufLen = 1
+
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{WatchVictimRetryInterval: time.Hour})
+
+	defer func() {
+		cleanup(s, b)
+		chanBufLen = oldChanBufLen
+	}()
+
+	before := testutil.ToFloat64(victimWatcherGauge)
+	testKey, testValue := []byte("foo"), []byte("bar")
+	w := s.NewWatchStream()
+	defer w.Close()
+	id, err := w.Watch(t.Context(), 0, testKey, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first event fills the channel, the second one is not read
+	s.Put(testKey, testValue, lease.NoLease)
+	s.Put(testKey, testValue, lease.NoLease)
+	if got := testutil.ToFloat64(victimWatcherGauge) - before; got != 1 {
+		t.Fatalf("victim watchers = %v, want 1", got)
+	}
+
+	// let the retry triggered by the new victim fail before reading
+	time.Sleep(100 * time.Millisecond)
+	<-w.Chan()
+	time.Sleep(100 * time.Millisecond)
+	select {
+	case wr := <-w.Chan():
+		t.Fatalf("unexpected retry before the retry interval: %v", wr)
+	default:
+	}
+	if got := testutil.ToFloat64(victimWatcherGauge) - before; got != 1 {
+		t.Fatalf("victim watchers = %v, want 1", got)
+	}
+
+	if err = w.Cancel(id); err != nil {
+		t.Fatal(err)
+	}
+	if got := testutil.ToFloat64(victimWatcherGauge) - before; got != 0 {
+		t.Fatalf("victim watchers after cancel = %v, want 0", got)
+	}
+}
+
 // TestStressWatchCancelClose tests closing a watch stream while
 // canceling its watches.
 func TestStressWatchCancelClose(t *testing.T) {
@@ -37,10 +37,14 @@ func (tw *watchableStoreTxnWrite) End() {
 			evs[i].Type = mvccpb.PUT
 		}
 	}
+	tw.s.fillPrevKVsOnDelete(evs)
 
 	// end write txn under watchable store lock so the updates are visible
 	// when asynchronous event posting checks the current store revision
 	tw.s.mu.Lock()
+	if tw.s.history != nil {
+		tw.s.history.add(evs)
+	}
 	tw.s.notify(rev, evs)
 	tw.TxnWrite.End()
 	tw.s.mu.Unlock()
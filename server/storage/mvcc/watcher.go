@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/trace"
 
@@ -37,6 +38,14 @@ type WatchID int64
 // FilterFunc returns true if the given event should be filtered out.
 type FilterFunc func(e mvccpb.Event) bool
 
+// KeyRange is a range of keys [Key, End) observed by a watcher.
+// If End is nil, only Key is observed. If End is empty, all keys
+// greater than or equal to Key are observed.
+type KeyRange struct {
+	Key []byte
+	End []byte
+}
+
 type WatchStream interface {
 	// Watch creates a watcher. The watcher watches the events happening or
 	// happened on the given key or range [key, end) from the given startRev.
@@ -50,6 +59,11 @@ type WatchStream interface {
 	// an auto-generated watch ID is returned.
 	Watch(ctx context.Context, id WatchID, key, end []byte, startRev int64, fcs ...FilterFunc) (WatchID, error)
 
+	// WatchRanges is like Watch, but the created watcher observes all the given
+	// key ranges, which need not be contiguous. Events on any of the ranges are
+	// delivered in a single response carrying the watcher's ID.
+	WatchRanges(ctx context.Context, id WatchID, ranges []KeyRange, startRev int64, fcs ...FilterFunc) (WatchID, error)
+
 	// Chan returns a chan. All watch response will be sent to the returned chan.
 	Chan() <-chan WatchResponse
 
@@ -61,6 +75,11 @@ type WatchStream interface {
 	// of the watchers since the watcher is currently synced.
 	RequestProgress(id WatchID)
 
+	// RequestProgressFrom is like RequestProgress, but the response will only
+	// be sent if the watcher is synced to at least revision minRev. Otherwise,
+	// the request is silently dropped.
+	RequestProgressFrom(id WatchID, minRev int64)
+
 	// RequestProgressAll requests a progress notification for all
 	// watchers sharing the stream.  If all watchers are synced, a
 	// progress notification with watch ID -1 will be sent to an
@@ -75,8 +94,18 @@ type WatchStream interface {
 	// Close closes Chan and release all related resources.
 	Close()
 
+	// CloseWithContext is like Close, but first waits until the events
+	// pending for the stream's watchers are queued on Chan, so they can still
+	// be received after Chan is closed. If ctx is done before that, the stream
+	// is closed anyway and the context error is returned.
+	CloseWithContext(ctx context.Context) error
+
 	// Rev returns the current revision of the KV the stream watches on.
 	Rev() int64
+
+	// CreatedRev returns the revision of the KV at the time the watcher with
+	// the given ID was created. It returns 0 if the watcher does not exist.
+	CreatedRev(id WatchID) int64
 }
 
 type WatchResponse struct {
@@ -94,9 +123,21 @@ type WatchResponse struct {
 	Revision int64
 
 	// CompactRevision is set when the watcher is cancelled due to compaction.
+	// It is the lowest revision the watcher may start from, which is below
+	// the compaction revision for watchers filtering out puts if deletes are
+	// retained.
 	CompactRevision int64
+
+	// Fragment is set when the events of a response exceeding
+	// MaxWatchResponseBytes continue in the next response sent to the
+	// watcher.
+	Fragment bool
 }
 
+// drainPollInterval is how often CloseWithContext checks whether all pending
+// events were queued on the watch stream.
+var drainPollInterval = 10 * time.Millisecond
+
 // watchStream contains a collection of watchers that share
 // one streaming chan to send out watched events and other control events.
 type watchStream struct {
@@ -109,15 +150,45 @@ type watchStream struct {
 	closed   bool
 	cancels  map[WatchID]cancelFunc
 	watchers map[WatchID]*watcher
+
+	// quarantine is the number of watcher creations during which a canceled
+	// ID is not auto-assigned again
+	quarantine int
+	// created counts the watchers created in this stream
+	created int
+	// canceled holds the recently canceled IDs in cancel order
+	canceled []canceledID
+}
+
+// canceledID is a WatchID canceled when the stream had created the given
+// number of watchers.
+type canceledID struct {
+	id      WatchID
+	created int
 }
 
 // Watch creates a new watcher in the stream and returns its WatchID.
 func (ws *watchStream) Watch(ctx context.Context, id WatchID, key, end []byte, startRev int64, fcs ...FilterFunc) (WatchID, error) {
-	// prevent wrong range where key >= end lexicographically
-	// watch request with 'WithFromKey' has empty-byte range end
-	if len(end) != 0 && bytes.Compare(key, end) != -1 {
+	return ws.WatchRanges(ctx, id, []KeyRange{{Key: key, End: end}}, startRev, fcs...)
+}
+
+// WatchRanges creates a new watcher over multiple key ranges in the stream
+// and returns its WatchID.
+func (ws *watchStream) WatchRanges(ctx context.Context, id WatchID, ranges []KeyRange, startRev int64, fcs ...FilterFunc) (WatchID, error) {
+	if len(ranges) == 0 {
 		return -1, ErrEmptyWatcherRange
 	}
+	uniq := make([]KeyRange, 0, len(ranges))
+	for _, r := range ranges {
+		// prevent wrong range where key >= end lexicographically
+		// watch request with 'WithFromKey' has empty-byte range end
+		if len(r.End) != 0 && bytes.Compare(r.Key, r.End) != -1 {
+			return -1, ErrEmptyWatcherRange
+		}
+		if !containsKeyRange(uniq, r) {
+			uniq = append(uniq, r)
+		}
+	}
 
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
@@ -126,7 +197,8 @@ func (ws *watchStream) Watch(ctx context.Context, id WatchID, key, end []byte, s
 	}
 
 	if id == clientv3.AutoWatchID {
-		for ws.watchers[ws.nextID] != nil {
+		ws.expireCanceled()
+		for ws.watchers[ws.nextID] != nil || ws.isQuarantined(ws.nextID) {
 			ws.nextID++
 		}
 		id = ws.nextID
@@ -135,7 +207,7 @@ func (ws *watchStream) Watch(ctx context.Context, id WatchID, key, end []byte, s
 		return -1, ErrWatcherDuplicateID
 	}
 
-	w, c := ws.watchable.watch(key, end, startRev, id, ws.ch, fcs...)
+	w, c := ws.watchable.watch(uniq, startRev, id, ws.ch, fcs...)
 
 	span := trace.SpanFromContext(ctx)
 	ws.cancels[id] = func() {
@@ -143,9 +215,40 @@ func (ws *watchStream) Watch(ctx context.Context, id WatchID, key, end []byte, s
 		c()
 	}
 	ws.watchers[id] = w
+	ws.created++
 	return id, nil
 }
 
+// expireCanceled drops the canceled IDs whose quarantine is over.
+func (ws *watchStream) expireCanceled() {
+	i := 0
+	for i < len(ws.canceled) && ws.created-ws.canceled[i].created >= ws.quarantine {
+		i++
+	}
+	ws.canceled = ws.canceled[i:]
+}
+
+// isQuarantined returns true if id was recently canceled and must not be
+// auto-assigned yet.
+func (ws *watchStream) isQuarantined(id WatchID) bool {
+	for _, c := range ws.canceled {
+		if c.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// containsKeyRange returns true if ranges has a range equal to r.
+func containsKeyRange(ranges []KeyRange, r KeyRange) bool {
+	for _, o := range ranges {
+		if bytes.Equal(o.Key, r.Key) && bytes.Equal(o.End, r.End) && (o.End == nil) == (r.End == nil) {
+			return true
+		}
+	}
+	return false
+}
+
 func (ws *watchStream) Chan() <-chan WatchResponse {
 	return ws.ch
 }
@@ -169,6 +272,10 @@ func (ws *watchStream) Cancel(id WatchID) error {
 	if ww := ws.watchers[id]; ww == w {
 		delete(ws.cancels, id)
 		delete(ws.watchers, id)
+		if ws.quarantine > 0 {
+			ws.expireCanceled()
+			ws.canceled = append(ws.canceled, canceledID{id: id, created: ws.created})
+		}
 	}
 	ws.mu.Unlock()
 
@@ -187,12 +294,42 @@ func (ws *watchStream) Close() {
 	watchStreamGauge.Dec()
 }
 
+func (ws *watchStream) CloseWithContext(ctx context.Context) error {
+	ws.mu.Lock()
+	watchers := make([]*watcher, 0, len(ws.watchers))
+	for _, w := range ws.watchers {
+		watchers = append(watchers, w)
+	}
+	ws.mu.Unlock()
+
+	for !ws.watchable.flushed(watchers) {
+		select {
+		case <-ctx.Done():
+			ws.Close()
+			return ctx.Err()
+		case <-time.After(drainPollInterval):
+		}
+	}
+	ws.Close()
+	return nil
+}
+
 func (ws *watchStream) Rev() int64 {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 	return ws.watchable.rev()
 }
 
+func (ws *watchStream) CreatedRev(id WatchID) int64 {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	w, ok := ws.watchers[id]
+	if !ok {
+		return 0
+	}
+	return w.createdRev
+}
+
 func (ws *watchStream) RequestProgress(id WatchID) {
 	ws.mu.Lock()
 	w, ok := ws.watchers[id]
@@ -203,6 +340,16 @@ func (ws *watchStream) RequestProgress(id WatchID) {
 	ws.watchable.progress(w)
 }
 
+func (ws *watchStream) RequestProgressFrom(id WatchID, minRev int64) {
+	ws.mu.Lock()
+	w, ok := ws.watchers[id]
+	ws.mu.Unlock()
+	if !ok {
+		return
+	}
+	ws.watchable.progressFrom(w, minRev)
+}
+
 func (ws *watchStream) RequestProgressAll() bool {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
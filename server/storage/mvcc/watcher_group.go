@@ -104,7 +104,8 @@ func (w watcherSet) add(wa *watcher) {
 
 func (w watcherSet) union(ws watcherSet) {
 	for wa := range ws {
-		w.add(wa)
+		// a watcher over multiple ranges may be in several sets
+		w[wa] = struct{}{}
 	}
 }
 
@@ -117,17 +118,17 @@ func (w watcherSet) delete(wa *watcher) {
 
 type watcherSetByKey map[string]watcherSet
 
-func (w watcherSetByKey) add(wa *watcher) {
-	set := w[string(wa.key)]
+func (w watcherSetByKey) add(key []byte, wa *watcher) {
+	set := w[string(key)]
 	if set == nil {
 		set = make(watcherSet)
-		w[string(wa.key)] = set
+		w[string(key)] = set
 	}
 	set.add(wa)
 }
 
-func (w watcherSetByKey) delete(wa *watcher) bool {
-	k := string(wa.key)
+func (w watcherSetByKey) delete(key []byte, wa *watcher) bool {
+	k := string(key)
 	if v, ok := w[k]; ok {
 		if _, ok := v[wa]; ok {
 			delete(v, wa)
@@ -162,13 +163,24 @@ func newWatcherGroup() watcherGroup {
 // add puts a watcher in the group.
 func (wg *watcherGroup) add(wa *watcher) {
 	wg.watchers.add(wa)
-	if wa.end == nil {
-		wg.keyWatchers.add(wa)
+	if wa.ranges == nil {
+		wg.addRange(wa, wa.key, wa.end)
+		return
+	}
+	for _, r := range wa.ranges {
+		wg.addRange(wa, r.Key, r.End)
+	}
+}
+
+// addRange indexes a watcher under the key range [key, end).
+func (wg *watcherGroup) addRange(wa *watcher, key, end []byte) {
+	if end == nil {
+		wg.keyWatchers.add(key, wa)
 		return
 	}
 
 	// interval already registered?
-	ivl := adt.NewStringAffineInterval(string(wa.key), string(wa.end))
+	ivl := adt.NewStringAffineInterval(string(key), string(end))
 	if iv := wg.ranges.Find(ivl); iv != nil {
 		iv.Val.(watcherSet).add(wa)
 		return
@@ -195,12 +207,24 @@ func (wg *watcherGroup) delete(wa *watcher) bool {
 		return false
 	}
 	wg.watchers.delete(wa)
-	if wa.end == nil {
-		wg.keyWatchers.delete(wa)
+	if wa.ranges == nil {
+		return wg.deleteRange(wa, wa.key, wa.end)
+	}
+	ok := true
+	for _, r := range wa.ranges {
+		ok = wg.deleteRange(wa, r.Key, r.End) && ok
+	}
+	return ok
+}
+
+// deleteRange removes a watcher from the index of the key range [key, end).
+func (wg *watcherGroup) deleteRange(wa *watcher, key, end []byte) bool {
+	if end == nil {
+		wg.keyWatchers.delete(key, wa)
 		return true
 	}
 
-	ivl := adt.NewStringAffineInterval(string(wa.key), string(wa.end))
+	ivl := adt.NewStringAffineInterval(string(key), string(end))
 	iv := wg.ranges.Find(ivl)
 	if iv == nil {
 		return false
@@ -219,9 +243,9 @@ func (wg *watcherGroup) delete(wa *watcher) bool {
 }
 
 // choose selects watchers from the watcher group to update
-func (wg *watcherGroup) choose(maxWatchers int, curRev, compactRev int64) (*watcherGroup, int64) {
+func (wg *watcherGroup) choose(maxWatchers int, curRev, compactRev, retainRev int64) (*watcherGroup, int64) {
 	if len(wg.watchers) < maxWatchers {
-		return wg, wg.chooseAll(curRev, compactRev)
+		return wg, wg.chooseAll(curRev, compactRev, retainRev)
 	}
 	ret := newWatcherGroup()
 	for w := range wg.watchers {
@@ -231,10 +255,10 @@ func (wg *watcherGroup) choose(maxWatchers int, curRev, compactRev int64) (*watc
 		maxWatchers--
 		ret.add(w)
 	}
-	return &ret, ret.chooseAll(curRev, compactRev)
+	return &ret, ret.chooseAll(curRev, compactRev, retainRev)
 }
 
-func (wg *watcherGroup) chooseAll(curRev, compactRev int64) int64 {
+func (wg *watcherGroup) chooseAll(curRev, compactRev, retainRev int64) int64 {
 	minRev := int64(math.MaxInt64)
 	for w := range wg.watchers {
 		if w.minRev > curRev {
@@ -248,9 +272,9 @@ func (wg *watcherGroup) chooseAll(curRev, compactRev int64) int64 {
 			// mark 'restore' done, since it's chosen
 			w.restore = false
 		}
-		if w.minRev < compactRev {
+		if wCompactRev := w.compactRev(compactRev, retainRev); w.minRev < wCompactRev {
 			select {
-			case w.ch <- WatchResponse{WatchID: w.id, CompactRevision: compactRev}:
+			case w.ch <- WatchResponse{WatchID: w.id, CompactRevision: wCompactRev}:
 				w.compacted = true
 				wg.delete(w)
 			default:
@@ -16,6 +16,7 @@ package mvcc
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -23,10 +24,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/lease"
 	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
 )
@@ -118,6 +121,44 @@ func TestWatcherRequestsCustomID(t *testing.T) {
 	}
 }
 
+// TestWatcherQuarantineCanceledID ensures a canceled ID is not auto-assigned
+// again until the configured number of watchers were created.
+func TestWatcherQuarantineCanceledID(t *testing.T) {
+	tests := []struct {
+		quarantine int
+		wids       []WatchID
+	}{
+		// without quarantine the canceled ID 1 is reused right away
+		{0, []WatchID{0, 1, 2}},
+		// ID 1 was canceled one creation ago, so it is skipped
+		{2, []WatchID{0, 2, 3}},
+	}
+	for i, tt := range tests {
+		b, _ := betesting.NewDefaultTmpBackend(t)
+		s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{WatchIDQuarantine: tt.quarantine})
+
+		w := s.NewWatchStream()
+		id, err := w.Watch(t.Context(), 1, []byte("foo"), nil, 0)
+		if err != nil {
+			t.Fatalf("#%d: unexpected error %v", i, err)
+		}
+		if err = w.Cancel(id); err != nil {
+			t.Fatalf("#%d: unexpected error %v", i, err)
+		}
+		for j, wid := range tt.wids {
+			id, err = w.Watch(t.Context(), clientv3.AutoWatchID, []byte("foo"), nil, 0)
+			if err != nil {
+				t.Fatalf("#%d.%d: unexpected error %v", i, j, err)
+			}
+			if id != wid {
+				t.Errorf("#%d.%d: id = %d, want %d", i, j, id, wid)
+			}
+		}
+		w.Close()
+		cleanup(s, b)
+	}
+}
+
 // TestWatcherWatchPrefix tests if Watch operation correctly watches
 // and returns events with matching prefixes.
 func TestWatcherWatchPrefix(t *testing.T) {
@@ -192,6 +233,126 @@ func TestWatcherWatchPrefix(t *testing.T) {
 	}
 }
 
+// TestWatcherWatchMultiRange ensures that a watcher created over multiple
+// key ranges receives events of all ranges under the same watch ID.
+func TestWatcherWatchMultiRange(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+
+	val := []byte("bar")
+	ranges := []KeyRange{
+		{Key: []byte("foo"), End: []byte("fop")},
+		{Key: []byte("key")},
+		{Key: []byte("zoo"), End: []byte{}},
+		// duplicated and overlapping ranges deliver each event once
+		{Key: []byte("key")},
+		{Key: []byte("foo1"), End: []byte("foo2")},
+	}
+	keyPuts := [][]byte{[]byte("foobar"), []byte("key"), []byte("zoo1"), []byte("foo1bar")}
+	keyMiss := [][]byte{[]byte("fop"), []byte("key1"), []byte("zon")}
+
+	// synced watchers
+	for i := 0; i < 10; i++ {
+		id, err := w.WatchRanges(t.Context(), 0, ranges, 0)
+		if err != nil {
+			t.Fatalf("#%d: unexpected watch error %v", i, err)
+		}
+
+		txn := s.Write(traceutil.TODO())
+		for _, k := range append(keyMiss, keyPuts...) {
+			txn.Put(k, val, lease.NoLease)
+		}
+		txn.End()
+
+		resp := <-w.Chan()
+		if resp.WatchID != id {
+			t.Errorf("#%d: watch id in event = %d, want %d", i, resp.WatchID, id)
+		}
+		if err := w.Cancel(id); err != nil {
+			t.Errorf("#%d: unexpected cancel error %v", i, err)
+		}
+
+		if len(resp.Events) != len(keyPuts) {
+			t.Fatalf("#%d: len(resp.Events) got = %d, want = %d", i, len(resp.Events), len(keyPuts))
+		}
+		for j, ev := range resp.Events {
+			if !bytes.Equal(ev.Kv.Key, keyPuts[j]) {
+				t.Errorf("#%d: resp.Events[%d] got = %s, want = %s", i, j, ev.Kv.Key, keyPuts[j])
+			}
+		}
+	}
+
+	// unsynced watchers
+	for i := 10; i < 15; i++ {
+		id, err := w.WatchRanges(t.Context(), 0, ranges, 1)
+		if err != nil {
+			t.Fatalf("#%d: unexpected watch error %v", i, err)
+		}
+
+		var evs []mvccpb.Event
+		for len(evs) < 10*len(keyPuts) {
+			resp := <-w.Chan()
+			if resp.WatchID != id {
+				t.Fatalf("#%d: watch id in event = %d, want %d", i, resp.WatchID, id)
+			}
+			evs = append(evs, resp.Events...)
+		}
+		if err := w.Cancel(id); err != nil {
+			t.Error(err)
+		}
+
+		for j, ev := range evs {
+			if want := keyPuts[j%len(keyPuts)]; !bytes.Equal(ev.Kv.Key, want) {
+				t.Errorf("#%d: events[%d] got = %s, want = %s", i, j, ev.Kv.Key, want)
+			}
+		}
+	}
+
+	ws := s.(*watchableStore)
+	if size := ws.synced.size() + ws.unsynced.size(); size != 0 {
+		t.Errorf("watchers left after cancel = %d, want 0", size)
+	}
+	if n := ws.synced.ranges.Len() + ws.unsynced.ranges.Len(); n != 0 {
+		t.Errorf("ranges left after cancel = %d, want 0", n)
+	}
+	if n := len(ws.synced.keyWatchers) + len(ws.unsynced.keyWatchers); n != 0 {
+		t.Errorf("key watchers left after cancel = %d, want 0", n)
+	}
+}
+
+// TestWatcherWatchMultiRangeWrongRange ensures that a multi-range watcher
+// is not created if any of its ranges is wrong.
+func TestWatcherWatchMultiRangeWrongRange(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+
+	tcs := []struct {
+		name   string
+		ranges []KeyRange
+	}{
+		{name: "no range"},
+		{name: "key == end", ranges: []KeyRange{{Key: []byte("bar")}, {Key: []byte("foa"), End: []byte("foa")}}},
+		{name: "key > end", ranges: []KeyRange{{Key: []byte("fob"), End: []byte("foa")}, {Key: []byte("bar")}}},
+	}
+	for _, tc := range tcs {
+		if _, err := w.WatchRanges(t.Context(), 0, tc.ranges, 1); !errors.Is(err, ErrEmptyWatcherRange) {
+			t.Fatalf("%s: expected ErrEmptyWatcherRange, got %+v", tc.name, err)
+		}
+	}
+	// watch request with 'WithFromKey' has empty-byte range end
+	if id, _ := w.WatchRanges(t.Context(), 0, []KeyRange{{Key: []byte("bar")}, {Key: []byte("foo"), End: []byte{}}}, 1); id != 0 {
+		t.Fatalf("\x00 is range given; id expected 0, got %d", id)
+	}
+}
+
 // TestWatcherWatchWrongRange ensures that watcher with wrong 'end' range
 // does not create watcher, which panics when canceling in range tree.
 func TestWatcherWatchWrongRange(t *testing.T) {
@@ -237,9 +398,9 @@ func TestWatchDeleteRange(t *testing.T) {
 	s.DeleteRange(from, to)
 
 	we := []mvccpb.Event{
-		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("foo_0"), ModRevision: 5}},
-		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("foo_1"), ModRevision: 5}},
-		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("foo_2"), ModRevision: 5}},
+		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("foo_0"), ModRevision: 5}, Seq: 1},
+		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("foo_1"), ModRevision: 5}, Seq: 2},
+		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("foo_2"), ModRevision: 5}, Seq: 3},
 	}
 
 	select {
@@ -252,6 +413,177 @@ func TestWatchDeleteRange(t *testing.T) {
 	}
 }
 
+// TestWatchReplay ensures a fresh watcher receives the events of a mixed
+// put/delete sequence as they are replayed from the store, and that replays
+// diverging in order or content are detected.
+func TestWatchReplay(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{}).(*watchableStore)
+	defer cleanup(s, b)
+
+	for i := 0; i < 3; i++ {
+		s.Put([]byte(fmt.Sprintf("foo_%d", i)), []byte("bar"), lease.NoLease) // 2, 3, 4
+	}
+	s.DeleteRange([]byte("foo_1"), nil)                  // 5
+	s.Put([]byte("foo_1"), []byte("baz"), lease.NoLease) // 6
+	s.Put([]byte("other"), []byte("bar"), lease.NoLease) // 7
+	s.DeleteRange([]byte("foo_"), []byte("foo_9"))       // 8
+	txn := s.Write(traceutil.TODO())
+	txn.Put([]byte("foo_3"), []byte("bar"), lease.NoLease) // 9
+	txn.Put([]byte("foo_0"), []byte("bar"), lease.NoLease)
+	txn.DeleteRange([]byte("other"), nil)
+	txn.End()
+
+	verifyWatchReplay(t, s, []byte("foo_"), []byte("foo_9"), 1)
+	verifyWatchReplay(t, s, []byte("foo_1"), nil, 3)
+	verifyWatchReplay(t, s, []byte("other"), nil, 8)
+	verifyWatchReplay(t, s, []byte("a"), []byte{}, 6)
+
+	evs := replayEvents(s, []byte("foo_"), []byte("foo_9"), 1)
+	require.NoError(t, diffEvents(evs, evs))
+
+	reordered := append([]mvccpb.Event(nil), evs...)
+	reordered[1], reordered[2] = reordered[2], reordered[1]
+	require.ErrorContains(t, diffEvents(evs, reordered), "event 1")
+
+	changed := append([]mvccpb.Event(nil), evs...)
+	kv := *changed[4].Kv
+	kv.Value = []byte("bar")
+	changed[4].Kv = &kv
+	require.ErrorContains(t, diffEvents(evs, changed), "event 4")
+
+	require.ErrorContains(t, diffEvents(evs, evs[:3]), "3 events, want")
+}
+
+// verifyWatchReplay asserts that a fresh watcher on [key, end) from startRev
+// receives the events on the range replayed from the store, in order, up to
+// its current revision.
+func verifyWatchReplay(t *testing.T, s *watchableStore, key, end []byte, startRev int64) {
+	t.Helper()
+	want := replayEvents(s, key, end, startRev)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+	_, err := w.Watch(t.Context(), 0, key, end, startRev)
+	require.NoError(t, err)
+
+	var got []mvccpb.Event
+	for len(got) < len(want) {
+		select {
+		case resp := <-w.Chan():
+			got = append(got, resp.Events...)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("received %d events, want %d", len(got), len(want))
+		}
+	}
+	select {
+	case resp := <-w.Chan():
+		got = append(got, resp.Events...)
+	case <-time.After(10 * time.Millisecond):
+	}
+	require.NoError(t, diffEvents(want, got))
+}
+
+// replayEvents returns the events on keys in [key, end) from startRev up to
+// the current revision, as read from the backend of s and numbered as sent to
+// a watcher. A nil end selects key only and an empty end all the keys from
+// key, as for watchers.
+func replayEvents(s *watchableStore, key, end []byte, startRev int64) []mvccpb.Event {
+	var evs []mvccpb.Event
+	for _, ev := range rangeEvents(s.store.lg, s.store.b, startRev, s.rev()+1) {
+		k := ev.Kv.Key
+		switch {
+		case end == nil && !bytes.Equal(k, key):
+		case end != nil && bytes.Compare(k, key) < 0:
+		case len(end) > 0 && bytes.Compare(k, end) >= 0:
+		default:
+			ev.Seq = uint64(len(evs) + 1)
+			evs = append(evs, ev)
+		}
+	}
+	return evs
+}
+
+// diffEvents returns an error describing the first event of got diverging
+// from want, in type, key-values or order.
+func diffEvents(want, got []mvccpb.Event) error {
+	for i := 0; i < len(want) && i < len(got); i++ {
+		if !reflect.DeepEqual(want[i], got[i]) {
+			return fmt.Errorf("event %d is %v, want %v", i, got[i], want[i])
+		}
+	}
+	if len(got) != len(want) {
+		return fmt.Errorf("got %d events, want %d", len(got), len(want))
+	}
+	return nil
+}
+
+// TestWatchStreamCloseWithContext ensures events pending for unsynced
+// watchers are queued on the channel before CloseWithContext closes it.
+func TestWatchStreamCloseWithContext(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	testKey, testValue := []byte("foo"), []byte("bar")
+	putN := 10
+	startRev := s.Put(testKey, testValue, lease.NoLease)
+	for i := 1; i < putN; i++ {
+		s.Put(testKey, testValue, lease.NoLease)
+	}
+
+	w := s.NewWatchStream()
+	if _, err := w.Watch(t.Context(), 0, testKey, nil, startRev); err != nil {
+		t.Fatalf("failed to watch: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	if err := w.CloseWithContext(ctx); err != nil {
+		t.Fatalf("failed to drain watch stream: %v", err)
+	}
+
+	events := 0
+	for resp := range w.Chan() {
+		events += len(resp.Events)
+	}
+	if events != putN {
+		t.Errorf("drained %d events, want %d", events, putN)
+	}
+}
+
+// TestWatchStreamCloseWithContextTimeout ensures CloseWithContext gives up
+// and closes the stream once the context is done if events cannot be queued.
+func TestWatchStreamCloseWithContextTimeout(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	testKey, testValue := []byte("foo"), []byte("bar")
+	w := s.NewWatchStream()
+	// more watchers than the channel can hold responses for
+	for i := 0; i < chanBufLen+1; i++ {
+		if _, err := w.Watch(t.Context(), 0, testKey, nil, 0); err != nil {
+			t.Fatalf("failed to watch: %v", err)
+		}
+	}
+	s.Put(testKey, testValue, lease.NoLease)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	if err := w.CloseWithContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	responses := 0
+	for range w.Chan() {
+		responses++
+	}
+	if responses != chanBufLen {
+		t.Errorf("received %d responses, want %d", responses, chanBufLen)
+	}
+}
+
 // TestWatchStreamCancelWatcherByID ensures cancel calls the cancel func of the watcher
 // with given id inside watchStream.
 func TestWatchStreamCancelWatcherByID(t *testing.T) {
@@ -304,6 +636,47 @@ func TestWatcherRequestProgressBadId(t *testing.T) {
 	}
 }
 
+// TestWatcherCreatedRev ensures CreatedRev returns the store revision at the
+// time each watcher was created, regardless of its start revision.
+func TestWatcherCreatedRev(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+
+	testKey, testValue := []byte("foo"), []byte("bar")
+	s.Put(testKey, testValue, lease.NoLease)
+	rev := s.Put(testKey, testValue, lease.NoLease)
+
+	for _, startRev := range []int64{0, 1, rev, rev + 5} {
+		id, err := w.Watch(t.Context(), 0, testKey, nil, startRev)
+		if err != nil {
+			t.Fatalf("failed to watch: %v", err)
+		}
+		if got := w.CreatedRev(id); got != rev {
+			t.Errorf("startRev %d: created revision = %d, want %d", startRev, got, rev)
+		}
+	}
+
+	s.Put(testKey, testValue, lease.NoLease)
+	id, err := w.Watch(t.Context(), 0, testKey, nil, 0)
+	if err != nil {
+		t.Fatalf("failed to watch: %v", err)
+	}
+	if got := w.CreatedRev(id); got != rev+1 {
+		t.Errorf("created revision = %d, want %d", got, rev+1)
+	}
+
+	if err = w.Cancel(id); err != nil {
+		t.Fatalf("failed to cancel watcher: %v", err)
+	}
+	if got := w.CreatedRev(id); got != 0 {
+		t.Errorf("created revision of canceled watcher = %d, want 0", got)
+	}
+}
+
 func TestWatcherRequestProgress(t *testing.T) {
 	testKey := []byte("foo")
 	notTestKey := []byte("bad")
@@ -360,6 +733,111 @@ func TestWatcherRequestProgress(t *testing.T) {
 	}
 }
 
+func TestWatcherRequestProgressFrom(t *testing.T) {
+	testKey := []byte("foo")
+	notTestKey := []byte("bad")
+	testValue := []byte("bar")
+	tcs := []struct {
+		name                     string
+		startRev                 int64
+		minRev                   int64
+		expectProgressBeforeSync bool
+		expectProgressAfterSync  bool
+	}{
+		{
+			name:                     "Zero revision, no floor",
+			startRev:                 0,
+			minRev:                   0,
+			expectProgressBeforeSync: true,
+			expectProgressAfterSync:  true,
+		},
+		{
+			name:                     "Zero revision, current revision floor",
+			startRev:                 0,
+			minRev:                   2,
+			expectProgressBeforeSync: true,
+			expectProgressAfterSync:  true,
+		},
+		{
+			name:     "Zero revision, future revision floor",
+			startRev: 0,
+			minRev:   3,
+		},
+		{
+			name:                    "Old revision, old revision floor",
+			startRev:                1,
+			minRev:                  1,
+			expectProgressAfterSync: true,
+		},
+		{
+			name:     "Old revision, future revision floor",
+			startRev: 1,
+			minRev:   3,
+		},
+		{
+			name:                    "Current revision, current revision floor",
+			startRev:                2,
+			minRev:                  2,
+			expectProgressAfterSync: true,
+		},
+		{
+			name:     "Current revision plus one, no floor",
+			startRev: 3,
+			minRev:   0,
+		},
+		{
+			name:     "Current revision plus one, future revision floor",
+			startRev: 3,
+			minRev:   3,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			b, _ := betesting.NewDefaultTmpBackend(t)
+			s := newWatchableStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+
+			defer cleanup(s, b)
+
+			s.Put(testKey, testValue, lease.NoLease)
+
+			w := s.NewWatchStream()
+
+			id, _ := w.Watch(t.Context(), 0, notTestKey, nil, tc.startRev)
+			w.RequestProgressFrom(id, tc.minRev)
+			asssertProgressSent(t, w, id, tc.expectProgressBeforeSync)
+			s.syncWatchers([]mvccpb.Event{})
+			w.RequestProgressFrom(id, tc.minRev)
+			asssertProgressSent(t, w, id, tc.expectProgressAfterSync)
+		})
+	}
+}
+
+func TestWatcherRequestProgressFromAfterPut(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := newWatchableStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+
+	defer cleanup(s, b)
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+
+	w := s.NewWatchStream()
+	id, _ := w.Watch(t.Context(), 0, []byte("bad"), nil, 0)
+	w.RequestProgressFrom(id, 3)
+	asssertProgressSent(t, w, id, false)
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	w.RequestProgressFrom(id, 3)
+	wrs := WatchResponse{WatchID: id, Revision: 3}
+	select {
+	case resp := <-w.Chan():
+		if !reflect.DeepEqual(resp, wrs) {
+			t.Fatalf("got %+v, expect %+v", resp, wrs)
+		}
+	default:
+		t.Fatal("failed to receive progress")
+	}
+}
+
 func asssertProgressSent(t *testing.T, stream WatchStream, id WatchID, expectProgress bool) {
 	select {
 	case resp := <-stream.Chan():
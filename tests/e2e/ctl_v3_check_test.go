@@ -0,0 +1,47 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/pkg/v3/expect"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestCtlV3CheckDatascaleWriters(t *testing.T) {
+	testCtl(t, checkDatascaleWritersTest, withCfg(*e2e.NewConfigNoTLS()), withTestTimeout(2*time.Minute))
+}
+
+func checkDatascaleWritersTest(cx ctlCtx) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cmdArgs := append(cx.PrefixArgs(), "check", "datascale", "--load", "s", "--writers", "4", "--auto-compact")
+	var expected []expect.ExpectedResponse
+	for i := 0; i < 4; i++ {
+		expected = append(expected, expect.ExpectedResponse{
+			Value:         fmt.Sprintf(`Writer %d: \d+ writes, p50 [0-9.]+s, p90 [0-9.]+s, p99 [0-9.]+s`, i),
+			IsRegularExpr: true,
+		})
+	}
+	expected = append(expected, expect.ExpectedResponse{Value: "PASS: Approximate system memory used"})
+	require.NoError(cx.t, e2e.SpawnWithExpectsContext(ctx, cmdArgs, cx.envMap, expected...))
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/pkg/v3/expect"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestCtlV3EndpointHealthRequireLeader(t *testing.T) {
+	testCtl(t, endpointHealthRequireLeaderTest, withCfg(*e2e.NewConfigNoTLS()), withQuorum(), withTestTimeout(time.Minute))
+}
+
+type endpointHealthJSON struct {
+	Endpoint  string  `json:"endpoint"`
+	Health    bool    `json:"health"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error"`
+}
+
+func endpointHealthJSONOutput(cx ctlCtx, eps []string) ([]endpointHealthJSON, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cmdArgs := append(cx.prefixArgs(eps), "endpoint", "health", "--require-leader", "--command-timeout=3s", "-w", "json")
+	// the command exits with an error if any endpoint is unhealthy, but
+	// still prints the health of all endpoints
+	lines, err := e2e.SpawnWithExpectLines(ctx, cmdArgs, cx.envMap, expect.ExpectedResponse{Value: `"endpoint"`})
+	if len(lines) != 1 {
+		return nil, fmt.Errorf("expected 1 line of output, got %q: %w", lines, err)
+	}
+	var hs []endpointHealthJSON
+	if jerr := json.Unmarshal([]byte(lines[0]), &hs); jerr != nil {
+		return nil, jerr
+	}
+	return hs, err
+}
+
+func endpointHealthRequireLeaderTest(cx ctlCtx) {
+	eps := cx.epc.EndpointsGRPC()
+	hs, err := endpointHealthJSONOutput(cx, eps)
+	require.NoError(cx.t, err)
+	require.Len(cx.t, hs, len(eps))
+	for _, h := range hs {
+		require.Truef(cx.t, h.Health, "endpoint %s: %s", h.Endpoint, h.Error)
+		require.Positivef(cx.t, h.LatencyMs, "endpoint %s", h.Endpoint)
+	}
+
+	// lose quorum by stopping all but the first member
+	for _, proc := range cx.epc.Procs[1:] {
+		require.NoError(cx.t, proc.Stop())
+	}
+	require.Eventually(cx.t, func() bool {
+		hs, err = endpointHealthJSONOutput(cx, eps[:1])
+		return err != nil && len(hs) == 1 && !hs[0].Health && strings.Contains(hs[0].Error, "no leader")
+	}, 30*time.Second, time.Second)
+}
@@ -16,7 +16,9 @@ package e2e
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -35,6 +37,8 @@ func TestCtlV3PutClientTLSFlagByEnv(t *testing.T) {
 func TestCtlV3PutIgnoreValue(t *testing.T) { testCtl(t, putTestIgnoreValue) }
 func TestCtlV3PutIgnoreLease(t *testing.T) { testCtl(t, putTestIgnoreLease) }
 
+func TestCtlV3PutPrevKVFormat(t *testing.T) { testCtl(t, putPrevKVFormatTest) }
+
 func TestCtlV3GetTimeout(t *testing.T) { testCtl(t, getTest, withDefaultDialTimeout()) }
 
 func TestCtlV3GetFormat(t *testing.T)             { testCtl(t, getFormatTest) }
@@ -42,6 +46,10 @@ func TestCtlV3GetRev(t *testing.T)                { testCtl(t, getRevTest) }
 func TestCtlV3GetMinMaxCreateModRev(t *testing.T) { testCtl(t, getMinMaxCreateModRevTest) }
 func TestCtlV3GetKeysOnly(t *testing.T)           { testCtl(t, getKeysOnlyTest) }
 func TestCtlV3GetCountOnly(t *testing.T)          { testCtl(t, getCountOnlyTest) }
+func TestCtlV3GetCountOnlySimple(t *testing.T)    { testCtl(t, getCountOnlySimpleTest) }
+func TestCtlV3GetWithLeaseTTL(t *testing.T)       { testCtl(t, getWithLeaseTTLTest) }
+
+func TestCtlV3CompactionDryRun(t *testing.T) { testCtl(t, compactionDryRunTest) }
 
 func TestCtlV3DelTimeout(t *testing.T) { testCtl(t, delTest, withDefaultDialTimeout()) }
 
@@ -111,6 +119,45 @@ func putTestIgnoreLease(cx ctlCtx) {
 	}
 }
 
+func putPrevKVFormatTest(cx ctlCtx) {
+	require.NoError(cx.t, ctlV3Put(cx, "abc", "v0", ""))
+
+	b64 := base64.StdEncoding.EncodeToString
+	// each put overwrites the value written by the previous one, at revision i+2
+	tests := []struct {
+		format string
+		wstr   func(prevVal string, prevRev int) string
+	}{
+		{"simple", func(v string, _ int) string { return "OK\nabc\n" + v }},
+		{"json", func(v string, rev int) string {
+			return fmt.Sprintf(`"prev_kv":{"key":"%s","create_revision":2,"mod_revision":%d,"version":%d,"value":"%s"}`, b64([]byte("abc")), rev, rev-1, b64([]byte(v)))
+		}},
+		{"fields", func(v string, rev int) string {
+			return fmt.Sprintf("\"PrevKey\" : \"abc\"\n\"PrevCreateRevision\" : 2\n\"PrevModRevision\" : %d\n\"PrevVersion\" : %d\n\"PrevValue\" : %q", rev, rev-1, v)
+		}},
+		{"table", func(v string, rev int) string {
+			return fmt.Sprintf(`\s%d\s+\S\s+abc\s+\S\s+%s\s+\S\s+%d\s+\S\s+%d\s`, rev+1, v, rev, rev-1)
+		}},
+	}
+
+	for i, tt := range tests {
+		cmdArgs := append(cx.PrefixArgs(), "put", "abc", fmt.Sprintf("v%d", i+1), "--prev-kv", "--write-out="+tt.format)
+		lines, err := e2e.RunUtilCompletion(cmdArgs, cx.envMap)
+		require.NoErrorf(cx.t, err, "format %s", tt.format)
+		for j := range lines {
+			lines[j] = strings.TrimRight(lines[j], "\r\n")
+		}
+		out := strings.Join(lines, "\n")
+		want := tt.wstr(fmt.Sprintf("v%d", i), i+2)
+		if tt.format == "table" {
+			assert.Contains(cx.t, out, "PREV VALUE")
+			assert.Regexp(cx.t, want, out)
+			continue
+		}
+		assert.Contains(cx.t, out, want, "format %s", tt.format)
+	}
+}
+
 func getTest(cx ctlCtx) {
 	var (
 		kvs    = []kv{{"key1", "val1"}, {"key2", "val2"}, {"key3", "val3"}}
@@ -269,6 +316,86 @@ func getCountOnlyTest(cx ctlCtx) {
 	require.NotContains(cx.t, lines, "\"Count\" : 3")
 }
 
+func getCountOnlySimpleTest(cx ctlCtx) {
+	for i := 0; i < 5; i++ {
+		require.NoError(cx.t, ctlV3Put(cx, fmt.Sprintf("key%d", i), "val", ""))
+	}
+	require.NoError(cx.t, ctlV3Put(cx, "other", "val", ""))
+
+	tests := []struct {
+		args   []string
+		wcount string
+	}{
+		{[]string{"key", "--prefix"}, "5"},
+		{[]string{"key3", "--from-key"}, "3"},
+		{[]string{"foo", "--prefix"}, "0"},
+		{[]string{"key1", "key3"}, "2"},
+	}
+	for i, tt := range tests {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		cmdArgs := append(append(cx.PrefixArgs(), "get", "--count-only"), tt.args...)
+		lines, err := e2e.SpawnWithExpectLines(ctx, cmdArgs, cx.envMap, expect.ExpectedResponse{Value: tt.wcount})
+		cancel()
+		require.NoErrorf(cx.t, err, "#%d", i)
+		require.Lenf(cx.t, lines, 1, "#%d", i)
+		require.Equalf(cx.t, tt.wcount, strings.TrimSpace(lines[0]), "#%d", i)
+	}
+}
+
+func getWithLeaseTTLTest(cx ctlCtx) {
+	leaseID, err := ctlV3LeaseGrant(cx, 100)
+	require.NoError(cx.t, err)
+	require.NoError(cx.t, ctlV3Put(cx, "key1", "val1", leaseID))
+	require.NoError(cx.t, ctlV3Put(cx, "key2", "val2", ""))
+
+	getTTL := func() int64 {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		cmdArgs := append(cx.PrefixArgs(), "get", "--prefix", "--with-lease-ttl", "key")
+		lines, err := e2e.SpawnWithExpectLines(ctx, cmdArgs, cx.envMap,
+			expect.ExpectedResponse{Value: "key1"},
+			expect.ExpectedResponse{Value: "val1"},
+			expect.ExpectedResponse{Value: "ttl: "},
+			expect.ExpectedResponse{Value: "key2"},
+			expect.ExpectedResponse{Value: "val2"},
+		)
+		require.NoError(cx.t, err)
+		require.Len(cx.t, lines, 5)
+		ttl, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(lines[2]), "ttl: "), 10, 64)
+		require.NoError(cx.t, err)
+		require.Positive(cx.t, ttl)
+		require.LessOrEqual(cx.t, ttl, int64(100))
+		return ttl
+	}
+
+	ttl := getTTL()
+	time.Sleep(2 * time.Second)
+	require.Less(cx.t, getTTL(), ttl)
+}
+
+func compactionDryRunTest(cx ctlCtx) {
+	for _, v := range []string{"val1", "val2", "val3"} {
+		require.NoError(cx.t, ctlV3Put(cx, "key", v, ""))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cmdArgs := append(cx.PrefixArgs(), "compaction", "--dry-run", "4")
+	lines, err := e2e.SpawnWithExpectLines(ctx, cmdArgs, cx.envMap,
+		expect.ExpectedResponse{Value: "current revision 4"},
+		expect.ExpectedResponse{Value: "target revision 4"},
+		expect.ExpectedResponse{Value: "estimated reclaimable bytes"},
+	)
+	require.NoError(cx.t, err)
+	require.Len(cx.t, lines, 3)
+	reclaimable, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(lines[2]), "estimated reclaimable bytes "), 10, 64)
+	require.NoError(cx.t, err)
+	require.Positive(cx.t, reclaimable)
+
+	// the superseded revisions must still be readable
+	require.NoError(cx.t, ctlV3Get(cx, []string{"key", "--rev", "2"}, kv{"key", "val1"}))
+}
+
 func delTest(cx ctlCtx) {
 	tests := []struct {
 		puts []kv
@@ -84,6 +84,10 @@ func TestCtlV3LeaseKeepAlivePeerTLSDisableFastKeepAlive(t *testing.T) {
 	testCtl(t, leaseTestKeepAlive, withCfg(*cfg))
 }
 
+func TestCtlV3LeaseStatus(t *testing.T) {
+	testCtl(t, leaseTestStatus)
+}
+
 func enableFastLeaseKeepAlive(cfg *e2e.EtcdProcessClusterConfig) {
 	e2e.WithServerFeatureGate("FastLeaseKeepAlive", true)(cfg)
 }
@@ -109,6 +113,42 @@ func leaseTestKeepAlive(cx ctlCtx) {
 	}
 }
 
+func leaseTestStatus(cx ctlCtx) {
+	leaseIDs := make([]string, 2)
+	for i, ttl := range []int{100, 200} {
+		leaseID, err := ctlV3LeaseGrant(cx, ttl)
+		if err != nil {
+			cx.t.Fatalf("leaseTestStatus: ctlV3LeaseGrant error (%v)", err)
+		}
+		leaseIDs[i] = leaseID
+	}
+	for _, kv := range []struct{ key, leaseID string }{
+		{"b", leaseIDs[0]},
+		{"a", leaseIDs[0]},
+		{"c", leaseIDs[1]},
+	} {
+		if err := ctlV3Put(cx, kv.key, "val", kv.leaseID); err != nil {
+			cx.t.Fatalf("leaseTestStatus: ctlV3Put error (%v)", err)
+		}
+	}
+
+	// leases are listed by expiry, with their keys sorted
+	cmdArgs := append(cx.PrefixArgs(), "lease", "status")
+	if err := e2e.SpawnWithExpects(cmdArgs, cx.envMap,
+		expect.ExpectedResponse{Value: leaseIDs[0] + `, \d+, 100, 2\s`, IsRegularExpr: true},
+		expect.ExpectedResponse{Value: leaseIDs[1] + `, \d+, 200, 1\s`, IsRegularExpr: true},
+	); err != nil {
+		cx.t.Fatalf("leaseTestStatus: lease status error (%v)", err)
+	}
+	cmdArgs = append(cx.PrefixArgs(), "lease", "status", "--with-keys")
+	if err := e2e.SpawnWithExpects(cmdArgs, cx.envMap,
+		expect.ExpectedResponse{Value: leaseIDs[0] + `, \d+, 100, 2, a,b`, IsRegularExpr: true},
+		expect.ExpectedResponse{Value: leaseIDs[1] + `, \d+, 200, 1, c`, IsRegularExpr: true},
+	); err != nil {
+		cx.t.Fatalf("leaseTestStatus: lease status --with-keys error (%v)", err)
+	}
+}
+
 func ctlV3LeaseGrant(cx ctlCtx, ttl int) (string, error) {
 	cmdArgs := append(cx.PrefixArgs(), "lease", "grant", strconv.Itoa(ttl))
 	proc, err := e2e.SpawnCmd(cmdArgs, cx.envMap)
@@ -36,6 +36,10 @@ import (
 
 func TestCtlV3MemberList(t *testing.T)        { testCtl(t, memberListTest) }
 func TestCtlV3MemberListWithHex(t *testing.T) { testCtl(t, memberListWithHexTest) }
+func TestCtlV3MemberListStatus(t *testing.T) {
+	testCtl(t, memberListStatusTest, withTestTimeout(time.Minute))
+}
+
 func TestCtlV3MemberListSerializable(t *testing.T) {
 	cfg := e2e.NewConfig(
 		e2e.WithClusterSize(1),
@@ -45,9 +49,14 @@ func TestCtlV3MemberListSerializable(t *testing.T) {
 
 func TestCtlV3MemberAdd(t *testing.T)          { testCtl(t, memberAddTest) }
 func TestCtlV3MemberAddAsLearner(t *testing.T) { testCtl(t, memberAddAsLearnerTest) }
+func TestCtlV3MemberAddWait(t *testing.T)      { testCtl(t, memberAddWaitTest) }
 
 func TestCtlV3MemberUpdate(t *testing.T) { testCtl(t, memberUpdateTest) }
 
+func TestCtlV3MemberPromoteWaitReady(t *testing.T) {
+	testCtl(t, memberPromoteWaitReadyTest, withTestTimeout(time.Minute))
+}
+
 func TestCtlV3MemberPromoteWithAuthFromLeader(t *testing.T) {
 	testCtl(t, memberPromoteWithAuth(false), withTestTimeout(30*time.Second))
 }
@@ -263,6 +272,44 @@ func memberListWithHexTest(cx ctlCtx) {
 	}
 }
 
+func memberListStatusTest(cx ctlCtx) {
+	ctx := context.Background()
+	learnerID, err := cx.epc.StartNewProc(ctx, nil, cx.t, true)
+	require.NoError(cx.t, err)
+	leaderIdx := cx.epc.WaitLeader(cx.t)
+	leaderStatus, err := cx.epc.Procs[leaderIdx].Etcdctl().Status(ctx)
+	require.NoError(cx.t, err)
+	leaderID := leaderStatus[0].Header.MemberId
+
+	lines, err := e2e.RunUtilCompletion(append(cx.PrefixArgs(), "--write-out", "json", "member", "list"), cx.envMap)
+	require.NoError(cx.t, err)
+	var resp struct {
+		Header  *etcdserverpb.ResponseHeader
+		Members []struct {
+			ID        uint64
+			IsLearner bool
+			IsLeader  *bool
+			RaftTerm  uint64
+		}
+	}
+	// the client may log retries of the learner endpoint before the response
+	out := lines[len(lines)-1]
+	require.NoError(cx.t, json.Unmarshal([]byte(out), &resp))
+	require.Len(cx.t, resp.Members, len(cx.epc.Procs))
+	for _, m := range resp.Members {
+		require.NotNilf(cx.t, m.IsLeader, "member %x", m.ID)
+		assert.Equal(cx.t, m.ID == leaderID, *m.IsLeader, "member %x", m.ID)
+		assert.Equal(cx.t, m.ID == learnerID, m.IsLearner, "member %x", m.ID)
+		assert.Equal(cx.t, resp.Header.RaftTerm, m.RaftTerm, "member %x", m.ID)
+	}
+
+	lines, err = e2e.RunUtilCompletion(append(cx.PrefixArgs(), "--write-out", "table", "member", "list"), cx.envMap)
+	require.NoError(cx.t, err)
+	out = strings.Join(lines, "\n")
+	assert.Contains(cx.t, out, "IS LEADER")
+	assert.Contains(cx.t, out, "RAFT TERM")
+}
+
 func memberAddTest(cx ctlCtx) {
 	peerURL := fmt.Sprintf("http://localhost:%d", e2e.EtcdProcessBasePort+11)
 	require.NoError(cx.t, ctlV3MemberAdd(cx, peerURL, false))
@@ -273,6 +320,53 @@ func memberAddAsLearnerTest(cx ctlCtx) {
 	require.NoError(cx.t, ctlV3MemberAdd(cx, peerURL, true))
 }
 
+func memberAddWaitTest(cx ctlCtx) {
+	serverCfg := cx.epc.NewMemberConfig(nil, cx.t)
+	cmdArgs := append(cx.PrefixArgs(), "member", "add", serverCfg.Name,
+		fmt.Sprintf("--peer-urls=%s", serverCfg.PeerURL.String()), "--wait", "--wait-timeout=30s")
+	proc, err := e2e.SpawnCmd(cmdArgs, cx.envMap)
+	require.NoError(cx.t, err)
+	defer proc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	_, err = proc.ExpectWithContext(ctx, expect.ExpectedResponse{Value: " added to cluster "})
+	require.NoError(cx.t, err)
+
+	// the command keeps waiting while the new member is not started
+	time.Sleep(2 * time.Second)
+	require.ErrorIs(cx.t, proc.ExitError(), expect.ErrProcessRunning)
+
+	require.NoError(cx.t, cx.epc.StartNewProcFromConfig(ctx, cx.t, serverCfg))
+	_, err = proc.ExpectWithContext(ctx, expect.ExpectedResponse{Value: "is started and healthy"})
+	require.NoError(cx.t, err)
+	require.NoError(cx.t, proc.Close())
+}
+
+func memberPromoteWaitReadyTest(cx ctlCtx) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	learnerID, serverCfg, err := cx.epc.AddMember(ctx, nil, cx.t, true)
+	require.NoError(cx.t, err)
+	for i := 0; i < 20; i++ {
+		require.NoError(cx.t, ctlV3Put(cx, fmt.Sprintf("key-%d", i), "value", ""))
+	}
+
+	// the learner is not started yet, so it is refused right away
+	cmdArgs := append(cx.PrefixArgs(), "member", "promote", fmt.Sprintf("%x", learnerID), "--wait-ready", "--max-lag=10")
+	proc, err := e2e.SpawnCmd(cmdArgs, cx.envMap)
+	require.NoError(cx.t, err)
+	_, err = proc.ExpectWithContext(ctx, expect.ExpectedResponse{Value: "is not ready to be promoted"})
+	require.NoError(cx.t, err)
+	proc.Wait()
+	require.Error(cx.t, proc.Close())
+
+	require.NoError(cx.t, cx.epc.StartNewProcFromConfig(ctx, cx.t, serverCfg))
+	cmdArgs = append(cmdArgs, "--wait-timeout=20s")
+	require.NoError(cx.t, e2e.SpawnWithExpectWithEnv(cmdArgs, cx.envMap, expect.ExpectedResponse{Value: fmt.Sprintf("Member %16x promoted in cluster", learnerID)}))
+}
+
 func memberPromoteWithAuth(fromFollower bool) func(cx ctlCtx) {
 	return func(cx ctlCtx) {
 		ctx := context.Background()
@@ -151,3 +151,43 @@ func setupEtcdctlTest(t *testing.T, cfg *e2e.EtcdProcessClusterConfig, quorum bo
 	}
 	return epc
 }
+
+func TestCtlV3MoveLeaderWait(t *testing.T) {
+	e2e.BeforeTest(t)
+	epc := setupEtcdctlTest(t, e2e.NewConfigNoTLS(), true)
+	defer func() {
+		if errC := epc.Close(); errC != nil {
+			t.Fatalf("error closing etcd processes (%v)", errC)
+		}
+	}()
+
+	leadIdx := epc.WaitLeader(t)
+	transfereeEP := epc.EndpointsGRPC()[(leadIdx+1)%len(epc.Procs)]
+	transferee := endpointStatus(t, transfereeEP).Header.GetMemberId()
+
+	cx := ctlCtx{
+		t:           t,
+		cfg:         *e2e.NewConfigNoTLS(),
+		dialTimeout: 7 * time.Second,
+		epc:         epc,
+	}
+	cmdArgs := append(cx.prefixArgs(epc.EndpointsGRPC()), "move-leader", types.ID(transferee).String(), "--wait", "--wait-timeout=30s")
+	require.NoError(t, e2e.SpawnWithExpectWithEnv(cmdArgs, cx.envMap, expect.ExpectedResponse{Value: "Leadership transferred"}))
+
+	// the command only returns once the transferee reports itself as leader
+	require.Equal(t, transferee, endpointStatus(t, transfereeEP).Leader)
+}
+
+func endpointStatus(t *testing.T, ep string) *clientv3.StatusResponse {
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{ep},
+		DialTimeout: 3 * time.Second,
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	resp, err := cli.Status(ctx, ep)
+	require.NoError(t, err)
+	return resp
+}
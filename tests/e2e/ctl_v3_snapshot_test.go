@@ -383,7 +383,7 @@ func TestRestoreCompactionRevBump(t *testing.T) {
 
 	cancelResult, ok := <-watchCh
 	require.Truef(t, ok, "watchChannel should be open")
-	require.Equal(t, v3rpc.ErrCompacted, cancelResult.Err())
+	require.ErrorIs(t, cancelResult.Err(), v3rpc.ErrCompacted)
 	require.Truef(t, cancelResult.Canceled, "expected ongoing watch to be cancelled after restoring with --mark-compacted")
 	require.Equal(t, int64(bumpAmount+currentRev), cancelResult.CompactRevision)
 	_, ok = <-watchCh
@@ -394,7 +394,7 @@ func TestRestoreCompactionRevBump(t *testing.T) {
 	for i := bumpAmount - 2; i < bumpAmount+currentRev; i++ {
 		watchCh = ctl.Watch(t.Context(), "foo", config.WatchOptions{Prefix: true, Revision: int64(i)})
 		cancelResult := <-watchCh
-		require.Equal(t, v3rpc.ErrCompacted, cancelResult.Err())
+		require.ErrorIs(t, cancelResult.Err(), v3rpc.ErrCompacted)
 		require.Truef(t, cancelResult.Canceled, "expected ongoing watch to be cancelled after restoring with --mark-compacted")
 		require.Equal(t, int64(bumpAmount+currentRev), cancelResult.CompactRevision)
 	}
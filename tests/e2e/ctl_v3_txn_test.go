@@ -0,0 +1,65 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/pkg/v3/expect"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestCtlV3TxnFile(t *testing.T) { testCtl(t, txnFileTest) }
+
+func txnFileTest(cx ctlCtx) {
+	dir := cx.t.TempDir()
+	yamlFile := filepath.Join(dir, "txn.yaml")
+	require.NoError(cx.t, os.WriteFile(yamlFile, []byte(`compares:
+- mod("key1") > "0"
+success:
+- put key1 "overwrote-key1"
+failure:
+- put key1 "created-key1"
+- put key2 "some extra key"
+`), 0o600))
+
+	// key1 does not exist yet, so the failure requests are applied
+	require.NoError(cx.t, ctlV3TxnFile(cx, yamlFile, "FAILURE", "OK", "OK"))
+	require.NoError(cx.t, ctlV3Get(cx, []string{"key", "--prefix"}, kv{"key1", "created-key1"}, kv{"key2", "some extra key"}))
+
+	require.NoError(cx.t, ctlV3TxnFile(cx, yamlFile, "SUCCESS", "OK"))
+	require.NoError(cx.t, ctlV3Get(cx, []string{"key1"}, kv{"key1", "overwrote-key1"}))
+
+	jsonFile := filepath.Join(dir, "txn.json")
+	require.NoError(cx.t, os.WriteFile(jsonFile, []byte(`{
+	"compares": ["val(\"key1\") = \"overwrote-key1\""],
+	"success": ["del key2", "get key1"]
+}`), 0o600))
+	require.NoError(cx.t, ctlV3TxnFile(cx, jsonFile, "SUCCESS", "1", "key1", "overwrote-key1"))
+	require.NoError(cx.t, ctlV3Get(cx, []string{"key", "--prefix"}, kv{"key1", "overwrote-key1"}))
+}
+
+func ctlV3TxnFile(cx ctlCtx, file string, lines ...string) error {
+	cmdArgs := append(cx.PrefixArgs(), "txn", "--file", file)
+	var expects []expect.ExpectedResponse
+	for _, l := range lines {
+		expects = append(expects, expect.ExpectedResponse{Value: l})
+	}
+	return e2e.SpawnWithExpects(cmdArgs, cx.envMap, expects...)
+}
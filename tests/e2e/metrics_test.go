@@ -158,6 +158,7 @@ func TestNoMetricsMissing(t *testing.T) {
 			"etcd_debugging_mvcc_total_put_size_in_bytes",
 			"etcd_debugging_mvcc_watch_stream_total",
 			"etcd_debugging_mvcc_watcher_total",
+			"etcd_debugging_mvcc_watcher_victim_total",
 			"etcd_debugging_server_lease_expired_total",
 			"etcd_debugging_snap_save_marshalling_duration_seconds",
 			"etcd_debugging_snap_save_total_duration_seconds",
@@ -459,7 +459,7 @@ func testStartWatcherFromCompactedRevision(t *testing.T, performCompactOnTombsto
 				}
 
 				if len(watchResp.Events) == 0 {
-					require.Equal(t, v3rpc.ErrCompacted, watchResp.Err())
+					require.ErrorIs(t, watchResp.Err(), v3rpc.ErrCompacted)
 					break watchLoop
 				}
 
@@ -873,8 +873,10 @@ func (epc *EtcdProcessCluster) StartNewProc(ctx context.Context, cfg *EtcdProces
 	return memberID, nil
 }
 
-// AddMember adds a new member to the cluster without starting it.
-func (epc *EtcdProcessCluster) AddMember(ctx context.Context, cfg *EtcdProcessClusterConfig, tb testing.TB, addAsLearner bool, opts ...config.ClientOption) (memberID uint64, serverCfg *EtcdServerProcessConfig, err error) {
+// NewMemberConfig returns the configuration of a new member joining the
+// cluster. The member is neither added to the cluster nor started.
+func (epc *EtcdProcessCluster) NewMemberConfig(cfg *EtcdProcessClusterConfig, tb testing.TB) *EtcdServerProcessConfig {
+	var serverCfg *EtcdServerProcessConfig
 	if cfg != nil {
 		serverCfg = cfg.EtcdServerProcessConfig(tb, epc.nextSeq)
 	} else {
@@ -891,6 +893,12 @@ func (epc *EtcdProcessCluster) AddMember(ctx context.Context, cfg *EtcdProcessCl
 	}
 
 	epc.Cfg.SetInitialOrDiscovery(serverCfg, initialCluster, "existing")
+	return serverCfg
+}
+
+// AddMember adds a new member to the cluster without starting it.
+func (epc *EtcdProcessCluster) AddMember(ctx context.Context, cfg *EtcdProcessClusterConfig, tb testing.TB, addAsLearner bool, opts ...config.ClientOption) (memberID uint64, serverCfg *EtcdServerProcessConfig, err error) {
+	serverCfg = epc.NewMemberConfig(cfg, tb)
 
 	// First add new member to cluster
 	tb.Logf("add new member to cluster; member-name %s, member-peer-url %s", serverCfg.Name, serverCfg.PeerURL.String())
@@ -28,6 +28,7 @@ import (
 
 	"go.etcd.io/etcd/api/v3/authpb"
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/expect"
 	"go.etcd.io/etcd/tests/v3/framework/config"
@@ -728,8 +729,7 @@ func (ctl *EtcdctlV3) Watch(ctx context.Context, key string, opts config.WatchOp
 				return
 			default:
 				if line := proc.ReadLine(); line != "" {
-					var resp clientv3.WatchResponse
-					json.Unmarshal([]byte(line), &resp)
+					resp := parseWatchLine(line)
 					if resp.Canceled {
 						ch <- resp
 						close(ch)
@@ -745,3 +745,27 @@ func (ctl *EtcdctlV3) Watch(ctx context.Context, key string, opts config.WatchOp
 
 	return ch
 }
+
+// parseWatchLine decodes a line of "etcdctl watch -w json" output. Each event
+// is printed on its own line, other responses are printed as a whole.
+func parseWatchLine(line string) clientv3.WatchResponse {
+	var ev struct {
+		Header etcdserverpb.ResponseHeader `json:"header"`
+		Type   string                      `json:"type"`
+		Kv     *mvccpb.KeyValue            `json:"kv"`
+		PrevKv *mvccpb.KeyValue            `json:"prev_kv"`
+	}
+	if err := json.Unmarshal([]byte(line), &ev); err == nil && ev.Kv != nil {
+		return clientv3.WatchResponse{
+			Header: ev.Header,
+			Events: []*clientv3.Event{{
+				Type:   mvccpb.Event_EventType(mvccpb.Event_EventType_value[ev.Type]),
+				Kv:     ev.Kv,
+				PrevKv: ev.PrevKv,
+			}},
+		}
+	}
+	var resp clientv3.WatchResponse
+	json.Unmarshal([]byte(line), &resp)
+	return resp
+}
@@ -142,8 +142,15 @@ type ClusterConfig struct {
 	AuthToken string
 
 	QuotaBackendBytes    int64
+	QuotaSoftRatio       float64
 	BackendBatchInterval time.Duration
 
+	AutoCompactionMode      string
+	AutoCompactionRetention time.Duration
+
+	CompactionBatchLimit    int
+	CompactionSleepInterval time.Duration
+
 	MaxTxnOps       uint
 	MaxRequestBytes uint
 
@@ -170,7 +177,21 @@ type ClusterConfig struct {
 	LeaseCheckpointInterval time.Duration
 	LeaseCheckpointPersist  bool
 
+	// EnableRuntimeCommitMode enables the RuntimeCommitMode feature gate.
+	EnableRuntimeCommitMode bool
+
 	WatchProgressNotifyInterval time.Duration
+	MaxWatchesPerUser           int
+	MaxValueBytes               int
+	HotKeyWriteRate             float64
+	HotKeyWriteBurst            int
+	MaxWatchResponseBytes       int
+	SnapshotCopy                bool
+	WatchHistorySize            int
+	WatchIDQuarantine           int
+	WatchVictimRetryInterval    time.Duration
+	TombstoneRetention          int64
+	AlwaysSendPrevKVOnDelete    bool
 	MaxLearners                 int
 	DisableStrictReconfigCheck  bool
 	CorruptCheckTime            time.Duration
@@ -266,7 +287,12 @@ func (c *Cluster) MustNewMember(t testutil.TB) *Member {
 			PeerTLS:                     c.Cfg.PeerTLS,
 			ClientTLS:                   c.Cfg.ClientTLS,
 			QuotaBackendBytes:           c.Cfg.QuotaBackendBytes,
+			QuotaSoftRatio:              c.Cfg.QuotaSoftRatio,
 			BackendBatchInterval:        c.Cfg.BackendBatchInterval,
+			AutoCompactionMode:          c.Cfg.AutoCompactionMode,
+			AutoCompactionRetention:     c.Cfg.AutoCompactionRetention,
+			CompactionBatchLimit:        c.Cfg.CompactionBatchLimit,
+			CompactionSleepInterval:     c.Cfg.CompactionSleepInterval,
 			MaxTxnOps:                   c.Cfg.MaxTxnOps,
 			MaxRequestBytes:             c.Cfg.MaxRequestBytes,
 			SnapshotCount:               c.Cfg.SnapshotCount,
@@ -283,7 +309,19 @@ func (c *Cluster) MustNewMember(t testutil.TB) *Member {
 			EnableLeaseCheckpoint:       c.Cfg.EnableLeaseCheckpoint,
 			LeaseCheckpointInterval:     c.Cfg.LeaseCheckpointInterval,
 			LeaseCheckpointPersist:      c.Cfg.LeaseCheckpointPersist,
+			EnableRuntimeCommitMode:     c.Cfg.EnableRuntimeCommitMode,
 			WatchProgressNotifyInterval: c.Cfg.WatchProgressNotifyInterval,
+			MaxWatchesPerUser:           c.Cfg.MaxWatchesPerUser,
+			MaxValueBytes:               c.Cfg.MaxValueBytes,
+			HotKeyWriteRate:             c.Cfg.HotKeyWriteRate,
+			HotKeyWriteBurst:            c.Cfg.HotKeyWriteBurst,
+			MaxWatchResponseBytes:       c.Cfg.MaxWatchResponseBytes,
+			SnapshotCopy:                c.Cfg.SnapshotCopy,
+			WatchHistorySize:            c.Cfg.WatchHistorySize,
+			WatchIDQuarantine:           c.Cfg.WatchIDQuarantine,
+			WatchVictimRetryInterval:    c.Cfg.WatchVictimRetryInterval,
+			TombstoneRetention:          c.Cfg.TombstoneRetention,
+			AlwaysSendPrevKVOnDelete:    c.Cfg.AlwaysSendPrevKVOnDelete,
 			MaxLearners:                 c.Cfg.MaxLearners,
 			DisableStrictReconfigCheck:  c.Cfg.DisableStrictReconfigCheck,
 			CorruptCheckTime:            c.Cfg.CorruptCheckTime,
@@ -591,7 +629,12 @@ type MemberConfig struct {
 	ClientTLS                   *transport.TLSInfo
 	AuthToken                   string
 	QuotaBackendBytes           int64
+	QuotaSoftRatio              float64
 	BackendBatchInterval        time.Duration
+	AutoCompactionMode          string
+	AutoCompactionRetention     time.Duration
+	CompactionBatchLimit        int
+	CompactionSleepInterval     time.Duration
 	MaxTxnOps                   uint
 	MaxRequestBytes             uint
 	SnapshotCount               uint64
@@ -608,7 +651,19 @@ type MemberConfig struct {
 	EnableLeaseCheckpoint       bool
 	LeaseCheckpointInterval     time.Duration
 	LeaseCheckpointPersist      bool
+	EnableRuntimeCommitMode     bool
 	WatchProgressNotifyInterval time.Duration
+	MaxWatchesPerUser           int
+	MaxValueBytes               int
+	HotKeyWriteRate             float64
+	HotKeyWriteBurst            int
+	MaxWatchResponseBytes       int
+	SnapshotCopy                bool
+	WatchHistorySize            int
+	WatchIDQuarantine           int
+	WatchVictimRetryInterval    time.Duration
+	TombstoneRetention          int64
+	AlwaysSendPrevKVOnDelete    bool
 	MaxLearners                 int
 	DisableStrictReconfigCheck  bool
 	CorruptCheckTime            time.Duration
@@ -665,7 +720,12 @@ func MustNewMember(t testutil.TB, mcfg MemberConfig) *Member {
 	m.TickMs = uint(framecfg.TickDuration / time.Millisecond)
 	m.PreVote = true
 	m.QuotaBackendBytes = mcfg.QuotaBackendBytes
+	m.QuotaSoftRatio = mcfg.QuotaSoftRatio
 	m.BackendBatchInterval = mcfg.BackendBatchInterval
+	m.AutoCompactionMode = mcfg.AutoCompactionMode
+	m.AutoCompactionRetention = mcfg.AutoCompactionRetention
+	m.CompactionBatchLimit = mcfg.CompactionBatchLimit
+	m.CompactionSleepInterval = mcfg.CompactionSleepInterval
 	m.MaxTxnOps = mcfg.MaxTxnOps
 	if m.MaxTxnOps == 0 {
 		m.MaxTxnOps = embed.DefaultMaxTxnOps
@@ -714,6 +774,17 @@ func MustNewMember(t testutil.TB, mcfg MemberConfig) *Member {
 	m.LeaseCheckpointInterval = mcfg.LeaseCheckpointInterval
 
 	m.WatchProgressNotifyInterval = mcfg.WatchProgressNotifyInterval
+	m.MaxWatchesPerUser = mcfg.MaxWatchesPerUser
+	m.MaxValueBytes = mcfg.MaxValueBytes
+	m.HotKeyWriteRate = mcfg.HotKeyWriteRate
+	m.HotKeyWriteBurst = mcfg.HotKeyWriteBurst
+	m.MaxWatchResponseBytes = mcfg.MaxWatchResponseBytes
+	m.SnapshotCopy = mcfg.SnapshotCopy
+	m.WatchHistorySize = mcfg.WatchHistorySize
+	m.WatchIDQuarantine = mcfg.WatchIDQuarantine
+	m.WatchVictimRetryInterval = mcfg.WatchVictimRetryInterval
+	m.TombstoneRetention = mcfg.TombstoneRetention
+	m.AlwaysSendPrevKVOnDelete = mcfg.AlwaysSendPrevKVOnDelete
 
 	m.InitialCorruptCheck = true
 	if mcfg.CorruptCheckTime > time.Duration(0) {
@@ -731,7 +802,7 @@ func MustNewMember(t testutil.TB, mcfg MemberConfig) *Member {
 
 	m.Logger, m.LogObserver = memberLogger(t, mcfg.Name)
 	m.ServerFeatureGate = features.NewDefaultServerFeatureGate(m.Name, m.Logger)
-	featureGates := fmt.Sprintf("LeaseCheckpoint=%v,LeaseCheckpointPersist=%v", mcfg.EnableLeaseCheckpoint, mcfg.LeaseCheckpointPersist)
+	featureGates := fmt.Sprintf("LeaseCheckpoint=%v,LeaseCheckpointPersist=%v,RuntimeCommitMode=%v", mcfg.EnableLeaseCheckpoint, mcfg.LeaseCheckpointPersist, mcfg.EnableRuntimeCommitMode)
 	if err := m.ServerFeatureGate.(featuregate.MutableFeatureGate).Set(featureGates); err != nil {
 		t.Fatalf("Set FeatureGate FAILED: %v", err)
 	}
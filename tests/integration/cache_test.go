@@ -367,7 +367,7 @@ func testWatch(t *testing.T, kv clientv3.KV, watcher Watcher) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			events, _ := collectAndAssertAtomicEvents(t, watches[i])
-			if diff := cmp.Diff(tc.wantEvents, events); diff != "" {
+			if diff := cmp.Diff(withSeq(tc.wantEvents), events); diff != "" {
 				t.Errorf("unexpected events (-want +got):\n%s", diff)
 			}
 		})
@@ -1263,6 +1263,18 @@ func collectAndAssertAtomicEvents(t *testing.T, watch clientv3.WatchChan) (event
 	}
 }
 
+// withSeq returns copies of events numbered as they are delivered to a new
+// watcher.
+func withSeq(events []*clientv3.Event) []*clientv3.Event {
+	var numbered []*clientv3.Event
+	for i, event := range events {
+		ev := *event
+		ev.Seq = uint64(i) + 1
+		numbered = append(numbered, &ev)
+	}
+	return numbered
+}
+
 func applyEvents(ctx context.Context, t *testing.T, kv clientv3.KV, evs []*clientv3.Event) int64 {
 	var lastRev int64
 	for _, batches := range batchEventsByRevision(evs) {
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.etcd.io/etcd/client/pkg/v3/types"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/tests/v3/framework/integration"
 )
 
@@ -429,3 +430,59 @@ func TestMaxLearnerInCluster(t *testing.T) {
 		t.Errorf("failed to add member %v", err)
 	}
 }
+
+func TestMemberChanges(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, UseTCP: true})
+	defer clus.Terminate(t)
+
+	m := clus.Members[0]
+	setClientURLs := func(urls ...string) {
+		m.Stop(t)
+		m.ClientURLs = types.MustNewURLs(urls)
+		require.NoError(t, m.Restart(t))
+		clus.WaitLeader(t)
+	}
+	// advertise the grpc endpoint, so that the client keeps working after its
+	// endpoints are refreshed from the member list
+	setClientURLs(m.GRPCURL)
+
+	cli, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:        []string{m.GRPCURL},
+		AutoSyncInterval: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	mch := cli.MemberChanges(ctx)
+	next := func() clientv3.MemberListResponse {
+		select {
+		case resp, ok := <-mch:
+			require.True(t, ok, "member changes closed")
+			return resp
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for member changes")
+		}
+		return clientv3.MemberListResponse{}
+	}
+
+	resp := next()
+	require.Len(t, resp.Members, 1)
+	require.Equal(t, []string{m.GRPCURL}, resp.Members[0].ClientURLs)
+
+	// the same grpc listener, reachable by IP
+	ipURL := strings.Replace(m.GRPCURL, "localhost", "127.0.0.1", 1)
+	setClientURLs(m.GRPCURL, ipURL)
+
+	resp = next()
+	require.Len(t, resp.Members, 1)
+	require.Equal(t, []string{m.GRPCURL, ipURL}, resp.Members[0].ClientURLs)
+	require.ElementsMatch(t, []string{m.GRPCURL, ipURL}, cli.Endpoints())
+
+	cancel()
+	for range mch {
+	}
+}
@@ -15,8 +15,11 @@
 package concurrency_test
 
 import (
+	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -88,3 +91,67 @@ func TestMutexUnlock(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestAcquireLock(t *testing.T) {
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	var (
+		mu     sync.Mutex
+		events []string
+		wg     sync.WaitGroup
+	)
+	record := func(ev string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, ev)
+	}
+
+	locked := make(chan struct{})
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		release, err := concurrency.AcquireLock(t.Context(), cli, "/acquire-lock", concurrency.WithTTL(1))
+		if err != nil {
+			t.Error(err)
+			close(locked)
+			return
+		}
+		record("first acquired")
+		close(locked)
+		// hold the lock for longer than the lease TTL
+		time.Sleep(2 * time.Second)
+		record("first released")
+		if err := release(); err != nil {
+			t.Error(err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		<-locked
+		release, err := concurrency.AcquireLock(t.Context(), cli, "/acquire-lock", concurrency.WithTTL(1))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		record("second acquired")
+		if err := release(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	<-locked
+	// a bounded acquisition gives up while the lock is held
+	ctx, cancel := context.WithTimeout(t.Context(), 500*time.Millisecond)
+	_, err = concurrency.AcquireLock(ctx, cli, "/acquire-lock")
+	cancel()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	wg.Wait()
+	require.Equal(t, []string{"first acquired", "first released", "second acquired"}, events)
+
+	resp, err := cli.Get(t.Context(), "/acquire-lock/", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Zero(t, resp.Count)
+}
@@ -25,6 +25,7 @@ import (
 	"google.golang.org/grpc"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/tests/v3/framework/integration"
@@ -46,6 +47,13 @@ var (
 		TrustedCAFile:  testutils.MustAbsPath("../../fixtures-expired/ca.crt"),
 		ClientCertAuth: true,
 	}
+
+	testTLSInfo2 = transport.TLSInfo{
+		KeyFile:        testutils.MustAbsPath("../../../fixtures/server2.key.insecure"),
+		CertFile:       testutils.MustAbsPath("../../../fixtures/server2.crt"),
+		TrustedCAFile:  testutils.MustAbsPath("../../../fixtures/ca.crt"),
+		ClientCertAuth: true,
+	}
 )
 
 // TestDialTLSExpired tests client with expired certs fails to dial.
@@ -66,6 +74,89 @@ func TestDialTLSExpired(t *testing.T) {
 	require.Truef(t, clientv3test.IsClientTimeout(err), "expected dial timeout error")
 }
 
+// TestDialTLSReload ensures a client presents the certificate set by
+// ReloadTLS once it reconnects, while a watch on the client survives.
+func TestDialTLSReload(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, ClientTLS: &testTLSInfo, UseBridge: true})
+	defer clus.Terminate(t)
+
+	// authenticate by the common name of the client certificate; the user
+	// of the reloaded certificate may also write "bar"
+	clus.Members[0].Stop(t)
+	clus.Members[0].ClientCertAuthEnabled = true
+	require.NoError(t, clus.Members[0].Restart(t))
+	clus.WaitLeader(t)
+
+	ctx := t.Context()
+	admin := clus.Client(0)
+	_, err := admin.UserAdd(ctx, "root", "123")
+	require.NoError(t, err)
+	_, err = admin.UserGrantRole(ctx, "root", "root")
+	require.NoError(t, err)
+	for role, key := range map[string]string{"foo-rw": "foo", "bar-rw": "bar"} {
+		_, err = admin.RoleAdd(ctx, role)
+		require.NoError(t, err)
+		_, err = admin.RoleGrantPermission(ctx, role, key, "", clientv3.PermissionType(clientv3.PermReadWrite))
+		require.NoError(t, err)
+	}
+	for user, roles := range map[string][]string{"example.com": {"foo-rw"}, "example2.com": {"foo-rw", "bar-rw"}} {
+		_, err = admin.UserAddWithOptions(ctx, user, "", &clientv3.UserAddOptions{NoPassword: true})
+		require.NoError(t, err)
+		for _, role := range roles {
+			_, err = admin.UserGrantRole(ctx, user, role)
+			require.NoError(t, err)
+		}
+	}
+	_, err = admin.AuthEnable(ctx)
+	require.NoError(t, err)
+
+	tls, err := testTLSInfo.ClientConfig()
+	require.NoError(t, err)
+	cli, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:   []string{clus.Members[0].GRPCURL},
+		DialTimeout: 5 * time.Second,
+		TLS:         tls,
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	wch := cli.Watch(ctx, "foo")
+	expectValue := func(val string) {
+		select {
+		case wresp, ok := <-wch:
+			require.True(t, ok, "watch channel closed")
+			require.NoError(t, wresp.Err())
+			require.Len(t, wresp.Events, 1)
+			require.Equal(t, val, string(wresp.Events[0].Kv.Value))
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for %q", val)
+		}
+	}
+	_, err = cli.Put(ctx, "foo", "1")
+	require.NoError(t, err)
+	expectValue("1")
+	_, err = cli.Put(ctx, "bar", "1")
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+
+	require.Error(t, cli.ReloadTLS("missing.crt", "missing.key"))
+	require.NoError(t, cli.ReloadTLS(testTLSInfo2.CertFile, testTLSInfo2.KeyFile))
+
+	// the established connection keeps the old certificate
+	_, err = cli.Put(ctx, "bar", "2")
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+
+	clus.Members[0].Bridge().DropConnections()
+	require.Eventually(t, func() bool {
+		_, err = cli.Put(ctx, "bar", "2")
+		return err == nil
+	}, 10*time.Second, 100*time.Millisecond, "put with reloaded certificate: %v", err)
+
+	_, err = cli.Put(ctx, "foo", "2")
+	require.NoError(t, err)
+	expectValue("2")
+}
+
 // TestDialTLSNoConfig ensures the client fails to dial / times out
 // when TLS endpoints (https, unixs) are given but no tls config.
 func TestDialTLSNoConfig(t *testing.T) {
@@ -19,10 +19,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/rand"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -35,6 +38,10 @@ import (
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/api/v3/version"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3compactor"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/etcd/server/v3/storage/schema"
 	"go.etcd.io/etcd/tests/v3/framework/integration"
 )
 
@@ -276,6 +283,34 @@ func TestKVRange(t *testing.T) {
 	}
 }
 
+// TestKVGetServingMember ensures range responses report the member that
+// served them and whether the read was serializable.
+func TestKVGetServingMember(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	_, err := clus.Client(0).Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+
+	seen := make(map[uint64]bool)
+	for i, m := range clus.Members {
+		cli := clus.Client(i)
+		resp, err := cli.Get(t.Context(), "foo", clientv3.WithSerializable())
+		require.NoError(t, err)
+		require.True(t, resp.Serializable)
+		require.Equal(t, uint64(m.Server.MemberID()), resp.Header.MemberId)
+		seen[resp.Header.MemberId] = true
+
+		resp, err = cli.Get(t.Context(), "foo")
+		require.NoError(t, err)
+		require.False(t, resp.Serializable)
+		require.Equal(t, uint64(m.Server.MemberID()), resp.Header.MemberId)
+	}
+	require.Len(t, seen, len(clus.Members))
+}
+
 func TestKVGetErrConnClosed(t *testing.T) {
 	integration.BeforeTest(t)
 
@@ -407,6 +442,43 @@ func TestKVCompactError(t *testing.T) {
 	}
 }
 
+// TestKVGetCompactedFallback ensures a serializable Get pinned to a compacted
+// revision with WithCompactedFallback ranges at the current revision and is
+// flagged as degraded.
+func TestKVGetCompactedFallback(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := t.Context()
+
+	presp, err := kv.Put(ctx, "foo", "bar")
+	require.NoError(t, err)
+	pinned := presp.Header.Revision
+	presp, err = kv.Put(ctx, "foo", "baz")
+	require.NoError(t, err)
+	_, err = kv.Compact(ctx, presp.Header.Revision)
+	require.NoError(t, err)
+
+	_, err = kv.Get(ctx, "foo", clientv3.WithRev(pinned), clientv3.WithSerializable())
+	require.ErrorIs(t, err, rpctypes.ErrCompacted)
+
+	oresp, err := kv.Do(ctx, clientv3.OpGet("foo", clientv3.WithRev(pinned), clientv3.WithSerializable(), clientv3.WithCompactedFallback()))
+	require.NoError(t, err)
+	require.True(t, oresp.Degraded())
+	resp := oresp.Get()
+	require.Equal(t, presp.Header.Revision, resp.Header.Revision)
+	require.Len(t, resp.Kvs, 1)
+	require.Equal(t, "baz", string(resp.Kvs[0].Value))
+
+	// reads at revisions that are not compacted are not degraded
+	oresp, err = kv.Do(ctx, clientv3.OpGet("foo", clientv3.WithRev(presp.Header.Revision), clientv3.WithSerializable(), clientv3.WithCompactedFallback()))
+	require.NoError(t, err)
+	require.False(t, oresp.Degraded())
+}
+
 func TestKVCompact(t *testing.T) {
 	integration.BeforeTest(t)
 
@@ -461,7 +533,165 @@ func TestKVCompact(t *testing.T) {
 	}
 }
 
+// TestKVCompactDryRun ensures a dry run compaction estimates the reclaimable
+// space without being proposed or compacting anything.
+func TestKVCompactDryRun(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	kv := clus.Client(0)
+	ctx := t.Context()
+
+	for i := 0; i < 10; i++ {
+		_, err := kv.Put(ctx, "foo", "bar")
+		require.NoError(t, err)
+	}
+
+	applied := clus.Members[0].Server.AppliedIndex()
+	resp, err := kv.Compact(ctx, 7, clientv3.WithCompactDryRun())
+	require.NoError(t, err)
+	require.Positive(t, resp.ReclaimableBytes)
+	require.Equal(t, applied, clus.Members[0].Server.AppliedIndex(), "dry run compaction was proposed")
+
+	_, err = kv.Get(ctx, "foo", clientv3.WithRev(2))
+	require.NoError(t, err)
+
+	_, err = kv.Compact(ctx, 100, clientv3.WithCompactDryRun())
+	require.ErrorIs(t, err, rpctypes.ErrFutureRev)
+}
+
+// TestKVCompactPhysical ensures a physical Compact returns only after the
+// compacted revisions are removed from the backend.
+func TestKVCompactPhysical(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := t.Context()
+
+	// span several compaction batches of the default batch limit
+	const numRevs = 3000
+	var lastRev int64
+	for i := 0; i < numRevs; i++ {
+		resp, err := kv.Put(ctx, "foo", strconv.Itoa(i))
+		require.NoError(t, err)
+		lastRev = resp.Header.Revision
+	}
+	require.GreaterOrEqual(t, backendKeyRevisions(clus.Members[0].Server.Backend()), numRevs)
+
+	_, err := kv.Compact(ctx, lastRev, clientv3.WithCompactPhysical())
+	require.NoError(t, err)
+	require.Equal(t, 1, backendKeyRevisions(clus.Members[0].Server.Backend()))
+
+	_, err = kv.Get(ctx, "foo", clientv3.WithRev(lastRev-1))
+	require.ErrorIs(t, err, rpctypes.ErrCompacted)
+}
+
+// TestKVCompactBoundByWatchers ensures a compaction bound by watchers does not
+// compact past the events a lagging watcher has yet to receive.
+func TestKVCompactBoundByWatchers(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := t.Context()
+
+	// a watcher that is not read blocks once its channel is full
+	ws := clus.Members[0].Server.KV().NewWatchStream()
+	defer ws.Close()
+	_, err := ws.Watch(ctx, 0, []byte("foo"), nil, 0)
+	require.NoError(t, err)
+
+	var firstRev, lastRev int64
+	for i := 0; i < mvcc.ChanBufLen()+10; i++ {
+		resp, err := kv.Put(ctx, "foo", strconv.Itoa(i))
+		require.NoError(t, err)
+		if i == 0 {
+			firstRev = resp.Header.Revision
+		}
+		lastRev = resp.Header.Revision
+	}
+	blockingRev := firstRev + int64(mvcc.ChanBufLen())
+
+	resp, err := kv.Compact(ctx, lastRev, clientv3.WithCompactBoundByWatchers())
+	require.NoError(t, err)
+	require.Equal(t, blockingRev, resp.BlockingRevision)
+	_, err = kv.Get(ctx, "foo", clientv3.WithRev(firstRev))
+	require.NoError(t, err)
+
+	resp, err = kv.Compact(ctx, blockingRev, clientv3.WithCompactBoundByWatchers())
+	require.NoError(t, err)
+	require.Zero(t, resp.BlockingRevision)
+	_, err = kv.Get(ctx, "foo", clientv3.WithRev(blockingRev-1))
+	require.ErrorIs(t, err, rpctypes.ErrCompacted)
+
+	// once the watcher catches up, nothing bounds the compaction
+	for caughtUp := false; !caughtUp; {
+		wr := <-ws.Chan()
+		for _, ev := range wr.Events {
+			caughtUp = caughtUp || ev.Kv.ModRevision == lastRev
+		}
+	}
+	require.Eventually(t, func() bool {
+		resp, err = kv.Compact(ctx, lastRev, clientv3.WithCompactBoundByWatchers())
+		return err == nil && resp.BlockingRevision == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = kv.Get(ctx, "foo", clientv3.WithRev(lastRev-1))
+	require.ErrorIs(t, err, rpctypes.ErrCompacted)
+}
+
+// backendKeyRevisions returns the number of key revisions stored in the
+// key bucket of be, including uncommitted changes.
+func backendKeyRevisions(be backend.Backend) int {
+	tx := be.BatchTx()
+	tx.LockOutsideApply()
+	defer tx.Unlock()
+	end := mvcc.RevToBytes(mvcc.Revision{Main: math.MaxInt64}, mvcc.NewRevBytes())
+	keys, _ := tx.UnsafeRange(schema.Key, mvcc.NewRevBytes(), end, 0)
+	return len(keys)
+}
+
 // TestKVGetRetry ensures get will retry on disconnect.
+// TestKVAutoCompaction ensures the server compacts old revisions on its own
+// when auto compaction is configured.
+func TestKVAutoCompaction(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{
+		Size:                    1,
+		AutoCompactionMode:      v3compactor.ModePeriodic,
+		AutoCompactionRetention: time.Second,
+	})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := t.Context()
+
+	for i := 0; i < 5; i++ {
+		_, err := kv.Put(ctx, "foo", fmt.Sprintf("bar%d", i))
+		require.NoError(t, err)
+	}
+
+	// the compactor catches up with the last put once it is a retention old
+	require.Eventually(t, func() bool {
+		if _, err := kv.Get(ctx, "foo", clientv3.WithRev(5)); !errors.Is(err, rpctypes.ErrCompacted) {
+			return false
+		}
+		rev, err := clus.Members[0].Metric("etcd_server_auto_compaction_last_revision")
+		return err == nil && rev == "6"
+	}, 10*time.Second, 100*time.Millisecond)
+
+	resp, err := kv.Get(ctx, "foo")
+	require.NoError(t, err)
+	require.Equal(t, "bar4", string(resp.Kvs[0].Value))
+}
+
 func TestKVGetRetry(t *testing.T) {
 	integration.BeforeTest(t)
 
@@ -660,6 +890,319 @@ func TestKVPutAtMostOnce(t *testing.T) {
 }
 
 // TestKVLargeRequests tests various client/server side request limits.
+// TestKVCompareAndSwap ensures CompareAndSwap only puts the new value
+// if the key is unchanged since the expected revision.
+func TestKVCompareAndSwap(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+
+	// expected revision 0 requires the key to not exist
+	presp, err := clientv3.CompareAndSwap(t.Context(), kv, "foo", 0, "bar")
+	require.NoError(t, err)
+	rev := presp.Header.Revision
+
+	_, err = clientv3.CompareAndSwap(t.Context(), kv, "foo", 0, "baz")
+	var mismatch *clientv3.CASMismatch
+	require.ErrorAs(t, err, &mismatch)
+	require.Equal(t, "foo", mismatch.Key)
+	require.Equal(t, int64(0), mismatch.ExpectedModRev)
+	require.NotNil(t, mismatch.Current)
+	require.Equal(t, "bar", string(mismatch.Current.Value))
+	require.Equal(t, rev, mismatch.Current.ModRevision)
+
+	presp, err = clientv3.CompareAndSwap(t.Context(), kv, "foo", rev, "baz", clientv3.WithPrevKV())
+	require.NoError(t, err)
+	require.NotNil(t, presp.PrevKv)
+	require.Equal(t, "bar", string(presp.PrevKv.Value))
+
+	_, err = clientv3.CompareAndSwap(t.Context(), kv, "foo", rev, "qux")
+	require.ErrorAs(t, err, &mismatch)
+	require.Equal(t, "baz", string(mismatch.Current.Value))
+
+	_, err = kv.Delete(t.Context(), "foo")
+	require.NoError(t, err)
+	_, err = clientv3.CompareAndSwap(t.Context(), kv, "foo", presp.Header.Revision, "qux")
+	require.ErrorAs(t, err, &mismatch)
+	require.Nil(t, mismatch.Current)
+}
+
+// TestKVPutAll ensures PutAll writes a set of keys exceeding the
+// transaction size limit, all visible at the returned revision.
+func TestKVPutAll(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+
+	const numKeys = 5000
+	kvs := make(map[string]string, numKeys)
+	for i := 0; i < numKeys; i++ {
+		kvs[fmt.Sprintf("key/%05d", i)] = strconv.Itoa(i)
+	}
+	rev, err := clientv3.PutAll(t.Context(), kv, kvs)
+	require.NoError(t, err)
+
+	resp, err := kv.Get(t.Context(), "key/", clientv3.WithPrefix(), clientv3.WithRev(rev))
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, numKeys)
+	for i, kv := range resp.Kvs {
+		require.Equal(t, fmt.Sprintf("key/%05d", i), string(kv.Key))
+		require.Equal(t, strconv.Itoa(i), string(kv.Value))
+	}
+	// each transaction commits at its own revision
+	require.Equal(t, resp.Kvs[0].ModRevision+numKeys/128, rev)
+}
+
+// TestKVChunkedTxn ensures ChunkedTxn applies more operations than the
+// transaction size limit of the server, which a single Txn refuses.
+func TestKVChunkedTxn(t *testing.T) {
+	integration.BeforeTest(t)
+
+	const maxTxnOps = 16
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, MaxTxnOps: maxTxnOps})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+
+	const numOps = 5*maxTxnOps + 3
+	var ops []clientv3.Op
+	for i := 0; i < numOps; i++ {
+		ops = append(ops, clientv3.OpPut(fmt.Sprintf("key/%03d", i), strconv.Itoa(i)))
+	}
+	_, err := kv.Txn(t.Context()).Then(ops...).Commit()
+	require.ErrorIs(t, err, rpctypes.ErrTooManyOps)
+
+	tresp, err := clientv3.ChunkedTxn(t.Context(), kv, ops, maxTxnOps)
+	require.NoError(t, err)
+	require.True(t, tresp.Succeeded)
+	require.Len(t, tresp.Responses, numOps)
+	for _, r := range tresp.Responses {
+		require.NotNil(t, r.GetResponsePut())
+	}
+
+	resp, err := kv.Get(t.Context(), "key/", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, numOps)
+	for i, kv := range resp.Kvs {
+		require.Equal(t, fmt.Sprintf("key/%03d", i), string(kv.Key))
+		require.Equal(t, strconv.Itoa(i), string(kv.Value))
+	}
+	// each chunk commits at its own revision
+	require.Equal(t, resp.Kvs[0].ModRevision+numOps/maxTxnOps, tresp.Header.Revision)
+
+	// a failing chunk leaves the chunks committed before applied
+	var dops []clientv3.Op
+	for i := 0; i < maxTxnOps; i++ {
+		dops = append(dops, clientv3.OpDelete(fmt.Sprintf("key/%03d", i)))
+	}
+	dops = append(dops, clientv3.OpPut("dup", "a"), clientv3.OpPut("dup", "b"))
+	tresp, err = clientv3.ChunkedTxn(t.Context(), kv, dops, maxTxnOps)
+	require.ErrorIs(t, err, rpctypes.ErrDuplicateKey)
+	require.Len(t, tresp.Responses, maxTxnOps)
+
+	resp, err = kv.Get(t.Context(), "key/", clientv3.WithPrefix(), clientv3.WithCountOnly())
+	require.NoError(t, err)
+	require.Equal(t, int64(numOps-maxTxnOps), resp.Count)
+}
+
+// TestKVDeleteKeys ensures DeleteKeys deletes a scattered set of keys
+// exceeding the transaction size limit.
+func TestKVDeleteKeys(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+
+	const numKeys = 20000
+	for i := 0; i < numKeys; i += 100 {
+		var ops []clientv3.Op
+		for j := i; j < i+100; j++ {
+			ops = append(ops, clientv3.OpPut(fmt.Sprintf("key/%05d", j), strconv.Itoa(j)))
+		}
+		_, err := kv.Txn(t.Context()).Then(ops...).Commit()
+		require.NoError(t, err)
+	}
+
+	// delete every other key in random order, along with a missing key
+	var dkeys []string
+	for _, i := range rand.Perm(numKeys / 2) {
+		dkeys = append(dkeys, fmt.Sprintf("key/%05d", 2*i))
+	}
+	dkeys = append(dkeys, "missing")
+	deleted, err := clientv3.DeleteKeys(t.Context(), kv, dkeys)
+	require.NoError(t, err)
+	require.Equal(t, int64(numKeys/2), deleted)
+
+	resp, err := kv.Get(t.Context(), "key/", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, numKeys/2)
+	for i, kv := range resp.Kvs {
+		require.Equal(t, fmt.Sprintf("key/%05d", 2*i+1), string(kv.Key))
+	}
+}
+
+// TestKVPaginate ensures Paginate covers a large range completely, without
+// overlapping pages, at the revision of the first page.
+func TestKVPaginate(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+
+	const (
+		numKeys  = 3000
+		pageSize = 128
+	)
+	var wkeys []string
+	for i := 0; i < numKeys; i += 100 {
+		var ops []clientv3.Op
+		for j := i; j < i+100; j++ {
+			key := fmt.Sprintf("key/%05d", j)
+			wkeys = append(wkeys, key)
+			ops = append(ops, clientv3.OpPut(key, strconv.Itoa(j)))
+		}
+		_, err := kv.Txn(t.Context()).Then(ops...).Commit()
+		require.NoError(t, err)
+	}
+	_, err := kv.Put(t.Context(), "other", "val")
+	require.NoError(t, err)
+
+	var keys []string
+	pages := 0
+	for resp, err := range clientv3.Paginate(t.Context(), kv, "key/", clientv3.GetPrefixRangeEnd("key/"), pageSize) {
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(resp.Kvs), pageSize)
+		for _, ekv := range resp.Kvs {
+			keys = append(keys, string(ekv.Key))
+		}
+		if pages == 0 {
+			// changes after the first page must not be visible
+			_, err = kv.Put(t.Context(), "key/99999", "val")
+			require.NoError(t, err)
+			_, err = kv.Delete(t.Context(), wkeys[numKeys-1])
+			require.NoError(t, err)
+		}
+		pages++
+	}
+	require.Equal(t, (numKeys+pageSize-1)/pageSize, pages)
+	require.Equal(t, wkeys, keys)
+}
+
+// TestKVScanKeys ensures ScanKeys streams every key of a large prefix
+// exactly once, in order, and reports the end of the scan.
+func TestKVScanKeys(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+
+	const numKeys = 5000
+	var wkeys []string
+	for i := 0; i < numKeys; i += 100 {
+		var ops []clientv3.Op
+		for j := i; j < i+100; j++ {
+			key := fmt.Sprintf("key/%05d", j)
+			wkeys = append(wkeys, key)
+			ops = append(ops, clientv3.OpPut(key, strconv.Itoa(j)))
+		}
+		_, err := kv.Txn(t.Context()).Then(ops...).Commit()
+		require.NoError(t, err)
+	}
+	_, err := kv.Put(t.Context(), "other", "val")
+	require.NoError(t, err)
+
+	keyc, errc := clientv3.ScanKeys(t.Context(), kv, "key/")
+	var keys []string
+	for key := range keyc {
+		keys = append(keys, key)
+	}
+	require.NoError(t, <-errc)
+	require.Equal(t, wkeys, keys)
+}
+
+// TestKVScanKeysCanceled ensures a canceled ScanKeys closes the key channel
+// and reports the cancellation.
+func TestKVScanKeysCanceled(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+
+	var ops []clientv3.Op
+	for i := 0; i < 100; i++ {
+		ops = append(ops, clientv3.OpPut(fmt.Sprintf("key/%03d", i), "val"))
+	}
+	_, err := kv.Txn(t.Context()).Then(ops...).Commit()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	keyc, errc := clientv3.ScanKeys(ctx, kv, "key/")
+	for range keyc {
+	}
+	require.ErrorIs(t, <-errc, context.Canceled)
+}
+
+// TestKVCompareAndSwapRace ensures exactly one of concurrent CompareAndSwap
+// calls from the same revision wins.
+func TestKVCompareAndSwapRace(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	presp, err := clus.Client(0).Put(t.Context(), "foo", "init")
+	require.NoError(t, err)
+	rev := presp.Header.Revision
+
+	for i := 0; i < 10; i++ {
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for j := range errs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[j] = clientv3.CompareAndSwap(t.Context(), clus.Client(j), "foo", rev, fmt.Sprintf("%d-%d", i, j))
+			}()
+		}
+		wg.Wait()
+
+		winner := -1
+		for j, err := range errs {
+			if err == nil {
+				require.Equalf(t, -1, winner, "round %d: both swaps succeeded", i)
+				winner = j
+				continue
+			}
+			var mismatch *clientv3.CASMismatch
+			require.ErrorAsf(t, err, &mismatch, "round %d", i)
+		}
+		require.NotEqualf(t, -1, winner, "round %d: no swap succeeded", i)
+
+		gresp, err := clus.Client(2).Get(t.Context(), "foo")
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("%d-%d", i, winner), string(gresp.Kvs[0].Value))
+		var mismatch *clientv3.CASMismatch
+		require.ErrorAs(t, errs[1-winner], &mismatch)
+		require.Equal(t, gresp.Kvs[0].Value, mismatch.Current.Value)
+		rev = gresp.Kvs[0].ModRevision
+	}
+}
+
 func TestKVLargeRequests(t *testing.T) {
 	integration.BeforeTest(t)
 	tests := []struct {
@@ -875,3 +1418,33 @@ func TestBalancerSupportLearner(t *testing.T) {
 		t.Errorf("expect no error (balancer should retry when request to learner fails), got error: %v", err)
 	}
 }
+
+// TestBalancerPinEndpointAfterWrite ensures that reads are served by the
+// member that served the last successful write when
+// clientv3.Config.PinEndpointAfterWrite is set.
+func TestBalancerPinEndpointAfterWrite(t *testing.T) {
+	integration.BeforeTest(t)
+	if integration.ThroughProxy {
+		t.Skipf("pinning an endpoint is not observable through the grpc proxy")
+	}
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:             clus.Endpoints(),
+		DialTimeout:           5 * time.Second,
+		DialOptions:           []grpc.DialOption{grpc.WithBlock()},
+		PinEndpointAfterWrite: true,
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	presp, err := cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		gresp, err := cli.Get(t.Context(), "foo")
+		require.NoError(t, err)
+		require.Equalf(t, presp.Header.MemberId, gresp.Header.MemberId, "get %d was not served by the member of the last write", i)
+	}
+}
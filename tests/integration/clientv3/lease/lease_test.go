@@ -114,6 +114,45 @@ func TestLeaseKeepAliveOnce(t *testing.T) {
 	}
 }
 
+// TestLeaseKeepAliveOnceMulti ensures all given leases are renewed by a
+// single KeepAliveOnceMulti call.
+func TestLeaseKeepAliveOnceMulti(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	lapi := clus.RandClient()
+
+	ttl := int64(10)
+	var ids []clientv3.LeaseID
+	for i := 0; i < 5; i++ {
+		resp, err := lapi.Grant(t.Context(), ttl)
+		require.NoError(t, err)
+		ids = append(ids, resp.ID)
+	}
+
+	// let the leases age so renewal is observable
+	time.Sleep(2 * time.Second)
+
+	missing := clientv3.LeaseID(0)
+	results := lapi.KeepAliveOnceMulti(t.Context(), append(ids, missing)...)
+	require.Len(t, results, len(ids)+1)
+	require.ErrorIs(t, results[missing].Err, rpctypes.ErrLeaseNotFound)
+	require.Nil(t, results[missing].Response)
+
+	for _, id := range ids {
+		res := results[id]
+		require.NoErrorf(t, res.Err, "lease %x", id)
+		require.Equal(t, id, res.Response.ID)
+		require.Equal(t, ttl, res.Response.TTL)
+
+		ttlResp, err := lapi.TimeToLive(t.Context(), id)
+		require.NoError(t, err)
+		require.GreaterOrEqualf(t, ttlResp.TTL, ttl-1, "lease %x was not renewed", id)
+	}
+}
+
 func TestLeaseKeepAlive(t *testing.T) {
 	integration.BeforeTest(t)
 
@@ -601,6 +640,56 @@ func TestLeaseLeases(t *testing.T) {
 	}
 }
 
+// TestLeaseExpirations ensures the lease expirations channel reports the
+// IDs of expired and revoked leases, but not of live ones.
+func TestLeaseExpirations(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	expc := clus.Client(0).Expirations(ctx)
+	// grant through another member so the revocation must be applied
+	// by the watching member
+	cli := clus.Client(1)
+
+	live, err := cli.Grant(t.Context(), 60)
+	require.NoError(t, err)
+	short, err := cli.Grant(t.Context(), 1)
+	require.NoError(t, err)
+
+	select {
+	case id := <-expc:
+		require.Equal(t, short.ID, id)
+	case <-time.After(10 * time.Second):
+		t.Fatalf("lease %x expiration was not reported", short.ID)
+	}
+
+	revoked, err := cli.Grant(t.Context(), 60)
+	require.NoError(t, err)
+	_, err = cli.Revoke(t.Context(), revoked.ID)
+	require.NoError(t, err)
+	select {
+	case id := <-expc:
+		require.Equal(t, revoked.ID, id)
+	case <-time.After(10 * time.Second):
+		t.Fatalf("lease %x revocation was not reported", revoked.ID)
+	}
+
+	ttl, err := cli.TimeToLive(t.Context(), live.ID)
+	require.NoError(t, err)
+	require.Positive(t, ttl.TTL)
+
+	cancel()
+	select {
+	case id, ok := <-expc:
+		require.Falsef(t, ok, "unexpected expiration of %x after cancel", id)
+	case <-time.After(10 * time.Second):
+		t.Fatal("expirations channel not closed after cancel")
+	}
+}
+
 // TestLeaseRenewLostQuorum ensures keepalives work after losing quorum
 // for a while.
 func TestLeaseRenewLostQuorum(t *testing.T) {
@@ -24,6 +24,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -34,14 +35,17 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/api/v3/version"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/etcdserver"
 	"go.etcd.io/etcd/server/v3/lease"
 	"go.etcd.io/etcd/server/v3/storage"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	"go.etcd.io/etcd/server/v3/storage/mvcc/testutil"
+	"go.etcd.io/etcd/server/v3/storage/schema"
 	"go.etcd.io/etcd/tests/v3/framework/integration"
 )
 
@@ -147,6 +151,338 @@ func TestMaintenanceMoveLeader(t *testing.T) {
 	}
 }
 
+// TestMaintenanceForceSnapshot ensures that ForceSnapshot saves a raft
+// snapshot to disk before the snapshot count is reached.
+func TestMaintenanceForceSnapshot(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, SnapshotCount: 10000})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	_, err := cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+
+	snapFiles := func() []string {
+		files, gerr := filepath.Glob(filepath.Join(clus.Members[0].SnapDir(), "*.snap"))
+		require.NoError(t, gerr)
+		return files
+	}
+	require.Empty(t, snapFiles())
+
+	resp, err := cli.ForceSnapshot(t.Context(), cli.Endpoints()[0])
+	require.NoError(t, err)
+	require.NotZero(t, resp.SnapshotIndex)
+
+	files := snapFiles()
+	require.Len(t, files, 1)
+	require.Equal(t, fmt.Sprintf("%016x-%016x.snap", resp.Header.RaftTerm, resp.SnapshotIndex), filepath.Base(files[0]))
+}
+
+// TestMaintenanceCancelCompaction ensures that CancelCompaction aborts a
+// running physical compaction, leaving the store readable and consistent.
+func TestMaintenanceCancelCompaction(t *testing.T) {
+	integration.BeforeTest(t)
+
+	// the compaction pauses for long after each batch, and is canceled
+	// during the pause following the first one
+	const sleepInterval = 10 * time.Second
+	clus := integration.NewCluster(t, &integration.ClusterConfig{
+		Size:                    1,
+		CompactionBatchLimit:    100,
+		CompactionSleepInterval: sleepInterval,
+	})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx := t.Context()
+
+	const keys, puts = 10, 100
+	var rev int64
+	for i := range puts {
+		for k := range keys {
+			resp, err := cli.Put(ctx, fmt.Sprintf("foo%d", k), fmt.Sprintf("%d", i))
+			require.NoError(t, err)
+			rev = resp.Header.Revision
+		}
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := cli.Compact(ctx, rev, clientv3.WithCompactPhysical())
+		errc <- err
+	}()
+	// the compaction context is taken when the compaction is applied
+	require.Eventually(t, func() bool {
+		_, err := cli.Get(ctx, "foo0", clientv3.WithRev(rev-1))
+		return errors.Is(err, rpctypes.ErrCompacted)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	_, err := cli.CancelCompaction(ctx, cli.Endpoints()[0])
+	require.NoError(t, err)
+	select {
+	case err = <-errc:
+		require.NoError(t, err)
+	case <-time.After(sleepInterval / 2):
+		t.Fatal("compaction was not canceled")
+	}
+
+	// only the first batch of revisions was freed
+	require.Equal(t, keys*puts-100, backendKeyRevisions(clus.Members[0].Server.Backend()))
+
+	// the store is readable and consistent at the compaction revision
+	resp, err := cli.Get(ctx, "foo", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, keys)
+	for k, kv := range resp.Kvs {
+		assert.Equal(t, fmt.Sprintf("foo%d", k), string(kv.Key))
+		assert.Equal(t, fmt.Sprintf("%d", puts-1), string(kv.Value))
+		assert.Equal(t, int64(puts), kv.Version)
+	}
+}
+
+// TestMaintenanceDefragmentWithProgress ensures that DefragmentWithProgress
+// streams monotonically increasing progress until all bytes are copied.
+func TestMaintenanceDefragmentWithProgress(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	const keys = 2000
+	val := strings.Repeat("a", 1024)
+	for i := 0; i < keys; i++ {
+		_, err := cli.Put(t.Context(), fmt.Sprintf("foo%d", i), val)
+		require.NoError(t, err)
+	}
+
+	var processed, total []int64
+	resp, err := cli.DefragmentWithProgress(t.Context(), cli.Endpoints()[0], func(p, tot int64) {
+		processed = append(processed, p)
+		total = append(total, tot)
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Header)
+
+	require.NotEmpty(t, processed)
+	require.IsNonDecreasing(t, processed)
+	require.Equal(t, total[len(total)-1], processed[len(processed)-1])
+	require.Greater(t, total[len(total)-1], int64(keys*len(val)))
+}
+
+// TestMaintenanceMemberRemovePreview ensures that MemberRemovePreview on a
+// cluster with down members matches whether the member accepts the removal.
+func TestMaintenanceMemberRemovePreview(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 5, UseBridge: true})
+	defer clus.Terminate(t)
+
+	// make cluster unhealthy (3 up, 2 down) and wait until the up members
+	// have been connected for a health interval
+	clus.Members[0].Stop(t)
+	clus.Members[1].Stop(t)
+	leader := clus.WaitLeader(t)
+	time.Sleep(etcdserver.HealthInterval)
+
+	cli := clus.Members[leader].Client
+	preview := func(id uint64) *clientv3.MemberRemovePreviewResponse {
+		resp, err := cli.MemberRemovePreview(t.Context(), cli.Endpoints()[0], id)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// (3,2)-(1,0) => (2,2) lacks quorum
+	activeID := uint64(clus.Members[2].Server.MemberID())
+	resp := preview(activeID)
+	require.False(t, resp.Accepted)
+	require.Contains(t, resp.Reason, "unhealthy cluster")
+	require.Equal(t, int64(4), resp.VotingMembers)
+	require.Equal(t, int64(2), resp.ActiveMembers)
+	require.Equal(t, int64(3), resp.Quorum)
+	require.Error(t, clus.RemoveMember(t, cli, activeID))
+
+	// (3,2)-(0,1) => (3,1) has quorum
+	downID := uint64(clus.Members[0].Server.MemberID())
+	resp = preview(downID)
+	require.True(t, resp.Accepted)
+	require.Empty(t, resp.Reason)
+	require.Equal(t, int64(4), resp.VotingMembers)
+	require.Equal(t, int64(3), resp.ActiveMembers)
+	require.Equal(t, int64(3), resp.Quorum)
+	require.NoError(t, clus.RemoveMember(t, cli, downID))
+
+	_, err := cli.MemberRemovePreview(t.Context(), cli.Endpoints()[0], downID)
+	require.ErrorIs(t, err, rpctypes.ErrMemberNotFound)
+}
+
+// TestMaintenanceLinearizableProbe ensures LinearizableProbe succeeds on a
+// stable cluster and fails fast once the member loses its leader.
+func TestMaintenanceLinearizableProbe(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(2)
+	resp, err := cli.LinearizableProbe(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint64(clus.Members[2].Server.MemberID()), resp.Header.MemberId)
+	require.Equal(t, clus.Members[2].Server.Term(), resp.Header.RaftTerm)
+	require.GreaterOrEqual(t, resp.LatencyUs, int64(0))
+
+	clus.Members[0].Stop(t)
+	clus.Members[1].Stop(t)
+	clus.WaitMembersNoLeader(clus.Members[2:])
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err = cli.LinearizableProbe(ctx)
+	require.ErrorIs(t, err, rpctypes.ErrNoLeader)
+	require.Less(t, time.Since(start), time.Second)
+
+	require.NoError(t, clus.Members[0].Restart(t))
+	require.NoError(t, clus.Members[1].Restart(t))
+	clus.WaitLeader(t)
+
+	resp, err = cli.LinearizableProbe(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, clus.Members[2].Server.Term(), resp.Header.RaftTerm)
+}
+
+// TestCurrentRevision ensures CurrentRevision tracks the store revision as
+// keys are written.
+func TestCurrentRevision(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	rev, err := cli.CurrentRevision(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rev)
+
+	for i := 0; i < 5; i++ {
+		presp, perr := cli.Put(t.Context(), fmt.Sprintf("foo%d", i), "bar")
+		require.NoError(t, perr)
+		rev, err = cli.CurrentRevision(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, presp.Header.Revision, rev)
+	}
+	require.Equal(t, int64(6), rev)
+
+	// reads do not move the revision
+	_, err = cli.Get(t.Context(), "foo", clientv3.WithPrefix())
+	require.NoError(t, err)
+	rev, err = cli.CurrentRevision(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, int64(6), rev)
+}
+
+func TestMaintenanceBucketStats(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx := t.Context()
+	const (
+		keys   = 100
+		leases = 3
+	)
+	val := strings.Repeat("v", 64)
+	for i := 0; i < keys; i++ {
+		_, err := cli.Put(ctx, fmt.Sprintf("foo%d", i), val)
+		require.NoError(t, err)
+	}
+	for i := 0; i < leases; i++ {
+		_, err := cli.Grant(ctx, 100)
+		require.NoError(t, err)
+	}
+
+	resp, err := cli.BucketStats(ctx, clus.Members[0].GRPCURL)
+	require.NoError(t, err)
+	require.Equal(t, uint64(clus.Members[0].Server.MemberID()), resp.Header.MemberId)
+
+	stats := make(map[string]*pb.BucketStat)
+	for _, b := range resp.Buckets {
+		stats[b.Name] = b
+	}
+	require.Len(t, stats, len(schema.AllBuckets))
+
+	// the key bucket holds one revision per put
+	require.Equal(t, int64(keys), stats["key"].Keys)
+	require.Greater(t, stats["key"].Bytes, int64(keys*len(val)))
+	require.Equal(t, int64(leases), stats["lease"].Keys)
+	require.Positive(t, stats["lease"].Bytes)
+	require.Zero(t, stats["alarm"].Keys)
+	require.Zero(t, stats["alarm"].Bytes)
+
+	be := clus.Members[0].Server.Backend()
+	for _, bkt := range schema.AllBuckets {
+		wantKeys, wantBytes := be.BucketStats(bkt)
+		require.Equal(t, wantKeys, stats[bkt.String()].Keys, bkt.String())
+		require.Equal(t, wantBytes, stats[bkt.String()].Bytes, bkt.String())
+	}
+}
+
+func TestMaintenanceSetCommitModeDisabled(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	_, err := clus.RandClient().SetCommitMode(t.Context(), clus.Members[0].GRPCURL, clientv3.CommitModeSync)
+	require.ErrorIs(t, err, rpctypes.ErrCommitModeDisabled)
+	require.Equal(t, backend.CommitModeBatched, clus.Members[0].Server.Backend().CommitMode())
+}
+
+func TestMaintenanceSetCommitMode(t *testing.T) {
+	integration.BeforeTest(t)
+	lg := zaptest.NewLogger(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, EnableRuntimeCommitMode: true})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx := t.Context()
+	ep := clus.Members[0].GRPCURL
+
+	resp, err := cli.SetCommitMode(ctx, ep, clientv3.CommitModeSync)
+	require.NoError(t, err)
+	require.Equal(t, pb.SetCommitModeRequest_BATCHED, resp.PreviousMode)
+	require.Equal(t, backend.CommitModeSync, clus.Members[0].Server.Backend().CommitMode())
+
+	_, err = cli.Put(ctx, "foo", "bar")
+	require.NoError(t, err)
+
+	// simulate a crash by reading a copy of the backend file as it is on disk
+	data, err := os.ReadFile(clus.Members[0].BackendPath())
+	require.NoError(t, err)
+	dpath := filepath.Join(t.TempDir(), "db")
+	require.NoError(t, os.WriteFile(dpath, data, 0o600))
+	b := backend.NewDefaultBackend(lg, dpath)
+	s := mvcc.NewStore(lg, b, &lease.FakeLessor{}, mvcc.StoreConfig{})
+	rr, err := s.Range(ctx, []byte("foo"), nil, mvcc.RangeOptions{})
+	require.NoError(t, err)
+	require.Len(t, rr.KVs, 1)
+	require.Equal(t, "bar", string(rr.KVs[0].Value))
+	s.Close()
+	b.Close()
+
+	resp, err = cli.SetCommitMode(ctx, ep, clientv3.CommitModeBatched)
+	require.NoError(t, err)
+	require.Equal(t, pb.SetCommitModeRequest_SYNC, resp.PreviousMode)
+	require.Equal(t, backend.CommitModeBatched, clus.Members[0].Server.Backend().CommitMode())
+
+	_, err = cli.SetCommitMode(ctx, ep, clientv3.CommitMode(100))
+	require.ErrorIs(t, err, rpctypes.ErrInvalidCommitMode)
+}
+
 // TestMaintenanceSnapshotCancel ensures that context cancel
 // before snapshot reading returns corresponding context errors.
 func TestMaintenanceSnapshotCancel(t *testing.T) {
@@ -50,6 +50,60 @@ func TestNamespacePutGet(t *testing.T) {
 	}
 }
 
+func TestNamespaceCompareAndSwap(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := clus.Client(0)
+	nsKV := namespace.NewKV(c.KV, "foo/")
+
+	presp, err := clientv3.CompareAndSwap(t.Context(), nsKV, "abc", 0, "bar")
+	require.NoError(t, err)
+
+	presp, err = clientv3.CompareAndSwap(t.Context(), nsKV, "abc", presp.Header.Revision, "baz", clientv3.WithPrevKV())
+	require.NoError(t, err)
+	require.Equal(t, "abc", string(presp.PrevKv.Key))
+
+	_, err = clientv3.CompareAndSwap(t.Context(), nsKV, "abc", 0, "qux")
+	var mismatch *clientv3.CASMismatch
+	require.ErrorAs(t, err, &mismatch)
+	require.Equal(t, "abc", mismatch.Key)
+	require.Equal(t, "abc", string(mismatch.Current.Key))
+	require.Equal(t, "baz", string(mismatch.Current.Value))
+
+	resp, err := c.Get(t.Context(), "foo/abc")
+	require.NoError(t, err)
+	require.Equal(t, "baz", string(resp.Kvs[0].Value))
+}
+
+func TestNamespacePaginate(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := clus.Client(0)
+	nsKV := namespace.NewKV(c.KV, "foo/")
+
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		_, err := nsKV.Put(t.Context(), key, "bar")
+		require.NoError(t, err)
+	}
+	_, err := c.Put(t.Context(), "zoo", "bar")
+	require.NoError(t, err)
+
+	var keys []string
+	for resp, err := range clientv3.Paginate(t.Context(), nsKV, "a", "\x00", 2) {
+		require.NoError(t, err)
+		for _, kv := range resp.Kvs {
+			keys = append(keys, string(kv.Key))
+		}
+	}
+	require.Equal(t, []string{"a", "b", "c", "d", "e"}, keys)
+}
+
 func TestNamespaceWatch(t *testing.T) {
 	integration.BeforeTest(t)
 
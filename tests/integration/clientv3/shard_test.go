@@ -0,0 +1,116 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"fmt"
+	"hash/fnv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/shard"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+const numShards = 3
+
+// moduloShard stores a key under the prefix of its hash modulo numShards.
+func moduloShard(key string) string {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return fmt.Sprintf("shard-%d/%s", h.Sum32()%numShards, key)
+}
+
+func TestShardPutGet(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := clus.Client(0)
+	shardKV := shard.NewKV(c.KV, moduloShard)
+
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	for _, key := range keys {
+		_, err := shardKV.Put(t.Context(), key, "v-"+key)
+		require.NoError(t, err)
+	}
+	for _, key := range keys {
+		resp, err := shardKV.Get(t.Context(), key)
+		require.NoError(t, err)
+		require.Len(t, resp.Kvs, 1)
+		require.Equal(t, key, string(resp.Kvs[0].Key))
+		require.Equal(t, "v-"+key, string(resp.Kvs[0].Value))
+	}
+
+	// the keys are stored in their shards
+	shards := make(map[string]bool)
+	resp, err := c.Get(t.Context(), "", clientv3.WithFromKey())
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, len(keys))
+	for _, kv := range resp.Kvs {
+		key := string(kv.Value)[len("v-"):]
+		require.Equal(t, moduloShard(key), string(kv.Key))
+		shards[string(kv.Key[:len("shard-0")])] = true
+	}
+	require.Len(t, shards, numShards)
+
+	tresp, err := shardKV.Txn(t.Context()).
+		If(clientv3.Compare(clientv3.Value("a"), "=", "v-a")).
+		Then(clientv3.OpGet("b"), clientv3.OpPut("c", "w-c", clientv3.WithPrevKV()), clientv3.OpDelete("d", clientv3.WithPrevKV())).
+		Commit()
+	require.NoError(t, err)
+	require.True(t, tresp.Succeeded)
+	require.Equal(t, "b", string(tresp.Responses[0].GetResponseRange().Kvs[0].Key))
+	require.Equal(t, "c", string(tresp.Responses[1].GetResponsePut().PrevKv.Key))
+	require.Equal(t, "d", string(tresp.Responses[2].GetResponseDeleteRange().PrevKvs[0].Key))
+
+	_, err = shardKV.Get(t.Context(), "a", clientv3.WithPrefix())
+	require.ErrorIs(t, err, shard.ErrRange)
+	_, err = shardKV.Txn(t.Context()).Then(clientv3.OpDelete("a", clientv3.WithRange("z"))).Commit()
+	require.ErrorIs(t, err, shard.ErrRange)
+}
+
+func TestShardWatch(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := clus.Client(0)
+	shardKV := shard.NewKV(c.KV, moduloShard)
+	shardWatcher := shard.NewWatcher(c.Watcher, moduloShard)
+
+	_, err := shardKV.Put(t.Context(), "abc", "bar")
+	require.NoError(t, err)
+
+	wr := <-shardWatcher.Watch(t.Context(), "abc", clientv3.WithRev(1))
+	require.Len(t, wr.Events, 1)
+	require.Equal(t, &mvccpb.KeyValue{Key: []byte("abc"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1}, wr.Events[0].Kv)
+
+	wr = <-c.Watch(t.Context(), moduloShard("abc"), clientv3.WithRev(1))
+	require.Len(t, wr.Events, 1)
+	require.Equal(t, moduloShard("abc"), string(wr.Events[0].Kv.Key))
+
+	wr = <-shardWatcher.Watch(t.Context(), "abc", clientv3.WithPrefix())
+	require.True(t, wr.Canceled)
+	require.ErrorIs(t, wr.Err(), shard.ErrRange)
+
+	// let client close teardown shard watch
+	c.Watcher = shardWatcher
+}
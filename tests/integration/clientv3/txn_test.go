@@ -183,6 +183,38 @@ func TestTxnCompareRange(t *testing.T) {
 	}
 }
 
+func TestTxnCompareLease(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	lresp, err := cli.Grant(t.Context(), 60)
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "foo", "bar", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "baz", "bar")
+	require.NoError(t, err)
+
+	tests := []struct {
+		cmp      clientv3.Cmp
+		wSucceed bool
+	}{
+		{clientv3.Compare(clientv3.LeaseValue("foo"), "=", lresp.ID), true},
+		{clientv3.Compare(clientv3.LeaseValue("foo"), "=", clientv3.NoLease), false},
+		{clientv3.Compare(clientv3.LeaseValue("foo"), "!=", lresp.ID), false},
+		{clientv3.Compare(clientv3.LeaseValue("foo"), "=", lresp.ID+1), false},
+		{clientv3.Compare(clientv3.LeaseValue("baz"), "=", clientv3.NoLease), true},
+		{clientv3.Compare(clientv3.LeaseValue("baz"), "=", lresp.ID), false},
+	}
+	for i, tt := range tests {
+		tresp, terr := cli.Txn(t.Context()).If(tt.cmp).Commit()
+		require.NoErrorf(t, terr, "#%d", i)
+		require.Equalf(t, tt.wSucceed, tresp.Succeeded, "#%d", i)
+	}
+}
+
 func TestTxnNested(t *testing.T) {
 	integration.BeforeTest(t)
 
@@ -214,3 +246,100 @@ func TestTxnNested(t *testing.T) {
 		t.Errorf("unexpected Get response %+v", resp)
 	}
 }
+
+// TestReadTxn ensures ReadTxn returns the same results, in request order, as
+// individual Gets at the revision of the transaction.
+func TestReadTxn(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	kv := clus.Client(0)
+	ctx := t.Context()
+
+	for _, key := range []string{"a", "b/1", "b/2", "b/3", "c"} {
+		_, err := kv.Put(ctx, key, "v-"+key)
+		require.NoError(t, err)
+	}
+
+	type get struct {
+		key  string
+		opts []clientv3.OpOption
+	}
+	gets := []get{
+		{key: "c"},
+		{key: "b/", opts: []clientv3.OpOption{clientv3.WithPrefix()}},
+		{key: "missing"},
+		{key: "a", opts: []clientv3.OpOption{clientv3.WithCountOnly()}},
+		{key: "b/", opts: []clientv3.OpOption{clientv3.WithPrefix(), clientv3.WithKeysOnly(), clientv3.WithLimit(2)}},
+	}
+
+	for _, serializable := range []bool{false, true} {
+		t.Run(fmt.Sprintf("serializable=%v", serializable), func(t *testing.T) {
+			rtxn := clientv3.NewReadTxn(ctx, clus.Client(1))
+			for _, g := range gets {
+				rtxn = rtxn.Get(g.key, g.opts...)
+			}
+			if serializable {
+				rtxn = rtxn.Serializable()
+			}
+			resp, err := rtxn.Commit()
+			require.NoError(t, err)
+			require.Len(t, resp.Responses, len(gets))
+
+			// writes after the transaction must not affect the comparison
+			_, err = kv.Put(ctx, "b/4", "v-b/4")
+			require.NoError(t, err)
+
+			rev := resp.Header.Revision
+			for i, g := range gets {
+				gresp, err := kv.Get(ctx, g.key, append(g.opts, clientv3.WithRev(rev))...)
+				require.NoError(t, err)
+				require.Equal(t, gresp.Kvs, resp.Responses[i].Kvs)
+				require.Equal(t, gresp.Count, resp.Responses[i].Count)
+				require.Equal(t, gresp.More, resp.Responses[i].More)
+			}
+		})
+	}
+}
+
+// TestTxnMixedSerializableRanges ensures a txn mixing a linearizable and a
+// serializable range is linearizable, while a txn of serializable ranges only
+// is served locally.
+func TestTxnMixedSerializableRanges(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	kv := clus.Client(0)
+	_, err := kv.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+
+	mixed := []clientv3.Op{clientv3.OpGet("foo"), clientv3.OpGet("foo", clientv3.WithSerializable())}
+	serializable := []clientv3.Op{clientv3.OpGet("foo", clientv3.WithSerializable()), clientv3.OpGet("foo", clientv3.WithSerializable())}
+
+	resp, err := kv.Txn(t.Context()).Then(mixed...).Commit()
+	require.NoError(t, err)
+	for _, r := range resp.Responses {
+		require.Equal(t, "bar", string(r.GetResponseRange().Kvs[0].Value))
+		require.False(t, r.GetResponseRange().Serializable)
+	}
+
+	// without quorum, only the serializable txn is served
+	clus.Members[1].Stop(t)
+	clus.Members[2].Stop(t)
+
+	resp, err = kv.Txn(t.Context()).Then(serializable...).Commit()
+	require.NoError(t, err)
+	for _, r := range resp.Responses {
+		require.Equal(t, "bar", string(r.GetResponseRange().Kvs[0].Value))
+		require.True(t, r.GetResponseRange().Serializable)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+	_, err = kv.Txn(ctx).Then(mixed...).Commit()
+	require.Error(t, err)
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
@@ -66,6 +67,7 @@ func TestV3WatchFromCurrentRevision(t *testing.T) {
 						{
 							Type: mvccpb.PUT,
 							Kv:   &mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1},
+							Seq:  1,
 						},
 					},
 				},
@@ -100,6 +102,7 @@ func TestV3WatchFromCurrentRevision(t *testing.T) {
 						{
 							Type: mvccpb.PUT,
 							Kv:   &mvccpb.KeyValue{Key: []byte("fooLong"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1},
+							Seq:  1,
 						},
 					},
 				},
@@ -135,6 +138,7 @@ func TestV3WatchFromCurrentRevision(t *testing.T) {
 						{
 							Type: mvccpb.PUT,
 							Kv:   &mvccpb.KeyValue{Key: []byte("fooLong"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1},
+							Seq:  1,
 						},
 					},
 				},
@@ -157,6 +161,7 @@ func TestV3WatchFromCurrentRevision(t *testing.T) {
 						{
 							Type: mvccpb.PUT,
 							Kv:   &mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1},
+							Seq:  1,
 						},
 					},
 				},
@@ -167,6 +172,7 @@ func TestV3WatchFromCurrentRevision(t *testing.T) {
 						{
 							Type: mvccpb.PUT,
 							Kv:   &mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 3, Version: 2},
+							Seq:  2,
 						},
 					},
 				},
@@ -177,6 +183,7 @@ func TestV3WatchFromCurrentRevision(t *testing.T) {
 						{
 							Type: mvccpb.PUT,
 							Kv:   &mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 4, Version: 3},
+							Seq:  3,
 						},
 					},
 				},
@@ -200,6 +207,7 @@ func TestV3WatchFromCurrentRevision(t *testing.T) {
 						{
 							Type: mvccpb.PUT,
 							Kv:   &mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1},
+							Seq:  1,
 						},
 					},
 				},
@@ -210,6 +218,7 @@ func TestV3WatchFromCurrentRevision(t *testing.T) {
 						{
 							Type: mvccpb.PUT,
 							Kv:   &mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 3, Version: 2},
+							Seq:  2,
 						},
 					},
 				},
@@ -220,6 +229,7 @@ func TestV3WatchFromCurrentRevision(t *testing.T) {
 						{
 							Type: mvccpb.PUT,
 							Kv:   &mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 4, Version: 3},
+							Seq:  3,
 						},
 					},
 				},
@@ -609,6 +619,7 @@ func TestV3WatchEmptyKey(t *testing.T) {
 		{
 			Type: mvccpb.PUT,
 			Kv:   &mvccpb.KeyValue{Key: []byte("foo"), CreateRevision: 2, ModRevision: 2, Version: 1},
+			Seq:  1,
 		},
 	}
 	if !reflect.DeepEqual(resp.Events, wevs) {
@@ -793,14 +804,17 @@ func testV3WatchMultipleEventsTxn(t *testing.T, startRev int64) {
 		{
 			Type: mvccpb.PUT,
 			Kv:   &mvccpb.KeyValue{Key: []byte("foo0"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1},
+			Seq:  1,
 		},
 		{
 			Type: mvccpb.PUT,
 			Kv:   &mvccpb.KeyValue{Key: []byte("foo1"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1},
+			Seq:  2,
 		},
 		{
 			Type: mvccpb.PUT,
 			Kv:   &mvccpb.KeyValue{Key: []byte("foo2"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1},
+			Seq:  3,
 		},
 	}
 
@@ -863,18 +877,22 @@ func TestV3WatchMultipleEventsPutUnsynced(t *testing.T) {
 		{
 			Type: mvccpb.PUT,
 			Kv:   &mvccpb.KeyValue{Key: []byte("foo0"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1},
+			Seq:  1,
 		},
 		{
 			Type: mvccpb.PUT,
 			Kv:   &mvccpb.KeyValue{Key: []byte("foo1"), Value: []byte("bar"), CreateRevision: 3, ModRevision: 3, Version: 1},
+			Seq:  2,
 		},
 		{
 			Type: mvccpb.PUT,
 			Kv:   &mvccpb.KeyValue{Key: []byte("foo0"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 4, Version: 2},
+			Seq:  3,
 		},
 		{
 			Type: mvccpb.PUT,
 			Kv:   &mvccpb.KeyValue{Key: []byte("foo1"), Value: []byte("bar"), CreateRevision: 3, ModRevision: 5, Version: 2},
+			Seq:  4,
 		},
 	}
 
@@ -1066,6 +1084,7 @@ func testV3WatchMultipleStreams(t *testing.T, startRev int64) {
 		{
 			Type: mvccpb.PUT,
 			Kv:   &mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1},
+			Seq:  1,
 		},
 	}
 	for i := range streams {
@@ -1266,6 +1285,7 @@ func TestV3WatchWithFilter(t *testing.T) {
 			{
 				Type: mvccpb.DELETE,
 				Kv:   &mvccpb.KeyValue{Key: []byte("foo"), ModRevision: 3},
+				Seq:  1,
 			},
 		}
 		if !reflect.DeepEqual(resp.Events, wevs) {
@@ -1344,6 +1364,71 @@ func TestV3WatchWithPrevKV(t *testing.T) {
 	}
 }
 
+// TestV3WatchFragmentRequested ensures the server only splits large watch
+// responses into fragments for watchers that requested fragmentation, or
+// whose client reassembles fragments.
+func TestV3WatchFragmentRequested(t *testing.T) {
+	integration.BeforeTest(t)
+	if integration.ThroughProxy {
+		t.Skipf("grpc-proxy does not fragment watch responses")
+	}
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, MaxRequestBytes: 1.5 * 1024 * 1024})
+	defer clus.Terminate(t)
+
+	kvc := integration.ToGRPC(clus.RandClient()).KV
+	for i := 0; i < 3; i++ {
+		_, err := kvc.Put(t.Context(), &pb.PutRequest{Key: fmt.Appendf(nil, "foo%d", i), Value: bytes.Repeat([]byte("a"), 1024*1024)})
+		require.NoError(t, err)
+	}
+
+	for _, tc := range []struct {
+		fragment    bool
+		reassembles bool
+		want        bool
+	}{
+		{fragment: false, reassembles: false, want: false},
+		{fragment: true, reassembles: false, want: true},
+		{fragment: false, reassembles: true, want: true},
+	} {
+		ctx := t.Context()
+		if tc.reassembles {
+			ctx = metadata.AppendToOutgoingContext(ctx, rpctypes.MetadataWatchFragmentKey, rpctypes.MetadataWatchFragment)
+		}
+		ws, err := integration.ToGRPC(clus.RandClient()).Watch.Watch(ctx)
+		require.NoError(t, err)
+		req := &pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+			CreateRequest: &pb.WatchCreateRequest{
+				Key:           []byte("foo"),
+				RangeEnd:      []byte("fop"),
+				StartRevision: 1,
+				Fragment:      tc.fragment,
+			},
+		}}
+		require.NoError(t, ws.Send(req))
+		resp, err := ws.Recv()
+		require.NoError(t, err)
+		require.True(t, resp.Created)
+
+		var resps, events int
+		for {
+			resp, err = ws.Recv()
+			require.NoError(t, err)
+			resps++
+			events += len(resp.Events)
+			if !resp.Fragment {
+				break
+			}
+		}
+		require.Equalf(t, 3, events, "%+v", tc)
+		if tc.want {
+			require.Greater(t, resps, 1)
+		} else {
+			require.Equal(t, 1, resps)
+		}
+		require.NoError(t, ws.CloseSend())
+	}
+}
+
 // TestV3WatchCancellation ensures that watch cancellation frees up server resources.
 func TestV3WatchCancellation(t *testing.T) {
 	integration.BeforeTest(t)
@@ -1585,3 +1670,66 @@ func TestV3NoEventsLostOnCompact(t *testing.T) {
 	}
 	assert.Truef(t, compacted, "Expected stream to get compacted, instead we got %d events out of %d events", eventCount, writeCount)
 }
+
+// TestV3WatchFromIndex ensures that watching from a raft index produces the
+// same events as a live watch started before the entries were applied, until
+// the revisions of the entries are compacted.
+func TestV3WatchFromIndex(t *testing.T) {
+	integration.BeforeTest(t)
+	if integration.ThroughProxy {
+		t.Skipf("events watched from an index are not namespaced by the grpc proxy")
+	}
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	srv := clus.Members[0].Server
+
+	_, err := cli.Put(t.Context(), "init", "0")
+	require.NoError(t, err)
+	startIndex := srv.AppliedIndex() + 1
+	startRev := srv.KV().Rev() + 1
+
+	wch := cli.Watch(t.Context(), "", clientv3.WithPrefix(), clientv3.WithRev(startRev))
+
+	_, err = cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "foo", "baz")
+	require.NoError(t, err)
+	_, err = cli.Delete(t.Context(), "missing")
+	require.NoError(t, err)
+	_, err = cli.Txn(t.Context()).Then(clientv3.OpPut("a", "1"), clientv3.OpPut("b", "2"), clientv3.OpDelete("foo")).Commit()
+	require.NoError(t, err)
+	_, err = cli.Delete(t.Context(), "", clientv3.WithPrefix())
+	require.NoError(t, err)
+
+	var live []mvccpb.Event
+	for len(live) < 8 {
+		wresp := <-wch
+		require.NoError(t, wresp.Err())
+		for _, ev := range wresp.Events {
+			live = append(live, mvccpb.Event(*ev))
+		}
+	}
+
+	var (
+		watched []mvccpb.Event
+		indexes []uint64
+	)
+	err = srv.WatchFromIndex(t.Context(), startIndex, func(index uint64, events []mvccpb.Event) error {
+		indexes = append(indexes, index)
+		watched = append(watched, events...)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, live, watched)
+	// the delete of a missing key does not produce any event
+	require.Len(t, indexes, 4)
+	require.IsIncreasing(t, indexes)
+
+	_, err = cli.Compact(t.Context(), srv.KV().Rev())
+	require.NoError(t, err)
+	err = srv.WatchFromIndex(t.Context(), startIndex, func(uint64, []mvccpb.Event) error { return nil })
+	require.ErrorIs(t, err, mvcc.ErrCompacted)
+}
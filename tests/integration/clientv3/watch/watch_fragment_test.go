@@ -39,9 +39,8 @@ func TestWatchFragmentDisable(t *testing.T) {
 // TestWatchFragmentDisableWithGRPCLimit verifies
 // large watch response exceeding server-side request
 // limit and client-side gRPC response receive limit
-// cannot arrive without watch events fragmentation,
-// because multiple events exceed client-side gRPC
-// response receive limit.
+// arrive even without WithFragment, because the
+// client tells the server it reassembles fragments.
 func TestWatchFragmentDisableWithGRPCLimit(t *testing.T) {
 	testWatchFragment(t, false, true)
 }
@@ -101,14 +100,6 @@ func testWatchFragment(t *testing.T, fragment, exceedRecvLimit bool) {
 	// expect 10 MiB watch response
 	select {
 	case ws := <-wch:
-		// without fragment, should exceed gRPC client receive limit
-		if !fragment && exceedRecvLimit {
-			require.Emptyf(t, ws.Events, "expected 0 events with watch fragmentation")
-			exp := "code = ResourceExhausted desc = grpc: received message larger than max ("
-			require.Containsf(t, ws.Err().Error(), exp, "expected 'ResourceExhausted' error")
-			return
-		}
-
 		// still expect merged watch events
 		require.Lenf(t, ws.Events, 10, "expected 10 events with watch fragmentation")
 		require.NoErrorf(t, ws.Err(), "unexpected error")
@@ -117,3 +108,96 @@ func testWatchFragment(t *testing.T, fragment, exceedRecvLimit bool) {
 		t.Fatalf("took too long to receive events")
 	}
 }
+
+// TestWatchFragmentLargeTxn ensures a single revision whose events exceed
+// the server-side request limit and the client-side gRPC receive limit
+// is delivered as one complete, ordered response.
+func TestWatchFragmentLargeTxn(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{
+		Size:            1,
+		MaxTxnOps:       4096,
+		MaxRequestBytes: 1.5 * 1024 * 1024,
+		// the server-side request limit including the gRPC overhead
+		ClientMaxCallRecvMsgSize: 2 * 1024 * 1024,
+	})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	const numKeys = 3000
+	putAll := func(val string) int64 {
+		ops := make([]clientv3.Op, numKeys)
+		for i := range ops {
+			ops[i] = clientv3.OpPut(fmt.Sprintf("foo%04d", i), val)
+		}
+		resp, err := cli.Txn(t.Context()).Then(ops...).Commit()
+		require.NoError(t, err)
+		return resp.Header.Revision
+	}
+	putAll(strings.Repeat("a", 400))
+	// with previous key-values, the events are twice as large as the txn
+	rev := putAll(strings.Repeat("b", 400))
+
+	wch := cli.Watch(t.Context(), "foo", clientv3.WithPrefix(), clientv3.WithRev(rev), clientv3.WithPrevKV())
+	select {
+	case ws := <-wch:
+		require.NoError(t, ws.Err())
+		require.Len(t, ws.Events, numKeys)
+		for i, ev := range ws.Events {
+			require.Equal(t, fmt.Sprintf("foo%04d", i), string(ev.Kv.Key))
+			require.Equal(t, rev, ev.Kv.ModRevision)
+			require.Equal(t, strings.Repeat("b", 400), string(ev.Kv.Value))
+			require.NotNil(t, ev.PrevKv)
+		}
+	case <-time.After(testutil.RequestTimeout):
+		t.Fatalf("took too long to receive events")
+	}
+}
+
+// TestWatchMaxResponseBytes ensures the events of a revision the server
+// splits to honor MaxWatchResponseBytes are all delivered, in order, to
+// both synced and unsynced watchers.
+func TestWatchMaxResponseBytes(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{
+		Size:                  1,
+		MaxWatchResponseBytes: 1024,
+	})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	synced := cli.Watch(t.Context(), "foo", clientv3.WithPrefix())
+
+	const numKeys = 5
+	ops := make([]clientv3.Op, numKeys)
+	for i := range ops {
+		ops[i] = clientv3.OpPut(fmt.Sprint("foo", i), strings.Repeat("a", 400))
+	}
+	resp, err := cli.Txn(t.Context()).Then(ops...).Commit()
+	require.NoError(t, err)
+	rev := resp.Header.Revision
+	_, err = cli.Put(t.Context(), "foo-last", "b")
+	require.NoError(t, err)
+
+	unsynced := cli.Watch(t.Context(), "foo", clientv3.WithPrefix(), clientv3.WithRev(rev))
+	for _, wch := range []clientv3.WatchChan{synced, unsynced} {
+		var evs []*clientv3.Event
+		for len(evs) < numKeys+1 {
+			select {
+			case ws := <-wch:
+				require.NoError(t, ws.Err())
+				evs = append(evs, ws.Events...)
+			case <-time.After(testutil.RequestTimeout):
+				t.Fatalf("took too long to receive events, got %d", len(evs))
+			}
+		}
+		require.Len(t, evs, numKeys+1)
+		for i, ev := range evs[:numKeys] {
+			require.Equal(t, fmt.Sprint("foo", i), string(ev.Kv.Key))
+			require.Equal(t, rev, ev.Kv.ModRevision)
+		}
+		require.Equal(t, "foo-last", string(evs[numKeys].Kv.Key))
+	}
+}
@@ -15,6 +15,7 @@
 package watch
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -22,19 +23,26 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
 	"google.golang.org/grpc/status"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	mvccpb "go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/api/v3/version"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	clientmetrics "go.etcd.io/etcd/client/v3/metrics"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3rpc"
 	"go.etcd.io/etcd/tests/v3/framework/integration"
 )
@@ -254,6 +262,196 @@ func testWatchReconnRunning(t *testing.T, wctx *watchctx) {
 	putAndWatch(t, wctx, "a", "b")
 }
 
+// TestWatchReconnResumed ensures exactly one response after a reconnection
+// is marked as resumed.
+func TestWatchReconnResumed(t *testing.T) {
+	runWatchTest(t, testWatchReconnResumed)
+}
+
+func testWatchReconnResumed(t *testing.T, wctx *watchctx) {
+	if integration.ThroughProxy {
+		t.Skipf("grpc-proxy keeps the client stream when its member connection is dropped")
+	}
+	wctx.ch = wctx.w.Watch(t.Context(), "a")
+	require.NotNilf(t, wctx.ch, "expected non-nil channel")
+
+	_, err := wctx.kv.Put(t.Context(), "a", "a")
+	require.NoError(t, err)
+	wresp := <-wctx.ch
+	require.NoError(t, wresp.Err())
+	require.Falsef(t, wresp.Resumed, "unexpected resumed response before reconnection")
+
+	// take down watcher connection
+	wctx.clus.Members[wctx.wclientMember].Bridge().DropConnections()
+
+	numPuts := 3
+	for i := 0; i < numPuts; i++ {
+		_, err = wctx.kv.Put(t.Context(), "a", strconv.Itoa(i))
+		require.NoError(t, err)
+	}
+
+	var evs []*clientv3.Event
+	resumed := 0
+	for len(evs) < numPuts {
+		select {
+		case wresp, ok := <-wctx.ch:
+			require.Truef(t, ok, "unexpected watch close")
+			require.NoError(t, wresp.Err())
+			if wresp.Resumed {
+				require.Emptyf(t, evs, "resumed response after %d events", len(evs))
+				resumed++
+			}
+			evs = append(evs, wresp.Events...)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("watch timed out after %d events", len(evs))
+		}
+	}
+	require.Equal(t, 1, resumed)
+	for i, ev := range evs {
+		require.Equal(t, strconv.Itoa(i), string(ev.Kv.Value))
+	}
+}
+
+// TestWatchReconnMonotonic ensures a watcher observes every event exactly once
+// and in revision order while its connection is dropped repeatedly.
+func TestWatchReconnMonotonic(t *testing.T) {
+	runWatchTest(t, testWatchReconnMonotonic)
+}
+
+func testWatchReconnMonotonic(t *testing.T, wctx *watchctx) {
+	wctx.ch = wctx.w.Watch(t.Context(), "a", clientv3.WithPrefix())
+	require.NotNilf(t, wctx.ch, "expected non-nil channel")
+
+	numPuts := 200
+	putc := make(chan error, 1)
+	go func() {
+		defer close(putc)
+		for i := 0; i < numPuts; i++ {
+			if _, err := wctx.kv.Put(t.Context(), fmt.Sprintf("a%d", i%10), strconv.Itoa(i)); err != nil {
+				putc <- err
+				return
+			}
+		}
+	}()
+	donec, stopc := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(donec)
+		// take down watcher connection while events are in flight
+		for {
+			select {
+			case <-stopc:
+				return
+			case <-time.After(10 * time.Millisecond):
+				wctx.clus.Members[wctx.wclientMember].Bridge().DropConnections()
+			}
+		}
+	}()
+	defer func() {
+		close(stopc)
+		<-donec
+	}()
+
+	var lastRev int64
+	for i := 0; i < numPuts; {
+		select {
+		case wresp, ok := <-wctx.ch:
+			require.Truef(t, ok, "unexpected watch close")
+			require.NoError(t, wresp.Err())
+			for _, ev := range wresp.Events {
+				require.Greaterf(t, ev.Kv.ModRevision, lastRev, "revision regressed after %d events", i)
+				require.Equal(t, strconv.Itoa(i), string(ev.Kv.Value))
+				lastRev = ev.Kv.ModRevision
+				i++
+			}
+		case <-time.After(30 * time.Second):
+			t.Fatalf("watch timed out after %d events", i)
+		}
+	}
+	require.NoError(t, <-putc)
+}
+
+// TestWatchEventSeq ensures the events of a txn writing several keys are
+// numbered one by one although they share a revision.
+func TestWatchEventSeq(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	wch := cli.Watch(t.Context(), "k", clientv3.WithPrefix())
+	_, err := cli.Txn(t.Context()).Then(
+		clientv3.OpPut("k1", "v"),
+		clientv3.OpPut("k2", "v"),
+		clientv3.OpPut("k3", "v"),
+	).Commit()
+	require.NoError(t, err)
+	_, err = cli.Delete(t.Context(), "k", clientv3.WithPrefix())
+	require.NoError(t, err)
+
+	var evs []*clientv3.Event
+	for len(evs) < 6 {
+		select {
+		case wresp := <-wch:
+			require.NoError(t, wresp.Err())
+			evs = append(evs, wresp.Events...)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("watch timed out after %d events", len(evs))
+		}
+	}
+	for i, ev := range evs {
+		require.Equalf(t, uint64(i+1), ev.Seq, "event %d", i)
+	}
+	require.Equal(t, evs[0].Kv.ModRevision, evs[2].Kv.ModRevision)
+}
+
+// TestWatchReconnBackoff ensures the watch stream is re-established within
+// the window configured by clientv3.Config.WatchBackoff after the connection
+// is dropped.
+func TestWatchReconnBackoff(t *testing.T) {
+	integration.BeforeTest(t)
+	if integration.ThroughProxy {
+		t.Skipf("grpc-proxy keeps the client stream when its member connection is dropped")
+	}
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, UseBridge: true})
+	defer clus.Terminate(t)
+
+	backoff := clientv3.WatchBackoff{Min: 200 * time.Millisecond, Max: 200 * time.Millisecond}
+	cli, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:    []string{clus.Members[0].GRPCURL},
+		WatchBackoff: backoff,
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	// put through another member so writes are unaffected by dropped connections
+	kv := clus.Client(1)
+	wch := cli.Watch(t.Context(), "a", clientv3.WithCreatedNotify())
+	wresp := <-wch
+	require.Truef(t, wresp.Created, "expected created event, got %v", wresp)
+
+	for i := 0; i < 5; i++ {
+		val := strconv.Itoa(i)
+		start := time.Now()
+		clus.Members[0].Bridge().DropConnections()
+		_, err = kv.Put(t.Context(), "a", val)
+		require.NoError(t, err)
+
+		select {
+		case wresp, ok := <-wch:
+			require.Truef(t, ok, "unexpected watch close")
+			require.NoError(t, wresp.Err())
+			require.Len(t, wresp.Events, 1)
+			require.Equal(t, val, string(wresp.Events[0].Kv.Value))
+		case <-time.After(5 * time.Second):
+			t.Fatalf("#%d: watch timed out", i)
+		}
+		took := time.Since(start)
+		require.GreaterOrEqualf(t, took, backoff.Min, "#%d: reconnected before the configured backoff", i)
+		require.Lessf(t, took, backoff.Max+time.Second, "#%d: reconnected too late", i)
+	}
+}
+
 // TestWatchCancelImmediate ensures a closed channel is returned
 // if the context is cancelled.
 func TestWatchCancelImmediate(t *testing.T) {
@@ -334,6 +532,406 @@ func putAndWatch(t *testing.T, wctx *watchctx, key, val string) {
 	}
 }
 
+// TestWatchWithBatchInterval ensures bursty puts are coalesced into batched
+// watch responses without losing or reordering events.
+func TestWatchWithBatchInterval(t *testing.T) {
+	runWatchTest(t, testWatchWithBatchInterval)
+}
+
+func testWatchWithBatchInterval(t *testing.T, wctx *watchctx) {
+	if integration.ThroughProxy {
+		t.Skipf("grpc-proxy does not support WatchProgress yet")
+	}
+	keys := []string{"/batch/a", "/batch/b", "/batch/c"}
+	numRounds := 10
+
+	wch := wctx.w.Watch(t.Context(), "/batch/", clientv3.WithPrefix(), clientv3.WithCreatedNotify(), clientv3.WithBatchInterval(time.Second))
+	wresp := <-wch
+	require.Truef(t, wresp.Created, "expected created event, got %v", wresp)
+
+	for i := 0; i < numRounds; i++ {
+		for _, k := range keys {
+			_, err := wctx.kv.Put(t.Context(), k, strconv.Itoa(i))
+			require.NoError(t, err)
+		}
+	}
+
+	var evs []*clientv3.Event
+	numResps := 0
+	for len(evs) < numRounds*len(keys) {
+		select {
+		case wresp, ok := <-wch:
+			require.Truef(t, ok, "unexpected watch close")
+			require.NoError(t, wresp.Err())
+			require.NotEmptyf(t, wresp.Events, "unexpected empty batch %+v", wresp)
+			lastRev := wresp.Events[len(wresp.Events)-1].Kv.ModRevision
+			require.Equalf(t, lastRev, wresp.Header.Revision, "expected header revision of the last batched event")
+			evs = append(evs, wresp.Events...)
+			numResps++
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for batched events, got %d", len(evs))
+		}
+	}
+	require.Lessf(t, numResps, len(evs), "expected events to be coalesced, got %d responses for %d events", numResps, len(evs))
+
+	next := make(map[string]int)
+	for i, ev := range evs {
+		if i > 0 {
+			require.Greater(t, ev.Kv.ModRevision, evs[i-1].Kv.ModRevision)
+		}
+		k := string(ev.Kv.Key)
+		require.Equalf(t, strconv.Itoa(next[k]), string(ev.Kv.Value), "out of order event for key %q", k)
+		next[k]++
+	}
+
+	// a progress notify must flush pending events ahead of the batch interval
+	_, err := wctx.kv.Put(t.Context(), keys[0], "flush")
+	require.NoError(t, err)
+	// the put must reach the watcher's member before the progress request
+	_, err = wctx.clus.Client(wctx.wclientMember).Get(t.Context(), "/")
+	require.NoError(t, err)
+	require.NoError(t, wctx.w.RequestProgress(t.Context()))
+	select {
+	case wresp := <-wch:
+		require.Lenf(t, wresp.Events, 1, "expected flushed event, got %+v", wresp)
+		require.Equal(t, "flush", string(wresp.Events[0].Kv.Value))
+	case <-time.After(time.Second / 2):
+		t.Fatal("batched events were not flushed on progress notify")
+	}
+}
+
+// TestWatchStats ensures WatchStats reports responses buffered for a
+// consumer that stopped reading its watch channel.
+func TestWatchStats(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	wch := cli.Watch(t.Context(), "foo", clientv3.WithCreatedNotify())
+	wresp := <-wch
+	require.Truef(t, wresp.Created, "expected created event, got %v", wresp)
+
+	stats := clientv3.WatchStats(cli)
+	require.Len(t, stats, 1)
+	require.Equal(t, 1, stats[0].Watchers)
+	require.Zero(t, stats[0].BufferedResponses)
+
+	numPuts := 20
+	for i := 0; i < numPuts; i++ {
+		_, err := cli.Put(t.Context(), "foo", strconv.Itoa(i))
+		require.NoError(t, err)
+	}
+
+	// one response may sit in the watch channel itself
+	want := numPuts - 1
+	var got int
+	for i := 0; i < 50; i++ {
+		stats = clientv3.WatchStats(cli)
+		if got = stats[0].BufferedResponses; got >= want {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.GreaterOrEqualf(t, got, want, "expected buffered responses to climb")
+	require.Equal(t, int64(numPuts+2), stats[0].ResumeRevision)
+
+	reg := prometheus.NewRegistry()
+	require.NoError(t, reg.Register(clientmetrics.NewWatchStatsCollector(cli.Watcher)))
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+	var buffered float64
+	for _, mf := range mfs {
+		if mf.GetName() == "etcd_client_watch_buffered_responses" {
+			buffered = mf.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	require.InDelta(t, float64(got), buffered, 1)
+
+	for i := 0; i < numPuts; i++ {
+		<-wch
+	}
+	require.Eventually(t, func() bool {
+		return clientv3.WatchStats(cli)[0].BufferedResponses == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+// TestWatchMaxWatchersPerStream ensures watchers are split across grpc
+// streams at the configured limit and that freed places are reused.
+func TestWatchMaxWatchersPerStream(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:            []string{clus.Members[0].GRPCURL},
+		MaxWatchersPerStream: 3,
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	streamWatchers := func() []int {
+		var watchers []int
+		for _, s := range clientv3.WatchStats(cli) {
+			watchers = append(watchers, s.Watchers)
+		}
+		sort.Ints(watchers)
+		return watchers
+	}
+
+	var (
+		wchs    []clientv3.WatchChan
+		cancels []context.CancelFunc
+	)
+	watch := func() {
+		ctx, cancel := context.WithCancel(t.Context())
+		wch := cli.Watch(ctx, "foo", clientv3.WithCreatedNotify())
+		wresp := <-wch
+		require.Truef(t, wresp.Created, "expected created event, got %v", wresp)
+		wchs = append(wchs, wch)
+		cancels = append(cancels, cancel)
+	}
+	for i := 0; i < 10; i++ {
+		watch()
+	}
+	require.Equal(t, []int{1, 3, 3, 3}, streamWatchers())
+
+	_, err = cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	for _, wch := range wchs {
+		select {
+		case wresp := <-wch:
+			require.Len(t, wresp.Events, 1)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for event")
+		}
+	}
+
+	// progress requests reach the watchers of every stream
+	require.NoError(t, cli.RequestProgress(t.Context()))
+	for _, wch := range wchs {
+		select {
+		case wresp := <-wch:
+			require.True(t, wresp.IsProgressNotify())
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for progress notify")
+		}
+	}
+
+	// watchers take the places freed on existing streams
+	cancels[0]()
+	cancels[1]()
+	require.Eventually(t, func() bool {
+		return reflect.DeepEqual([]int{1, 1, 3, 3}, streamWatchers())
+	}, 5*time.Second, 10*time.Millisecond)
+	watch()
+	watch()
+	require.Equal(t, []int{1, 3, 3, 3}, streamWatchers())
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+}
+
+// TestWatchWithCompression ensures events delivered to a watcher created
+// WithCompression are identical to those of an uncompressed watcher.
+func TestWatchWithCompression(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	rec := &watchCompressionRecorder{}
+	cli, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:   clus.Client(0).Endpoints(),
+		DialOptions: []grpc.DialOption{grpc.WithStatsHandler(rec)},
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+	ctx := t.Context()
+	wchs := map[string]clientv3.WatchChan{
+		"plain":      cli.Watch(ctx, "/compress/", clientv3.WithPrefix(), clientv3.WithPrevKV(), clientv3.WithCreatedNotify()),
+		"compressed": cli.Watch(ctx, "/compress/", clientv3.WithPrefix(), clientv3.WithPrevKV(), clientv3.WithCreatedNotify(), clientv3.WithCompression()),
+		"fragmented": cli.Watch(ctx, "/compress/", clientv3.WithPrefix(), clientv3.WithPrevKV(), clientv3.WithCreatedNotify(), clientv3.WithCompression(), clientv3.WithFragment()),
+	}
+	for name, wch := range wchs {
+		wresp := <-wch
+		require.Truef(t, wresp.Created, "%s: expected created event, got %v", name, wresp)
+	}
+
+	numPuts := 10
+	for i := 0; i < numPuts; i++ {
+		val := strings.Repeat(strconv.Itoa(i), 64*1024)
+		_, err = cli.Put(ctx, fmt.Sprintf("/compress/%d", i%3), val)
+		require.NoError(t, err)
+	}
+	_, err = cli.Delete(ctx, "/compress/", clientv3.WithPrefix())
+	require.NoError(t, err)
+
+	evs := make(map[string][]*clientv3.Event)
+	for name, wch := range wchs {
+		for len(evs[name]) < numPuts+3 {
+			select {
+			case wresp := <-wch:
+				require.NoError(t, wresp.Err())
+				evs[name] = append(evs[name], wresp.Events...)
+			case <-time.After(10 * time.Second):
+				t.Fatalf("%s: timed out waiting for events, got %d", name, len(evs[name]))
+			}
+		}
+	}
+	require.Equal(t, evs["plain"], evs["compressed"])
+	require.Equal(t, evs["plain"], evs["fragmented"])
+	if !integration.ThroughProxy {
+		// the plain watcher has a stream of its own
+		require.ElementsMatch(t, []string{"", "gzip"}, rec.compressors())
+	}
+}
+
+// TestWatchWithRawEvents ensures the raw events delivered to a watcher
+// created WithRawEvents decode to the events of a plain watcher.
+func TestWatchWithRawEvents(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	ctx := t.Context()
+	wchs := map[string]clientv3.WatchChan{
+		"plain":      cli.Watch(ctx, "/raw/", clientv3.WithPrefix(), clientv3.WithPrevKV(), clientv3.WithCreatedNotify()),
+		"raw":        cli.Watch(ctx, "/raw/", clientv3.WithPrefix(), clientv3.WithPrevKV(), clientv3.WithCreatedNotify(), clientv3.WithRawEvents()),
+		"compressed": cli.Watch(ctx, "/raw/", clientv3.WithPrefix(), clientv3.WithPrevKV(), clientv3.WithCreatedNotify(), clientv3.WithRawEvents(), clientv3.WithCompression()),
+		"fragmented": cli.Watch(ctx, "/raw/", clientv3.WithPrefix(), clientv3.WithPrevKV(), clientv3.WithCreatedNotify(), clientv3.WithRawEvents(), clientv3.WithFragment()),
+	}
+	for name, wch := range wchs {
+		wresp := <-wch
+		require.Truef(t, wresp.Created, "%s: expected created event, got %v", name, wresp)
+	}
+
+	numPuts := 10
+	for i := 0; i < numPuts; i++ {
+		val := strings.Repeat(strconv.Itoa(i), 64*1024)
+		_, err := cli.Put(ctx, fmt.Sprintf("/raw/%d", i%3), val)
+		require.NoError(t, err)
+	}
+	_, err := cli.Delete(ctx, "/raw/", clientv3.WithPrefix())
+	require.NoError(t, err)
+
+	evs := make(map[string][]*clientv3.Event)
+	for name, wch := range wchs {
+		for len(evs[name]) < numPuts+3 {
+			select {
+			case wresp := <-wch:
+				require.NoError(t, wresp.Err())
+				if name == "plain" {
+					require.Empty(t, wresp.RawEvents)
+					evs[name] = append(evs[name], wresp.Events...)
+					continue
+				}
+				require.Emptyf(t, wresp.Events, "%s: expected raw events only", name)
+				var decoded pb.WatchResponse
+				require.NoError(t, decoded.Unmarshal(wresp.RawEvents))
+				for _, ev := range decoded.Events {
+					evs[name] = append(evs[name], (*clientv3.Event)(ev))
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatalf("%s: timed out waiting for events, got %d", name, len(evs[name]))
+			}
+		}
+	}
+	require.Equal(t, evs["plain"], evs["raw"])
+	require.Equal(t, evs["plain"], evs["compressed"])
+	require.Equal(t, evs["plain"], evs["fragmented"])
+}
+
+// TestWatchCompressionNegotiation ensures the server compresses the responses
+// of a watch stream only with a registered compressor the client requested.
+func TestWatchCompressionNegotiation(t *testing.T) {
+	integration.BeforeTest(t)
+	if integration.ThroughProxy {
+		t.Skipf("grpc-proxy does not support watch compression")
+	}
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	tcs := []struct {
+		name      string
+		requested string
+		want      string
+	}{
+		{name: "not requested"},
+		{name: "unsupported", requested: "zstd"},
+		{name: "gzip", requested: "gzip", want: "gzip"},
+	}
+	for i, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := &watchCompressionRecorder{}
+			cli, err := integration.NewClient(t, clientv3.Config{
+				Endpoints:   clus.Client(0).Endpoints(),
+				DialOptions: []grpc.DialOption{grpc.WithStatsHandler(rec)},
+			})
+			require.NoError(t, err)
+			defer cli.Close()
+
+			ctx := t.Context()
+			if tc.requested != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, rpctypes.MetadataWatchCompressionKey, tc.requested)
+			}
+			wc, err := pb.NewWatchClient(cli.ActiveConnection()).Watch(ctx)
+			require.NoError(t, err)
+
+			key := fmt.Sprintf("/negotiate/%d", i)
+			req := &pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+				CreateRequest: &pb.WatchCreateRequest{Key: []byte(key)},
+			}}
+			require.NoError(t, wc.Send(req))
+			wresp, err := wc.Recv()
+			require.NoError(t, err)
+			require.True(t, wresp.Created)
+
+			_, err = clus.Client(0).Put(ctx, key, "bar")
+			require.NoError(t, err)
+			wresp, err = wc.Recv()
+			require.NoError(t, err)
+			require.Len(t, wresp.Events, 1)
+			require.Equal(t, "bar", string(wresp.Events[0].Kv.Value))
+			require.Equal(t, []string{tc.want}, rec.compressors())
+		})
+	}
+}
+
+// watchCompressionRecorder is a gRPC stats handler recording the compressor
+// of the responses of each watch stream.
+type watchCompressionRecorder struct {
+	mu    sync.Mutex
+	names []string
+}
+
+type watchMethodKey struct{}
+
+func (r *watchCompressionRecorder) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, watchMethodKey{}, info.FullMethodName == "/etcdserverpb.Watch/Watch")
+}
+
+func (r *watchCompressionRecorder) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if h, ok := s.(*stats.InHeader); ok && ctx.Value(watchMethodKey{}) == true {
+		r.mu.Lock()
+		r.names = append(r.names, h.Compression)
+		r.mu.Unlock()
+	}
+}
+
+func (r *watchCompressionRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (r *watchCompressionRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+func (r *watchCompressionRecorder) compressors() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.names...)
+}
+
 // TestWatchResumeAfterDisconnect tests watch resume after member disconnects then connects.
 // It ensures that correct events are returned corresponding to the start revision.
 func TestWatchResumeAfterDisconnect(t *testing.T) {
@@ -386,18 +984,78 @@ func TestWatchResumeAfterDisconnect(t *testing.T) {
 	}
 }
 
-// TestWatchResumeCompacted checks that the watcher gracefully closes in case
-// that it tries to resume to a revision that's been compacted out of the store.
-// Since the watcher's server restarts with stale data, the watcher will receive
-// either a compaction error or all keys by staying in sync before the compaction
-// is finally applied.
-func TestWatchResumeCompacted(t *testing.T) {
+// TestWatchLatestPerKeyAfterDisconnect checks that a watcher created with
+// WithLatestPerKey receives only the latest value of a key put several times
+// while the watcher was disconnected.
+func TestWatchLatestPerKeyAfterDisconnect(t *testing.T) {
 	integration.BeforeTest(t)
-
-	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, UseBridge: true})
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, UseBridge: true})
 	defer clus.Terminate(t)
 
-	// create a waiting watcher at rev 1
+	cli := clus.Client(0)
+	wch := cli.Watch(t.Context(), "a", clientv3.WithLatestPerKey(), clientv3.WithPrevKV(), clientv3.WithCreatedNotify())
+	resp := <-wch
+	require.True(t, resp.Created)
+
+	_, err := cli.Put(t.Context(), "a", "1")
+	require.NoError(t, err)
+	select {
+	case resp = <-wch:
+		require.Len(t, resp.Events, 1)
+		require.Equal(t, "1", string(resp.Events[0].Kv.Value))
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch timed out")
+	}
+
+	clus.Members[0].Bridge().DropConnections()
+	clus.Members[0].Bridge().PauseConnections()
+	// put on the server directly, bypassing the paused bridge
+	for _, v := range []string{"2", "3", "4"} {
+		_, err = clus.Members[0].Server.Put(t.Context(), &pb.PutRequest{Key: []byte("a"), Value: []byte(v)})
+		require.NoError(t, err)
+	}
+	select {
+	case resp, ok := <-wch:
+		t.Skipf("wch should block, got (%+v, %v); drop not fast enough", resp, ok)
+	case <-time.After(100 * time.Millisecond):
+	}
+	clus.Members[0].Bridge().UnpauseConnections()
+
+	select {
+	case resp, ok := <-wch:
+		require.True(t, ok, "unexpected watch close")
+		require.Lenf(t, resp.Events, 1, "expected one event, got %+v", resp.Events)
+		require.Equal(t, "4", string(resp.Events[0].Kv.Value))
+		require.Equal(t, int64(4), resp.Events[0].Kv.Version)
+		require.NotNil(t, resp.Events[0].PrevKv)
+		require.Equal(t, "1", string(resp.Events[0].PrevKv.Value))
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch timed out")
+	}
+
+	_, err = cli.Delete(t.Context(), "a")
+	require.NoError(t, err)
+	select {
+	case resp = <-wch:
+		require.Len(t, resp.Events, 1)
+		require.Equal(t, clientv3.EventTypeDelete, resp.Events[0].Type)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch timed out")
+	}
+}
+
+// TestWatchResumeCompacted checks that the watcher gracefully closes in case
+// that it tries to resume to a revision that's been compacted out of the store.
+// Since the watcher's server restarts with stale data, the watcher will receive
+// either a compaction error or all keys by staying in sync before the compaction
+// is finally applied.
+func TestWatchResumeCompacted(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, UseBridge: true})
+	defer clus.Terminate(t)
+
+	// create a waiting watcher at rev 1
 	w := clus.Client(0)
 	wch := w.Watch(t.Context(), "foo", clientv3.WithRev(1))
 	select {
@@ -494,6 +1152,9 @@ func TestWatchCompactRevision(t *testing.T) {
 	if !wresp.Canceled {
 		t.Fatalf("wresp.Canceled expected true, got %+v", wresp)
 	}
+	var cerr *clientv3.CompactedError
+	require.ErrorAs(t, wresp.Err(), &cerr)
+	require.Equal(t, int64(4), cerr.CompactedRev)
 
 	// ensure the channel is closed
 	if wresp, ok = <-wch; ok {
@@ -547,6 +1208,7 @@ func testWatchWithProgressNotify(t *testing.T, watchOnPut bool) {
 			ev := []*clientv3.Event{{
 				Type: clientv3.EventTypePut,
 				Kv:   &mvccpb.KeyValue{Key: []byte("foox"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1},
+				Seq:  1,
 			}}
 			if !reflect.DeepEqual(ev, resp.Events) {
 				t.Fatalf("expected %+v, got %+v", ev, resp.Events)
@@ -585,6 +1247,41 @@ func TestConfigurableWatchProgressNotifyInterval(t *testing.T) {
 	}
 }
 
+// TestWatchProgressNotifyIntervalPerWatch ensures watchers created with
+// WithProgressNotifyInterval receive progress notifies at their own interval.
+func TestWatchProgressNotifyIntervalPerWatch(t *testing.T) {
+	integration.BeforeTest(t)
+	if integration.ThroughProxy {
+		t.Skipf("grpc-proxy does not support per-watch progress intervals")
+	}
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	// both watchers share a stream; the server default interval is 10 minutes
+	cli := clus.RandClient()
+	fastc := cli.Watch(t.Context(), "foo", clientv3.WithProgressNotifyInterval(200*time.Millisecond))
+	slowc := cli.Watch(t.Context(), "bar", clientv3.WithProgressNotifyInterval(time.Second))
+
+	var fast, slow int
+	timeout := time.After(2500 * time.Millisecond)
+	for done := false; !done; {
+		select {
+		case resp := <-fastc:
+			require.Truef(t, resp.IsProgressNotify(), "expected progress notify, got %v", resp)
+			fast++
+		case resp := <-slowc:
+			require.Truef(t, resp.IsProgressNotify(), "expected progress notify, got %v", resp)
+			slow++
+		case <-timeout:
+			done = true
+		}
+	}
+	require.GreaterOrEqualf(t, fast, 6, "too few progress notifies at 200ms interval")
+	require.GreaterOrEqualf(t, slow, 1, "too few progress notifies at 1s interval")
+	require.LessOrEqualf(t, slow, 3, "too many progress notifies at 1s interval")
+}
+
 func TestWatchRequestProgress(t *testing.T) {
 	if integration.ThroughProxy {
 		t.Skipf("grpc-proxy does not support WatchProgress yet")
@@ -843,6 +1540,81 @@ func TestWatchWithRequireLeader(t *testing.T) {
 	require.GreaterOrEqualf(t, cv, int64(2), "expected at least 2, got %q", cnt)
 }
 
+// TestWatchWithStaleOK ensures a watch created with WithStaleOK keeps serving
+// events from a member that lost its leader, while a watch requiring a
+// leader is closed.
+func TestWatchWithStaleOK(t *testing.T) {
+	integration.BeforeTest(t)
+	if integration.ThroughProxy {
+		t.Skipf("grpc-proxy does not support stale_ok watchers")
+	}
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	// put keys through member[0] so it has them after losing quorum
+	liveClient := clus.Client(0)
+	numKeys := 3
+	for i := 0; i < numKeys; i++ {
+		_, err := liveClient.Put(t.Context(), fmt.Sprintf("foo%d", i), "bar")
+		require.NoError(t, err)
+	}
+
+	ctx := clientv3.WithRequireLeader(t.Context())
+	chLeader := liveClient.Watch(ctx, "foo", clientv3.WithPrefix(), clientv3.WithRev(1))
+	chStale := liveClient.Watch(ctx, "foo", clientv3.WithPrefix(), clientv3.WithRev(1), clientv3.WithStaleOK())
+	for _, ch := range []clientv3.WatchChan{chLeader, chStale} {
+		var evs []*clientv3.Event
+		for len(evs) < numKeys {
+			resp, ok := <-ch
+			require.Truef(t, ok, "unexpected watch close")
+			require.NoError(t, resp.Err())
+			require.Falsef(t, resp.Stale, "unexpected stale response with a leader")
+			evs = append(evs, resp.Events...)
+		}
+	}
+
+	clus.Members[1].Stop(t)
+	clus.Members[2].Stop(t)
+	clus.Client(1).Close()
+	clus.Client(2).Close()
+	clus.TakeClient(1)
+	clus.TakeClient(2)
+
+	select {
+	case resp, ok := <-chLeader:
+		require.Truef(t, ok, "expected %v watch channel, got closed channel", rpctypes.ErrNoLeader)
+		require.ErrorIsf(t, resp.Err(), rpctypes.ErrNoLeader, "expected %v watch response error, got %+v", rpctypes.ErrNoLeader, resp)
+	case <-time.After(integration.RequestWaitTimeout):
+		t.Fatal("watch without leader took too long to close")
+	}
+
+	// a new stale watch still replays the member's history, flagged stale
+	chReplay := liveClient.Watch(ctx, "foo", clientv3.WithPrefix(), clientv3.WithRev(1), clientv3.WithStaleOK())
+	var evs []*clientv3.Event
+	for len(evs) < numKeys {
+		select {
+		case resp, ok := <-chReplay:
+			require.Truef(t, ok, "unexpected watch close")
+			require.NoError(t, resp.Err())
+			require.Truef(t, resp.Stale, "expected stale response without a leader")
+			evs = append(evs, resp.Events...)
+		case <-time.After(integration.RequestWaitTimeout):
+			t.Fatalf("stale watch timed out after %d events", len(evs))
+		}
+	}
+	for i, ev := range evs {
+		require.Equal(t, fmt.Sprintf("foo%d", i), string(ev.Kv.Key))
+	}
+
+	// the stale watch created with a leader is still open
+	select {
+	case resp, ok := <-chStale:
+		t.Fatalf("unexpected response on stale watch (open: %v): %+v", ok, resp)
+	case <-time.After(time.Second):
+	}
+}
+
 // TestWatchWithFilter checks that watch filtering works.
 func TestWatchWithFilter(t *testing.T) {
 	integration.BeforeTest(t)
@@ -879,6 +1651,57 @@ func TestWatchWithFilter(t *testing.T) {
 	}
 }
 
+// TestWatchWithCreateOnly checks that WithCreateOnly delivers only the events
+// creating a key, and WithFilterModify the deletions as well.
+func TestWatchWithCreateOnly(t *testing.T) {
+	integration.BeforeTest(t)
+
+	cluster := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer cluster.Terminate(t)
+
+	client := cluster.RandClient()
+	ctx := t.Context()
+
+	wcCreate := client.Watch(ctx, "a", clientv3.WithCreateOnly())
+	wcNoMod := client.Watch(ctx, "a", clientv3.WithFilterModify())
+
+	for _, op := range []clientv3.Op{
+		clientv3.OpPut("a", "1"),
+		clientv3.OpPut("a", "2"),
+		clientv3.OpDelete("a"),
+		clientv3.OpPut("a", "3"),
+		clientv3.OpPut("a", "4"),
+	} {
+		_, err := client.Do(ctx, op)
+		require.NoError(t, err)
+	}
+
+	collect := func(wc clientv3.WatchChan, n int) (evs []string) {
+		for len(evs) < n {
+			select {
+			case resp := <-wc:
+				require.NoError(t, resp.Err())
+				for _, ev := range resp.Events {
+					evs = append(evs, fmt.Sprintf("%s %s", ev.Type, ev.Kv.Value))
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out after events %v", evs)
+			}
+		}
+		return evs
+	}
+	require.Equal(t, []string{"PUT 1", "PUT 3"}, collect(wcCreate, 2))
+	require.Equal(t, []string{"PUT 1", "DELETE ", "PUT 3"}, collect(wcNoMod, 3))
+
+	select {
+	case resp := <-wcCreate:
+		t.Fatalf("unexpected event on create only watch (%+v)", resp)
+	case resp := <-wcNoMod:
+		t.Fatalf("unexpected event on filtered modify watch (%+v)", resp)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 // TestWatchWithCreatedNotification checks that WithCreatedNotify returns a
 // Created watch response.
 func TestWatchWithCreatedNotification(t *testing.T) {
@@ -891,11 +1714,32 @@ func TestWatchWithCreatedNotification(t *testing.T) {
 
 	ctx := t.Context()
 
+	presp, err := client.Put(ctx, "b", "b")
+	require.NoError(t, err)
+	createdRev := presp.Header.Revision
+
 	createC := client.Watch(ctx, "a", clientv3.WithCreatedNotify())
 
 	resp := <-createC
 
 	require.Truef(t, resp.Created, "expected created event, got %v", resp)
+
+	// grpc-proxy does not report the creation revision
+	if !integration.ThroughProxy {
+		require.Equal(t, createdRev, resp.CreatedRevision)
+
+		// the creation revision does not depend on the start revision
+		resp = <-client.Watch(ctx, "a", clientv3.WithCreatedNotify(), clientv3.WithRev(1))
+		require.Truef(t, resp.Created, "expected created event, got %v", resp)
+		require.Equal(t, createdRev, resp.CreatedRevision)
+	}
+
+	// events are delivered from the revision after creation
+	_, err = client.Put(ctx, "a", "a")
+	require.NoError(t, err)
+	resp = <-createC
+	require.Len(t, resp.Events, 1)
+	require.Equal(t, createdRev+1, resp.Events[0].Kv.ModRevision)
 }
 
 // TestWatchWithCreatedNotificationDropConn ensures that
@@ -927,6 +1771,57 @@ func TestWatchWithCreatedNotificationDropConn(t *testing.T) {
 	}
 }
 
+// TestWatchWithCreatedNotificationMultipleReconnects ensures that a watcher
+// resumed over several reconnects posts exactly one created notification.
+func TestWatchWithCreatedNotificationMultipleReconnects(t *testing.T) {
+	integration.BeforeTest(t)
+
+	cluster := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, UseBridge: true})
+	defer cluster.Terminate(t)
+
+	client := cluster.RandClient()
+
+	wch := client.Watch(t.Context(), "a", clientv3.WithCreatedNotify())
+
+	created := 0
+	recv := func() clientv3.WatchResponse {
+		select {
+		case wresp, ok := <-wch:
+			require.Truef(t, ok, "watch channel closed unexpectedly")
+			if wresp.Created {
+				created++
+			}
+			return wresp
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for watch response")
+		}
+		return clientv3.WatchResponse{}
+	}
+
+	resp := recv()
+	require.Truef(t, resp.Created, "expected created event, got %v", resp)
+
+	for i := 0; i < 3; i++ {
+		cluster.Members[0].Bridge().DropConnections()
+
+		// the resumed watcher receives the server's created response
+		// before any event, so observing the put means it was handled
+		val := fmt.Sprintf("v%d", i)
+		require.Eventually(t, func() bool {
+			_, err := client.Put(t.Context(), "a", val)
+			return err == nil
+		}, 10*time.Second, 10*time.Millisecond)
+		for {
+			wresp := recv()
+			require.NoError(t, wresp.Err())
+			if n := len(wresp.Events); n > 0 && string(wresp.Events[n-1].Kv.Value) == val {
+				break
+			}
+		}
+	}
+	require.Equal(t, 1, created)
+}
+
 // TestWatchCancelOnServer ensures client watcher cancels propagate back to the server.
 func TestWatchCancelOnServer(t *testing.T) {
 	integration.BeforeTest(t)
@@ -1228,3 +2123,670 @@ func collectEvents(ctx context.Context, watch clientv3.WatchChan) (events []*cli
 		}
 	}
 }
+
+// TestWatchAuthRevisionNotify ensures auth revision watchers are notified of
+// auth changes and do not receive key events.
+func TestWatchAuthRevisionNotify(t *testing.T) {
+	integration.BeforeTest(t)
+	if integration.ThroughProxy {
+		t.Skipf("grpc-proxy does not support auth revision watchers")
+	}
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	_, err := cli.RoleAdd(t.Context(), "role")
+	require.NoError(t, err)
+	_, err = cli.UserAdd(t.Context(), "user", "123")
+	require.NoError(t, err)
+
+	wch := cli.Watch(t.Context(), "", clientv3.WithAuthRevisionNotify(), clientv3.WithCreatedNotify())
+	resp := <-wch
+	require.NoError(t, resp.Err())
+	require.True(t, resp.Created)
+	status, err := cli.AuthStatus(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, status.AuthRevision, resp.AuthRevision)
+
+	_, err = cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	_, err = cli.UserGrantRole(t.Context(), "user", "role")
+	require.NoError(t, err)
+	status, err = cli.AuthStatus(t.Context())
+	require.NoError(t, err)
+	require.Greater(t, status.AuthRevision, resp.AuthRevision)
+
+	select {
+	case resp = <-wch:
+		require.NoError(t, resp.Err())
+		require.Empty(t, resp.Events)
+		require.False(t, resp.IsProgressNotify())
+		require.Equal(t, status.AuthRevision, resp.AuthRevision)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for auth revision notification")
+	}
+}
+
+// TestMergeWatch ensures MergeWatch merges the events of several prefixes
+// into a single stream ordered by revision.
+func TestMergeWatch(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx := t.Context()
+
+	resp, err := cli.Get(ctx, "foo")
+	require.NoError(t, err)
+	startRev := resp.Header.Revision + 1
+
+	prefixes := []string{"a/", "b/", "c/"}
+	var specs []clientv3.WatchSpec
+	for _, prefix := range prefixes {
+		specs = append(specs, clientv3.WatchSpec{Key: prefix, Opts: []clientv3.OpOption{clientv3.WithPrefix(), clientv3.WithRev(startRev)}})
+	}
+	wch := clientv3.MergeWatch(ctx, cli.Watcher, specs...)
+
+	// write through another client so the writes race with the watches
+	wcli := clus.Client(0)
+	var wkeys []string
+	for i := 0; i < 300; i++ {
+		key := fmt.Sprintf("%s%03d", prefixes[rand.Intn(len(prefixes))], i)
+		if i%10 == 0 {
+			// writes to several prefixes at the same revision
+			key2 := fmt.Sprintf("%s%03d", prefixes[(i/10)%len(prefixes)], i+1000)
+			_, err = wcli.Txn(ctx).Then(clientv3.OpPut(key, "v"), clientv3.OpPut(key2, "v")).Commit()
+			wkeys = append(wkeys, key, key2)
+		} else {
+			_, err = wcli.Put(ctx, key, "v")
+			wkeys = append(wkeys, key)
+		}
+		require.NoError(t, err)
+		if i%50 == 0 {
+			_, err = wcli.Put(ctx, "other", "v")
+			require.NoError(t, err)
+		}
+	}
+
+	var (
+		keys    []string
+		lastRev int64
+	)
+	for len(keys) < len(wkeys) {
+		select {
+		case wresp, ok := <-wch:
+			require.True(t, ok, "merged watch closed")
+			require.NoError(t, wresp.Err())
+			require.NotEmpty(t, wresp.Events)
+			for _, ev := range wresp.Events {
+				require.GreaterOrEqual(t, ev.Kv.ModRevision, lastRev)
+				lastRev = ev.Kv.ModRevision
+				keys = append(keys, string(ev.Kv.Key))
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out after %d of %d events", len(keys), len(wkeys))
+		}
+	}
+	sort.Strings(keys)
+	sort.Strings(wkeys)
+	require.Equal(t, wkeys, keys)
+}
+
+// TestMergeWatchError ensures MergeWatch forwards the error of a watch and
+// closes.
+func TestMergeWatchError(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx := t.Context()
+
+	var rev int64
+	for i := 0; i < 5; i++ {
+		resp, err := cli.Put(ctx, "a/foo", strconv.Itoa(i))
+		require.NoError(t, err)
+		rev = resp.Header.Revision
+	}
+	_, err := cli.Compact(ctx, rev)
+	require.NoError(t, err)
+
+	wch := clientv3.MergeWatch(ctx, cli.Watcher,
+		clientv3.WatchSpec{Key: "a/", Opts: []clientv3.OpOption{clientv3.WithPrefix()}},
+		clientv3.WatchSpec{Key: "b/", Opts: []clientv3.OpOption{clientv3.WithPrefix(), clientv3.WithRev(1)}},
+	)
+	select {
+	case wresp, ok := <-wch:
+		require.True(t, ok)
+		require.ErrorIs(t, wresp.Err(), rpctypes.ErrCompacted)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for compaction error")
+	}
+	select {
+	case _, ok := <-wch:
+		require.False(t, ok, "expected merged watch to close")
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for merged watch to close")
+	}
+}
+
+// TestWatchHealthCheck ensures a watcher created with WithHealthCheck stays
+// open while its stream is idle but healthy, and fails once the stream stalls.
+func TestWatchHealthCheck(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, UseBridge: true})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	wch := cli.Watch(t.Context(), "foo", clientv3.WithHealthCheck(200*time.Millisecond))
+
+	// the idle watch is kept alive by progress notifications
+	progress := 0
+	for progress < 3 {
+		select {
+		case wresp, ok := <-wch:
+			require.True(t, ok, "healthy watch closed")
+			require.NoError(t, wresp.Err())
+			if wresp.IsProgressNotify() {
+				progress++
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for progress notifications")
+		}
+	}
+
+	// the watch stream cannot reconnect while connections are paused
+	clus.Members[0].Bridge().PauseConnections()
+	defer clus.Members[0].Bridge().UnpauseConnections()
+	clus.Members[0].Bridge().DropConnections()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case wresp, ok := <-wch:
+			require.True(t, ok, "watch closed without an error")
+			if wresp.IsProgressNotify() {
+				// sent before the connection was paused
+				continue
+			}
+			require.ErrorIs(t, wresp.Err(), clientv3.ErrWatchStalled)
+			require.True(t, wresp.Canceled)
+			_, ok = <-wch
+			require.False(t, ok)
+			return
+		case <-timeout:
+			t.Fatal("health check did not fire")
+		}
+	}
+}
+
+// TestWatchHealthCheckProbesOwnWatcher ensures the progress requests of a
+// health check only notify the probed watcher, not the others on its stream.
+func TestWatchHealthCheckProbesOwnWatcher(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	ctx := t.Context()
+	other := cli.Watch(ctx, "bar", clientv3.WithCreatedNotify())
+	wresp := <-other
+	require.True(t, wresp.Created)
+	wch := cli.Watch(ctx, "foo", clientv3.WithHealthCheck(100*time.Millisecond))
+
+	for progress := 0; progress < 3; {
+		select {
+		case wresp, ok := <-wch:
+			require.True(t, ok, "healthy watch closed")
+			require.NoError(t, wresp.Err())
+			if wresp.IsProgressNotify() {
+				progress++
+			}
+		case wresp := <-other:
+			t.Fatalf("unexpected response on the other watcher: %+v", wresp)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for progress notifications")
+		}
+	}
+}
+
+// TestWatchFromBookmark checks that a watch resumed from a bookmark by a new
+// client receives every event following the bookmark exactly once.
+func TestWatchFromBookmark(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.Client(0)
+	ctx := t.Context()
+
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}})
+	require.NoError(t, err)
+	wch := cli.Watch(ctx, "k/", clientv3.WithPrefix(), clientv3.WithCreatedNotify())
+	wresp := <-wch
+	require.True(t, wresp.Created)
+	require.Nil(t, wresp.Bookmark())
+
+	var bookmark []byte
+	for i := 0; i < 3; i++ {
+		_, err = kv.Put(ctx, fmt.Sprintf("k/%d", i), "v")
+		require.NoError(t, err)
+		select {
+		case wresp = <-wch:
+			require.NoError(t, wresp.Err())
+			require.Len(t, wresp.Events, 1)
+			require.Equal(t, fmt.Sprintf("k/%d", i), string(wresp.Events[0].Kv.Key))
+			bookmark = wresp.Bookmark()
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for event")
+		}
+	}
+
+	// simulate a restart; the events written meanwhile must not be lost
+	require.NoError(t, cli.Close())
+	for i := 3; i < 6; i++ {
+		_, err = kv.Put(ctx, fmt.Sprintf("k/%d", i), "v")
+		require.NoError(t, err)
+	}
+
+	cli, err = integration.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}})
+	require.NoError(t, err)
+	defer cli.Close()
+	wch = clientv3.WatchFromBookmark(ctx, cli, "k/", bookmark, clientv3.WithPrefix())
+
+	var keys []string
+	for len(keys) < 3 {
+		select {
+		case wresp = <-wch:
+			require.NoError(t, wresp.Err())
+			for _, ev := range wresp.Events {
+				keys = append(keys, string(ev.Kv.Key))
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after events %v", keys)
+		}
+	}
+	require.Equal(t, []string{"k/3", "k/4", "k/5"}, keys)
+	select {
+	case wresp = <-wch:
+		t.Fatalf("unexpected watch response (%+v)", wresp)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// a bookmark older than the compact revision cannot be resumed
+	presp, err := kv.Put(ctx, "k/6", "v")
+	require.NoError(t, err)
+	_, err = kv.Compact(ctx, presp.Header.Revision)
+	require.NoError(t, err)
+	wresp = <-clientv3.WatchFromBookmark(ctx, cli, "k/", bookmark, clientv3.WithPrefix())
+	require.ErrorIs(t, wresp.Err(), rpctypes.ErrCompacted)
+
+	wresp = <-clientv3.WatchFromBookmark(ctx, cli, "k/", []byte("garbage"), clientv3.WithPrefix())
+	require.ErrorIs(t, wresp.Err(), clientv3.ErrInvalidBookmark)
+}
+
+func TestWatchWithRevSince(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx := t.Context()
+
+	for i := 0; i < 3; i++ {
+		_, err := cli.Put(ctx, fmt.Sprintf("k/old%d", i), "v")
+		require.NoError(t, err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	since := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	for i := 0; i < 3; i++ {
+		_, err := cli.Put(ctx, fmt.Sprintf("k/new%d", i), "v")
+		require.NoError(t, err)
+	}
+
+	wch := cli.Watch(ctx, "k/", clientv3.WithPrefix(), clientv3.WithRevSince(since))
+	var keys []string
+	for len(keys) < 3 {
+		select {
+		case wresp := <-wch:
+			require.NoError(t, wresp.Err())
+			for _, ev := range wresp.Events {
+				keys = append(keys, string(ev.Kv.Key))
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after events %v", keys)
+		}
+	}
+	require.Equal(t, []string{"k/new0", "k/new1", "k/new2"}, keys)
+
+	// the member does not know when revisions from before it started were committed
+	wresp := <-cli.Watch(ctx, "k/", clientv3.WithPrefix(), clientv3.WithRevSince(since.Add(-time.Hour)))
+	require.True(t, wresp.Canceled)
+	require.ErrorIs(t, wresp.Err(), rpctypes.ErrRevisionTimeUnknown)
+
+	resp, err := cli.Get(ctx, "k/new2")
+	require.NoError(t, err)
+	_, err = cli.Compact(ctx, resp.Kvs[0].ModRevision)
+	require.NoError(t, err)
+	wresp = <-cli.Watch(ctx, "k/", clientv3.WithPrefix(), clientv3.WithRevSince(since))
+	require.True(t, wresp.Canceled)
+	require.ErrorIs(t, wresp.Err(), rpctypes.ErrCompacted)
+}
+
+// TestWatchMaxEventRate ensures a watcher with a maximum event rate receives
+// events at about that rate while keys are written faster, without losing
+// any delete.
+func TestWatchMaxEventRate(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx := t.Context()
+
+	const maxRate = 200
+	wch := cli.Watch(ctx, "k/", clientv3.WithPrefix(), clientv3.WithMaxEventRate(maxRate), clientv3.WithCreatedNotify())
+	wresp := <-wch
+	require.True(t, wresp.Created)
+	start := time.Now()
+
+	// each transaction puts new keys and deletes one put before
+	const numTxns, putsPerTxn = 120, 5
+	deleted := make(map[string]bool)
+	for i := 0; i < numTxns; i++ {
+		var ops []clientv3.Op
+		for j := 0; j < putsPerTxn; j++ {
+			ops = append(ops, clientv3.OpPut(fmt.Sprintf("k/%04d", i*putsPerTxn+j), "v"))
+		}
+		if i > 0 {
+			key := fmt.Sprintf("k/%04d", i*putsPerTxn-2)
+			ops = append(ops, clientv3.OpDelete(key))
+			deleted[key] = true
+		}
+		_, err := cli.Txn(ctx).Then(ops...).Commit()
+		require.NoError(t, err)
+	}
+	require.Less(t, time.Since(start), numTxns*putsPerTxn*time.Second/maxRate/2, "keys were not written faster than the rate")
+
+	// puts of deleted keys may be dropped, all other events are delivered
+	puts, deletes := make(map[string]bool), make(map[string]bool)
+	var events, lateEvents int
+	var last time.Time
+	for len(deletes) < len(deleted) || len(puts) < numTxns*putsPerTxn-len(deleted) {
+		select {
+		case wresp = <-wch:
+			require.NoError(t, wresp.Err())
+			last = time.Now()
+			for _, ev := range wresp.Events {
+				key := string(ev.Kv.Key)
+				if ev.Type == mvccpb.DELETE {
+					require.True(t, deleted[key], "unexpected delete of %q", key)
+					deletes[key] = true
+				} else if !deleted[key] {
+					puts[key] = true
+				}
+			}
+			events += len(wresp.Events)
+			if last.Sub(start) > time.Second {
+				lateEvents += len(wresp.Events)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out with %d puts and %d deletes received", len(puts), len(deletes))
+		}
+	}
+
+	// a second worth of events is sent at once, then events follow at the rate
+	elapsed := last.Sub(start)
+	require.LessOrEqual(t, float64(events), maxRate*(elapsed.Seconds()+1)*1.1)
+	lateRate := float64(lateEvents) / (elapsed - time.Second).Seconds()
+	require.InDelta(t, maxRate, lateRate, maxRate*0.3, "delivered %d events in %v", events, elapsed)
+}
+
+// TestWatchMaxEventRateHeld ensures a progress request does not flush the
+// events held for a rate limited watcher, and that a watcher holding more
+// than a minute worth of events is canceled.
+func TestWatchMaxEventRateHeld(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx := t.Context()
+
+	wch := cli.Watch(ctx, "k/", clientv3.WithPrefix(), clientv3.WithMaxEventRate(1), clientv3.WithCreatedNotify())
+	wresp := <-wch
+	require.True(t, wresp.Created)
+
+	for i := 0; i < 10; i++ {
+		_, err := cli.Put(ctx, fmt.Sprintf("k/%02d", i), "v")
+		require.NoError(t, err)
+	}
+	require.NoError(t, cli.RequestProgress(ctx))
+	var events int
+	timeout := time.After(time.Second)
+	for done := false; !done; {
+		select {
+		case wresp = <-wch:
+			require.NoError(t, wresp.Err())
+			events += len(wresp.Events)
+		case <-timeout:
+			done = true
+		}
+	}
+	require.LessOrEqual(t, events, 2, "held events were flushed")
+
+	for i := 10; i < 80; i++ {
+		_, err := cli.Put(ctx, fmt.Sprintf("k/%02d", i), "v")
+		require.NoError(t, err)
+	}
+	for {
+		select {
+		case wresp = <-wch:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the watch to be canceled")
+		}
+		if wresp.Canceled {
+			require.ErrorContains(t, wresp.Err(), rpctypes.ErrWatchRateExceeded.Error())
+			return
+		}
+	}
+}
+
+// TestWatchValueProjection ensures a watcher with a value projection
+// receives only the projected field of JSON values, so that puts leaving the
+// field unchanged can be filtered out.
+func TestWatchValueProjection(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx := t.Context()
+
+	wch := cli.Watch(ctx, "deploy", clientv3.WithValueProjection("spec.replicas"), clientv3.WithPrevKV())
+	values := []string{
+		`{"spec": {"replicas": 1, "image": "a"}, "status": "ok"}`,
+		`{"spec": {"replicas": 1, "image": "b"}}`,
+		`{"spec": {"image": "b", "replicas": 3}}`,
+		`not json`,
+	}
+	for _, v := range values {
+		_, err := cli.Put(ctx, "deploy", v)
+		require.NoError(t, err)
+	}
+	_, err := cli.Delete(ctx, "deploy")
+	require.NoError(t, err)
+
+	var events []*clientv3.Event
+	for len(events) < len(values)+1 {
+		select {
+		case wresp := <-wch:
+			require.NoError(t, wresp.Err())
+			events = append(events, wresp.Events...)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out with %d events received", len(events))
+		}
+	}
+
+	var projected, prevProjected, changed []string
+	for _, ev := range events {
+		projected = append(projected, string(ev.Kv.Value))
+		var prev []byte
+		if ev.PrevKv != nil {
+			prev = ev.PrevKv.Value
+			prevProjected = append(prevProjected, string(prev))
+		}
+		if ev.Type == mvccpb.PUT && !bytes.Equal(ev.Kv.Value, prev) {
+			changed = append(changed, values[ev.Kv.Version-1])
+		}
+	}
+	require.Equal(t, []string{"1", "1", "3", "", ""}, projected)
+	require.Equal(t, []string{"1", "1", "3", ""}, prevProjected)
+	// the put leaving the replicas unchanged is filtered out
+	require.Equal(t, []string{values[0], values[2], values[3]}, changed)
+
+	wresp := <-cli.Watch(ctx, "deploy", clientv3.WithValueProjection("spec..replicas"))
+	require.ErrorContains(t, wresp.Err(), rpctypes.ErrInvalidProjection.Error())
+}
+
+func TestWatchCallback(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx := t.Context()
+
+	const puts = 10
+	var mu sync.Mutex
+	var keys []string
+	errStop := errors.New("stop")
+	errc := clientv3.WatchCallback(ctx, cli, "k/", func(ev *clientv3.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, string(ev.Kv.Key))
+		if len(keys) == puts {
+			return errStop
+		}
+		return nil
+	}, clientv3.WithPrefix())
+
+	var wantKeys []string
+	for i := 0; i < puts; i++ {
+		key := fmt.Sprintf("k/%d", i)
+		wantKeys = append(wantKeys, key)
+		_, err := cli.Put(ctx, key, "v")
+		require.NoError(t, err)
+	}
+	select {
+	case err := <-errc:
+		require.ErrorIs(t, err, errStop)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the callbacks")
+	}
+
+	// the callback is no longer invoked
+	_, err := cli.Put(ctx, "k/after", "v")
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	mu.Lock()
+	require.Equal(t, wantKeys, keys)
+	mu.Unlock()
+
+	// compaction errors are surfaced
+	_, err = cli.Compact(ctx, 3)
+	require.NoError(t, err)
+	errc = clientv3.WatchCallback(ctx, cli, "k/", func(*clientv3.Event) error { return nil }, clientv3.WithPrefix(), clientv3.WithRev(2))
+	select {
+	case err = <-errc:
+		var cerr *clientv3.CompactedError
+		require.ErrorAs(t, err, &cerr)
+		require.Equal(t, int64(3), cerr.CompactedRev)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the compaction error")
+	}
+}
+
+// TestWatchAlwaysSendPrevKVOnDelete ensures a member configured with
+// AlwaysSendPrevKVOnDelete sends the previous key-value of deletes to
+// watchers that did not request it.
+func TestWatchAlwaysSendPrevKVOnDelete(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, AlwaysSendPrevKVOnDelete: true})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+	ctx := t.Context()
+
+	_, err := cli.Put(ctx, "foo", "bar")
+	require.NoError(t, err)
+	wch := cli.Watch(ctx, "foo")
+	_, err = cli.Delete(ctx, "foo")
+	require.NoError(t, err)
+
+	select {
+	case wresp := <-wch:
+		require.NoError(t, wresp.Err())
+		require.Len(t, wresp.Events, 1)
+		require.Equal(t, mvccpb.DELETE, wresp.Events[0].Type)
+		require.NotNil(t, wresp.Events[0].PrevKv)
+		require.Equal(t, "bar", string(wresp.Events[0].PrevKv.Value))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the delete event")
+	}
+}
+
+// TestWatchTombstoneRetention ensures a member configured with
+// TombstoneRetention sends the deletes kept by a compaction to watchers
+// filtering out puts resuming from before the compaction revision, while
+// other watchers are compacted.
+func TestWatchTombstoneRetention(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, TombstoneRetention: 10})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+	ctx := t.Context()
+
+	_, err := cli.Put(ctx, "foo", "bar")
+	require.NoError(t, err)
+	dresp, err := cli.Delete(ctx, "foo")
+	require.NoError(t, err)
+	presp, err := cli.Put(ctx, "baz", "bar")
+	require.NoError(t, err)
+	_, err = cli.Compact(ctx, presp.Header.Revision)
+	require.NoError(t, err)
+
+	wch := cli.Watch(ctx, "foo", clientv3.WithRev(dresp.Header.Revision), clientv3.WithFilterPut())
+	select {
+	case wresp := <-wch:
+		require.NoError(t, wresp.Err())
+		require.Len(t, wresp.Events, 1)
+		require.Equal(t, mvccpb.DELETE, wresp.Events[0].Type)
+		require.Equal(t, dresp.Header.Revision, wresp.Events[0].Kv.ModRevision)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the retained delete event")
+	}
+
+	wch = cli.Watch(ctx, "foo", clientv3.WithRev(dresp.Header.Revision))
+	select {
+	case wresp := <-wch:
+		require.ErrorIs(t, wresp.Err(), rpctypes.ErrCompacted)
+		require.Equal(t, presp.Header.Revision, wresp.CompactRevision)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the compacted response")
+	}
+}
@@ -20,20 +20,26 @@
 package embed_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/client/pkg/v3/testutil"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/embed"
 	"go.etcd.io/etcd/tests/v3/framework/integration"
 	"go.etcd.io/etcd/tests/v3/framework/testutils"
@@ -217,6 +223,85 @@ func TestEmbedEtcdAutoCompactionRetentionRetained(t *testing.T) {
 	e.Close()
 }
 
+// TestEmbedEtcdTraceExport ensures the trace of a request slower than the
+// export threshold is written to the trace sink.
+func TestEmbedEtcdTraceExport(t *testing.T) {
+	cfg := embed.NewConfig()
+	urls := newEmbedURLs(false, 2)
+	setupEmbedCfg(cfg, []url.URL{urls[0]}, []url.URL{urls[1]})
+	cfg.Dir = filepath.Join(t.TempDir(), "embed-etcd")
+
+	var buf bytes.Buffer
+	cfg.TraceExportSink = traceutil.NewJSONSink(&buf)
+	// every request is slower than this
+	cfg.TraceExportThreshold = time.Nanosecond
+
+	e, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	<-e.Server.ReadyNotify()
+
+	var rev int64
+	for i := 0; i < 3; i++ {
+		resp, perr := e.Server.Put(t.Context(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")})
+		require.NoError(t, perr)
+		rev = resp.Header.Revision
+	}
+	_, err = e.Server.Compact(t.Context(), &pb.CompactionRequest{Revision: rev, Physical: true})
+	require.NoError(t, err)
+	e.Close()
+
+	var compactions []traceutil.Event
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var ev traceutil.Event
+		require.NoError(t, dec.Decode(&ev))
+		if ev.Operation == "compact" {
+			compactions = append(compactions, ev)
+		}
+	}
+	require.Len(t, compactions, 1)
+	assert.Equal(t, float64(rev), compactions[0].Fields["revision"])
+	assert.Positive(t, compactions[0].Duration)
+	assert.NotEmpty(t, compactions[0].Steps)
+}
+
+// TestEmbedEtcdAuditHook ensures the audit hook of an embedded server
+// observes the applied mutations with the member ID and raft term.
+func TestEmbedEtcdAuditHook(t *testing.T) {
+	cfg := embed.NewConfig()
+	urls := newEmbedURLs(false, 2)
+	setupEmbedCfg(cfg, []url.URL{urls[0]}, []url.URL{urls[1]})
+	cfg.Dir = filepath.Join(t.TempDir(), "embed-etcd")
+
+	var mu sync.Mutex
+	var headers []*pb.ResponseHeader
+	cfg.AuditHook = func(r *pb.InternalRaftRequest, header *pb.ResponseHeader, _ proto.Message, err error) {
+		if r.Put == nil || err != nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		headers = append(headers, header)
+	}
+
+	e, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	defer e.Close()
+	<-e.Server.ReadyNotify()
+
+	resp, err := e.Server.Put(t.Context(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")})
+	require.NoError(t, err)
+	_, err = e.Server.Range(t.Context(), &pb.RangeRequest{Key: []byte("foo")})
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, headers, 1)
+	assert.Equal(t, uint64(e.Server.MemberID()), headers[0].MemberId)
+	assert.Equal(t, e.Server.Term(), headers[0].RaftTerm)
+	assert.Equal(t, resp.Header.Revision, headers[0].Revision)
+}
+
 func TestEmbedEtcdStopDuringBootstrapping(t *testing.T) {
 	integration.BeforeTest(t, integration.WithFailpoint("beforePublishing", `sleep("2s")`))
 
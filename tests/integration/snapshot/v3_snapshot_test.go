@@ -126,6 +126,88 @@ func TestSnapshotV3RestoreMulti(t *testing.T) {
 	}
 }
 
+// TestSnapshotV3RestoreConcurrentWrites ensures that a snapshot taken under
+// concurrent writes restores the keys as they were at its revision.
+func TestSnapshotV3RestoreConcurrentWrites(t *testing.T) {
+	integration.BeforeTest(t)
+	testutil.SkipTestIfShortMode(t,
+		"Snapshot creation tests are depending on embedded etcd server so are integration-level tests.")
+	urls := newEmbedURLs(t, 2)
+	cfg := integration.NewEmbedConfig(t, "default")
+	cfg.ClusterState = "new"
+	cfg.ListenClientUrls, cfg.AdvertiseClientUrls = urls[:1], urls[:1]
+	cfg.ListenPeerUrls, cfg.AdvertisePeerUrls = urls[1:], urls[1:]
+	cfg.InitialCluster = fmt.Sprintf("%s=%s", cfg.Name, urls[1].String())
+	cfg.SnapshotCopy = true
+	srv, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	defer srv.Close()
+	select {
+	case <-srv.Server.ReadyNotify():
+	case <-time.After(3 * time.Second):
+		t.Fatalf("failed to start embed.Etcd for creating snapshots")
+	}
+
+	ccfg := clientv3.Config{Endpoints: []string{cfg.AdvertiseClientUrls[0].String()}}
+	cli, err := integration.NewClient(t, ccfg)
+	require.NoError(t, err)
+	defer cli.Close()
+
+	// write keys in sequence, so that a consistent snapshot holds a prefix
+	// of them
+	stopc, donec := make(chan struct{}), make(chan error, 1)
+	written := make(chan int, 1)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-stopc:
+				donec <- nil
+				return
+			default:
+			}
+			if _, err := cli.Put(t.Context(), fmt.Sprintf("key-%06d", i), fmt.Sprint(i)); err != nil {
+				donec <- err
+				return
+			}
+			if i == 100 {
+				written <- i
+			}
+		}
+	}()
+	<-written
+
+	sp := snapshot.NewV3(zaptest.NewLogger(t))
+	dbPath := filepath.Join(t.TempDir(), "snapshot.db")
+	_, err = sp.Save(t.Context(), ccfg, dbPath)
+	close(stopc)
+	require.NoError(t, err)
+	require.NoError(t, <-donec)
+
+	// the temporary copy of the database is removed
+	tmps, err := filepath.Glob(filepath.Join(cfg.Dir, "member", "snap", "db.snapshot.*"))
+	require.NoError(t, err)
+	require.Empty(t, tmps)
+
+	cURLs, _, srvs := restoreCluster(t, 1, dbPath)
+	defer srvs[0].Close()
+	rcli, err := integration.NewClient(t, clientv3.Config{Endpoints: []string{cURLs[0].String()}})
+	require.NoError(t, err)
+	defer rcli.Close()
+
+	restored, err := rcli.Get(t.Context(), "key-", clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	require.NoError(t, err)
+	require.Greater(t, len(restored.Kvs), 100)
+	for i, kv := range restored.Kvs {
+		require.Equal(t, fmt.Sprintf("key-%06d", i), string(kv.Key))
+		require.Equal(t, fmt.Sprint(i), string(kv.Value))
+	}
+
+	snapRev := restored.Kvs[len(restored.Kvs)-1].ModRevision
+	original, err := cli.Get(t.Context(), "key-", clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend), clientv3.WithRev(snapRev))
+	require.NoError(t, err)
+	require.Equal(t, original.Kvs, restored.Kvs)
+}
+
 // TestCorruptedBackupFileCheck tests if we can correctly identify a corrupted backup file.
 func TestCorruptedBackupFileCheck(t *testing.T) {
 	if cpuutil.ByteOrder() == binary.BigEndian {
@@ -246,6 +246,10 @@ func TestTracing(t *testing.T) {
 						Key:   "progress_notify",
 						Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_BoolValue{BoolValue: true}},
 					},
+					{
+						Key:   "progress_notify_interval_ms",
+						Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_IntValue{IntValue: 0}},
+					},
 					{
 						Key:   "prev_kv",
 						Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_BoolValue{BoolValue: false}},
@@ -254,6 +258,26 @@ func TestTracing(t *testing.T) {
 						Key:   "fragment",
 						Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_BoolValue{BoolValue: false}},
 					},
+					{
+						Key:   "raw_events",
+						Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_BoolValue{BoolValue: false}},
+					},
+					{
+						Key:   "stale_ok",
+						Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_BoolValue{BoolValue: false}},
+					},
+					{
+						Key:   "auth_revision_notify",
+						Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_BoolValue{BoolValue: false}},
+					},
+					{
+						Key:   "max_event_rate",
+						Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_IntValue{IntValue: 0}},
+					},
+					{
+						Key:   "value_projection",
+						Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: ""}},
+					},
 				},
 			},
 		},
@@ -27,6 +27,7 @@ import (
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/lease/leasepb"
 	"go.etcd.io/etcd/server/v3/storage/backend"
@@ -126,6 +127,42 @@ func TestV3StorageQuotaApply(t *testing.T) {
 	require.Errorf(t, err, "alarmed instance should reject put after reset")
 }
 
+// TestV3StorageSoftQuotaWarning tests puts and txns applied above the soft
+// quota carry a quota warning to the client.
+func TestV3StorageSoftQuotaWarning(t *testing.T) {
+	integration.BeforeTest(t)
+	quotasize := int64(1024 * 1024)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{
+		Size:              1,
+		QuotaBackendBytes: quotasize,
+		QuotaSoftRatio:    0.5,
+	})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	resp, err := cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	require.False(t, resp.Header.QuotaWarning)
+
+	// the put is still within the quota, but above the soft quota
+	bigbuf := make([]byte, quotasize*6/10)
+	resp, err = cli.Put(t.Context(), "foo", string(bigbuf))
+	require.NoError(t, err)
+	require.True(t, resp.Header.QuotaWarning)
+
+	// once the backend holds the big put, small writes are above it too
+	clus.Members[0].Server.Backend().ForceCommit()
+	tresp, err := cli.Txn(t.Context()).Then(clientv3.OpPut("foo", "bar")).Commit()
+	require.NoError(t, err)
+	require.True(t, tresp.Header.QuotaWarning)
+
+	// reads do not count against the quota
+	gresp, err := cli.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	require.False(t, gresp.Header.QuotaWarning)
+}
+
 // TestV3AlarmDeactivate ensures that space alarms can be deactivated so puts go through.
 func TestV3AlarmDeactivate(t *testing.T) {
 	integration.BeforeTest(t)
@@ -158,6 +195,51 @@ func TestV3AlarmDeactivate(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestV3ReadOnlyAlarm ensures writes are rejected while the read-only alarm is
+// active and go through again once it is deactivated.
+func TestV3ReadOnlyAlarm(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	_, err := cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+
+	status, err := cli.Status(t.Context(), cli.Endpoints()[0])
+	require.NoError(t, err)
+	am := &clientv3.AlarmMember{MemberID: status.Header.MemberId, Alarm: pb.AlarmType_READONLY}
+	resp, err := cli.AlarmActivate(t.Context(), am)
+	require.NoError(t, err)
+	require.Len(t, resp.Alarms, 1)
+
+	// every member rejects writes
+	for i := range clus.Members {
+		_, err = clus.Client(i).Put(t.Context(), "foo", "baz")
+		require.ErrorIs(t, err, rpctypes.ErrReadOnly)
+	}
+	_, err = cli.Delete(t.Context(), "foo")
+	require.ErrorIs(t, err, rpctypes.ErrReadOnly)
+	_, err = cli.Txn(t.Context()).Then(clientv3.OpGet("foo"), clientv3.OpPut("foo", "baz")).Commit()
+	require.ErrorIs(t, err, rpctypes.ErrReadOnly)
+
+	// reads are still served
+	_, err = cli.Txn(t.Context()).Then(clientv3.OpGet("foo")).Commit()
+	require.NoError(t, err)
+	gresp, err := cli.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	require.Equal(t, "bar", string(gresp.Kvs[0].Value))
+
+	_, err = cli.AlarmDisarm(t.Context(), am)
+	require.NoError(t, err)
+
+	_, err = cli.Put(t.Context(), "foo", "baz")
+	require.NoError(t, err)
+	_, err = cli.Delete(t.Context(), "foo")
+	require.NoError(t, err)
+}
+
 func TestV3CorruptAlarm(t *testing.T) {
 	integration.BeforeTest(t)
 	lg := zaptest.NewLogger(t)
@@ -184,7 +266,7 @@ func TestV3CorruptAlarm(t *testing.T) {
 	// NOTE: cluster_proxy mode with namespacing won't set 'k', but namespace/'k'.
 	s.Put([]byte("abc"), []byte("def"), 0)
 	s.Put([]byte("xyz"), []byte("123"), 0)
-	s.Compact(traceutil.TODO(), 5)
+	s.Compact(t.Context(), traceutil.TODO(), 5)
 	s.Commit()
 	s.Close()
 	be.Close()
@@ -422,3 +422,69 @@ func TestV3AuthWatchErrorAndWatchId0(t *testing.T) {
 
 	<-watchEndCh
 }
+
+// TestV3AuthWatchQuota ensures that a user cannot open more watches than
+// the watch quota, and that canceled and closed watches are released.
+func TestV3AuthWatchQuota(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, MaxWatchesPerUser: 2})
+	defer clus.Terminate(t)
+
+	users := []user{
+		{
+			name:     "user1",
+			password: "user1-123",
+			role:     "role1",
+			key:      "k1",
+			end:      "k2",
+		},
+	}
+	authSetupUsers(t, integration.ToGRPC(clus.Client(0)).Auth, users)
+	authSetupRoot(t, integration.ToGRPC(clus.Client(0)).Auth)
+
+	// watch opens a watch on k1 and returns the error of its creation
+	watch := func(ctx context.Context, c *clientv3.Client) error {
+		wr, ok := <-c.Watch(ctx, "k1", clientv3.WithCreatedNotify())
+		if !ok {
+			return ctx.Err()
+		}
+		if err := wr.Err(); err != nil {
+			return err
+		}
+		require.True(t, wr.Created)
+		return nil
+	}
+
+	c, err := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "user1", Password: "user1-123"})
+	require.NoError(t, err)
+	defer c.Close()
+
+	ctx1, cancel1 := context.WithCancel(t.Context())
+	defer cancel1()
+	require.NoError(t, watch(ctx1, c))
+	require.NoError(t, watch(t.Context(), c))
+	require.ErrorContains(t, watch(t.Context(), c), rpctypes.ErrWatchQuotaExceeded.Error())
+
+	// the quota is per user
+	rootc, err := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "root", Password: "123"})
+	require.NoError(t, err)
+	defer rootc.Close()
+	require.NoError(t, watch(t.Context(), rootc))
+
+	// a canceled watch no longer counts
+	cancel1()
+	require.Eventually(t, func() bool {
+		return watch(t.Context(), c) == nil
+	}, 10*time.Second, 100*time.Millisecond)
+	require.ErrorContains(t, watch(t.Context(), c), rpctypes.ErrWatchQuotaExceeded.Error())
+
+	// nor do the watches of a closed stream
+	require.NoError(t, c.Close())
+	c2, err := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "user1", Password: "user1-123"})
+	require.NoError(t, err)
+	defer c2.Close()
+	require.Eventually(t, func() bool {
+		return watch(t.Context(), c2) == nil
+	}, 10*time.Second, 100*time.Millisecond)
+	require.NoError(t, watch(t.Context(), c2))
+}
@@ -1170,6 +1170,71 @@ func TestV3TooLargeRequest(t *testing.T) {
 	}
 }
 
+// TestV3MaxValueBytes ensures values over the value size limit are rejected
+// before being proposed.
+func TestV3MaxValueBytes(t *testing.T) {
+	integration.BeforeTest(t)
+
+	const limit = 16
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, MaxValueBytes: limit})
+	defer clus.Terminate(t)
+
+	kvc := integration.ToGRPC(clus.RandClient()).KV
+	resp, err := kvc.Put(t.Context(), &pb.PutRequest{Key: []byte("foo"), Value: make([]byte, limit)})
+	require.NoError(t, err)
+	rev := resp.Header.Revision
+
+	_, err = kvc.Put(t.Context(), &pb.PutRequest{Key: []byte("foo"), Value: make([]byte, limit+1)})
+	require.ErrorContains(t, err, "etcdserver: value is too large")
+	txn := &pb.TxnRequest{Failure: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{
+		RequestPut: &pb.PutRequest{Key: []byte("foo"), Value: make([]byte, limit+1)},
+	}}}}
+	_, err = kvc.Txn(t.Context(), txn)
+	require.ErrorContains(t, err, "etcdserver: value is too large")
+
+	// the rejected writes were never proposed
+	rresp, err := kvc.Range(t.Context(), &pb.RangeRequest{Key: []byte("foo")})
+	require.NoError(t, err)
+	require.Equal(t, rev, rresp.Header.Revision)
+}
+
+// TestV3HotKeyWriteRate ensures writes to a key over the hot key write rate
+// are rejected before being proposed, without throttling other keys.
+func TestV3HotKeyWriteRate(t *testing.T) {
+	integration.BeforeTest(t)
+
+	// the rate is low enough that no token is refilled during the test
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, HotKeyWriteRate: 0.001, HotKeyWriteBurst: 2})
+	defer clus.Terminate(t)
+
+	kvc := integration.ToGRPC(clus.RandClient()).KV
+	var rev int64
+	for i := 0; i < 2; i++ {
+		resp, err := kvc.Put(t.Context(), &pb.PutRequest{Key: []byte("hot"), Value: []byte("v")})
+		require.NoError(t, err)
+		rev = resp.Header.Revision
+	}
+
+	_, err := kvc.Put(t.Context(), &pb.PutRequest{Key: []byte("hot"), Value: []byte("v")})
+	require.Truef(t, eqErrGRPC(err, rpctypes.ErrGRPCRequestTooManyRequests), "err = %v", err)
+	_, err = kvc.DeleteRange(t.Context(), &pb.DeleteRangeRequest{Key: []byte("hot")})
+	require.Truef(t, eqErrGRPC(err, rpctypes.ErrGRPCRequestTooManyRequests), "err = %v", err)
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{
+		RequestPut: &pb.PutRequest{Key: []byte("hot"), Value: []byte("v")},
+	}}}}
+	_, err = kvc.Txn(t.Context(), txn)
+	require.Truef(t, eqErrGRPC(err, rpctypes.ErrGRPCRequestTooManyRequests), "err = %v", err)
+
+	// the rejected writes were never proposed
+	rresp, err := kvc.Range(t.Context(), &pb.RangeRequest{Key: []byte("hot")})
+	require.NoError(t, err)
+	require.Equal(t, rev, rresp.Header.Revision)
+
+	resp, err := kvc.Put(t.Context(), &pb.PutRequest{Key: []byte("cold"), Value: []byte("v")})
+	require.NoError(t, err)
+	require.Equal(t, rev+1, resp.Header.Revision)
+}
+
 // TestV3Hash tests hash.
 func TestV3Hash(t *testing.T) {
 	integration.BeforeTest(t)
@@ -362,6 +362,17 @@ func TestV3LeaseCheckpoint(t *testing.T) {
 			clusterSize:           3,
 			expectTTLIsLT:         280 * time.Second,
 		},
+		{
+			// A short interval keeps the TTL close to the one the old leader had.
+			name:                  "Checkpointing enabled 2s, lease TTL is preserved closely after 2 leader changes",
+			ttl:                   300 * time.Second,
+			checkpointingEnabled:  true,
+			checkpointingInterval: 2 * time.Second,
+			leaderChanges:         2,
+			clusterSize:           3,
+			expectTTLIsGT:         288 * time.Second,
+			expectTTLIsLT:         296 * time.Second,
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {